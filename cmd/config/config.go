@@ -0,0 +1,22 @@
+// Package configcmd provides commands to read and change the .contextvibes.yaml settings.
+package configcmd
+
+import (
+	"github.com/contextvibes/cli/cmd/config/get"
+	"github.com/contextvibes/cli/cmd/config/set"
+	"github.com/spf13/cobra"
+)
+
+// ConfigCmd represents the base command for the 'config' subcommand group.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and change settings in .contextvibes.yaml.",
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	ConfigCmd.AddCommand(get.GetCmd)
+	ConfigCmd.AddCommand(set.SetCmd)
+}
@@ -0,0 +1,52 @@
+// Package get provides the command to print a configuration value.
+package get
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed get.md.tpl
+var getLongDescription string
+
+// GetCmd represents the config get command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var GetCmd = &cobra.Command{
+	Use:          "get <key>",
+	Example:      "  contextvibes config get git.defaultMainBranch\n  contextvibes config get describe",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+
+		value, err := config.GetValue(globals.LoadedAppConfig, args[0])
+		if err != nil {
+			presenter.Error("%v", err)
+
+			return fmt.Errorf("failed to read config value: %w", err)
+		}
+
+		//nolint:errcheck // Printing to stdout is best effort.
+		fmt.Fprintln(cmd.OutOrStdout(), value)
+
+		return nil
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(getLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	GetCmd.Short = desc.Short
+	GetCmd.Long = desc.Long
+}
@@ -0,0 +1,9 @@
+# Prints the effective value of a configuration key.
+
+Looks up a dotted key such as `git.defaultMainBranch` using the YAML names from
+.contextvibes.yaml and prints its effective value: the config file merged with
+the built-in defaults and any environment overrides.
+
+A key naming a whole section (for example `describe`) prints that section as
+YAML. Entries of maps are addressed by their key, as in
+`feedback.repositories.cli`.
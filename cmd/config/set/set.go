@@ -0,0 +1,77 @@
+// Package set provides the command to change a configuration value.
+package set
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed set.md.tpl
+var setLongDescription string
+
+// SetCmd represents the config set command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var SetCmd = &cobra.Command{
+	Use: "set <key> <value>",
+	Example: `  contextvibes config set git.defaultMainBranch develop
+  contextvibes config set behavior.dualOutput true
+  contextvibes config set feedback.repositories.fork my-org/cli`,
+	//nolint:mnd // Key and value.
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		key, value := args[0], args[1]
+
+		configPath, err := config.TargetConfigPath(globals.ExecClient, globals.ConfigPath)
+		if err != nil {
+			presenter.Error("Could not locate the config file: %v", err)
+
+			return fmt.Errorf("failed to locate config file: %w", err)
+		}
+
+		// Only the file's own settings are written back, not the merged defaults.
+		onDisk, err := config.LoadConfig(configPath)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", configPath, err)
+		}
+
+		if onDisk == nil {
+			onDisk = &config.Config{} //nolint:exhaustruct // A new file holds only the set value.
+		}
+
+		err = config.SetValue(onDisk, key, value)
+		if err != nil {
+			presenter.Error("%v", err)
+
+			return fmt.Errorf("failed to set config value: %w", err)
+		}
+
+		err = config.UpdateAndSaveConfig(onDisk, configPath)
+		if err != nil {
+			return fmt.Errorf("failed to save %s: %w", configPath, err)
+		}
+
+		presenter.Success("Set %s = %s in %s", key, value, configPath)
+
+		return nil
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(setLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	SetCmd.Short = desc.Short
+	SetCmd.Long = desc.Long
+}
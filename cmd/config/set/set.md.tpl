@@ -0,0 +1,19 @@
+# Sets a configuration key in .contextvibes.yaml.
+
+Writes a single value to the project's .contextvibes.yaml (or the file given
+with `--config`), creating the file if needed. Keys are dotted paths using the
+YAML names, such as `git.defaultMainBranch`.
+
+The value is checked before anything is saved:
+
+- booleans accept `true` or `false`, and integers must be whole numbers;
+- `logging.level` accepts `debug`, `info`, `warn` or `error`;
+- `project.provider` accepts `github`;
+- validation patterns must be valid regular expressions.
+
+Lists such as `describe.includePatterns` take a comma-separated value and
+replace the whole list. Entries of maps are set by key, as in
+`feedback.repositories.fork`. Sections such as `run.examples` cannot be set
+from the command line; edit the YAML for those.
+
+A file written for an older schema version is saved in the current layout.
@@ -3,8 +3,11 @@ package kickoff
 
 import (
 	_ "embed"
+	"fmt"
 
 	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/kickoff"
 	"github.com/contextvibes/cli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -12,24 +15,52 @@ import (
 //go:embed kickoff.md.tpl
 var kickoffLongDescription string
 
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	outputFlag       string
+	markCompleteFlag bool
+)
+
 // KickoffCmd represents the craft kickoff command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
 var KickoffCmd = &cobra.Command{
-	Use:   "kickoff",
+	Use:   "kickoff [--output <file>] [--mark-complete]",
 	Short: "Starts an AI-guided strategic project planning session.",
-
+	Example: `  contextvibes craft kickoff
+  contextvibes craft kickoff --mark-complete`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		presenter.SetInput(cmd.InOrStdin())
+
+		orchestrator := kickoff.NewOrchestrator(presenter, globals.LoadedAppConfig, ".")
+		orchestrator.SetClock(globals.Clock)
+
+		if markCompleteFlag {
+			date, err := orchestrator.MarkStrategicKickoffComplete()
+			if err != nil {
+				presenter.Error("Failed to mark the strategic kickoff complete: %v", err)
 
-		// This is the new home for the logic from the old 'kickoff --strategic'.
-		// The full implementation of the orchestrator would be called from here.
+				return fmt.Errorf("failed to mark strategic kickoff complete: %w", err)
+			}
+
+			presenter.Success("Strategic kickoff marked as complete.")
+			presenter.Detail("Saved to %s (lastStrategicKickoffDate: %s)", orchestrator.ConfigPath(), date)
+
+			return nil
+		}
 
 		presenter.Summary("Initiating Strategic Kickoff Session...")
-		presenter.Info(
-			"This feature will guide you through generating a master prompt for your AI.",
-		)
-		presenter.Warning("Full implementation is pending.")
+
+		err := orchestrator.GenerateMasterPrompt(outputFlag)
+		if err != nil {
+			presenter.Error("Strategic kickoff failed: %v", err)
+
+			return fmt.Errorf("strategic kickoff failed: %w", err)
+		}
+
+		presenter.Info("Pass this file to your AI to start the strategic kickoff discussion.")
 
 		return nil
 	},
@@ -44,4 +75,8 @@ func init() {
 
 	KickoffCmd.Short = desc.Short
 	KickoffCmd.Long = desc.Long
+	KickoffCmd.Flags().
+		StringVarP(&outputFlag, "output", "o", "_contextvibes.md", "Output file for the master prompt")
+	KickoffCmd.Flags().BoolVar(&markCompleteFlag, "mark-complete", false,
+		"Record the strategic kickoff as complete in .contextvibes.yaml without generating a prompt")
 }
@@ -4,3 +4,14 @@ This command initiates an interactive session to gather high-level project
 details. It then generates a comprehensive master prompt file that you can
 use with an external AI (like Gemini or Claude) to facilitate a detailed
 strategic kickoff discussion for your project.
+
+The session asks for the project name and type (defaulting to the `go.mod`
+module name or directory name, and the detected project type) and for your AI
+collaboration preferences (defaulting to `ai.collaborationPreferences`). Your
+answers are saved back to `.contextvibes.yaml` (under `kickoff` and `ai`) and
+offered as the defaults on the next run. The master prompt is written to
+`_contextvibes.md` (see `--output`).
+
+Once the kickoff discussion is done, run with `--mark-complete` to set
+`projectState.strategicKickoffCompleted` and record the date, without
+regenerating the prompt.
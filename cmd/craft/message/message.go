@@ -41,7 +41,7 @@ var MessageCmd = &cobra.Command{
 		}
 
 		// 2. Initialize Workflow Runner
-		runner := workflow.NewRunner(presenter, globals.AssumeYes)
+		runner := workflow.NewRunner(presenter, globals.AssumeYes, workflow.WithVerboseTiming(globals.Verbose))
 
 		// 3. Define and Run Steps
 		return runner.Run(
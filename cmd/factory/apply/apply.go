@@ -11,11 +11,16 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"regexp"
+	"strings"
 
+	"github.com/contextvibes/cli/cmd/factory/apply/validate"
 	"github.com/contextvibes/cli/internal/apply"
 	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/codemod"
+	"github.com/contextvibes/cli/internal/exitcode"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/network"
+	"github.com/contextvibes/cli/internal/tools"
 	"github.com/contextvibes/cli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -24,20 +29,46 @@ import (
 var applyLongDescription string
 
 //nolint:gochecknoglobals // Cobra flags require package-level variables.
-var scriptPath string
+var (
+	scriptPath       string
+	fromURL          string
+	requireClean     bool
+	interactive      bool
+	detailedExitCode bool
+	baseDir          string
+)
+
+// stepDecision is the user's answer to a per-step prompt in --interactive mode.
+type stepDecision int
+
+const (
+	stepContinue stepDecision = iota
+	stepSkip
+	stepAbort
+)
+
+// fileChange is the computed result of applying a FileChangeSet, before it is written.
+type fileChange struct {
+	path     string
+	target   string
+	original string
+	updated  string
+}
 
 // ApplyCmd represents the apply command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
 var ApplyCmd = &cobra.Command{
-	Use:     "apply [--script <file>]",
-	Example: `  contextvibes factory apply --script ./plan.json`,
-	Args:    cobra.NoArgs,
+	Use: "apply [--script <file> | --from-url <url>]",
+	Example: `  contextvibes factory apply --script ./plan.json
+  contextvibes factory apply --from-url https://gist.githubusercontent.com/me/abc123/raw/plan.json`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		presenter.SetInput(cmd.InOrStdin())
 		ctx := cmd.Context()
 
-		scriptContent, _, err := readInput(scriptPath)
+		scriptContent, _, err := readInput(ctx, scriptPath, fromURL)
 		if err != nil {
 			presenter.Error("Failed to read input: %v", err)
 
@@ -50,15 +81,43 @@ var ApplyCmd = &cobra.Command{
 			return nil
 		}
 
+		var changed bool
 		if isJSON(scriptContent) {
-			return handleJSONPlan(ctx, presenter, scriptContent)
+			changed, err = handleJSONPlan(ctx, presenter, scriptContent)
+		} else {
+			changed, err = handleShellScript(ctx, presenter, scriptContent)
+		}
+
+		if err != nil {
+			return err
 		}
 
-		return handleShellScript(ctx, presenter, scriptContent)
+		if detailedExitCode && changed {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return exitcode.New(exitcode.ChangesApplied)
+		}
+
+		return nil
 	},
 }
 
-func readInput(scriptPath string) ([]byte, string, error) {
+func readInput(ctx context.Context, scriptPath, fromURL string) ([]byte, string, error) {
+	if fromURL != "" {
+		if network.Offline(ctx) {
+			return nil, "URL", fmt.Errorf("cannot download %s: %w", fromURL, network.ErrOffline)
+		}
+
+		content, err := apply.FetchPlan(ctx, network.HTTPClient(ctx), fromURL)
+		if err != nil {
+			//nolint:wrapcheck // Fetch errors already name the URL.
+			return nil, "URL", err
+		}
+
+		return content, "URL", nil
+	}
+
 	if scriptPath != "" {
 		//nolint:gosec // Reading user-provided script file is intended.
 		content, err := os.ReadFile(scriptPath)
@@ -72,7 +131,7 @@ func readInput(scriptPath string) ([]byte, string, error) {
 	stat, _ := os.Stdin.Stat()
 	if (stat.Mode() & os.ModeCharDevice) != 0 {
 		//nolint:err113 // Dynamic error is appropriate here.
-		return nil, "", errors.New("no script provided via --script flag or standard input")
+		return nil, "", errors.New("no script provided via --script, --from-url or standard input")
 	}
 
 	content, err := io.ReadAll(os.Stdin)
@@ -87,73 +146,306 @@ func isJSON(data []byte) bool {
 	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
 }
 
-//nolint:cyclop // Complexity is acceptable for plan handling.
-func handleJSONPlan(ctx context.Context, presenter *ui.Presenter, data []byte) error {
+// handleJSONPlan validates and executes a Change Plan. It reports whether any step
+// changed the project: a file modification that altered content, or a command that ran.
+//
+//nolint:cyclop,funlen,gocognit // Complexity is acceptable for plan handling.
+func handleJSONPlan(ctx context.Context, presenter *ui.Presenter, data []byte) (bool, error) {
 	var plan apply.ChangePlan
 
 	err := json.Unmarshal(data, &plan)
 	if err != nil {
 		presenter.Error("Failed to parse JSON Change Plan: %v", err)
 
-		return fmt.Errorf("failed to unmarshal plan: %w", err)
+		return false, fmt.Errorf("failed to unmarshal plan: %w", err)
+	}
+
+	templateData := plan.TemplateData(os.Environ())
+
+	err = plan.Validate()
+	if err == nil {
+		err = plan.CheckTemplates(templateData)
+	}
+
+	if err != nil {
+		var validationErr *apply.ValidationError
+		if errors.As(err, &validationErr) {
+			presenter.Error("The Change Plan is invalid (%d problem(s)):", len(validationErr.Problems))
+
+			for _, problem := range validationErr.Problems {
+				presenter.Detail("%s", problem)
+			}
+		}
+
+		//nolint:wrapcheck // Validation errors are already descriptive.
+		return false, err
+	}
+
+	gitClient := apply.NewPreflightGitClient(ctx, globals.AppLogger, globals.ExecClient.UnderlyingExecutor())
+	base := apply.ResolveBaseDir(baseDir, gitClient)
+
+	targets, err := resolveTargets(presenter, base, plan.TargetPaths())
+	if err != nil {
+		return false, err
+	}
+
+	err = checkWorkingDirs(presenter, base, plan.Steps)
+	if err != nil {
+		return false, err
 	}
 
 	presenter.Header("--- Change Plan Summary ---")
 
 	for i, step := range plan.Steps {
 		presenter.Step("Step %d: [%s] %s", i+1, step.Type, step.Description)
+
+		if step.Type == apply.StepTypeBulkRename {
+			err = previewBulkRename(ctx, presenter, gitClient, base, step)
+			if err != nil {
+				presenter.Error("Step %d cannot be applied: %v", i+1, err)
+
+				return false, err
+			}
+		}
 	}
 
-	if !globals.AssumeYes {
+	err = apply.CheckDirtyTargets(
+		ctx,
+		presenter,
+		gitClient,
+		targets,
+		requireClean,
+	)
+	if err != nil {
+		//nolint:wrapcheck // Preflight errors are already descriptive.
+		return false, err
+	}
+
+	stepByStep := interactive && !globals.AssumeYes
+
+	if !globals.AssumeYes && !stepByStep {
 		confirmed, err := presenter.PromptForConfirmation("Execute the structured plan?")
 		if err != nil {
-			return fmt.Errorf("confirmation failed: %w", err)
+			return false, fmt.Errorf("confirmation failed: %w", err)
 		}
 
 		if !confirmed {
 			presenter.Info("Execution aborted.")
 
-			return nil
+			return false, nil
 		}
 	}
 
-	for _, step := range plan.Steps {
+	changed := false
+
+	for i, step := range plan.Steps {
+		if stepByStep {
+			decision, err := promptForStep(presenter, i+1, step, templateData, base)
+			if err != nil {
+				return changed, err
+			}
+
+			if decision == stepSkip {
+				presenter.Info("Skipped step %d.", i+1)
+
+				continue
+			}
+
+			if decision == stepAbort {
+				presenter.Info("Execution aborted at step %d.", i+1)
+
+				return changed, nil
+			}
+		}
+
 		switch step.Type {
-		case "file_modification":
-			for _, changeSet := range step.Changes {
-				original, _ := os.ReadFile(changeSet.FilePath)
-				current := string(original)
-
-				for _, operation := range changeSet.Operations {
-					if operation.Type == "create_or_overwrite" {
-						current = *operation.Content
-					}
-
-					if operation.Type == "regex_replace" {
-						re, _ := regexp.Compile(operation.FindRegex)
-						current = re.ReplaceAllString(current, operation.ReplaceWith)
-					}
-				}
-
-				//nolint:mnd // 0750 is standard directory permission.
-				_ = os.MkdirAll(filepath.Dir(changeSet.FilePath), 0o750)
-				//nolint:mnd // 0600 is standard file permission.
-				_ = os.WriteFile(changeSet.FilePath, []byte(current), 0o600)
+		case apply.StepTypeFileModification:
+			modified, err := executeFileModificationStep(step, templateData, base)
+			if err != nil {
+				return changed, err
+			}
+
+			if modified > 0 {
+				changed = true
 			}
-		case "command_execution":
-			err := globals.ExecClient.Execute(ctx, ".", step.Command, step.Args...)
+		case apply.StepTypeCommandExecution:
+			err := executeCommandExecutionStep(ctx, step, base)
 			if err != nil {
-				return fmt.Errorf("command execution failed: %w", err)
+				return changed, err
+			}
+
+			changed = true
+		case apply.StepTypeBulkRename:
+			modified, err := executeBulkRenameStep(ctx, gitClient, base, step)
+			if err != nil {
+				return changed, err
+			}
+
+			if modified > 0 {
+				changed = true
 			}
 		}
 	}
 
 	presenter.Success("Plan executed successfully.")
 
+	return changed, nil
+}
+
+// promptForStep shows a step (with a diff for file modifications) and asks whether to run it.
+func promptForStep(
+	presenter *ui.Presenter,
+	number int,
+	step apply.Step,
+	templateData map[string]string,
+	base string,
+) (stepDecision, error) {
+	presenter.Newline()
+	presenter.Header("Step %d: [%s] %s", number, step.Type, step.Description)
+
+	switch step.Type {
+	case apply.StepTypeFileModification:
+		changes, err := computeFileChanges(step, templateData, base)
+		if err != nil {
+			return stepAbort, fmt.Errorf("file modification failed: %w", err)
+		}
+
+		for _, change := range changes {
+			presenter.Detail("--- %s", change.path)
+
+			diff := tools.LineDiff(change.original, change.updated)
+			if diff == "" {
+				presenter.Detail("(no changes)")
+			}
+
+			//nolint:errcheck // Printing to stdout is best effort.
+			fmt.Fprint(presenter.Out(), diff)
+		}
+	case apply.StepTypeCommandExecution:
+		presenter.Detail("$ %s %s", step.Command, strings.Join(step.Args, " "))
+	case apply.StepTypeBulkRename:
+		presenter.Detail("Rename tracked paths matching '%s' to '%s' (listed in the summary above)",
+			step.FindRegex, step.ReplaceWith)
+	}
+
+	for {
+		answer, err := presenter.PromptForInput("Run this step? [c]ontinue / [s]kip / [a]bort")
+		if err != nil {
+			return stepAbort, fmt.Errorf("step confirmation failed: %w", err)
+		}
+
+		switch strings.ToLower(answer) {
+		case "c", "continue", "y", "yes":
+			return stepContinue, nil
+		case "s", "skip":
+			return stepSkip, nil
+		case "a", "abort", "q", "quit":
+			return stepAbort, nil
+		}
+
+		presenter.Warning("Invalid choice '%s'. Please enter c, s, or a.", answer)
+	}
+}
+
+// computeFileChanges applies a step's operations in memory and returns the results.
+// File paths are resolved against, and confined to, base.
+func computeFileChanges(step apply.Step, data map[string]string, base string) ([]fileChange, error) {
+	changes := make([]fileChange, 0, len(step.Changes))
+
+	for _, changeSet := range step.Changes {
+		target, err := codemod.ResolvePath(base, changeSet.FilePath)
+		if err != nil {
+			//nolint:wrapcheck // Path errors are already descriptive.
+			return nil, err
+		}
+
+		//nolint:gosec // The target is confined to the base directory.
+		original, _ := os.ReadFile(target)
+		current := string(original)
+
+		for _, operation := range changeSet.Operations {
+			operation, err := operation.RenderContent(data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", changeSet.FilePath, err)
+			}
+
+			updated, err := operation.Apply(current)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", changeSet.FilePath, err)
+			}
+
+			current = updated
+		}
+
+		changes = append(changes, fileChange{
+			path:     changeSet.FilePath,
+			target:   target,
+			original: string(original),
+			updated:  current,
+		})
+	}
+
+	return changes, nil
+}
+
+// executeFileModificationStep writes a step's file changes and returns how many
+// files actually changed. Nothing is written when any operation fails.
+func executeFileModificationStep(step apply.Step, templateData map[string]string, base string) (int, error) {
+	changes, err := computeFileChanges(step, templateData, base)
+	if err != nil {
+		return 0, fmt.Errorf("file modification failed: %w", err)
+	}
+
+	modified := 0
+
+	for _, change := range changes {
+		_, statErr := os.Stat(change.target)
+		if statErr == nil && change.updated == change.original {
+			continue
+		}
+
+		//nolint:mnd // 0750 is standard directory permission.
+		err = os.MkdirAll(filepath.Dir(change.target), 0o750)
+		if err != nil {
+			return modified, fmt.Errorf("failed to create directory for '%s': %w", change.path, err)
+		}
+
+		//nolint:mnd // 0600 is standard file permission.
+		err = tools.ReplaceFile(change.target, strings.NewReader(change.updated), 0o600)
+		if err != nil {
+			return modified, fmt.Errorf("failed to write '%s': %w", change.path, err)
+		}
+
+		modified++
+	}
+
+	return modified, nil
+}
+
+// executeCommandExecutionStep runs a command step in base, or in its working_dir
+// resolved against it.
+func executeCommandExecutionStep(ctx context.Context, step apply.Step, base string) error {
+	dir, err := step.ResolveWorkingDir(base)
+	if err != nil {
+		//nolint:wrapcheck // Path errors are already descriptive.
+		return err
+	}
+
+	if len(step.Env) > 0 {
+		err = globals.ExecClient.ExecuteWithEnv(ctx, dir, step.Env, step.Command, step.Args...)
+	} else {
+		err = globals.ExecClient.Execute(ctx, dir, step.Command, step.Args...)
+	}
+
+	if err != nil {
+		return fmt.Errorf("command execution failed: %w", err)
+	}
+
 	return nil
 }
 
-func handleShellScript(ctx context.Context, presenter *ui.Presenter, scriptContent []byte) error {
+// handleShellScript runs a fallback shell script. A script that ran is reported as a change.
+func handleShellScript(ctx context.Context, presenter *ui.Presenter, scriptContent []byte) (bool, error) {
 	presenter.Header("--- Script to be Applied ---")
 	//nolint:errcheck // Printing to stdout is best effort.
 	fmt.Fprintln(presenter.Out(), "```bash\n"+string(scriptContent)+"\n```")
@@ -161,13 +453,13 @@ func handleShellScript(ctx context.Context, presenter *ui.Presenter, scriptConte
 	if !globals.AssumeYes {
 		confirmed, err := presenter.PromptForConfirmation("Execute the shell script?")
 		if err != nil {
-			return fmt.Errorf("confirmation failed: %w", err)
+			return false, fmt.Errorf("confirmation failed: %w", err)
 		}
 
 		if !confirmed {
 			presenter.Info("Execution aborted.")
 
-			return nil
+			return false, nil
 		}
 	}
 
@@ -180,7 +472,47 @@ func handleShellScript(ctx context.Context, presenter *ui.Presenter, scriptConte
 
 	err := globals.ExecClient.Execute(ctx, ".", "bash", tempFile.Name())
 	if err != nil {
-		return fmt.Errorf("script execution failed: %w", err)
+		return false, fmt.Errorf("script execution failed: %w", err)
+	}
+
+	return true, nil
+}
+
+// resolveTargets resolves every target path of the plan against base, so a plan
+// that reaches outside it is refused before anything is shown or written.
+func resolveTargets(presenter *ui.Presenter, base string, paths []string) ([]string, error) {
+	targets := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		target, err := codemod.ResolvePath(base, path)
+		if err != nil {
+			presenter.Error("Refusing to modify '%s': %v", path, err)
+
+			//nolint:wrapcheck // Path errors are already descriptive.
+			return nil, err
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// checkWorkingDirs refuses a plan whose command steps would run outside base,
+// before any step is applied.
+func checkWorkingDirs(presenter *ui.Presenter, base string, steps []apply.Step) error {
+	for _, step := range steps {
+		if step.Type != apply.StepTypeCommandExecution {
+			continue
+		}
+
+		_, err := step.ResolveWorkingDir(base)
+		if err != nil {
+			presenter.Error("Refusing to run '%s' in '%s': %v", step.Command, step.WorkingDir, err)
+
+			//nolint:wrapcheck // Path errors are already descriptive.
+			return err
+		}
 	}
 
 	return nil
@@ -195,6 +527,20 @@ func init() {
 
 	ApplyCmd.Short = desc.Short
 	ApplyCmd.Long = desc.Long
+	ApplyCmd.AddCommand(validate.ValidateCmd)
 	ApplyCmd.Flags().
 		StringVarP(&scriptPath, "script", "s", "", "Path to the Change Plan (JSON) or shell script to apply.")
+	ApplyCmd.Flags().
+		StringVar(&fromURL, "from-url", "", "HTTPS URL to download the Change Plan (JSON) or shell script from.")
+	ApplyCmd.MarkFlagsMutuallyExclusive("script", "from-url")
+	ApplyCmd.Flags().
+		StringVar(&baseDir, "base-dir", "",
+			"Directory that file paths are resolved against and confined to (default: repository root).")
+	ApplyCmd.Flags().
+		BoolVarP(&interactive, "interactive", "i", false, "Confirm each step individually, showing a diff for file changes.")
+	ApplyCmd.Flags().
+		BoolVar(&requireClean, "require-clean", false, "Abort if any target file has uncommitted changes.")
+	ApplyCmd.Flags().
+		BoolVar(&detailedExitCode, "detailed-exitcode", false,
+			"Exit with code 2 when changes were applied (0 = no changes, 1 = error).")
 }
@@ -5,4 +5,43 @@ in two modes:
 1. Structured Plan (JSON): This is the preferred and safer mode of operation.
 2. Fallback Script (Shell): For simple, imperative scripts.
 
-Input can be read from a file with --script or piped from standard input.
+Input can be read from a file with --script, downloaded with --from-url, or
+piped from standard input.
+
+--from-url fetches a plan shared as a gist or paste. The URL must use HTTPS,
+redirects to plain HTTP are refused, the download is limited to 1 MiB and 30
+seconds, and the server must answer with JSON or text (not an HTML page, so
+link to the raw file). The downloaded plan is shown and confirmed like any
+other input.
+
+A `bulk_rename` step moves every tracked file whose path matches a regular
+expression, optionally rewriting references to the old paths (including import
+paths) in other files. The moves are listed in the plan summary before you
+confirm, and the step needs a git repository.
+
+File operations can set `"template": true` to render their content as a Go
+text/template, using the plan's `vars` and the environment. A missing value is
+reported, with every other one, before anything is written.
+
+File paths in a Change Plan are resolved against the repository root (or the
+current directory outside a repository); --base-dir picks another directory.
+A plan that names an absolute path, or a path leading outside the base
+directory such as `../../etc/hosts`, is refused before anything is written.
+Bulk renames and the `working_dir` of command steps, which default to the
+base directory, are held to the same directory. Files are written to a
+temporary file and renamed into place, keeping their permissions, so an
+interrupted run never leaves a half-written file.
+
+For Change Plans, target files that already have uncommitted changes are
+reported before execution. Use --require-clean to abort instead.
+
+Use --interactive to confirm each step of a Change Plan individually. File
+modifications are shown as a diff, and each step can be continued, skipped, or
+used to abort the rest of the plan. --yes bypasses all prompts.
+
+With --detailed-exitcode the command exits with 2 when changes were applied
+and 0 when the plan was a no-op (1 still means an error). File modifications
+count as changes only when they alter a file; command steps and shell scripts
+always count once they have run.
+
+To check a plan without applying it, use `contextvibes factory apply validate`.
@@ -0,0 +1,430 @@
+// Package apply_test contains tests for the apply command.
+package apply_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/apply"
+	"github.com/contextvibes/cli/internal/codemod"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/exitcode"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupApplyTest(t *testing.T, plan string) *exectest.Executor {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(tempDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	require.NoError(t, os.WriteFile("plan.json", []byte(plan), 0o600))
+
+	//nolint:exhaustruct // Recorded fields start empty.
+	mockExec := &exectest.Executor{RepoDir: tempDir}
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.AssumeYes = true
+
+	t.Cleanup(func() { globals.AssumeYes = false })
+
+	return mockExec
+}
+
+func runApplyCmd(t *testing.T, args ...string) error {
+	t.Helper()
+
+	_, err := runApplyCmdWithInput(t, "", args...)
+
+	return err
+}
+
+func runApplyCmdWithInput(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := *apply.ApplyCmd
+	outBuf := new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs(args)
+
+	// Reset global flags that earlier subtests may have set.
+	_ = cmd.Flags().Set("interactive", "false")
+	_ = cmd.Flags().Set("require-clean", "false")
+	_ = cmd.Flags().Set("detailed-exitcode", "false")
+	_ = cmd.Flags().Set("base-dir", "")
+
+	err := cmd.Execute()
+
+	return outBuf.String(), err
+}
+
+//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+func TestApplyCmd_CommandExecution(t *testing.T) {
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("runs in the repo root by default", func(t *testing.T) {
+		mockExec := setupApplyTest(t, `{"steps":[{"type":"command_execution","command":"go","args":["test"]}]}`)
+
+		subDir := filepath.Join(mockExec.RepoDir, "services")
+		require.NoError(t, os.MkdirAll(subDir, 0o750))
+		//nolint:usetesting // os.Chdir is required for test setup.
+		require.NoError(t, os.Chdir(subDir))
+
+		require.NoError(t, runApplyCmd(t, "--script", "../plan.json"))
+
+		lastRun := lastExecuted(t, mockExec)
+		assert.Equal(t, mockExec.RepoDir, lastRun.Dir, "commands run where file paths are resolved")
+		assert.Nil(t, lastRun.Env)
+		assert.Equal(t, "go test", lastRun.String())
+	})
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("honors working_dir and env", func(t *testing.T) {
+		mockExec := setupApplyTest(t, `{"steps":[{
+			"type":"command_execution",
+			"command":"go",
+			"args":["build","./..."],
+			"working_dir":"services/api",
+			"env":{"CGO_ENABLED":"0","GOOS":"linux"}
+		}]}`)
+		require.NoError(t, os.MkdirAll(filepath.Join(mockExec.RepoDir, "services", "api"), 0o750))
+
+		require.NoError(t, runApplyCmd(t, "--script", "plan.json"))
+
+		lastRun := lastExecuted(t, mockExec)
+		assert.Equal(t, filepath.Join(mockExec.RepoDir, "services", "api"), lastRun.Dir)
+		assert.Equal(t, map[string]string{"CGO_ENABLED": "0", "GOOS": "linux"}, lastRun.Env)
+		assert.Equal(t, "go build ./...", lastRun.String())
+	})
+
+	for _, workingDir := range []string{"../outside", "/tmp"} {
+		//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+		t.Run("refuses working_dir "+workingDir, func(t *testing.T) {
+			mockExec := setupApplyTest(t, `{"steps":[
+				{"type":"file_modification","changes":[
+					{"file_path":"first.txt","operations":[{"type":"create_or_overwrite","content":"x\n"}]}
+				]},
+				{"type":"command_execution","command":"go","args":["test"],"working_dir":"`+workingDir+`"}
+			]}`)
+
+			err := runApplyCmd(t, "--script", "plan.json")
+			require.ErrorIs(t, err, codemod.ErrPathOutsideBase)
+
+			assert.Empty(t, mockExec.Executed(), "nothing runs")
+			assert.NoFileExists(t, "first.txt", "the plan is refused before any step is applied")
+		})
+	}
+}
+
+//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+func TestApplyCmd_Interactive(t *testing.T) {
+	plan := `{"steps":[
+		{"type":"file_modification","description":"create skipped file","changes":[
+			{"file_path":"skipped.txt","operations":[{"type":"create_or_overwrite","content":"nope\n"}]}
+		]},
+		{"type":"file_modification","description":"create kept file","changes":[
+			{"file_path":"kept.txt","operations":[{"type":"create_or_overwrite","content":"hello\n"}]}
+		]}
+	]}`
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("skips one step and executes another", func(t *testing.T) {
+		setupApplyTest(t, plan)
+		globals.AssumeYes = false
+
+		out, err := runApplyCmdWithInput(t, "s\nc\n", "--script", "plan.json", "--interactive")
+		require.NoError(t, err)
+
+		assert.NoFileExists(t, "skipped.txt")
+
+		content, err := os.ReadFile("kept.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "hello\n", string(content))
+
+		assert.Contains(t, out, "+hello")
+		assert.Contains(t, out, "Skipped step 1.")
+		assert.Contains(t, out, "Plan executed successfully.")
+	})
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("abort stops before later steps", func(t *testing.T) {
+		setupApplyTest(t, plan)
+		globals.AssumeYes = false
+
+		out, err := runApplyCmdWithInput(t, "a\n", "--script", "plan.json", "--interactive")
+		require.NoError(t, err)
+
+		assert.NoFileExists(t, "skipped.txt")
+		assert.NoFileExists(t, "kept.txt")
+		assert.Contains(t, out, "Execution aborted at step 1.")
+	})
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("--yes bypasses the per-step prompts", func(t *testing.T) {
+		setupApplyTest(t, plan)
+
+		_, err := runApplyCmdWithInput(t, "", "--script", "plan.json", "--interactive")
+		require.NoError(t, err)
+
+		assert.FileExists(t, "skipped.txt")
+		assert.FileExists(t, "kept.txt")
+	})
+}
+
+//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+func TestApplyCmd_DetailedExitCode(t *testing.T) {
+	plan := `{
+		"steps": [{
+			"type": "file_modification",
+			"description": "Rename widget",
+			"changes": [{
+				"file_path": "widget.txt",
+				"operations": [{"type": "regex_replace", "find_regex": "old", "replace_with": "new"}]
+			}]
+		}]
+	}`
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("no-op plan exits 0", func(t *testing.T) {
+		setupApplyTest(t, plan)
+		require.NoError(t, os.WriteFile("widget.txt", []byte("already new\n"), 0o600))
+
+		_, err := runApplyCmdWithInput(t, "", "--script", "plan.json", "--detailed-exitcode")
+		require.NoError(t, err)
+		assert.Equal(t, exitcode.Success, exitcode.FromError(err))
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("changed plan exits 2", func(t *testing.T) {
+		setupApplyTest(t, plan)
+		require.NoError(t, os.WriteFile("widget.txt", []byte("old widget\n"), 0o600))
+
+		_, err := runApplyCmdWithInput(t, "", "--script", "plan.json", "--detailed-exitcode")
+		require.Error(t, err)
+		assert.Equal(t, exitcode.ChangesApplied, exitcode.FromError(err))
+
+		content, readErr := os.ReadFile("widget.txt")
+		require.NoError(t, readErr)
+		assert.Equal(t, "new widget\n", string(content))
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("changes without the flag exit 0", func(t *testing.T) {
+		setupApplyTest(t, plan)
+		require.NoError(t, os.WriteFile("widget.txt", []byte("old widget\n"), 0o600))
+
+		_, err := runApplyCmdWithInput(t, "", "--script", "plan.json")
+		require.NoError(t, err)
+	})
+}
+
+//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+func TestApplyCmd_AppendToFile(t *testing.T) {
+	plan := `{"steps":[{"type":"file_modification","description":"ignore build output","changes":[
+		{"file_path":".gitignore","operations":[
+			{"type":"append_to_file","content":"dist/","ensure_newline":true,"skip_if_contains":"dist/"}
+		]}
+	]}]}`
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("creates a missing file", func(t *testing.T) {
+		setupApplyTest(t, plan)
+
+		require.NoError(t, runApplyCmd(t, "--script", "plan.json"))
+
+		content, err := os.ReadFile(".gitignore")
+		require.NoError(t, err)
+		assert.Equal(t, "dist/\n", string(content))
+	})
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("appends to an existing file once", func(t *testing.T) {
+		setupApplyTest(t, plan)
+		require.NoError(t, os.WriteFile(".gitignore", []byte("node_modules/"), 0o600))
+
+		require.NoError(t, runApplyCmd(t, "--script", "plan.json"))
+		require.NoError(t, runApplyCmd(t, "--script", "plan.json"))
+
+		content, err := os.ReadFile(".gitignore")
+		require.NoError(t, err)
+		assert.Equal(t, "node_modules/\ndist/\n", string(content))
+	})
+}
+
+//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+func TestApplyCmd_TemplatedContent(t *testing.T) {
+	plan := `{"vars":{"SERVICE":"billing"},"steps":[{"type":"file_modification","changes":[
+		{"file_path":"service.yaml","operations":[
+			{"type":"create_or_overwrite","template":true,"content":"name: {{.SERVICE}}\nregion: {{.APPLY_TEST_REGION}}\n"}
+		]}
+	]}]}`
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("renders plan vars and environment", func(t *testing.T) {
+		setupApplyTest(t, plan)
+		t.Setenv("APPLY_TEST_REGION", "europe-west1")
+
+		require.NoError(t, runApplyCmd(t, "--script", "plan.json"))
+
+		content, err := os.ReadFile("service.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "name: billing\nregion: europe-west1\n", string(content))
+	})
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("missing variable stops the plan before any write", func(t *testing.T) {
+		setupApplyTest(t, plan)
+
+		err := runApplyCmd(t, "--script", "plan.json")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `map has no entry for key "APPLY_TEST_REGION"`)
+		assert.NoFileExists(t, "service.yaml")
+	})
+}
+
+//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+func TestApplyCmd_BulkRename(t *testing.T) {
+	plan := `{"steps":[{
+		"type":"bulk_rename",
+		"description":"rename the legacy package",
+		"find_regex":"^internal/legacy/",
+		"replace_with":"internal/billing/",
+		"path_glob":"internal/**",
+		"update_references":true
+	}]}`
+
+	mockExec := setupApplyTest(t, plan)
+	files := map[string]string{
+		"internal/legacy/invoice.go":     "package legacy\n",
+		"internal/legacy/tax/tax.go":     "package tax\n",
+		"cmd/main.go":                    "import \"example.com/app/internal/legacy\"\nimport \"example.com/app/internal/legacy/tax\"\n",
+		"internal/legacyfree/keep.go":    "package legacyfree\n",
+		"docs/layout.md":                 "See internal/legacy/invoice.go.\n",
+		"internal/legacy/testdata/a.bin": "\x00\x01",
+	}
+
+	tracked := make([]string, 0, len(files))
+
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o750))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+		tracked = append(tracked, name)
+	}
+
+	slices.Sort(tracked)
+
+	mockExec.Responses = map[string]exectest.Response{
+		"git ls-files -z --full-name": {Stdout: strings.Join(tracked, "\x00"), Stderr: "", Err: nil},
+	}
+
+	out, err := runApplyCmdWithInput(t, "", "--script", "plan.json")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "internal/legacy/invoice.go -> internal/billing/invoice.go")
+	assert.FileExists(t, "internal/billing/invoice.go")
+	assert.FileExists(t, "internal/billing/tax/tax.go")
+	assert.FileExists(t, "internal/billing/testdata/a.bin")
+	assert.NoDirExists(t, "internal/legacy", "emptied directories are removed")
+	assert.FileExists(t, "internal/legacyfree/keep.go")
+
+	mainGo, err := os.ReadFile("cmd/main.go")
+	require.NoError(t, err)
+	assert.Equal(t,
+		"import \"example.com/app/internal/billing\"\nimport \"example.com/app/internal/billing/tax\"\n",
+		string(mainGo))
+
+	layout, err := os.ReadFile("docs/layout.md")
+	require.NoError(t, err)
+	assert.Equal(t, "See internal/billing/invoice.go.\n", string(layout))
+}
+
+//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+func TestApplyCmd_BaseDirConfinement(t *testing.T) {
+	planFor := func(filePath string) string {
+		return `{"steps":[{"type":"file_modification","changes":[
+			{"file_path":"` + filePath + `","operations":[{"type":"create_or_overwrite","content":"pwned"}]}
+		]}]}`
+	}
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("rejects paths that climb out", func(t *testing.T) {
+		setupApplyTest(t, planFor("../../etc/x"))
+
+		err := runApplyCmd(t, "--script", "plan.json")
+		require.ErrorIs(t, err, codemod.ErrPathOutsideBase)
+	})
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("rejects absolute paths", func(t *testing.T) {
+		target := filepath.Join(t.TempDir(), "x")
+		setupApplyTest(t, planFor(filepath.ToSlash(target)))
+
+		err := runApplyCmd(t, "--script", "plan.json")
+		require.ErrorIs(t, err, codemod.ErrPathOutsideBase)
+		assert.NoFileExists(t, target)
+	})
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("rejects symlinks out of the base directory", func(t *testing.T) {
+		outside := t.TempDir()
+		setupApplyTest(t, planFor("link/x"))
+		require.NoError(t, os.Symlink(outside, "link"))
+
+		err := runApplyCmd(t, "--script", "plan.json")
+		require.ErrorIs(t, err, codemod.ErrPathOutsideBase)
+		assert.NoFileExists(t, filepath.Join(outside, "x"))
+	})
+
+	//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+	t.Run("resolves paths against --base-dir", func(t *testing.T) {
+		setupApplyTest(t, planFor("config/app.txt"))
+		require.NoError(t, os.Mkdir("service", 0o750))
+
+		require.NoError(t, runApplyCmd(t, "--script", "plan.json", "--base-dir", "service"))
+
+		content, err := os.ReadFile(filepath.Join("service", "config", "app.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "pwned", string(content))
+	})
+}
+
+//nolint:paralleltest // ApplyCmd uses global flags and changes the working directory.
+func TestApplyCmd_PreservesPermissions(t *testing.T) {
+	setupApplyTest(t, `{"steps":[{"type":"file_modification","changes":[
+		{"file_path":"build.sh","operations":[{"type":"regex_replace","find_regex":"old","replace_with":"new"}]}
+	]}]}`)
+	require.NoError(t, os.WriteFile("build.sh", []byte("echo old\n"), 0o600))
+	require.NoError(t, os.Chmod("build.sh", 0o750))
+
+	require.NoError(t, runApplyCmd(t, "--script", "plan.json"))
+
+	info, err := os.Stat("build.sh")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o750), info.Mode().Perm(), "the executable bit survives the rewrite")
+}
+
+// lastExecuted returns the most recent command that ran without capturing its output.
+func lastExecuted(t *testing.T, executor *exectest.Executor) exectest.Call {
+	t.Helper()
+
+	executed := executor.Executed()
+	require.NotEmpty(t, executed)
+
+	return executed[len(executed)-1]
+}
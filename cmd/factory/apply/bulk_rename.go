@@ -0,0 +1,184 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/apply"
+	"github.com/contextvibes/cli/internal/codemod"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/contextvibes/cli/internal/ui"
+)
+
+// errBulkRenameNeedsRepo is returned for bulk_rename steps outside a git repository,
+// where there is no list of tracked files to rename.
+var errBulkRenameNeedsRepo = errors.New("bulk_rename requires a git repository")
+
+// planBulkRename lists the tracked files and works out the moves of a bulk_rename
+// step. Every source and destination must be inside base.
+func planBulkRename(
+	ctx context.Context,
+	gitClient *git.GitClient,
+	base string,
+	step apply.Step,
+) ([]apply.Rename, []string, error) {
+	if gitClient == nil {
+		return nil, nil, errBulkRenameNeedsRepo
+	}
+
+	tracked, err := gitClient.ListTrackedFiles(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bulk rename failed: %w", err)
+	}
+
+	renames, err := step.PlanRenames(tracked)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bulk rename failed: %w", err)
+	}
+
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve base directory '%s': %w", base, err)
+	}
+
+	for _, rename := range renames {
+		for _, renamePath := range []string{rename.From, rename.To} {
+			err = codemod.EnsureWithin(absBase, filepath.Join(gitClient.Path(), filepath.FromSlash(renamePath)))
+			if err != nil {
+				return nil, nil, fmt.Errorf("bulk rename failed: %w", err)
+			}
+		}
+	}
+
+	return renames, tracked, nil
+}
+
+// previewBulkRename prints the moves a bulk_rename step will make, so they are
+// seen before the plan is confirmed.
+func previewBulkRename(
+	ctx context.Context,
+	presenter *ui.Presenter,
+	gitClient *git.GitClient,
+	base string,
+	step apply.Step,
+) error {
+	renames, _, err := planBulkRename(ctx, gitClient, base, step)
+	if err != nil {
+		return err
+	}
+
+	if len(renames) == 0 {
+		presenter.Detail("No tracked files match; nothing will be renamed.")
+
+		return nil
+	}
+
+	for _, rename := range renames {
+		presenter.Detail("%s -> %s", rename.From, rename.To)
+	}
+
+	if step.UpdateReferences {
+		presenter.Detail("References to the old paths in other tracked files will be updated.")
+	}
+
+	return nil
+}
+
+// executeBulkRenameStep moves the matching tracked files, removes directories
+// left empty, and optionally rewrites references to the old paths. It returns
+// the number of files renamed or rewritten.
+func executeBulkRenameStep(ctx context.Context, gitClient *git.GitClient, base string, step apply.Step) (int, error) {
+	renames, tracked, err := planBulkRename(ctx, gitClient, base, step)
+	if err != nil {
+		return 0, err
+	}
+
+	root := gitClient.Path()
+
+	for _, rename := range renames {
+		from := filepath.Join(root, filepath.FromSlash(rename.From))
+		to := filepath.Join(root, filepath.FromSlash(rename.To))
+
+		//nolint:mnd // 0750 is standard directory permission.
+		err = os.MkdirAll(filepath.Dir(to), 0o750)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create directory for '%s': %w", rename.To, err)
+		}
+
+		err = os.Rename(from, to)
+		if err != nil {
+			return 0, fmt.Errorf("failed to rename '%s' to '%s': %w", rename.From, rename.To, err)
+		}
+
+		removeEmptyParents(root, filepath.Dir(from))
+	}
+
+	changed := len(renames)
+
+	if step.UpdateReferences && len(renames) > 0 {
+		rewritten, err := rewriteTrackedReferences(root, renames, tracked)
+		if err != nil {
+			return changed, err
+		}
+
+		changed += rewritten
+	}
+
+	return changed, nil
+}
+
+// rewriteTrackedReferences updates mentions of renamed paths in every tracked
+// text file, at its new location if it moved itself.
+func rewriteTrackedReferences(root string, renames []apply.Rename, tracked []string) (int, error) {
+	newPathOf := make(map[string]string, len(renames))
+	for _, rename := range renames {
+		newPathOf[rename.From] = rename.To
+	}
+
+	rewritten := 0
+
+	for _, trackedPath := range tracked {
+		if newPath, ok := newPathOf[trackedPath]; ok {
+			trackedPath = newPath
+		}
+
+		filePath := filepath.Join(root, filepath.FromSlash(trackedPath))
+
+		//nolint:gosec // Tracked files of the repository are read by design.
+		content, err := os.ReadFile(filePath)
+		if err != nil || tools.IsBinaryContent(content) {
+			continue
+		}
+
+		updated := apply.RewriteReferences(string(content), renames, tracked)
+		if updated == string(content) {
+			continue
+		}
+
+		//nolint:mnd // 0600 is standard file permission.
+		err = tools.ReplaceFile(filePath, strings.NewReader(updated), 0o600)
+		if err != nil {
+			return rewritten, fmt.Errorf("failed to update references in '%s': %w", trackedPath, err)
+		}
+
+		rewritten++
+	}
+
+	return rewritten, nil
+}
+
+// removeEmptyParents deletes dir and its parents while they are empty, stopping at root.
+func removeEmptyParents(root, dir string) {
+	for dir != root && len(dir) > len(root) {
+		if os.Remove(dir) != nil {
+			return
+		}
+
+		dir = filepath.Dir(dir)
+	}
+}
@@ -0,0 +1,119 @@
+// Package validate provides the command to check a Change Plan without applying it.
+package validate
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/contextvibes/cli/internal/apply"
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed validate.md.tpl
+var validateLongDescription string
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var scriptPath string
+
+// ValidateCmd represents the apply validate command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var ValidateCmd = &cobra.Command{
+	Use: "validate [--script <file>]",
+	Example: `  contextvibes factory apply validate --script ./plan.json
+  cat plan.json | contextvibes factory apply validate`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+
+		data, source, err := readPlan(cmd.InOrStdin())
+		if err != nil {
+			presenter.Error("Failed to read input: %v", err)
+
+			return err
+		}
+
+		if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+			presenter.Error("The %s is not a JSON Change Plan; shell scripts cannot be validated.", source)
+
+			return apply.ErrInvalidPlan
+		}
+
+		var plan apply.ChangePlan
+
+		err = json.Unmarshal(data, &plan)
+		if err != nil {
+			presenter.Error("Failed to parse JSON Change Plan: %v", err)
+
+			return fmt.Errorf("%w: %w", apply.ErrInvalidPlan, err)
+		}
+
+		err = plan.Validate()
+		if err == nil {
+			err = plan.CheckTemplates(plan.TemplateData(os.Environ()))
+		}
+
+		if err != nil {
+			var validationErr *apply.ValidationError
+			if errors.As(err, &validationErr) {
+				presenter.Error("The Change Plan is invalid (%d problem(s)):", len(validationErr.Problems))
+
+				for _, problem := range validationErr.Problems {
+					presenter.Detail("%s", problem)
+				}
+			}
+
+			//nolint:wrapcheck // Validation errors are already descriptive.
+			return err
+		}
+
+		presenter.Success("The Change Plan is valid (%d step(s)).", len(plan.Steps))
+
+		return nil
+	},
+}
+
+// readPlan reads the plan from --script, or from stdin when no file is given.
+func readPlan(stdin io.Reader) ([]byte, string, error) {
+	if scriptPath != "" {
+		//nolint:gosec // Reading user-provided script file is intended.
+		content, err := os.ReadFile(scriptPath)
+		if err != nil {
+			return nil, "file", fmt.Errorf("failed to read script file: %w", err)
+		}
+
+		return content, "file", nil
+	}
+
+	content, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, "standard input", fmt.Errorf("failed to read from stdin: %w", err)
+	}
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		//nolint:err113 // Dynamic error is appropriate here.
+		return nil, "", errors.New("no plan provided via --script flag or standard input")
+	}
+
+	return content, "standard input", nil
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(validateLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	ValidateCmd.Short = desc.Short
+	ValidateCmd.Long = desc.Long
+	ValidateCmd.Flags().StringVarP(&scriptPath, "script", "s", "", "Path to the Change Plan (JSON) to validate.")
+}
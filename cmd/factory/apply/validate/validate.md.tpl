@@ -0,0 +1,10 @@
+# Validates a Change Plan without applying it.
+
+Parses a JSON Change Plan and runs the same checks `factory apply` performs
+before executing anything: known step and operation types, required fields, and
+regular expressions that compile. Nothing is written and nothing is run, and
+there is no confirmation prompt.
+
+The plan is read from the file given with --script, or from standard input.
+The command prints OK or the full list of problems, and exits non-zero when any
+are found, which makes it suitable for checking AI-generated plans in CI.
@@ -0,0 +1,87 @@
+// Package validate_test contains tests for the apply validate command.
+package validate_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/apply/validate"
+	"github.com/contextvibes/cli/internal/apply"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runValidate(t *testing.T, input string, args ...string) (string, string, error) {
+	t.Helper()
+
+	cmd := *validate.ValidateCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs(args)
+
+	_ = cmd.Flags().Set("script", "")
+
+	err := cmd.Execute()
+
+	return outBuf.String(), errBuf.String(), err
+}
+
+//nolint:paralleltest // ValidateCmd uses global flags.
+func TestValidateCmd(t *testing.T) {
+	//nolint:paralleltest // ValidateCmd uses global flags.
+	t.Run("valid plan from a file", func(t *testing.T) {
+		planPath := filepath.Join(t.TempDir(), "plan.json")
+		plan := `{"steps":[
+			{"type":"command_execution","command":"go","args":["test"]},
+			{"type":"file_modification","changes":[{"file_path":"a.go","operations":[
+				{"type":"regex_replace","find_regex":"foo","replace_with":"bar"}
+			]}]}
+		]}`
+		require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+
+		out, _, err := runValidate(t, "", "--script", planPath)
+		require.NoError(t, err)
+		assert.Contains(t, out, "The Change Plan is valid (2 step(s)).")
+	})
+
+	//nolint:paralleltest // ValidateCmd uses global flags.
+	t.Run("every problem is reported", func(t *testing.T) {
+		plan := `{"steps":[
+			{"type":"command_execution"},
+			{"type":"deploy_everything"},
+			{"type":"file_modification","changes":[{"operations":[
+				{"type":"regex_replace","find_regex":"("}
+			]}]}
+		]}`
+
+		out, errOut, err := runValidate(t, plan)
+		require.ErrorIs(t, err, apply.ErrInvalidPlan)
+		assert.Contains(t, errOut, "The Change Plan is invalid (4 problem(s)):")
+		assert.Contains(t, out, "step 1: command_execution requires a command")
+		assert.Contains(t, out, "step 2: unknown step type 'deploy_everything'")
+		assert.Contains(t, out, "step 3, change 1: file_path is required")
+		assert.Contains(t, out, "step 3, change 1, operation 1: invalid find_regex")
+		assert.NotContains(t, out, "valid (")
+	})
+
+	//nolint:paralleltest // ValidateCmd uses global flags.
+	t.Run("malformed json", func(t *testing.T) {
+		_, errOut, err := runValidate(t, `{"steps": [`)
+		require.ErrorIs(t, err, apply.ErrInvalidPlan)
+		assert.Contains(t, errOut, "Failed to parse JSON Change Plan")
+	})
+
+	//nolint:paralleltest // ValidateCmd uses global flags.
+	t.Run("shell scripts are rejected", func(t *testing.T) {
+		_, errOut, err := runValidate(t, "echo hello\n")
+		require.ErrorIs(t, err, apply.ErrInvalidPlan)
+		assert.Contains(t, errOut, "not a JSON Change Plan")
+	})
+}
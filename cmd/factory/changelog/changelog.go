@@ -0,0 +1,116 @@
+// Package changelog provides the command to generate a changelog from the commit history.
+package changelog
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	"github.com/contextvibes/cli/internal/changelog"
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed changelog.md.tpl
+var changelogLongDescription string
+
+// filePermUserRW represents read/write permissions for the user (0600).
+const filePermUserRW = 0o600
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	fromRef    string
+	toRef      string
+	outputPath string
+)
+
+// ChangelogCmd represents the changelog command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var ChangelogCmd = &cobra.Command{
+	Use: "changelog [--from <ref>] [--to <ref>] [--output <file>]",
+	Example: `  contextvibes factory changelog
+  contextvibes factory changelog --from v1.2.0 --to v1.3.0 --output CHANGELOG-1.3.0.md`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+
+		//nolint:exhaustruct // Partial config is sufficient.
+		gitClient, err := git.NewClient(ctx, ".", git.GitClientConfig{
+			Logger:            globals.AppLogger,
+			DefaultRemoteName: globals.LoadedAppConfig.Git.DefaultRemote,
+			Executor:          globals.ExecClient.UnderlyingExecutor(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to initialize git client: %w", err)
+		}
+
+		from := fromRef
+		if from == "" {
+			from, err = gitClient.GetLatestTag(ctx)
+			if err != nil {
+				presenter.Error("Failed to find the latest tag: %v", err)
+
+				return fmt.Errorf("failed to find latest tag: %w", err)
+			}
+		}
+
+		revRange := toRef
+		if from != "" {
+			revRange = from + ".." + toRef
+		}
+
+		commits, err := gitClient.GetLog(ctx, revRange, 0)
+		if err != nil {
+			presenter.Error("Failed to read commit history for '%s': %v", revRange, err)
+
+			return fmt.Errorf("failed to read commit history: %w", err)
+		}
+
+		title := toRef
+		if toRef == "HEAD" {
+			title = "Unreleased"
+		}
+
+		markdown := changelog.Render(commits, changelog.Options{
+			Title:   title,
+			RepoURL: changelog.RepoURL(ctx, gitClient),
+		})
+
+		if outputPath == "" {
+			//nolint:errcheck // Printing to stdout is best effort.
+			fmt.Fprint(presenter.Out(), markdown)
+
+			return nil
+		}
+
+		err = os.WriteFile(outputPath, []byte(markdown), filePermUserRW)
+		if err != nil {
+			return fmt.Errorf("failed to write changelog to %s: %w", outputPath, err)
+		}
+
+		presenter.Success("Changelog with %d commit(s) written to %s", len(commits), outputPath)
+
+		return nil
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(changelogLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	ChangelogCmd.Short = desc.Short
+	ChangelogCmd.Long = desc.Long
+	ChangelogCmd.Flags().
+		StringVar(&fromRef, "from", "", "Start of the range, exclusive (defaults to the latest tag)")
+	ChangelogCmd.Flags().StringVar(&toRef, "to", "HEAD", "End of the range, inclusive")
+	ChangelogCmd.Flags().
+		StringVarP(&outputPath, "output", "o", "", "Write the changelog to this file instead of standard output")
+}
@@ -0,0 +1,13 @@
+# Generates a Markdown changelog from Conventional Commits.
+
+Reads the commit history between two refs and groups the commits by their
+Conventional Commit type (Features, Bug Fixes, Documentation, ...). Breaking
+changes (`feat!:` or a `BREAKING CHANGE` footer) are listed first, and commits
+that do not follow the format are collected under "Other Changes".
+
+By default the range starts at the most recent tag (or the first commit when
+there are no tags) and ends at `HEAD`. Use `--from` and `--to` to pick another
+range. When the default remote (`git.defaultRemote`) points at GitHub, issue references like `#42`
+and commit hashes are linked.
+
+The changelog is printed to standard output unless `--output` names a file.
@@ -0,0 +1,113 @@
+// Package cleanup provides the command to delete merged local branches.
+package cleanup
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed cleanup.md.tpl
+var cleanupLongDescription string
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var baseFlag string
+
+// CleanupCmd represents the cleanup command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var CleanupCmd = &cobra.Command{
+	Use: "cleanup [--base <branch>]",
+	Example: `  contextvibes factory cleanup
+  contextvibes factory cleanup --base develop --yes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+
+		//nolint:exhaustruct // Partial config is sufficient.
+		gitClient, err := git.NewClient(ctx, ".", git.GitClientConfig{
+			Logger:                globals.AppLogger,
+			DefaultRemoteName:     globals.LoadedAppConfig.Git.DefaultRemote,
+			DefaultMainBranchName: globals.LoadedAppConfig.Git.DefaultMainBranch,
+			Executor:              globals.ExecClient.UnderlyingExecutor(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to initialize git client: %w", err)
+		}
+
+		base := baseFlag
+		if base == "" {
+			base = gitClient.MainBranchName()
+		}
+
+		presenter.Summary("Finding branches merged into '%s'...", base)
+
+		branches, err := gitClient.ListMergedBranches(ctx, base)
+		if err != nil {
+			presenter.Error("Failed to list merged branches: %v", err)
+
+			return fmt.Errorf("failed to list merged branches: %w", err)
+		}
+
+		if len(branches) == 0 {
+			presenter.Info("No merged branches to clean up.")
+
+			return nil
+		}
+
+		for _, branch := range branches {
+			presenter.Detail("%s", branch)
+		}
+
+		if !globals.AssumeYes {
+			confirmed, err := presenter.PromptForConfirmation(
+				fmt.Sprintf("Delete %d merged branch(es)?", len(branches)),
+			)
+			if err != nil {
+				return fmt.Errorf("confirmation failed: %w", err)
+			}
+
+			if !confirmed {
+				presenter.Info("Aborted by user.")
+
+				return nil
+			}
+		}
+
+		deleted := 0
+
+		for _, branch := range branches {
+			err := gitClient.DeleteLocalBranch(ctx, branch, false)
+			if err != nil {
+				presenter.Warning("Skipped '%s': %v", branch, err)
+
+				continue
+			}
+
+			deleted++
+		}
+
+		presenter.Success("Deleted %d of %d merged branch(es).", deleted, len(branches))
+
+		return nil
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(cleanupLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	CleanupCmd.Short = desc.Short
+	CleanupCmd.Long = desc.Long
+	CleanupCmd.Flags().
+		StringVar(&baseFlag, "base", "", "Branch to check merges against (defaults to the main branch)")
+}
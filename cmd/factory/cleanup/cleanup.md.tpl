@@ -0,0 +1,8 @@
+# Deletes local branches that have been merged into main.
+
+Lists the local branches whose work is already contained in the main branch (or
+the branch given with `--base`) and deletes them after confirmation. Branches
+are removed with `git branch -d`, so git still refuses anything that is not
+fully merged. The current branch and the main branch are never deleted.
+
+Use `tidy` instead to finish the single branch you are currently on.
@@ -5,7 +5,6 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/contextvibes/cli/internal/cmddocs"
@@ -13,6 +12,7 @@ import (
 	"github.com/contextvibes/cli/internal/git"
 	"github.com/contextvibes/cli/internal/globals"
 	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -43,30 +43,21 @@ var CommitCmd = &cobra.Command{
 		fullMessage := strings.Join(commitMessages, "\n\n")
 
 		// 2. Validate ONLY the Subject (First line)
-		// We split by newline to isolate the subject for regex checking.
-		subject, _, _ := strings.Cut(fullMessage, "\n")
+		subject := config.CommitSubject(fullMessage)
 
-		validationRule := globals.LoadedAppConfig.Validation.CommitMessage
-		validationEnabled := validationRule.Enable == nil || *validationRule.Enable
-
-		if validationEnabled {
-			pattern := validationRule.Pattern
-			if pattern == "" {
-				pattern = config.DefaultCommitMessagePattern
-			}
-			re, err := regexp.Compile(pattern)
-			if err != nil {
-				//nolint:err113 // Dynamic error is appropriate here.
-				return errors.New("invalid commit message validation regex")
-			}
-			if !re.MatchString(subject) {
+		err := config.ValidateCommitMessage(globals.LoadedAppConfig.Validation.CommitMessage, fullMessage)
+		if err != nil {
+			if errors.Is(err, config.ErrInvalidCommitMessage) {
 				presenter.Error("Invalid commit subject format.")
 				presenter.Detail("Subject: %s", subject)
-				presenter.Advice("Subject must match pattern: %s", pattern)
-
-				//nolint:err113 // Dynamic error is appropriate here.
-				return errors.New("invalid commit message format")
+				presenter.Advice(
+					"Subject must match pattern: %s",
+					globals.LoadedAppConfig.Validation.CommitMessage.PatternOrDefault(config.DefaultCommitMessagePattern),
+				)
 			}
+
+			//nolint:wrapcheck // Validation errors are already descriptive.
+			return err
 		}
 
 		// 3. Initialize Git Client
@@ -82,6 +73,17 @@ var CommitCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize git client: %w", err)
 		}
 
+		detached, err := client.IsDetachedHead(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check HEAD: %w", err)
+		}
+
+		if detached {
+			workflow.AdviseDetachedHead(presenter, client.MainBranchName())
+
+			return fmt.Errorf("cannot commit: %w", git.ErrDetachedHead)
+		}
+
 		// 4. Stage Changes
 		if err := client.AddAll(ctx); err != nil {
 			return fmt.Errorf("failed to stage changes: %w", err)
@@ -97,7 +99,20 @@ var CommitCmd = &cobra.Command{
 			return nil
 		}
 
-		// 5. Confirm and Commit
+		// 5. Scan for Secrets (opt-in)
+		if globals.LoadedAppConfig.Behavior.ScanSecretsBeforeCommit {
+			scan := &workflow.SecretsScanStep{
+				ExecClient: globals.ExecClient,
+				Presenter:  presenter,
+				Dir:        client.Path(),
+			}
+			if err := scan.Execute(ctx); err != nil {
+				//nolint:wrapcheck // Scan errors are already descriptive.
+				return err
+			}
+		}
+
+		// 6. Confirm and Commit
 		currentBranch, _ := client.GetCurrentBranchName(ctx)
 		statusOutput, _, _ := client.GetStatusShort(ctx)
 
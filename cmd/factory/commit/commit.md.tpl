@@ -5,3 +5,7 @@ and commits them locally using the message provided via the -m/--message flag.
 
 Commit message validation is active by default, expecting a Conventional Commits format.
 This can be configured in '.contextvibes.yaml'.
+
+Set `behavior.scanSecretsBeforeCommit: true` to run `gitleaks protect --staged`
+on the staged changes before committing. Any finding aborts the commit. The scan
+is skipped with a warning when gitleaks is not installed.
@@ -0,0 +1,86 @@
+// Package commit_test contains tests for the commit command.
+package commit_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/commit"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errExit = errors.New("exit status 1")
+
+func runCommit(t *testing.T, detached bool) (*exectest.Executor, string, error) {
+	t.Helper()
+
+	repoDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(repoDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	mockExec := newCommitExecutor(repoDir, detached)
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+
+	cmd := *commit.CommitCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{"-m", "feat(auth): add login"})
+
+	err = cmd.Execute()
+
+	return mockExec, outBuf.String() + errBuf.String(), err
+}
+
+//nolint:paralleltest // CommitCmd uses global state which is not thread-safe.
+func TestCommitCmd_DetachedHead(t *testing.T) {
+	//nolint:paralleltest // CommitCmd uses global state which is not thread-safe.
+	t.Run("refuses to commit with advice", func(t *testing.T) {
+		mockExec, out, err := runCommit(t, true)
+		require.ErrorIs(t, err, git.ErrDetachedHead)
+
+		assert.NotContains(t, mockExec.Commands(), "git add .", "nothing is staged on a detached HEAD")
+		assert.Contains(t, out, "HEAD is detached")
+		assert.Contains(t, out, "git switch main")
+		assert.Contains(t, out, "git switch -c <new-branch>")
+	})
+
+	//nolint:paralleltest // CommitCmd uses global state which is not thread-safe.
+	t.Run("continues on a branch", func(t *testing.T) {
+		mockExec, out, err := runCommit(t, false)
+		require.NoError(t, err)
+
+		assert.Contains(t, mockExec.Commands(), "git add .")
+		assert.NotContains(t, out, "HEAD is detached")
+	})
+}
+
+// newCommitExecutor stubs git, reporting a detached HEAD when detached is set.
+func newCommitExecutor(repoDir string, detached bool) *exectest.Executor {
+	//nolint:exhaustruct // Recorded fields start empty.
+	executor := &exectest.Executor{RepoDir: repoDir}
+	if detached {
+		executor.Responses = map[string]exectest.Response{
+			"git symbolic-ref --quiet HEAD": {Stdout: "", Stderr: "", Err: errExit},
+		}
+	}
+
+	return executor
+}
@@ -0,0 +1,156 @@
+// Package createrepo provides the command to create a GitHub repository.
+package createrepo
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed createrepo.md.tpl
+var createRepoLongDescription string
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	isPrivate   bool
+	description string
+	org         string
+	setRemote   bool
+)
+
+// newGHClient is the factory used to obtain the GitHub client. It is a variable
+// so tests can substitute a client pointed at a test server.
+//
+//nolint:gochecknoglobals // Replaceable factory for tests.
+var newGHClient = defaultGHClient
+
+// defaultGHClient returns a GitHub client for creating name. Creation does not
+// depend on the current repository, so no remote discovery is needed.
+func defaultGHClient(ctx context.Context, logger *slog.Logger, owner, name string) (*github.Client, error) {
+	client, err := github.NewClient(ctx, logger, owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create github client: %w", err)
+	}
+
+	return client, nil
+}
+
+// CreateRepoCmd represents the factory create-repo command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var CreateRepoCmd = &cobra.Command{
+	Use: "create-repo <name>",
+	Example: `  contextvibes factory create-repo widgets --private --description "Widget service"
+  contextvibes factory create-repo widgets --org octo --set-remote`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+		name := args[0]
+
+		client, err := newGHClient(ctx, globals.AppLogger, org, name)
+		if err != nil {
+			presenter.Error("Failed to initialize GitHub client: %v", err)
+
+			return err
+		}
+
+		visibility := "public"
+		if isPrivate {
+			visibility = "private"
+		}
+
+		presenter.Summary("Creating %s repository '%s'...", visibility, qualifiedName(org, name))
+
+		repo, err := client.CreateRepo(ctx, org, name, description, isPrivate)
+		if errors.Is(err, github.ErrRepoAlreadyExists) {
+			presenter.Warning("Repository '%s' already exists; nothing was created.", qualifiedName(org, name))
+			presenter.Advice("Choose a different name, or clone the existing repository.")
+
+			return nil
+		}
+
+		if err != nil {
+			presenter.Error("Failed to create repository: %v", err)
+
+			return fmt.Errorf("failed to create repository: %w", err)
+		}
+
+		presenter.Success("Created repository: %s", repo.GetHTMLURL())
+
+		if setRemote {
+			addRemote(ctx, presenter, repo.GetCloneURL())
+		}
+
+		return nil
+	},
+}
+
+// addRemote adds cloneURL as the default remote of the current repository. It
+// only reports problems, since the repository itself was already created.
+func addRemote(ctx context.Context, presenter *ui.Presenter, cloneURL string) {
+	remoteName := globals.LoadedAppConfig.Git.DefaultRemote
+
+	//nolint:exhaustruct // Partial config is sufficient for adding a remote.
+	gitClient, err := git.NewClient(ctx, ".", git.GitClientConfig{
+		Executor: globals.ExecClient.UnderlyingExecutor(),
+		Logger:   globals.AppLogger,
+	})
+	if err != nil {
+		presenter.Warning("Not inside a Git repository; the remote was not set.")
+		presenter.Advice("Run 'git init && git remote add %s %s' to connect a local repository.", remoteName, cloneURL)
+
+		return
+	}
+
+	existing, err := gitClient.GetRemoteURL(ctx, remoteName)
+	if err == nil {
+		presenter.Warning("Remote '%s' already points to %s; leaving it unchanged.", remoteName, existing)
+		presenter.Advice("Run 'git remote set-url %s %s' to switch it.", remoteName, cloneURL)
+
+		return
+	}
+
+	err = gitClient.AddRemote(ctx, remoteName, cloneURL)
+	if err != nil {
+		presenter.Warning("Failed to add remote '%s': %v", remoteName, err)
+
+		return
+	}
+
+	presenter.Success("Added remote '%s': %s", remoteName, cloneURL)
+}
+
+func qualifiedName(owner, name string) string {
+	if owner == "" {
+		return name
+	}
+
+	return owner + "/" + name
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(createRepoLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	CreateRepoCmd.Short = desc.Short
+	CreateRepoCmd.Long = desc.Long
+
+	CreateRepoCmd.Flags().BoolVar(&isPrivate, "private", false, "Create a private repository (default is public)")
+	CreateRepoCmd.Flags().StringVarP(&description, "description", "d", "", "A short description of the repository")
+	CreateRepoCmd.Flags().StringVar(&org, "org", "", "Organization to own the repository (default is your account)")
+	CreateRepoCmd.Flags().
+		BoolVar(&setRemote, "set-remote", false, "Add the new repository as the default remote of the current repository")
+}
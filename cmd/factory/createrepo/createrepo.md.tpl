@@ -0,0 +1,12 @@
+# Create a new GitHub repository.
+
+Creates a repository on GitHub, owned by you or, with `--org`, by an
+organization. The repository is public unless `--private` is given, and it is
+initialized with an empty commit so it can be cloned right away.
+
+With `--set-remote`, the new repository is added as the default remote
+(usually `origin`) of the current Git repository. An existing remote of that
+name is left unchanged.
+
+If a repository with the same name already exists, nothing is created and the
+command exits successfully with a warning.
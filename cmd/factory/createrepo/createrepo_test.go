@@ -0,0 +1,167 @@
+package createrepo_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/createrepo"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/globals"
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errExit = errors.New("exit status 2")
+
+// reposAPI stubs repository creation, recording the request path and body, or
+// answering that the name is taken when exists is set.
+type reposAPI struct {
+	exists bool
+	path   string
+	body   map[string]any
+}
+
+func (a *reposAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	a.path = r.URL.Path
+	_ = json.NewDecoder(r.Body).Decode(&a.body)
+
+	if a.exists {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = io.WriteString(w, `{"message": "Repository creation failed.",
+			"errors": [{"resource": "Repository", "code": "custom", "field": "name",
+			"message": "name already exists on this account"}]}`)
+
+		return
+	}
+
+	_, _ = io.WriteString(w, `{"html_url": "https://github.com/octo/widgets",
+		"clone_url": "https://github.com/octo/widgets.git"}`)
+}
+
+func runCreateRepo(t *testing.T, api *reposAPI, args ...string) (*exectest.Executor, string, error) {
+	t.Helper()
+
+	repoDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(repoDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	restClient := gogithub.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+
+	restClient.BaseURL = baseURL
+	createrepo.SetClient(t, github.NewClientWithAPI(restClient, slog.New(slog.DiscardHandler), "", "widgets"))
+
+	mockExec := newRemoteExecutor(repoDir)
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+
+	cmd := *createrepo.CreateRepoCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs(args)
+
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		require.NoError(t, flag.Value.Set(flag.DefValue))
+		flag.Changed = false
+	})
+
+	err = cmd.Execute()
+
+	return mockExec, outBuf.String() + errBuf.String(), err
+}
+
+//nolint:paralleltest // CreateRepoCmd uses global state which is not thread-safe.
+func TestCreateRepoCmd_Visibility(t *testing.T) {
+	//nolint:paralleltest // CreateRepoCmd uses global state which is not thread-safe.
+	t.Run("public for the authenticated user", func(t *testing.T) {
+		api := &reposAPI{exists: false, path: "", body: nil}
+
+		mockExec, out, err := runCreateRepo(t, api, "widgets", "--description", "Widget service")
+		require.NoError(t, err)
+
+		assert.Equal(t, "/user/repos", api.path)
+		assert.Equal(t, "widgets", api.body["name"])
+		assert.Equal(t, "Widget service", api.body["description"])
+		assert.Equal(t, false, api.body["private"])
+		assert.Contains(t, out, "Creating public repository 'widgets'")
+		assert.Contains(t, out, "Created repository: https://github.com/octo/widgets")
+		assert.Empty(t, mockExec.Commands(), "no remote is set without --set-remote")
+	})
+
+	//nolint:paralleltest // CreateRepoCmd uses global state which is not thread-safe.
+	t.Run("private for an organization", func(t *testing.T) {
+		api := &reposAPI{exists: false, path: "", body: nil}
+
+		_, out, err := runCreateRepo(t, api, "widgets", "--private", "--org", "octo")
+		require.NoError(t, err)
+
+		assert.Equal(t, "/orgs/octo/repos", api.path)
+		assert.Equal(t, true, api.body["private"])
+		assert.Contains(t, out, "Creating private repository 'octo/widgets'")
+	})
+}
+
+//nolint:paralleltest // CreateRepoCmd uses global state which is not thread-safe.
+func TestCreateRepoCmd_AlreadyExists(t *testing.T) {
+	api := &reposAPI{exists: true, path: "", body: nil}
+
+	mockExec, out, err := runCreateRepo(t, api, "widgets", "--set-remote")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "Repository 'widgets' already exists; nothing was created.")
+	assert.Empty(t, mockExec.Commands(), "no remote is set when nothing was created")
+}
+
+//nolint:paralleltest // CreateRepoCmd uses global state which is not thread-safe.
+func TestCreateRepoCmd_SetRemote(t *testing.T) {
+	api := &reposAPI{exists: false, path: "", body: nil}
+
+	mockExec, out, err := runCreateRepo(t, api, "widgets", "--set-remote")
+	require.NoError(t, err)
+
+	assert.Contains(t, mockExec.Commands(), "git remote add origin https://github.com/octo/widgets.git")
+	assert.Contains(t, out, "Added remote 'origin': https://github.com/octo/widgets.git")
+}
+
+// newRemoteExecutor stubs git in a repository without remotes.
+func newRemoteExecutor(repoDir string) *exectest.Executor {
+	//nolint:exhaustruct // Recorded fields start empty.
+	return &exectest.Executor{
+		RepoDir: repoDir,
+		Handler: func(call exectest.Call) exectest.Response {
+			if strings.HasPrefix(call.String(), "git remote get-url") {
+				return exectest.Response{Stdout: "", Stderr: "error: No such remote 'origin'", Err: errExit}
+			}
+
+			return exectest.Response{Stdout: "", Stderr: "", Err: nil}
+		},
+	}
+}
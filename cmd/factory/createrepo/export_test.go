@@ -0,0 +1,21 @@
+package createrepo
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/github"
+)
+
+// SetClient makes CreateRepoCmd use client for the duration of the test.
+func SetClient(t *testing.T, client *github.Client) {
+	t.Helper()
+
+	original := newGHClient
+	newGHClient = func(context.Context, *slog.Logger, string, string) (*github.Client, error) {
+		return client, nil
+	}
+
+	t.Cleanup(func() { newGHClient = original })
+}
@@ -8,25 +8,32 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/contextvibes/cli/internal/cmddocs"
 	"github.com/contextvibes/cli/internal/exec"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/iac"
 	"github.com/contextvibes/cli/internal/project"
 	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
 //go:embed deploy.md.tpl
 var deployLongDescription string
 
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var stackFlag string
+
 // DeployCmd represents the deploy command
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
 var DeployCmd = &cobra.Command{
-	Use:     "deploy",
-	Example: `  contextvibes factory deploy`,
-	Args:    cobra.NoArgs,
+	Use: "deploy [--stack <name>]",
+	Example: `  contextvibes factory deploy
+  contextvibes factory deploy --stack staging`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
 		ctx := cmd.Context()
@@ -36,32 +43,58 @@ var DeployCmd = &cobra.Command{
 			return fmt.Errorf("failed to get working directory: %w", err)
 		}
 
-		projType, err := project.Detect(cwd)
+		projTypes, err := project.DetectAll(cwd)
 		if err != nil {
 			return fmt.Errorf("failed to detect project type: %w", err)
 		}
 
-		switch projType {
-		case project.Terraform:
-			return executeTerraformDeploy(
-				ctx,
-				presenter,
-				globals.ExecClient,
-				cwd,
-				globals.AssumeYes,
+		infraTypes := slices.DeleteFunc(slices.Clone(projTypes), func(t project.Type) bool {
+			return !t.IsInfrastructure()
+		})
+		if len(infraTypes) == 0 {
+			presenter.Info(
+				"Deploy command is not applicable for this project type (%s).",
+				project.JoinTypes(projTypes),
 			)
-		case project.Pulumi:
-			return executePulumiDeploy(ctx, presenter, globals.ExecClient, cwd, globals.AssumeYes)
-		case project.Go, project.Python, project.Unknown:
-			fallthrough
-		default:
-			presenter.Info("Deploy command is not applicable for this project type (%s).", projType)
 
 			return nil
 		}
+
+		err = workflow.RequireCommands(presenter, globals.ExecClient, project.InfrastructureTools(infraTypes)...)
+		if err != nil {
+			//nolint:wrapcheck // The missing tools have already been reported.
+			return err
+		}
+
+		// Each infrastructure type is deployed in turn; every deployment asks
+		// for its own confirmation unless --yes is set.
+		for _, projType := range infraTypes {
+			if len(infraTypes) > 1 {
+				presenter.Header("%s Deploy", projType)
+			}
+
+			err = executeDeploy(ctx, presenter, projType, cwd)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
 	},
 }
 
+func executeDeploy(ctx context.Context, presenter *ui.Presenter, projType project.Type, dir string) error {
+	//nolint:exhaustive // Only infrastructure types reach this point.
+	switch projType {
+	case project.Terraform:
+		return executeTerraformDeploy(ctx, presenter, globals.ExecClient, dir, globals.AssumeYes)
+	case project.Pulumi:
+		return executePulumiDeploy(ctx, presenter, globals.ExecClient, dir, globals.AssumeYes)
+	default:
+		return nil
+	}
+}
+
 func executeTerraformDeploy(
 	ctx context.Context,
 	presenter *ui.Presenter,
@@ -84,7 +117,25 @@ func executeTerraformDeploy(
 		return fmt.Errorf("failed to check plan file: %w", err)
 	}
 
+	err := iac.CheckPlanFresh(dir, planFile)
+	if err != nil {
+		presenter.Error("Terraform plan '%s' is stale: %v", planFile, err)
+		presenter.Advice("Please run `contextvibes factory plan` again.")
+
+		//nolint:wrapcheck // Staleness errors are already descriptive.
+		return err
+	}
+
+	summary, err := iac.ShowPlan(ctx, execClient, dir, planFile)
+	if err != nil {
+		presenter.Error("Failed to read Terraform plan '%s': %v", planFile, err)
+
+		//nolint:wrapcheck // Plan errors are already descriptive.
+		return err
+	}
+
 	presenter.Info("Proposed Deploy Action: Apply Terraform plan '%s'", planFile)
+	renderPlanSummary(presenter, summary)
 
 	if !skipConfirm {
 		confirmed, err := presenter.PromptForConfirmation("Proceed with Terraform deployment?")
@@ -99,7 +150,7 @@ func executeTerraformDeploy(
 		}
 	}
 
-	err := execClient.Execute(ctx, dir, "terraform", "apply", "-auto-approve", planFile)
+	err = execClient.Execute(ctx, dir, "terraform", "apply", "-auto-approve", planFile)
 	if err != nil {
 		return fmt.Errorf("terraform apply failed: %w", err)
 	}
@@ -107,6 +158,28 @@ func executeTerraformDeploy(
 	return nil
 }
 
+// renderPlanSummary prints the resource change counts, calling out every
+// resource that the plan destroys.
+func renderPlanSummary(presenter *ui.Presenter, summary iac.PlanSummary) {
+	presenter.Detail(
+		"Plan: %d to create, %d to update, %d to replace, %d to delete.",
+		summary.Create,
+		summary.Update,
+		summary.Replace,
+		summary.Delete,
+	)
+
+	if len(summary.Destroyed) == 0 {
+		return
+	}
+
+	presenter.Warning("This plan destroys %d resource(s):", len(summary.Destroyed))
+
+	for _, address := range summary.Destroyed {
+		presenter.Detail("- %s", address)
+	}
+}
+
 func executePulumiDeploy(
 	ctx context.Context,
 	presenter *ui.Presenter,
@@ -114,7 +187,19 @@ func executePulumiDeploy(
 	dir string,
 	skipConfirm bool,
 ) error {
-	presenter.Info("Proposed Deploy Action: Run 'pulumi up'")
+	err := iac.SelectPulumiStack(ctx, execClient, dir, stackFlag)
+	if err != nil {
+		presenter.Error("Could not select Pulumi stack '%s': %v", stackFlag, err)
+
+		//nolint:wrapcheck // Stack errors are already descriptive.
+		return err
+	}
+
+	if stackFlag != "" {
+		presenter.Info("Proposed Deploy Action: Run 'pulumi up' on stack '%s'", stackFlag)
+	} else {
+		presenter.Info("Proposed Deploy Action: Run 'pulumi up'")
+	}
 
 	if !skipConfirm {
 		confirmed, err := presenter.PromptForConfirmation("Proceed to run 'pulumi up'?")
@@ -129,7 +214,7 @@ func executePulumiDeploy(
 		}
 	}
 
-	err := execClient.Execute(ctx, dir, "pulumi", "up")
+	err = execClient.Execute(ctx, dir, "pulumi", "up")
 	if err != nil {
 		return fmt.Errorf("pulumi up failed: %w", err)
 	}
@@ -146,4 +231,5 @@ func init() {
 
 	DeployCmd.Short = desc.Short
 	DeployCmd.Long = desc.Long
+	DeployCmd.Flags().StringVar(&stackFlag, "stack", "", "Pulumi stack to select before deploying.")
 }
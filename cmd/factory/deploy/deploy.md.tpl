@@ -4,4 +4,15 @@ Detects the project type (Terraform, Pulumi), explains the deployment action,
 and executes the deployment after confirmation.
 
 - Terraform: Requires 'tfplan.out' from 'contextvibes factory plan'. Runs 'terraform apply tfplan.out'.
+  Before asking for confirmation, the plan is summarized from 'terraform show -json'
+  (resources to create, update, replace and delete), and every destroyed resource is
+  listed. A plan older than any '.tf' or '.tfvars' file is rejected as stale.
 - Pulumi: Runs 'pulumi up', which internally includes a preview and confirmation.
+
+When both Terraform and Pulumi are detected, each is deployed in turn with its
+own confirmation. Application code detected alongside (Go, Python, Node.js,
+Rust) does not prevent infrastructure from being deployed.
+
+Use `--stack <name>` to run `pulumi stack select <name>` before deploying, so the
+command never runs against whichever stack happens to be active. A stack that
+does not exist is reported as an error.
@@ -0,0 +1,136 @@
+// Package deploy_test contains tests for the deploy command.
+package deploy_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/contextvibes/cli/cmd/factory/deploy"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/iac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errExitStatus = errors.New("exit status 1")
+
+func runDeploy(t *testing.T, mockExec *exectest.Executor, args []string, files ...string) (string, error) {
+	t.Helper()
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(t.TempDir()))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	// Files are written oldest first, so later files are newer.
+	start := time.Now().Add(-time.Hour)
+
+	for i, name := range files {
+		require.NoError(t, os.WriteFile(name, []byte{}, 0o600))
+
+		modTime := start.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(name, modTime, modTime))
+	}
+
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.AssumeYes = true
+
+	t.Cleanup(func() { globals.AssumeYes = false })
+
+	cmd := *deploy.DeployCmd
+	out := new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+
+	_ = cmd.Flags().Set("stack", "")
+
+	err = cmd.Execute()
+
+	return out.String(), err
+}
+
+//nolint:paralleltest // DeployCmd uses global state which is not thread-safe.
+func TestDeployCmd_Stack(t *testing.T) {
+	//nolint:paralleltest // DeployCmd uses global state which is not thread-safe.
+	t.Run("stack is selected before pulumi up", func(t *testing.T) {
+		mockExec := newRecordingExecutor("", "", "")
+
+		out, err := runDeploy(t, mockExec, []string{"--stack", "staging"}, "Pulumi.yaml")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"pulumi stack select staging", "pulumi up"}, mockExec.Commands())
+		assert.Contains(t, out, "on stack 'staging'")
+	})
+
+	//nolint:paralleltest // DeployCmd uses global state which is not thread-safe.
+	t.Run("unknown stack never runs pulumi up", func(t *testing.T) {
+		mockExec := newRecordingExecutor("pulumi stack select", "error: no stack named 'prod' found\n", "")
+
+		_, err := runDeploy(t, mockExec, []string{"--stack", "prod"}, "Pulumi.yaml")
+		require.ErrorIs(t, err, iac.ErrPulumiStackNotFound)
+
+		assert.Equal(t, []string{"pulumi stack select prod"}, mockExec.Commands())
+	})
+}
+
+const planJSON = `{"resource_changes": [
+  {"address": "aws_s3_bucket.logs", "change": {"actions": ["create"]}},
+  {"address": "aws_db_instance.old", "change": {"actions": ["delete"]}}
+]}`
+
+//nolint:paralleltest // DeployCmd uses global state which is not thread-safe.
+func TestDeployCmd_Terraform(t *testing.T) {
+	//nolint:paralleltest // DeployCmd uses global state which is not thread-safe.
+	t.Run("summary is shown before apply", func(t *testing.T) {
+		mockExec := newRecordingExecutor("", "", planJSON)
+
+		out, err := runDeploy(t, mockExec, nil, "main.tf", "tfplan.out")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"terraform show -json tfplan.out",
+			"terraform apply -auto-approve tfplan.out",
+		}, mockExec.Commands())
+		assert.Contains(t, out, "Plan: 1 to create, 0 to update, 0 to replace, 1 to delete.")
+		assert.Contains(t, out, "This plan destroys 1 resource(s):")
+		assert.Contains(t, out, "aws_db_instance.old")
+	})
+
+	//nolint:paralleltest // DeployCmd uses global state which is not thread-safe.
+	t.Run("stale plan is rejected", func(t *testing.T) {
+		mockExec := newRecordingExecutor("", "", planJSON)
+
+		_, err := runDeploy(t, mockExec, nil, "tfplan.out", "main.tf")
+		require.ErrorIs(t, err, iac.ErrStalePlan)
+
+		assert.Empty(t, mockExec.Commands())
+	})
+}
+
+// newRecordingExecutor records every command and fails those starting with failOn,
+// reporting stderr as their captured error output. Successful captures return stdout.
+func newRecordingExecutor(failOn, stderr, stdout string) *exectest.Executor {
+	//nolint:exhaustruct // Recorded fields start empty.
+	return &exectest.Executor{
+		Handler: func(call exectest.Call) exectest.Response {
+			if failOn != "" && strings.HasPrefix(call.String(), failOn) {
+				return exectest.Response{Stdout: "", Stderr: stderr, Err: errExitStatus}
+			}
+
+			return exectest.Response{Stdout: stdout, Stderr: "", Err: nil}
+		},
+	}
+}
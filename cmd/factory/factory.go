@@ -3,19 +3,27 @@ package factory
 
 import (
 	"github.com/contextvibes/cli/cmd/factory/apply"
+	"github.com/contextvibes/cli/cmd/factory/changelog"
+	"github.com/contextvibes/cli/cmd/factory/cleanup"
 	"github.com/contextvibes/cli/cmd/factory/commit"
+	"github.com/contextvibes/cli/cmd/factory/createrepo"
 	"github.com/contextvibes/cli/cmd/factory/deploy"
 	"github.com/contextvibes/cli/cmd/factory/diff"
 	"github.com/contextvibes/cli/cmd/factory/finish"
 	init_cmd "github.com/contextvibes/cli/cmd/factory/init"
 	"github.com/contextvibes/cli/cmd/factory/kickoff"
 	"github.com/contextvibes/cli/cmd/factory/plan"
+	"github.com/contextvibes/cli/cmd/factory/protectbranch"
+	"github.com/contextvibes/cli/cmd/factory/release"
+	"github.com/contextvibes/cli/cmd/factory/scaffold"
 	"github.com/contextvibes/cli/cmd/factory/scrub"
 	"github.com/contextvibes/cli/cmd/factory/setupidentity"
+	"github.com/contextvibes/cli/cmd/factory/squash"
 	"github.com/contextvibes/cli/cmd/factory/status"
 	"github.com/contextvibes/cli/cmd/factory/sync"
 	"github.com/contextvibes/cli/cmd/factory/tidy"
 	"github.com/contextvibes/cli/cmd/factory/tools" // Added
+	"github.com/contextvibes/cli/cmd/factory/upgradecli"
 	"github.com/spf13/cobra"
 )
 
@@ -37,10 +45,18 @@ func init() {
 	FactoryCmd.AddCommand(sync.SyncCmd)
 	FactoryCmd.AddCommand(finish.FinishCmd)
 	FactoryCmd.AddCommand(tidy.TidyCmd)
+	FactoryCmd.AddCommand(cleanup.CleanupCmd)
 	FactoryCmd.AddCommand(plan.PlanCmd)
 	FactoryCmd.AddCommand(apply.ApplyCmd)
 	FactoryCmd.AddCommand(deploy.DeployCmd)
 	FactoryCmd.AddCommand(scrub.ScrubCmd)
+	FactoryCmd.AddCommand(scaffold.ScaffoldCmd)
 	FactoryCmd.AddCommand(setupidentity.SetupIdentityCmd)
+	FactoryCmd.AddCommand(protectbranch.ProtectBranchCmd)
+	FactoryCmd.AddCommand(createrepo.CreateRepoCmd)
+	FactoryCmd.AddCommand(squash.SquashCmd)
+	FactoryCmd.AddCommand(changelog.ChangelogCmd)
+	FactoryCmd.AddCommand(release.ReleaseCmd)
 	FactoryCmd.AddCommand(tools.ToolsCmd) // Added
+	FactoryCmd.AddCommand(upgradecli.UpgradeCLICmd)
 }
@@ -2,20 +2,69 @@
 package finish
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/config"
 	"github.com/contextvibes/cli/internal/git"
 	"github.com/contextvibes/cli/internal/globals"
 	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/contextvibes/cli/internal/workitem"
+	"github.com/contextvibes/cli/internal/workitem/github"
 	"github.com/spf13/cobra"
 )
 
 //go:embed finish.md.tpl
 var finishLongDescription string
 
+// newProvider is the factory used to reach the remote repository. It is a
+// variable so tests can substitute a provider pointed at a test server.
+//
+//nolint:gochecknoglobals // Replaceable factory for tests.
+var newProvider = defaultProvider
+
+// defaultProvider returns the work item provider configured in .contextvibes.yaml.
+func defaultProvider(
+	ctx context.Context,
+	logger *slog.Logger,
+	cfg *config.Config,
+) (workitem.Provider, error) {
+	switch cfg.Project.Provider {
+	case "github", "":
+		//nolint:wrapcheck // Factory function.
+		return github.New(ctx, logger, cfg)
+	default:
+		//nolint:err113 // Dynamic error is appropriate here.
+		return nil, fmt.Errorf(
+			"unsupported work item provider '%s' specified in .contextvibes.yaml",
+			cfg.Project.Provider,
+		)
+	}
+}
+
+// baseBranch returns the remote default branch to open the pull request
+// against, or fallback (the configured main branch) when it cannot be resolved.
+func baseBranch(ctx context.Context, fallback string) string {
+	provider, err := newProvider(ctx, globals.AppLogger, globals.LoadedAppConfig)
+	if err == nil {
+		var branch string
+
+		branch, err = provider.DefaultBranch(ctx)
+		if err == nil {
+			return branch
+		}
+	}
+
+	globals.AppLogger.DebugContext(ctx, "Falling back to configured main branch", "fallback", fallback, "error", err)
+
+	return fallback
+}
+
 // FinishCmd represents the finish command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
@@ -41,6 +90,10 @@ var FinishCmd = &cobra.Command{
 		}
 
 		currentBranch, err := gitClient.GetCurrentBranchName(ctx)
+		if errors.Is(err, git.ErrDetachedHead) {
+			workflow.AdviseDetachedHead(presenter, gitClient.MainBranchName())
+		}
+
 		if err != nil {
 			return fmt.Errorf("failed to get current branch: %w", err)
 		}
@@ -70,9 +123,11 @@ var FinishCmd = &cobra.Command{
 			return nil
 		}
 
-		presenter.Step("Running 'gh pr create'...")
-		//nolint:noinlineerr // Inline check is standard.
-		if err := globals.ExecClient.Execute(ctx, ".", "gh", "pr", "create", "--fill", "--web"); err != nil {
+		base := baseBranch(ctx, gitClient.MainBranchName())
+
+		presenter.Step("Running 'gh pr create' against '%s'...", base)
+		err = globals.ExecClient.Execute(ctx, ".", "gh", "pr", "create", "--fill", "--web", "--base", base)
+		if err != nil {
 			return fmt.Errorf("gh pr create failed: %w", err)
 		}
 
@@ -2,4 +2,5 @@
 
 Standardizes the process of finalizing a feature branch. This command first
 pushes the current branch to the remote, then uses the GitHub CLI ('gh')
-to interactively create a pull request.
+to interactively create a pull request against the repository's default
+branch on GitHub (falling back to the configured main branch).
@@ -0,0 +1,107 @@
+// Package finish_test contains tests for the finish command.
+package finish_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/finish"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	wigithub "github.com/contextvibes/cli/internal/workitem/github"
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runFinish runs finish on a feature branch against a REST API served by
+// handler, answering yes to the pull request prompt.
+func runFinish(t *testing.T, handler http.HandlerFunc) (*exectest.Executor, string, error) {
+	t.Helper()
+
+	repoDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(repoDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	restClient := gogithub.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+
+	restClient.BaseURL = baseURL
+	logger := slog.New(slog.DiscardHandler)
+	client := github.NewClientWithAPI(restClient, logger, "octo", "widgets")
+	finish.SetProvider(t, wigithub.NewWithClient(client, logger, "octo", "widgets"))
+
+	//nolint:exhaustruct // Recorded fields start empty.
+	mockExec := &exectest.Executor{
+		RepoDir: repoDir,
+		Responses: map[string]exectest.Response{
+			"git rev-parse --abbrev-ref HEAD": {Stdout: "feature/login\n", Stderr: "", Err: nil},
+		},
+	}
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = logger
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+
+	//nolint:exhaustruct // Only AssumeYes matters here.
+	ui.SetDefaultOptions(ui.Options{AssumeYes: true})
+	//nolint:exhaustruct // Restore the zero options.
+	t.Cleanup(func() { ui.SetDefaultOptions(ui.Options{}) })
+
+	cmd := *finish.FinishCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs(nil)
+
+	err = cmd.Execute()
+
+	return mockExec, outBuf.String() + errBuf.String(), err
+}
+
+//nolint:paralleltest // FinishCmd uses global state which is not thread-safe.
+func TestFinishCmd_Base(t *testing.T) {
+	//nolint:paralleltest // FinishCmd uses global state which is not thread-safe.
+	t.Run("targets the remote default branch", func(t *testing.T) {
+		var path string
+
+		mockExec, _, err := runFinish(t, func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"default_branch": "develop"}`)
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "/repos/octo/widgets", path)
+		assert.Equal(t, "gh pr create --fill --web --base develop", mockExec.LastCall().String())
+	})
+
+	//nolint:paralleltest // FinishCmd uses global state which is not thread-safe.
+	t.Run("falls back to the configured main branch", func(t *testing.T) {
+		mockExec, _, err := runFinish(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "gh pr create --fill --web --base main", mockExec.LastCall().String())
+	})
+}
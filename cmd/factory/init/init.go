@@ -51,6 +51,15 @@ var InitCmd = &cobra.Command{
 		if _, err := os.Stat(configPath); err == nil {
 			presenter.Info("Configuration file already exists: %s", presenter.Highlight(configPath))
 
+			migrated, err := config.MigrateConfigFile(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to migrate config: %w", err)
+			}
+
+			if migrated {
+				presenter.Success("Migrated it to schema version %d.", config.CurrentSchemaVersion)
+			}
+
 			return nil
 		}
 
@@ -3,3 +3,6 @@
 Checks for an existing .contextvibes.yaml file in the project root.
 If one does not exist, it creates a new file populated with the default
 configuration values, including Git settings and validation patterns.
+
+If the file exists but was written for an older schema version, it is
+rewritten in the current layout (renamed keys are carried over).
@@ -2,14 +2,21 @@
 package kickoff
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
 
 	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/config"
 	"github.com/contextvibes/cli/internal/git"
 	"github.com/contextvibes/cli/internal/globals"
 	"github.com/contextvibes/cli/internal/ui"
 	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/contextvibes/cli/internal/workitem"
+	"github.com/contextvibes/cli/internal/workitem/github"
 	"github.com/spf13/cobra"
 )
 
@@ -17,13 +24,37 @@ import (
 var kickoffLongDescription string
 
 //nolint:gochecknoglobals // Cobra flags require package-level variables.
-var branchNameFlag string
+var (
+	branchNameFlag string
+	fromIssueFlag  int
+)
+
+// newProvider is a factory function that returns the configured work item provider.
+func newProvider(
+	ctx context.Context,
+	logger *slog.Logger,
+	cfg *config.Config,
+) (workitem.Provider, error) {
+	switch cfg.Project.Provider {
+	case "github", "":
+		//nolint:wrapcheck // Wrapping is handled by caller.
+		return github.New(ctx, logger, cfg)
+	default:
+		//nolint:err113 // Dynamic error is appropriate here.
+		return nil, fmt.Errorf(
+			"unsupported work item provider '%s' specified in .contextvibes.yaml",
+			cfg.Project.Provider,
+		)
+	}
+}
 
 // KickoffCmd represents the kickoff command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
 var KickoffCmd = &cobra.Command{
-	Use:  "kickoff [--branch <branch-name>]",
+	Use: "kickoff [--branch <branch-name> | --from-issue <number>]",
+	Example: `  contextvibes factory kickoff --branch feature/ISSUE-42-fix-login
+  contextvibes factory kickoff --from-issue 42`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
@@ -40,9 +71,22 @@ var KickoffCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize git client: %w", err)
 		}
 
+		branchName := branchNameFlag
+		if fromIssueFlag > 0 {
+			if branchName != "" {
+				//nolint:err113 // Dynamic error is appropriate here.
+				return errors.New("use either --branch or --from-issue, not both")
+			}
+
+			branchName, err = proposeBranchFromIssue(ctx, presenter, fromIssueFlag)
+			if err != nil {
+				return err
+			}
+		}
+
 		validatedBranchName, err := workflow.GetValidatedBranchName(
 			ctx,
-			branchNameFlag,
+			branchName,
 			globals.LoadedAppConfig,
 			presenter,
 			gitClient,
@@ -52,7 +96,7 @@ var KickoffCmd = &cobra.Command{
 			return fmt.Errorf("branch validation failed: %w", err)
 		}
 
-		runner := workflow.NewRunner(presenter, globals.AssumeYes)
+		runner := workflow.NewRunner(presenter, globals.AssumeYes, workflow.WithVerboseTiming(globals.Verbose))
 
 		return runner.Run(
 			ctx,
@@ -73,6 +117,44 @@ var KickoffCmd = &cobra.Command{
 	},
 }
 
+// proposeBranchFromIssue fetches the work item title and offers a slugified branch
+// name that the user can accept or edit.
+func proposeBranchFromIssue(ctx context.Context, presenter *ui.Presenter, number int) (string, error) {
+	provider, err := newProvider(ctx, globals.AppLogger, globals.LoadedAppConfig)
+	if err != nil {
+		presenter.Error("Failed to initialize work item provider: %v", err)
+
+		return "", err
+	}
+
+	item, err := provider.GetItem(ctx, number, false)
+	if err != nil {
+		presenter.Error("Failed to fetch issue #%d: %v", number, err)
+
+		return "", fmt.Errorf("failed to fetch issue #%d: %w", number, err)
+	}
+
+	proposed := workflow.ExpandBranchNameTemplate(
+		globals.LoadedAppConfig.Git.BranchNameTemplate,
+		strconv.Itoa(number),
+		item.Title,
+	)
+	presenter.Info("Issue #%d: %s", number, item.Title)
+
+	if globals.AssumeYes {
+		presenter.Info("Using proposed branch name: %s", proposed)
+
+		return proposed, nil
+	}
+
+	answer, err := presenter.PromptForInputWithDefault("Branch name", proposed)
+	if err != nil {
+		return "", fmt.Errorf("prompt failed: %w", err)
+	}
+
+	return answer, nil
+}
+
 //nolint:gochecknoinits // Cobra requires init() for command registration.
 func init() {
 	desc, err := cmddocs.ParseAndExecute(kickoffLongDescription, nil)
@@ -84,4 +166,6 @@ func init() {
 	KickoffCmd.Long = desc.Long
 	KickoffCmd.Flags().
 		StringVarP(&branchNameFlag, "branch", "b", "", "Name for the new feature branch")
+	KickoffCmd.Flags().
+		IntVar(&fromIssueFlag, "from-issue", 0, "Propose a branch name from the title of this issue number")
 }
@@ -2,3 +2,10 @@
 
 Handles the start of a new unit of work. It automates the creation of a new,
 clean, up-to-date feature branch from the main branch.
+
+Use `--from-issue <number>` to fetch an issue from the configured work item
+provider and propose a branch name such as `feature/ISSUE-42-fix-login-redirect`.
+The proposal can be accepted or edited at the prompt (with `--yes` it is used
+as-is), and is then validated like any other branch name. The proposal is built
+from `git.branchNameTemplate` (default `feature/ISSUE-{ticket}-{slug}`), where
+`{ticket}` is the issue number and `{slug}` the slugified issue title.
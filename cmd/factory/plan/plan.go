@@ -8,25 +8,36 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 
 	"github.com/contextvibes/cli/internal/cmddocs"
 	internal_exec "github.com/contextvibes/cli/internal/exec"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/iac"
 	"github.com/contextvibes/cli/internal/project"
 	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
 //go:embed plan.md.tpl
 var planLongDescription string
 
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	validateFlag bool
+	stackFlag    string
+)
+
 // PlanCmd represents the plan command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
 var PlanCmd = &cobra.Command{
-	Use:     "plan",
-	Example: `  contextvibes factory plan`,
-	Args:    cobra.NoArgs,
+	Use: "plan [--validate] [--stack <name>]",
+	Example: `  contextvibes factory plan
+  contextvibes factory plan --validate
+  contextvibes factory plan --stack staging`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
 		ctx := cmd.Context()
@@ -36,26 +47,97 @@ var PlanCmd = &cobra.Command{
 			return fmt.Errorf("failed to get working directory: %w", err)
 		}
 
-		projType, err := project.Detect(cwd)
+		projTypes, err := project.DetectAll(cwd)
 		if err != nil {
 			return fmt.Errorf("failed to detect project type: %w", err)
 		}
 
-		switch projType {
-		case project.Terraform:
-			return executeTerraformPlan(ctx, presenter, globals.ExecClient, cwd)
-		case project.Pulumi:
-			return executePulumiPreview(ctx, presenter, globals.ExecClient, cwd)
-		case project.Go, project.Python, project.Unknown:
-			fallthrough
-		default:
-			presenter.Info("Plan command is not applicable for this project type.")
+		infraTypes := slices.DeleteFunc(slices.Clone(projTypes), func(t project.Type) bool {
+			return !t.IsInfrastructure()
+		})
+		if len(infraTypes) == 0 {
+			presenter.Info(
+				"Plan command is not applicable for this project type (%s).",
+				project.JoinTypes(projTypes),
+			)
 
 			return nil
 		}
+
+		err = workflow.RequireCommands(presenter, globals.ExecClient, project.InfrastructureTools(infraTypes)...)
+		if err != nil {
+			//nolint:wrapcheck // The missing tools have already been reported.
+			return err
+		}
+
+		// Every infrastructure type in the directory is planned, so infra
+		// living next to application code is never silently skipped.
+		for _, projType := range infraTypes {
+			if len(infraTypes) > 1 {
+				presenter.Header("%s Plan", projType)
+			}
+
+			err = executePlan(ctx, presenter, projType, cwd)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
 	},
 }
 
+func executePlan(ctx context.Context, presenter *ui.Presenter, projType project.Type, dir string) error {
+	//nolint:exhaustive // Only infrastructure types reach this point.
+	switch projType {
+	case project.Terraform:
+		if validateFlag {
+			err := executeTerraformValidate(ctx, presenter, globals.ExecClient, dir)
+			if err != nil {
+				return err
+			}
+		}
+
+		return executeTerraformPlan(ctx, presenter, globals.ExecClient, dir)
+	case project.Pulumi:
+		return executePulumiPreview(ctx, presenter, globals.ExecClient, dir)
+	default:
+		return nil
+	}
+}
+
+// executeTerraformValidate runs the fast static checks (formatting, then
+// configuration validity) so their errors surface before the slower plan.
+func executeTerraformValidate(
+	ctx context.Context,
+	presenter *ui.Presenter,
+	execClient *internal_exec.ExecutorClient,
+	dir string,
+) error {
+	presenter.Step("Checking Terraform formatting...")
+
+	err := execClient.Execute(ctx, dir, "terraform", "fmt", "-check", "-recursive")
+	if err != nil {
+		presenter.Error("'terraform fmt -check' found unformatted files.")
+		presenter.Advice("Run `terraform fmt -recursive` to fix formatting, then plan again.")
+
+		return fmt.Errorf("terraform fmt check failed: %w", err)
+	}
+
+	presenter.Step("Validating Terraform configuration...")
+
+	err = execClient.Execute(ctx, dir, "terraform", "validate")
+	if err != nil {
+		presenter.Error("'terraform validate' reported errors.")
+
+		return fmt.Errorf("terraform validate failed: %w", err)
+	}
+
+	presenter.Success("Terraform configuration is formatted and valid.")
+
+	return nil
+}
+
 func executeTerraformPlan(
 	ctx context.Context,
 	presenter *ui.Presenter,
@@ -91,7 +173,15 @@ func executePulumiPreview(
 	execClient *internal_exec.ExecutorClient,
 	dir string,
 ) error {
-	err := execClient.Execute(ctx, dir, "pulumi", "preview")
+	err := iac.SelectPulumiStack(ctx, execClient, dir, stackFlag)
+	if err != nil {
+		presenter.Error("Could not select Pulumi stack '%s': %v", stackFlag, err)
+
+		//nolint:wrapcheck // Stack errors are already descriptive.
+		return err
+	}
+
+	err = execClient.Execute(ctx, dir, "pulumi", "preview")
 	if err != nil {
 		//nolint:err113 // Dynamic error is appropriate here.
 		return errors.New("pulumi preview failed")
@@ -111,4 +201,7 @@ func init() {
 
 	PlanCmd.Short = desc.Short
 	PlanCmd.Long = desc.Long
+	PlanCmd.Flags().BoolVar(&validateFlag, "validate", false,
+		"Run 'terraform fmt -check' and 'terraform validate' before planning.")
+	PlanCmd.Flags().StringVar(&stackFlag, "stack", "", "Pulumi stack to select before previewing.")
 }
@@ -5,3 +5,14 @@ command to generate an execution plan, showing expected changes.
 
 - Terraform: Runs 'terraform plan -out=tfplan.out'
 - Pulumi: Runs 'pulumi preview'
+
+Use `--validate` to run `terraform fmt -check -recursive` and `terraform validate`
+first. Formatting and validation errors then stop the command before the slower
+plan runs.
+
+When a directory contains several project types (for example Terraform next
+to a Go module), every infrastructure type found is planned in turn.
+
+Use `--stack <name>` to run `pulumi stack select <name>` before previewing, so the
+command never runs against whichever stack happens to be active. A stack that
+does not exist is reported as an error.
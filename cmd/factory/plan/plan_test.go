@@ -0,0 +1,201 @@
+// Package plan_test contains tests for the plan command.
+package plan_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/plan"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/iac"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errExitStatus = errors.New("exit status 1")
+
+func runPlan(t *testing.T, files ...string) (*exectest.Executor, string) {
+	t.Helper()
+
+	mockExec := newRecordingExecutor("", "")
+	out, err := runPlanWith(t, mockExec, nil, files...)
+	require.NoError(t, err)
+
+	return mockExec, out
+}
+
+func runPlanWith(t *testing.T, mockExec *exectest.Executor, args []string, files ...string) (string, error) {
+	t.Helper()
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(t.TempDir()))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	for _, name := range files {
+		require.NoError(t, os.WriteFile(name, []byte{}, 0o600))
+	}
+
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+
+	cmd := *plan.PlanCmd
+	out := new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+
+	_ = cmd.Flags().Set("validate", "false")
+	_ = cmd.Flags().Set("stack", "")
+
+	err = cmd.Execute()
+
+	return out.String(), err
+}
+
+//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+func TestPlanCmd(t *testing.T) {
+	//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+	t.Run("terraform next to a go module is planned", func(t *testing.T) {
+		mockExec, _ := runPlan(t, "go.mod", "main.tf")
+
+		assert.Equal(t, []string{"terraform plan -out=tfplan.out"}, mockExec.Commands())
+	})
+
+	//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+	t.Run("terraform and pulumi are both planned", func(t *testing.T) {
+		mockExec, out := runPlan(t, "main.tf", "Pulumi.yaml")
+
+		assert.Equal(t, []string{"terraform plan -out=tfplan.out", "pulumi preview"}, mockExec.Commands())
+		assert.Contains(t, out, "Terraform Plan")
+		assert.Contains(t, out, "Pulumi Plan")
+	})
+
+	//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+	t.Run("application-only directory is not applicable", func(t *testing.T) {
+		mockExec, out := runPlan(t, "go.mod", "package.json")
+
+		assert.Empty(t, mockExec.Commands())
+		assert.Contains(t, out, "not applicable for this project type (Go, Node.js)")
+	})
+}
+
+//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+func TestPlanCmd_Validate(t *testing.T) {
+	//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+	t.Run("passing checks continue to the plan", func(t *testing.T) {
+		mockExec := newRecordingExecutor("", "")
+
+		_, err := runPlanWith(t, mockExec, []string{"--validate"}, "main.tf")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"terraform fmt -check -recursive",
+			"terraform validate",
+			"terraform plan -out=tfplan.out",
+		}, mockExec.Commands())
+	})
+
+	//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+	t.Run("failing validation stops the plan", func(t *testing.T) {
+		mockExec := newRecordingExecutor("terraform validate", "")
+
+		out, err := runPlanWith(t, mockExec, []string{"--validate"}, "main.tf")
+		require.ErrorIs(t, err, errExitStatus)
+
+		assert.Equal(t, []string{"terraform fmt -check -recursive", "terraform validate"}, mockExec.Commands())
+		assert.Contains(t, out, "'terraform validate' reported errors.")
+	})
+
+	//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+	t.Run("unformatted files stop the plan", func(t *testing.T) {
+		mockExec := newRecordingExecutor("terraform fmt", "")
+
+		_, err := runPlanWith(t, mockExec, []string{"--validate"}, "main.tf")
+		require.Error(t, err)
+
+		assert.Equal(t, []string{"terraform fmt -check -recursive"}, mockExec.Commands())
+	})
+}
+
+//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+func TestPlanCmd_Stack(t *testing.T) {
+	//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+	t.Run("stack is selected before the preview", func(t *testing.T) {
+		mockExec := newRecordingExecutor("", "")
+
+		_, err := runPlanWith(t, mockExec, []string{"--stack", "staging"}, "Pulumi.yaml")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"pulumi stack select staging", "pulumi preview"}, mockExec.Commands())
+	})
+
+	//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+	t.Run("unknown stack stops before the preview", func(t *testing.T) {
+		mockExec := newRecordingExecutor("pulumi stack select", "error: no stack named 'prod' found\n")
+
+		_, err := runPlanWith(t, mockExec, []string{"--stack", "prod"}, "Pulumi.yaml")
+		require.ErrorIs(t, err, iac.ErrPulumiStackNotFound)
+
+		assert.Equal(t, []string{"pulumi stack select prod"}, mockExec.Commands())
+	})
+
+	//nolint:paralleltest // PlanCmd uses global state which is not thread-safe.
+	t.Run("no stack keeps the current selection", func(t *testing.T) {
+		mockExec, _ := runPlan(t, "Pulumi.yaml")
+
+		assert.Equal(t, []string{"pulumi preview"}, mockExec.Commands())
+	})
+}
+
+//nolint:paralleltest // PlanCmd and the presenter defaults are global state.
+func TestPlanCmd_Quiet(t *testing.T) {
+	ui.SetDefaultOptions(ui.Options{Quiet: true, NoColor: true, Events: false})
+	t.Cleanup(func() { ui.SetDefaultOptions(ui.Options{Quiet: false, NoColor: false, Events: false}) })
+
+	mockExec := newRecordingExecutor("", "")
+
+	out, err := runPlanWith(t, mockExec, []string{"--validate"}, "main.tf")
+	require.NoError(t, err)
+
+	assert.Equal(t, "+ Terraform configuration is formatted and valid.\n", out)
+}
+
+//nolint:paralleltest // PlanCmd and the presenter defaults are global state.
+func TestPlanCmd_Events(t *testing.T) {
+	ui.SetDefaultOptions(ui.Options{Quiet: false, NoColor: false, Events: true})
+	t.Cleanup(func() { ui.SetDefaultOptions(ui.Options{Quiet: false, NoColor: false, Events: false}) })
+
+	mockExec := newRecordingExecutor("", "")
+
+	out, err := runPlanWith(t, mockExec, nil, "main.tf")
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"level":"info","message":"Terraform plan successful (no changes detected)."}`+"\n", out)
+}
+
+// newRecordingExecutor records every command and fails those starting with failOn,
+// reporting stderr as their captured error output.
+func newRecordingExecutor(failOn, stderr string) *exectest.Executor {
+	//nolint:exhaustruct // Recorded fields start empty.
+	return &exectest.Executor{
+		Handler: func(call exectest.Call) exectest.Response {
+			if failOn != "" && strings.HasPrefix(call.String(), failOn) {
+				return exectest.Response{Stdout: "", Stderr: stderr, Err: errExitStatus}
+			}
+
+			return exectest.Response{Stdout: "", Stderr: "", Err: nil}
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package protectbranch
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/github"
+)
+
+// SetClient makes ProtectBranchCmd use client for the duration of the test.
+func SetClient(t *testing.T, client *github.Client) {
+	t.Helper()
+
+	original := newGHClient
+	newGHClient = func(context.Context, *slog.Logger, *config.Config) (*github.Client, error) {
+		return client, nil
+	}
+
+	t.Cleanup(func() { newGHClient = original })
+}
@@ -0,0 +1,219 @@
+// Package protectbranch provides the command to apply branch protection rules on GitHub.
+package protectbranch
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed protectbranch.md.tpl
+var protectBranchLongDescription string
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	requiredReviews     int
+	dismissStaleReviews bool
+	codeOwnerReviews    bool
+	statusChecks        []string
+	strictStatusChecks  bool
+	enforceAdmins       bool
+)
+
+// newGHClient is the factory used to obtain the GitHub client. It is a variable
+// so tests can substitute a client pointed at a test server.
+//
+//nolint:gochecknoglobals // Replaceable factory for tests.
+var newGHClient = defaultGHClient
+
+// defaultGHClient returns a GitHub client for the repository behind the default remote.
+func defaultGHClient(
+	ctx context.Context,
+	logger *slog.Logger,
+	cfg *config.Config,
+) (*github.Client, error) {
+	//nolint:exhaustruct // Partial config is sufficient for discovery.
+	gitClient, err := git.NewClient(ctx, ".", git.GitClientConfig{
+		Executor: globals.ExecClient.UnderlyingExecutor(),
+		Logger:   logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize git client for repo discovery: %w", err)
+	}
+
+	remoteURL, err := gitClient.GetRemoteURL(ctx, cfg.Git.DefaultRemote)
+	if err != nil {
+		return nil, fmt.Errorf("could not get remote URL for '%s': %w", cfg.Git.DefaultRemote, err)
+	}
+
+	remote, err := github.ParseRemote(remoteURL, cfg.Project.GitHubHost)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not parse owner/repo from remote URL '%s': %w",
+			remoteURL,
+			err,
+		)
+	}
+
+	client, err := github.NewClientForHost(ctx, logger, remote.Host, remote.Owner, remote.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create github client: %w", err)
+	}
+
+	return client, nil
+}
+
+// ProtectBranchCmd represents the factory protect-branch command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var ProtectBranchCmd = &cobra.Command{
+	Use: "protect-branch [branch]",
+	Example: `  contextvibes factory protect-branch
+  contextvibes factory protect-branch main --required-reviews 2 --status-check build --strict`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+
+		spec := buildSpec(cmd, globals.LoadedAppConfig.Git.BranchProtection)
+
+		request, err := spec.Request()
+		if err != nil {
+			presenter.Error("Invalid protection rules: %v", err)
+
+			//nolint:wrapcheck // Validation errors are already descriptive.
+			return err
+		}
+
+		client, err := newGHClient(ctx, globals.AppLogger, globals.LoadedAppConfig)
+		if err != nil {
+			presenter.Error("Failed to initialize GitHub client: %v", err)
+
+			return err
+		}
+
+		var branch string
+		if len(args) > 0 {
+			branch = args[0]
+		}
+
+		presenter.Summary("Applying branch protection to %s...", branchLabel(branch))
+		presentSpec(presenter, spec)
+
+		err = client.UpdateBranchProtection(ctx, branch, request)
+		if err != nil {
+			presenter.Error("Failed to apply branch protection: %v", err)
+
+			if errors.Is(err, github.ErrRepoNotFoundOrAuth) {
+				presenter.Advice(
+					"GitHub reports 404 when the repository does not exist or your token cannot administer it.",
+				)
+				presenter.Advice("Check the remote URL, and that your token has admin rights on the repository.")
+			}
+
+			return fmt.Errorf("failed to apply branch protection: %w", err)
+		}
+
+		presenter.Success("Branch protection applied to %s.", branchLabel(branch))
+
+		return nil
+	},
+}
+
+// buildSpec starts from the configured rules and applies any flags the user set.
+func buildSpec(cmd *cobra.Command, settings config.BranchProtectionSettings) github.ProtectionSpec {
+	spec := github.ProtectionSpec{
+		RequiredApprovingReviews: settings.RequiredApprovingReviews,
+		DismissStaleReviews:      settings.DismissStaleReviews,
+		RequireCodeOwnerReviews:  settings.RequireCodeOwnerReviews,
+		RequiredStatusChecks:     settings.RequiredStatusChecks,
+		StrictStatusChecks:       settings.StrictStatusChecks,
+		EnforceAdmins:            settings.EnforceAdmins,
+	}
+
+	flags := cmd.Flags()
+
+	if flags.Changed("required-reviews") {
+		spec.RequiredApprovingReviews = requiredReviews
+	}
+
+	if flags.Changed("dismiss-stale-reviews") {
+		spec.DismissStaleReviews = dismissStaleReviews
+	}
+
+	if flags.Changed("code-owner-reviews") {
+		spec.RequireCodeOwnerReviews = codeOwnerReviews
+	}
+
+	if flags.Changed("status-check") {
+		spec.RequiredStatusChecks = statusChecks
+	}
+
+	if flags.Changed("strict") {
+		spec.StrictStatusChecks = strictStatusChecks
+	}
+
+	if flags.Changed("enforce-admins") {
+		spec.EnforceAdmins = enforceAdmins
+	}
+
+	return spec
+}
+
+func presentSpec(presenter *ui.Presenter, spec github.ProtectionSpec) {
+	if spec.RequiredApprovingReviews > 0 {
+		presenter.Detail("Required approving reviews: %d", spec.RequiredApprovingReviews)
+		presenter.Detail("Dismiss stale reviews: %t", spec.DismissStaleReviews)
+		presenter.Detail("Require code owner reviews: %t", spec.RequireCodeOwnerReviews)
+	} else {
+		presenter.Detail("Required approving reviews: none")
+	}
+
+	if len(spec.RequiredStatusChecks) > 0 {
+		presenter.Detail("Required status checks: %s", strings.Join(spec.RequiredStatusChecks, ", "))
+	}
+
+	if len(spec.RequiredStatusChecks) > 0 || spec.StrictStatusChecks {
+		presenter.Detail("Require up-to-date branches: %t", spec.StrictStatusChecks)
+	}
+
+	presenter.Detail("Enforce for admins: %t", spec.EnforceAdmins)
+}
+
+func branchLabel(branch string) string {
+	if branch == "" {
+		return "the default branch"
+	}
+
+	return fmt.Sprintf("'%s'", branch)
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(protectBranchLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	ProtectBranchCmd.Short = desc.Short
+	ProtectBranchCmd.Long = desc.Long
+
+	flags := ProtectBranchCmd.Flags()
+	flags.IntVar(&requiredReviews, "required-reviews", 0, "Approving reviews required on pull requests (0-6)")
+	flags.BoolVar(&dismissStaleReviews, "dismiss-stale-reviews", false, "Dismiss approvals when new commits are pushed")
+	flags.BoolVar(&codeOwnerReviews, "code-owner-reviews", false, "Require review from code owners")
+	flags.StringSliceVar(&statusChecks, "status-check", nil, "Status check that must pass before merging (repeatable)")
+	flags.BoolVar(&strictStatusChecks, "strict", false, "Require branches to be up to date before merging")
+	flags.BoolVar(&enforceAdmins, "enforce-admins", false, "Apply the rules to administrators too")
+}
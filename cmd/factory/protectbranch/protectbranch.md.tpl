@@ -0,0 +1,16 @@
+# Apply branch protection rules on GitHub.
+
+Applies the branch protection rules from `git.branchProtection` in
+`.contextvibes.yaml` to a branch of the GitHub repository behind the default
+remote. Without a branch argument, the repository's default branch is protected.
+
+Flags override individual settings from the config file:
+
+- `--required-reviews`: approvals a pull request needs (1-6; 0 disables reviews).
+- `--dismiss-stale-reviews`, `--code-owner-reviews`: review options.
+- `--status-check`: a check that must pass before merging (repeatable).
+- `--strict`: require branches to be up to date before merging.
+- `--enforce-admins`: apply the rules to administrators too.
+
+The rules replace any protection already on the branch. Your token needs admin
+rights on the repository.
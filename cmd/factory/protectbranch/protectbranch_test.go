@@ -0,0 +1,152 @@
+package protectbranch_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/protectbranch"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/globals"
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// protectionAPI stubs the REST API, recording the protection request body and
+// path, or answering 404 when notFound is set.
+type protectionAPI struct {
+	notFound bool
+	path     string
+	body     map[string]any
+}
+
+func (a *protectionAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if a.notFound {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"message": "Not Found"}`)
+
+		return
+	}
+
+	a.path = r.URL.Path
+	_ = json.NewDecoder(r.Body).Decode(&a.body)
+	_, _ = io.WriteString(w, `{}`)
+}
+
+func runProtect(
+	t *testing.T,
+	api *protectionAPI,
+	settings config.BranchProtectionSettings,
+	args ...string,
+) (string, error) {
+	t.Helper()
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	restClient := gogithub.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+
+	restClient.BaseURL = baseURL
+	protectbranch.SetClient(t, github.NewClientWithAPI(restClient, slog.New(slog.DiscardHandler), "octo", "widgets"))
+
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+	globals.LoadedAppConfig.Git.BranchProtection = settings
+
+	cmd := *protectbranch.ProtectBranchCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs(args)
+
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			require.NoError(t, slice.Replace(nil))
+		} else {
+			require.NoError(t, flag.Value.Set(flag.DefValue))
+		}
+
+		flag.Changed = false
+	})
+
+	err = cmd.Execute()
+
+	return outBuf.String() + errBuf.String(), err
+}
+
+//nolint:paralleltest // ProtectBranchCmd uses global state which is not thread-safe.
+func TestProtectBranchCmd_FromConfig(t *testing.T) {
+	api := &protectionAPI{notFound: false, path: "", body: nil}
+
+	out, err := runProtect(t, api, config.BranchProtectionSettings{
+		RequiredApprovingReviews: 1,
+		DismissStaleReviews:      true,
+		RequireCodeOwnerReviews:  false,
+		RequiredStatusChecks:     []string{"build"},
+		StrictStatusChecks:       true,
+		EnforceAdmins:            true,
+	}, "main")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/repos/octo/widgets/branches/main/protection", api.path)
+	assert.Equal(t, map[string]any{
+		"required_status_checks": map[string]any{
+			"strict": true,
+			"checks": []any{map[string]any{"context": "build"}},
+		},
+		"required_pull_request_reviews": map[string]any{
+			"dismiss_stale_reviews":           true,
+			"require_code_owner_reviews":      false,
+			"required_approving_review_count": float64(1),
+		},
+		"enforce_admins": true,
+		"restrictions":   nil,
+	}, api.body)
+	assert.Contains(t, out, "Branch protection applied to 'main'.")
+}
+
+//nolint:paralleltest // ProtectBranchCmd uses global state which is not thread-safe.
+func TestProtectBranchCmd_FlagsOverrideConfig(t *testing.T) {
+	api := &protectionAPI{notFound: false, path: "", body: nil}
+
+	//nolint:exhaustruct // Only the overridden settings matter.
+	_, err := runProtect(t, api, config.BranchProtectionSettings{
+		RequiredApprovingReviews: 1,
+		RequiredStatusChecks:     []string{"build"},
+	}, "release", "--required-reviews", "0", "--status-check", "test", "--status-check", "vet")
+	require.NoError(t, err)
+
+	assert.Nil(t, api.body["required_pull_request_reviews"], "zero reviews disables the requirement")
+	assert.Equal(t, map[string]any{
+		"strict": false,
+		"checks": []any{map[string]any{"context": "test"}, map[string]any{"context": "vet"}},
+	}, api.body["required_status_checks"])
+}
+
+//nolint:paralleltest // ProtectBranchCmd uses global state which is not thread-safe.
+func TestProtectBranchCmd_Errors(t *testing.T) {
+	//nolint:exhaustruct // Only the review count matters.
+	_, err := runProtect(t, &protectionAPI{notFound: false, path: "", body: nil},
+		config.BranchProtectionSettings{RequiredApprovingReviews: 9}, "main")
+	require.ErrorIs(t, err, github.ErrInvalidReviewCount)
+
+	//nolint:exhaustruct // Empty rules are enough to reach the API.
+	out, err := runProtect(t, &protectionAPI{notFound: true, path: "", body: nil},
+		config.BranchProtectionSettings{}, "main")
+	require.ErrorIs(t, err, github.ErrRepoNotFoundOrAuth)
+	assert.Contains(t, out, "does not exist or your token cannot administer it")
+}
@@ -0,0 +1,185 @@
+// Package release provides the command to tag and publish a release.
+package release
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/contextvibes/cli/internal/changelog"
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed release.md.tpl
+var releaseLongDescription string
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	messageFlag   string
+	signFlag      bool
+	changelogFlag bool
+)
+
+// ErrTagExists is returned when the release tag is already present.
+var ErrTagExists = errors.New("tag already exists")
+
+// ReleaseCmd represents the release command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var ReleaseCmd = &cobra.Command{
+	Use: "release <version> [--changelog] [--sign] [-m <message>]",
+	Example: `  contextvibes factory release v1.4.0
+  contextvibes factory release v2.0.0-rc.1 --changelog --sign`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+		tag := args[0]
+
+		err := git.ValidateSemverTag(tag)
+		if err != nil {
+			presenter.Error("%v", err)
+
+			//nolint:wrapcheck // Validation errors are already descriptive.
+			return err
+		}
+
+		//nolint:exhaustruct // Partial config is sufficient.
+		gitClient, err := git.NewClient(ctx, ".", git.GitClientConfig{
+			Logger:            globals.AppLogger,
+			DefaultRemoteName: globals.LoadedAppConfig.Git.DefaultRemote,
+			Executor:          globals.ExecClient.UnderlyingExecutor(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to initialize git client: %w", err)
+		}
+
+		tags, err := gitClient.ListTags(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list tags: %w", err)
+		}
+
+		if slices.Contains(tags, tag) {
+			presenter.Error("Tag '%s' already exists.", tag)
+
+			return fmt.Errorf("%w: %s", ErrTagExists, tag)
+		}
+
+		message := messageFlag
+		if changelogFlag {
+			message, err = releaseNotes(ctx, gitClient, tag)
+			if err != nil {
+				return err
+			}
+
+			presenter.Header("Release notes for %s", tag)
+			//nolint:errcheck // Printing to stdout is best effort.
+			fmt.Fprint(presenter.Out(), message)
+			presenter.Newline()
+		}
+
+		kind := "annotated"
+		if signFlag {
+			kind = "signed"
+		}
+
+		confirmed, err := confirm(presenter, fmt.Sprintf("Create %s tag '%s' on HEAD?", kind, tag))
+		if err != nil || !confirmed {
+			return err
+		}
+
+		err = gitClient.CreateTag(ctx, tag, message, signFlag)
+		if err != nil {
+			presenter.Error("Failed to create tag: %v", err)
+
+			return fmt.Errorf("failed to create tag: %w", err)
+		}
+
+		presenter.Success("Created tag %s.", tag)
+
+		confirmed, err = confirm(
+			presenter,
+			fmt.Sprintf("Push tag '%s' to '%s'?", tag, gitClient.RemoteName()),
+		)
+		if err != nil || !confirmed {
+			presenter.Advice("Push it later with: git push %s %s", gitClient.RemoteName(), tag)
+
+			return err
+		}
+
+		err = gitClient.PushTag(ctx, tag)
+		if err != nil {
+			presenter.Error("Failed to push tag: %v", err)
+
+			return fmt.Errorf("failed to push tag: %w", err)
+		}
+
+		presenter.Success("Pushed tag %s to %s.", tag, gitClient.RemoteName())
+
+		return nil
+	},
+}
+
+// releaseNotes renders the changelog from the newest existing tag to HEAD.
+func releaseNotes(ctx context.Context, gitClient *git.GitClient, tag string) (string, error) {
+	previous, err := gitClient.GetLatestTag(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to find the previous tag: %w", err)
+	}
+
+	revRange := ""
+	if previous != "" {
+		revRange = previous + "..HEAD"
+	}
+
+	commits, err := gitClient.GetLog(ctx, revRange, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to read commit history: %w", err)
+	}
+
+	return changelog.Render(commits, changelog.Options{
+		Title:   tag,
+		RepoURL: changelog.RepoURL(ctx, gitClient),
+	}), nil
+}
+
+// confirm asks for confirmation unless --yes was given, reporting an abort.
+func confirm(presenter *ui.Presenter, prompt string) (bool, error) {
+	if globals.AssumeYes {
+		return true, nil
+	}
+
+	confirmed, err := presenter.PromptForConfirmation(prompt)
+	if err != nil {
+		return false, fmt.Errorf("confirmation failed: %w", err)
+	}
+
+	if !confirmed {
+		presenter.Info("Aborted by user.")
+	}
+
+	return confirmed, nil
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(releaseLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	ReleaseCmd.Short = desc.Short
+	ReleaseCmd.Long = desc.Long
+	ReleaseCmd.Flags().
+		StringVarP(&messageFlag, "message", "m", "", "Tag message (defaults to the tag name)")
+	ReleaseCmd.Flags().
+		BoolVar(&changelogFlag, "changelog", false, "Use the changelog since the previous tag as the tag message")
+	ReleaseCmd.Flags().BoolVar(&signFlag, "sign", false, "Create a GPG-signed tag")
+	ReleaseCmd.MarkFlagsMutuallyExclusive("message", "changelog")
+}
@@ -0,0 +1,10 @@
+# Tags a release and pushes the tag.
+
+Creates an annotated tag for the given semantic version (for example `v1.4.0`
+or `v2.0.0-rc.1`) on the current commit and, after confirmation, pushes it to
+the default remote. Tags that are not semantic versions, or that already
+exist, are rejected before anything is changed.
+
+Use `--changelog` to generate release notes from the Conventional Commits since
+the previous tag (see `factory changelog`) and store them as the tag message.
+Use `--sign` to create a GPG-signed tag instead of a plain annotated tag.
@@ -0,0 +1,70 @@
+// Package scaffold provides the command to generate project configuration files.
+package scaffold
+
+import (
+	_ "embed"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/scaffold"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+//go:embed scaffold.md.tpl
+var scaffoldLongDescription string
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	onlyFiles []string
+	skipFiles []string
+)
+
+// ScaffoldCmd represents the scaffold command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var ScaffoldCmd = &cobra.Command{
+	Use: "scaffold <target>",
+	Example: `  contextvibes factory scaffold vscode
+  contextvibes factory scaffold vscode --only launch.json
+  contextvibes factory scaffold vscode --skip settings.json,extensions.json`,
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: scaffold.Targets(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		presenter.SetInput(cmd.InOrStdin())
+		ctx := cmd.Context()
+
+		runner := workflow.NewRunner(presenter, globals.AssumeYes, workflow.WithVerboseTiming(globals.Verbose))
+
+		return runner.Run(
+			ctx,
+			"Scaffolding '"+args[0]+"' configuration",
+			&workflow.ScaffoldStep{
+				Target:    args[0],
+				RootDir:   ".",
+				Only:      onlyFiles,
+				Skip:      skipFiles,
+				Presenter: presenter,
+				AssumeYes: globals.AssumeYes,
+			},
+		)
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(scaffoldLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	ScaffoldCmd.Short = desc.Short
+	ScaffoldCmd.Long = desc.Long
+	ScaffoldCmd.Flags().
+		StringSliceVar(&onlyFiles, "only", nil, "Write only these files of the target (comma-separated)")
+	ScaffoldCmd.Flags().
+		StringSliceVar(&skipFiles, "skip", nil, "Leave these files of the target untouched (comma-separated)")
+	ScaffoldCmd.MarkFlagsMutuallyExclusive("only", "skip")
+}
@@ -0,0 +1,21 @@
+# Generates editor and environment configuration for the project.
+
+Writes the embedded configuration files of a scaffold target into the current
+repository.
+
+- **vscode:** `.vscode/settings.json`, `extensions.json`, and a Go-oriented
+  `launch.json`.
+
+Files that already exist are only overwritten after confirmation; use --yes
+to overwrite without prompting.
+
+To touch just some of a target's files, name them with --only (for example
+`--only launch.json`) or leave some out with --skip. Files are named by their
+file name or their path, separated by commas; unknown names are rejected
+before anything is written.
+
+To customize a file without forking the CLI, commit a file of the same name
+under `.contextvibes/templates/<target>/` (for example
+`.contextvibes/templates/vscode/settings.json`). It replaces the embedded
+default; files without an override use the default. The output reports which
+source each written file came from.
@@ -0,0 +1,208 @@
+// Package scaffold_test contains tests for the scaffold command.
+package scaffold_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/scaffold"
+	"github.com/contextvibes/cli/internal/globals"
+	internalscaffold "github.com/contextvibes/cli/internal/scaffold"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupScaffoldTest(t *testing.T, assumeYes bool) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(tempDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.AssumeYes = assumeYes
+
+	t.Cleanup(func() { globals.AssumeYes = false })
+
+	return tempDir
+}
+
+func runScaffoldCmd(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := *scaffold.ScaffoldCmd
+	outBuf := new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs(args)
+
+	// Reset slice flags that earlier subtests may have set.
+	for _, name := range []string{"only", "skip"} {
+		flag := cmd.Flags().Lookup(name)
+		sliceFlag, ok := flag.Value.(pflag.SliceValue)
+		require.True(t, ok)
+		require.NoError(t, sliceFlag.Replace(nil))
+
+		flag.Changed = false
+	}
+
+	err := cmd.Execute()
+
+	return outBuf.String(), err
+}
+
+//nolint:paralleltest // ScaffoldCmd uses globals and changes the working directory.
+func TestScaffoldCmd_VSCode(t *testing.T) {
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("writes all files", func(t *testing.T) {
+		dir := setupScaffoldTest(t, true)
+
+		_, err := runScaffoldCmd(t, "", "vscode")
+		require.NoError(t, err)
+
+		for _, name := range []string{"settings.json", "extensions.json", "launch.json"} {
+			content, err := os.ReadFile(filepath.Join(dir, ".vscode", name))
+			require.NoError(t, err, name)
+			assert.NotEmpty(t, content, name)
+		}
+
+		launch, err := os.ReadFile(filepath.Join(dir, ".vscode", "launch.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(launch), `"type": "go"`)
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("preserves existing files without --yes", func(t *testing.T) {
+		dir := setupScaffoldTest(t, false)
+
+		settingsPath := filepath.Join(dir, ".vscode", "settings.json")
+		require.NoError(t, os.MkdirAll(filepath.Dir(settingsPath), 0o750))
+		require.NoError(t, os.WriteFile(settingsPath, []byte(`{"custom": true}`), 0o600))
+
+		// Proceed with the workflow, then decline the overwrite.
+		out, err := runScaffoldCmd(t, "y\nn\n", "vscode")
+		require.NoError(t, err)
+
+		settings, err := os.ReadFile(settingsPath)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"custom": true}`, string(settings))
+		assert.Contains(t, out, "Preserved existing .vscode/settings.json")
+
+		assert.FileExists(t, filepath.Join(dir, ".vscode", "extensions.json"))
+		assert.FileExists(t, filepath.Join(dir, ".vscode", "launch.json"))
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("overwrites existing files with --yes", func(t *testing.T) {
+		dir := setupScaffoldTest(t, true)
+
+		settingsPath := filepath.Join(dir, ".vscode", "settings.json")
+		require.NoError(t, os.MkdirAll(filepath.Dir(settingsPath), 0o750))
+		require.NoError(t, os.WriteFile(settingsPath, []byte(`{"custom": true}`), 0o600))
+
+		_, err := runScaffoldCmd(t, "", "vscode")
+		require.NoError(t, err)
+
+		settings, err := os.ReadFile(settingsPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(settings), "go.useLanguageServer")
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("reports each step's time with --verbose", func(t *testing.T) {
+		setupScaffoldTest(t, true)
+
+		globals.Verbose = true
+
+		t.Cleanup(func() { globals.Verbose = false })
+
+		out, err := runScaffoldCmd(t, "", "vscode")
+		require.NoError(t, err)
+		assert.Regexp(t, `(?m)^.*Scaffold .* ok in \S+$`, out)
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("rejects unknown targets", func(t *testing.T) {
+		setupScaffoldTest(t, true)
+
+		_, err := runScaffoldCmd(t, "", "emacs")
+		require.Error(t, err)
+	})
+}
+
+//nolint:paralleltest // ScaffoldCmd uses globals and changes the working directory.
+func TestScaffoldCmd_RepoOverrides(t *testing.T) {
+	dir := setupScaffoldTest(t, true)
+
+	overridePath := filepath.Join(dir, ".contextvibes", "templates", "vscode", "settings.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(overridePath), 0o750))
+	require.NoError(t, os.WriteFile(overridePath, []byte(`{"team": true}`), 0o600))
+
+	out, err := runScaffoldCmd(t, "", "vscode")
+	require.NoError(t, err)
+
+	settings, err := os.ReadFile(filepath.Join(dir, ".vscode", "settings.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"team": true}`, string(settings))
+	assert.Contains(t, out, "Wrote .vscode/settings.json (from .contextvibes/templates/vscode/settings.json)")
+
+	launch, err := os.ReadFile(filepath.Join(dir, ".vscode", "launch.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(launch), `"type": "go"`, "files without an override use the default")
+	assert.Contains(t, out, "Wrote .vscode/launch.json (from embedded template)")
+}
+
+//nolint:paralleltest // ScaffoldCmd uses globals and changes the working directory.
+func TestScaffoldCmd_FileSelection(t *testing.T) {
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("--only writes just the named files", func(t *testing.T) {
+		dir := setupScaffoldTest(t, true)
+
+		_, err := runScaffoldCmd(t, "", "vscode", "--only", "launch.json")
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(dir, ".vscode", "launch.json"))
+		assert.NoFileExists(t, filepath.Join(dir, ".vscode", "settings.json"))
+		assert.NoFileExists(t, filepath.Join(dir, ".vscode", "extensions.json"))
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("--skip leaves the named files alone", func(t *testing.T) {
+		dir := setupScaffoldTest(t, false)
+
+		settingsPath := filepath.Join(dir, ".vscode", "settings.json")
+		require.NoError(t, os.MkdirAll(filepath.Dir(settingsPath), 0o750))
+		require.NoError(t, os.WriteFile(settingsPath, []byte(`{"custom": true}`), 0o600))
+
+		// Only the workflow confirmation is asked; the skipped file is never prompted for.
+		out, err := runScaffoldCmd(t, "y\n", "vscode", "--skip", ".vscode/settings.json")
+		require.NoError(t, err)
+
+		settings, err := os.ReadFile(settingsPath)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"custom": true}`, string(settings))
+		assert.NotContains(t, out, "settings.json already exists")
+		assert.FileExists(t, filepath.Join(dir, ".vscode", "launch.json"))
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("rejects unknown file names", func(t *testing.T) {
+		dir := setupScaffoldTest(t, true)
+
+		_, err := runScaffoldCmd(t, "", "vscode", "--only", "dev.nix")
+		require.ErrorIs(t, err, internalscaffold.ErrUnknownFile)
+		assert.NoDirExists(t, filepath.Join(dir, ".vscode"))
+	})
+}
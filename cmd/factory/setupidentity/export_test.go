@@ -0,0 +1,13 @@
+package setupidentity
+
+import "testing"
+
+// SetTokenPrompt replaces the GitHub token prompt with one that returns token.
+func SetTokenPrompt(t *testing.T, token string) {
+	t.Helper()
+
+	original := promptGitHubToken
+	promptGitHubToken = func() (string, error) { return token, nil }
+
+	t.Cleanup(func() { promptGitHubToken = original })
+}
@@ -12,7 +12,9 @@ import (
 
 	"github.com/charmbracelet/huh"
 	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/git"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/tools"
 	"github.com/contextvibes/cli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -27,6 +29,17 @@ const (
 //go:embed setupidentity.md.tpl
 var setupIdentityLongDescription string
 
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var dryRun bool
+
+// promptGitHubToken asks the user for the GitHub token to store.
+//
+//nolint:gochecknoglobals // Replaceable prompt for tests.
+var promptGitHubToken = defaultPromptGitHubToken
+
+// dryRunKeyPlaceholder stands in for the key ID when a dry run cannot import a key.
+const dryRunKeyPlaceholder = "<imported-key-id>"
+
 // SetupIdentityCmd represents the setup-identity command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
@@ -41,23 +54,28 @@ var SetupIdentityCmd = &cobra.Command{
 
 		presenter.Summary("Secure Environment Bootstrap")
 
+		if dryRun {
+			presenter.Warning("Dry run: detecting current state only; no changes will be made.")
+			presenter.Newline()
+		}
+
 		// --- Phase 1: Plumbing (Configuration) ---
 		presenter.Header("1. Configuring Tools & Shell")
 
 		// 1.1 GPG Agent
-		err := configureGPGAgent(ctx, presenter)
+		err := configureGPGAgent(ctx, presenter, dryRun)
 		if err != nil {
 			return err
 		}
 
 		// 1.2 Git Security
-		err = configureGitSecurity(ctx, presenter)
+		err = configureGitSecurity(ctx, presenter, dryRun)
 		if err != nil {
 			return err
 		}
 
 		// 1.3 Bashrc Integration
-		err = configureBashrc(presenter)
+		err = configureBashrc(presenter, dryRun)
 		if err != nil {
 			return err
 		}
@@ -68,29 +86,35 @@ var SetupIdentityCmd = &cobra.Command{
 		presenter.Header("2. Identity & Secrets")
 
 		// 2.1 Import GPG Key
-		keyID, err := importGPGKey(ctx, presenter)
+		keyID, err := importGPGKey(ctx, presenter, dryRun)
 		if err != nil {
 			return err
 		}
 
 		// 2.2 Trust Key
-		err = trustGPGKey(ctx, presenter, keyID)
+		err = trustGPGKey(ctx, presenter, keyID, dryRun)
 		if err != nil {
 			return err
 		}
 
 		// 2.3 Initialize Pass
-		err = initPass(ctx, presenter, keyID)
+		err = initPass(ctx, presenter, keyID, dryRun)
 		if err != nil {
 			return err
 		}
 
 		// 2.4 GitHub Auth
-		err = authenticateGitHub(ctx, presenter)
+		err = authenticateGitHub(ctx, presenter, dryRun)
 		if err != nil {
 			return err
 		}
 
+		if dryRun {
+			presenter.Success("Dry run complete. Re-run without --dry-run to apply these changes.")
+
+			return nil
+		}
+
 		presenter.Success("Bootstrap Complete! Your environment is secure.")
 		presenter.Advice("Run 'source ~/.bashrc' to refresh your shell configuration.")
 
@@ -99,13 +123,17 @@ var SetupIdentityCmd = &cobra.Command{
 }
 
 //nolint:varnamelen // 'p' is standard for presenter.
-func configureGPGAgent(ctx context.Context, p *ui.Presenter) error {
+func configureGPGAgent(ctx context.Context, p *ui.Presenter, dryRun bool) error {
 	home, _ := os.UserHomeDir()
 	gnupgDir := filepath.Join(home, ".gnupg")
 
-	err := os.MkdirAll(gnupgDir, dirPermSecure)
-	if err != nil {
-		return fmt.Errorf("failed to create ~/.gnupg: %w", err)
+	if dryRun {
+		p.Info("Would create %s (mode 0700) if missing.", gnupgDir)
+	} else {
+		err := os.MkdirAll(gnupgDir, dirPermSecure)
+		if err != nil {
+			return fmt.Errorf("failed to create ~/.gnupg: %w", err)
+		}
 	}
 
 	// Find pinentry-curses
@@ -120,6 +148,12 @@ func configureGPGAgent(ctx context.Context, p *ui.Presenter) error {
 		confPath := filepath.Join(gnupgDir, "gpg-agent.conf")
 		confContent := fmt.Sprintf("pinentry-program %s\n", pinentryPath)
 
+		if dryRun {
+			p.Info("Would write %s with 'pinentry-program %s' and reload gpg-agent.", confPath, pinentryPath)
+
+			return nil
+		}
+
 		err = os.WriteFile(confPath, []byte(confContent), filePermRW)
 		if err != nil {
 			return fmt.Errorf("failed to write gpg-agent.conf: %w", err)
@@ -134,7 +168,7 @@ func configureGPGAgent(ctx context.Context, p *ui.Presenter) error {
 }
 
 //nolint:varnamelen // 'p' is standard for presenter.
-func configureGitSecurity(ctx context.Context, p *ui.Presenter) error {
+func configureGitSecurity(ctx context.Context, p *ui.Presenter, dryRun bool) error {
 	keyID := os.Getenv("GPG_KEY_ID")
 	if keyID == "" {
 		p.Info("GPG_KEY_ID env var not set. Skipping automatic Git signing config.")
@@ -142,44 +176,56 @@ func configureGitSecurity(ctx context.Context, p *ui.Presenter) error {
 		return nil
 	}
 
-	cmds := [][]string{
-		{"config", "--global", "user.signingkey", keyID},
-		{"config", "--global", "commit.gpgsign", "true"},
-		{"config", "--global", "gpg.program", "gpg"},
+	settings := []struct{ key, value string }{
+		{"user.signingkey", keyID},
+		{"commit.gpgsign", "true"},
+		{"gpg.program", "gpg"},
 	}
 
-	for _, args := range cmds {
-		err := globals.ExecClient.Execute(ctx, ".", "git", args...)
+	executor := globals.ExecClient.UnderlyingExecutor()
+
+	for _, setting := range settings {
+		key, value := setting.key, setting.value
+
+		current, err := git.GetGlobalConfig(ctx, executor, key)
+		if err != nil && !errors.Is(err, git.ErrConfigNotSet) {
+			return fmt.Errorf("failed to read git config: %w", err)
+		}
+
+		if current == value {
+			p.Detail("git %s is already %s", key, value)
+
+			continue
+		}
+
+		if dryRun {
+			p.Info("Would run: git config --global %s %s", key, value)
+
+			continue
+		}
+
+		err = git.SetGlobalConfig(ctx, executor, key, value)
 		if err != nil {
-			return fmt.Errorf("failed to configure git: %w", err)
+			return fmt.Errorf("failed to configure git %s: %w", key, err)
 		}
 	}
 
+	if dryRun {
+		return nil
+	}
+
 	p.Success("✓ Git configured to sign commits with key %s", keyID)
 
 	return nil
 }
 
-func configureBashrc(presenter *ui.Presenter) error {
-	home, _ := os.UserHomeDir()
-	bashrcPath := filepath.Join(home, ".bashrc")
-	marker := "# --- SECURE ENV CONFIG ---"
-
-	//nolint:gosec // Reading user bashrc is intended.
-	content, err := os.ReadFile(bashrcPath)
-	if err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to read .bashrc: %w", err)
-	}
-
-	if strings.Contains(string(content), marker) {
-		presenter.Info("Shell configuration already present.")
+// secureEnvBlockName names the marked .bashrc region managed by setup-identity.
+const secureEnvBlockName = "SECURE ENV CONFIG"
 
-		return nil
-	}
+// legacySecureEnvMarker opened the unterminated block written by older versions.
+const legacySecureEnvMarker = "# --- SECURE ENV CONFIG ---"
 
-	block := `
-# --- SECURE ENV CONFIG ---
-export GPG_TTY=$(tty)
+const secureEnvBlock = `export GPG_TTY=$(tty)
 
 # Status Check
 if ! gpg --list-secret-keys --with-colons 2>/dev/null | grep -q "^sec:"; then
@@ -195,17 +241,48 @@ fi
 alias p='pass'
 alias g='git'
 `
-	//nolint:gosec // Writing to user's bashrc is intended.
-	file, err := os.OpenFile(bashrcPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermRead)
+
+func configureBashrc(presenter *ui.Presenter, dryRun bool) error {
+	home, _ := os.UserHomeDir()
+	bashrcPath := filepath.Join(home, ".bashrc")
+
+	//nolint:gosec // Reading user bashrc is intended.
+	content, err := os.ReadFile(bashrcPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .bashrc: %w", err)
+	}
+
+	current := removeLegacySecureEnvBlock(string(content))
+
+	updated, changed, err := tools.UpsertMarkedBlock(current, secureEnvBlockName, secureEnvBlock)
 	if err != nil {
-		return fmt.Errorf("failed to open .bashrc: %w", err)
+		return fmt.Errorf("failed to update .bashrc: %w", err)
+	}
+
+	if !changed && current == string(content) {
+		presenter.Info("Shell configuration already up to date.")
+
+		return nil
+	}
+
+	if dryRun {
+		presenter.Info("Would write the secure environment block to %s.", bashrcPath)
+
+		return nil
+	}
+
+	// Write through a symlinked ~/.bashrc (e.g. one kept in a dotfiles repo) rather
+	// than replacing the link with a regular file.
+	target := bashrcPath
+
+	resolved, err := filepath.EvalSymlinks(bashrcPath)
+	if err == nil {
+		target = resolved
 	}
-	//nolint:errcheck // Defer close is sufficient.
-	defer file.Close()
 
-	_, err = file.WriteString(block)
+	err = tools.WriteFileAtomic(target, []byte(updated), filePermRead)
 	if err != nil {
-		return fmt.Errorf("failed to write to .bashrc: %w", err)
+		return fmt.Errorf("failed to write .bashrc: %w", err)
 	}
 
 	presenter.Success("✓ Shell configuration updated (.bashrc)")
@@ -213,7 +290,25 @@ alias g='git'
 	return nil
 }
 
-func importGPGKey(ctx context.Context, presenter *ui.Presenter) (string, error) {
+// removeLegacySecureEnvBlock strips the block written by older versions, which had
+// no END marker and always ended with the 'g' alias.
+func removeLegacySecureEnvBlock(content string) string {
+	start := strings.Index(content, "\n"+legacySecureEnvMarker+"\n")
+	if start < 0 {
+		return content
+	}
+
+	const legacyLastLine = "alias g='git'\n"
+
+	end := strings.Index(content[start:], legacyLastLine)
+	if end < 0 {
+		return content
+	}
+
+	return content[:start] + content[start+end+len(legacyLastLine):]
+}
+
+func importGPGKey(ctx context.Context, presenter *ui.Presenter, dryRun bool) (string, error) {
 	// Check if key exists
 	out, _, _ := globals.ExecClient.CaptureOutput(ctx, ".", "gpg", "--list-secret-keys", "--with-colons")
 	if strings.Contains(out, "sec:") {
@@ -222,6 +317,12 @@ func importGPGKey(ctx context.Context, presenter *ui.Presenter) (string, error)
 		return extractKeyID(out), nil
 	}
 
+	if dryRun {
+		presenter.Info("Would prompt for an ASCII-armored private GPG key and run 'gpg --import'.")
+
+		return dryRunKeyPlaceholder, nil
+	}
+
 	presenter.Info("👉 Please paste your ASCII-Armored Private GPG Key.")
 	presenter.Info("   (Press Enter, paste key, then press Ctrl+D to finish)")
 
@@ -261,12 +362,20 @@ func extractKeyID(gpgOutput string) string {
 }
 
 //nolint:varnamelen // 'p' is standard for presenter.
-func trustGPGKey(ctx context.Context, p *ui.Presenter, keyID string) error {
-	p.Step("Applying 'Ultimate Trust' to key: %s", keyID)
+func trustGPGKey(ctx context.Context, p *ui.Presenter, keyID string, dryRun bool) error {
+	if dryRun {
+		p.Info("Would apply 'Ultimate Trust' to key: %s", keyID)
 
-	cmdStr := fmt.Sprintf("echo -e \"5\ny\n\" | gpg --command-fd 0 --edit-key %s trust", keyID)
+		return nil
+	}
+
+	p.Step("Applying 'Ultimate Trust' to key: %s", keyID)
 
-	err := globals.ExecClient.Execute(ctx, ".", "sh", "-c", cmdStr)
+	// Answer gpg's trust prompt: level 5 (ultimate), then confirm.
+	err := globals.ExecClient.ExecuteWithStdin(
+		ctx, ".", strings.NewReader("5\ny\n"),
+		"gpg", "--command-fd", "0", "--edit-key", keyID, "trust",
+	)
 	if err != nil {
 		p.Warning("Failed to automate trust setting. You may need to trust the key manually.")
 	} else {
@@ -277,7 +386,7 @@ func trustGPGKey(ctx context.Context, p *ui.Presenter, keyID string) error {
 }
 
 //nolint:varnamelen // 'p' is standard for presenter.
-func initPass(ctx context.Context, p *ui.Presenter, keyID string) error {
+func initPass(ctx context.Context, p *ui.Presenter, keyID string, dryRun bool) error {
 	home, _ := os.UserHomeDir()
 	passDir := filepath.Join(home, ".password-store")
 
@@ -288,6 +397,12 @@ func initPass(ctx context.Context, p *ui.Presenter, keyID string) error {
 		return nil
 	}
 
+	if dryRun {
+		p.Info("Would run: pass init %s", keyID)
+
+		return nil
+	}
+
 	p.Step("Initializing 'pass' vault...")
 
 	err = globals.ExecClient.Execute(ctx, ".", "pass", "init", keyID)
@@ -301,11 +416,31 @@ func initPass(ctx context.Context, p *ui.Presenter, keyID string) error {
 }
 
 //nolint:varnamelen // 'p' is standard for presenter.
-func authenticateGitHub(ctx context.Context, p *ui.Presenter) error {
-	var token string
+func authenticateGitHub(ctx context.Context, p *ui.Presenter, dryRun bool) error {
+	if dryRun {
+		p.Info("Would prompt for a GitHub token, store it in 'pass' (github/token), and run 'gh auth login'.")
+
+		return nil
+	}
 
 	p.Newline()
 
+	token, err := promptGitHubToken()
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(token) == "" {
+		//nolint:err113 // Dynamic error is appropriate here.
+		return errors.New("token cannot be empty")
+	}
+
+	return storeGitHubToken(ctx, p, token)
+}
+
+func defaultPromptGitHubToken() (string, error) {
+	var token string
+
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewInput().
@@ -318,19 +453,24 @@ func authenticateGitHub(ctx context.Context, p *ui.Presenter) error {
 
 	err := form.Run()
 	if err != nil {
-		return fmt.Errorf("input form failed: %w", err)
+		return "", fmt.Errorf("input form failed: %w", err)
 	}
 
-	if strings.TrimSpace(token) == "" {
-		//nolint:err113 // Dynamic error is appropriate here.
-		return errors.New("token cannot be empty")
-	}
+	return token, nil
+}
 
+// storeGitHubToken saves the token in 'pass' (github/token) and logs the GitHub CLI in
+// with it. The token is only ever written to the commands' stdin; it never appears in
+// an argument list or passes through a shell, so quotes, '$' and backticks are safe.
+//
+//nolint:varnamelen // 'p' is standard for presenter.
+func storeGitHubToken(ctx context.Context, p *ui.Presenter, token string) error {
 	p.Step("Storing token in vault...")
-	// Pipe token to pass insert
-	insertCmd := "echo \"" + token + "\" | pass insert -m -f github/token"
 
-	err = globals.ExecClient.Execute(ctx, ".", "sh", "-c", insertCmd)
+	err := globals.ExecClient.ExecuteWithStdin(
+		ctx, ".", strings.NewReader(token+"\n"),
+		"pass", "insert", "-m", "-f", "github/token",
+	)
 	if err != nil {
 		return fmt.Errorf("failed to store token in pass: %w", err)
 	}
@@ -338,10 +478,11 @@ func authenticateGitHub(ctx context.Context, p *ui.Presenter) error {
 	p.Success("✓ Token stored in vault (github/token).")
 
 	p.Step("Authenticating GitHub CLI...")
-	// Pipe token to gh auth login
-	loginCmd := "echo \"" + token + "\" | gh auth login --with-token"
 
-	err = globals.ExecClient.Execute(ctx, ".", "sh", "-c", loginCmd)
+	err = globals.ExecClient.ExecuteWithStdin(
+		ctx, ".", strings.NewReader(token+"\n"),
+		"gh", "auth", "login", "--with-token",
+	)
 	if err != nil {
 		return fmt.Errorf("gh auth login failed: %w", err)
 	}
@@ -368,4 +509,6 @@ func init() {
 
 	SetupIdentityCmd.Short = desc.Short
 	SetupIdentityCmd.Long = desc.Long
+	SetupIdentityCmd.Flags().
+		BoolVar(&dryRun, "dry-run", false, "Report the changes that would be made without making them")
 }
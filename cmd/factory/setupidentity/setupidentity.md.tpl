@@ -5,3 +5,7 @@ Configures the "Chain of Trust" workflow:
 2.  **Identity:** Imports your GPG Key and applies "Ultimate Trust".
 3.  **Vault:** Initializes the `pass` password store.
 4.  **Auth:** Securely injects your GitHub PAT into the vault and authenticates the CLI.
+
+Use --dry-run to preview the bootstrap: all detection still runs, but the
+command only reports the files it would write, the git config it would set,
+and the keys and secrets it would import.
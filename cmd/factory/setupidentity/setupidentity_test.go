@@ -0,0 +1,195 @@
+// Package setupidentity_test contains tests for the setup-identity command.
+package setupidentity_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/setupidentity"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Uses t.Setenv and global command flags.
+func TestSetupIdentityCmd_DryRun(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GPG_KEY_ID", "ABCDEF0123456789")
+
+	mockExec := newIdentityExecutor("")
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+
+	cmd := *setupidentity.SetupIdentityCmd
+	cmd.SetContext(context.Background())
+
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{"--dry-run"})
+
+	require.NoError(t, cmd.Execute())
+
+	entries, err := os.ReadDir(home)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "dry run must not write any files")
+	assert.Empty(t, mockExec.Executed(), "dry run must not run any mutating commands")
+	assert.Contains(t, mockExec.Commands(), "git config --global --get user.signingkey")
+	assert.NotContains(t, mockExec.Commands(), "git rev-parse --show-toplevel", "no repository is needed")
+
+	out := outBuf.String()
+	assert.Contains(t, out, "Would write "+home+"/.gnupg/gpg-agent.conf")
+	assert.Contains(t, out, "Would run: git config --global user.signingkey ABCDEF0123456789")
+	assert.Contains(t, out, "Would run: git config --global commit.gpgsign true")
+	assert.Contains(t, out, "Would write the secure environment block to "+home+"/.bashrc")
+	assert.Contains(t, out, "Would prompt for an ASCII-armored private GPG key")
+	assert.Contains(t, out, "Would run: pass init <imported-key-id>")
+	assert.Contains(t, out, "Would prompt for a GitHub token")
+	assert.Contains(t, out, "Dry run complete")
+}
+
+//nolint:paralleltest // Uses t.Setenv and global command state.
+func TestSetupIdentityCmd_TokenSpecialCharacters(t *testing.T) {
+	// Each of these broke (or injected into) the old `sh -c "echo \"<token>\" | ..."` pipeline.
+	tokens := []string{
+		`ghp_with"quote`,
+		`ghp_$(touch pwned)`,
+		"ghp_`id`",
+		`ghp_$HOME\n; rm -rf /`,
+	}
+
+	for _, token := range tokens {
+		t.Run(token, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("GPG_KEY_ID", "")
+			// An existing key and password store skip the interactive import and pass init.
+			require.NoError(t, os.Mkdir(filepath.Join(home, ".password-store"), 0o700))
+
+			mockExec := newIdentityExecutor("sec:u:4096:1:ABCDEF0123456789:1700000000::u:::scESC:\n")
+			globals.ExecClient = exec.NewClient(mockExec)
+			globals.AppLogger = slog.New(slog.DiscardHandler)
+			setupidentity.SetTokenPrompt(t, token)
+
+			cmd := *setupidentity.SetupIdentityCmd
+			cmd.SetContext(context.Background())
+
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs([]string{"--dry-run=false"})
+
+			require.NoError(t, cmd.Execute())
+
+			executed := mockExec.Executed()
+			require.GreaterOrEqual(t, len(executed), 3)
+
+			last := executed[len(executed)-3:]
+			assert.Equal(t, "pass insert -m -f github/token", last[0].String())
+			assert.Equal(t, "gh auth login --with-token", last[1].String())
+			assert.Equal(t, "gh auth setup-git", last[2].String())
+			assert.Equal(t, token+"\n", last[0].Stdin)
+			assert.Equal(t, token+"\n", last[1].Stdin)
+
+			for _, command := range mockExec.Commands() {
+				assert.NotContains(t, command, token, "token must not appear in arguments")
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Uses t.Setenv and global command state.
+func TestSetupIdentityCmd_GitSigningConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GPG_KEY_ID", "ABCDEF0123456789")
+	require.NoError(t, os.Mkdir(filepath.Join(home, ".password-store"), 0o700))
+
+	mockExec := newIdentityExecutor("sec:u:4096:1:ABCDEF0123456789:1700000000::u:::scESC:\n")
+	mockExec.Responses = map[string]exectest.Response{
+		"git config --global --get commit.gpgsign": {Stdout: "true\n", Stderr: "", Err: nil},
+	}
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	setupidentity.SetTokenPrompt(t, "ghp_token")
+
+	cmd := *setupidentity.SetupIdentityCmd
+	cmd.SetContext(context.Background())
+
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"--dry-run=false"})
+
+	require.NoError(t, cmd.Execute())
+
+	commands := mockExec.Commands()
+	assert.Contains(t, commands, "git config --global user.signingkey ABCDEF0123456789")
+	assert.Contains(t, commands, "git config --global gpg.program gpg")
+	assert.NotContains(t, commands, "git config --global commit.gpgsign true", "settings already in place are kept")
+	assert.NotContains(t, commands, "git rev-parse --show-toplevel", "no repository is needed")
+	assert.Contains(t, out.String(), "Git configured to sign commits with key ABCDEF0123456789")
+}
+
+//nolint:paralleltest // Uses t.Setenv and global command state.
+func TestSetupIdentityCmd_SymlinkedBashrc(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GPG_KEY_ID", "")
+	require.NoError(t, os.Mkdir(filepath.Join(home, ".password-store"), 0o700))
+
+	dotfiles := t.TempDir()
+	target := filepath.Join(dotfiles, "bashrc")
+	require.NoError(t, os.WriteFile(target, []byte("export EDITOR=vim\n"), 0o600))
+
+	link := filepath.Join(home, ".bashrc")
+	require.NoError(t, os.Symlink(target, link))
+
+	mockExec := newIdentityExecutor("sec:u:4096:1:ABCDEF0123456789:1700000000::u:::scESC:\n")
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	setupidentity.SetTokenPrompt(t, "ghp_token")
+
+	cmd := *setupidentity.SetupIdentityCmd
+	cmd.SetContext(context.Background())
+
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"--dry-run=false"})
+
+	require.NoError(t, cmd.Execute())
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&os.ModeSymlink, "~/.bashrc must remain a symlink")
+
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "export EDITOR=vim")
+	assert.Contains(t, string(content), "export GPG_TTY=$(tty)")
+}
+
+// newIdentityExecutor finds pinentry and reports secretKeys as gpg's secret key listing.
+func newIdentityExecutor(secretKeys string) *exectest.Executor {
+	//nolint:exhaustruct // Recorded fields start empty.
+	return &exectest.Executor{
+		Handler: func(call exectest.Call) exectest.Response {
+			switch call.Name {
+			case "which":
+				return exectest.Response{Stdout: "/usr/bin/pinentry-curses\n", Stderr: "", Err: nil}
+			case "gpg":
+				return exectest.Response{Stdout: secretKeys, Stderr: "", Err: nil}
+			default:
+				return exectest.Response{Stdout: "", Stderr: "", Err: nil}
+			}
+		},
+	}
+}
@@ -0,0 +1,113 @@
+// Package squash provides the command to squash a feature branch into a single commit.
+package squash
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+//go:embed squash.md.tpl
+var squashLongDescription string
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	intoFlag       string
+	skipPromptFlag bool
+	squashMessages []string
+)
+
+// SquashCmd represents the squash command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var SquashCmd = &cobra.Command{
+	Use: "squash [--into <branch>] [--skip-prompt] [-m <msg>]",
+	Example: `  contextvibes factory squash
+  contextvibes factory squash --into release/1.4
+  contextvibes factory squash --yes -m "feat(auth): Add login" -m "Details about the login logic."`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		presenter.SetInput(cmd.InOrStdin())
+		ctx := cmd.Context()
+
+		//nolint:exhaustruct // Partial config is sufficient.
+		gitCfg := git.GitClientConfig{
+			Logger:                globals.AppLogger,
+			DefaultRemoteName:     globals.LoadedAppConfig.Git.DefaultRemote,
+			DefaultMainBranchName: globals.LoadedAppConfig.Git.DefaultMainBranch,
+			Executor:              globals.ExecClient.UnderlyingExecutor(),
+		}
+
+		client, err := git.NewClient(ctx, ".", gitCfg)
+		if err != nil {
+			return fmt.Errorf("failed to initialize git client: %w", err)
+		}
+
+		//nolint:exhaustruct // The remaining fields are filled in by AnalyzeBranchStep.
+		state := &workflow.SquashState{TargetBase: intoFlag}
+
+		// Multiple -m flags are separated by a blank line, as with git commit.
+		message := strings.Join(squashMessages, "\n\n")
+
+		runner := workflow.NewRunner(presenter, globals.AssumeYes, workflow.WithVerboseTiming(globals.Verbose))
+
+		return runner.Run(
+			ctx,
+			"Squashing Branch",
+			&workflow.EnsureNotMainBranchStep{
+				GitClient: client,
+				Presenter: presenter,
+			},
+			&workflow.AnalyzeBranchStep{
+				GitClient: client,
+				Presenter: presenter,
+				State:     state,
+			},
+			&workflow.GenerateSquashPromptStep{
+				GitClient: client,
+				Presenter: presenter,
+				State:     state,
+				Skip:      skipPromptFlag || message != "",
+			},
+			&workflow.CommitSquashStep{
+				GitClient:  client,
+				Presenter:  presenter,
+				State:      state,
+				Message:    message,
+				Validation: globals.LoadedAppConfig.Validation.CommitMessage,
+				AssumeYes:  globals.AssumeYes,
+			},
+			&workflow.ForcePushSquashStep{
+				GitClient: client,
+				Presenter: presenter,
+				State:     state,
+				AssumeYes: globals.AssumeYes,
+			},
+		)
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(squashLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	SquashCmd.Short = desc.Short
+	SquashCmd.Long = desc.Long
+	SquashCmd.Flags().
+		StringVar(&intoFlag, "into", "", "Base branch to squash onto (default: <remote>/<main>)")
+	SquashCmd.Flags().
+		BoolVar(&skipPromptFlag, "skip-prompt", false, "Do not generate the AI prompt file for the commit message")
+	SquashCmd.Flags().
+		StringArrayVarP(&squashMessages, "message", "m", []string{}, "Squash commit message (can be repeated for body)")
+}
@@ -0,0 +1,26 @@
+# Squashes the current branch into a single commit.
+
+Rewrites the commits on the current feature branch as one commit on top of the
+point where it diverged from its base branch, then force-pushes it with
+`--force-with-lease` (after confirmation).
+
+1.  **Analyze:** Finds the merge base with the base branch and counts the commits
+    on the branch. At least two commits are required, and the working directory
+    must be clean.
+2.  **Prompt:** Writes `_contextvibes.md` with the commit log and combined diff,
+    so an AI can draft the squash commit message.
+3.  **Commit:** Asks for the message, soft-resets to the merge base and commits.
+4.  **Push:** Force-pushes the rewritten branch.
+
+The base defaults to `<remote>/<main>` from the git settings in
+`.contextvibes.yaml`. Use `--into <branch>` to squash onto another branch, such
+as a release branch; a bare name is also looked up as `<remote>/<branch>`.
+
+If you already know the message, pass it with `-m` (repeat it for the body).
+This skips both the prompt file and the interactive question, so
+`contextvibes factory squash --yes -m "..."` runs without any input. Use
+`--skip-prompt` to skip only the prompt file and still be asked for the message.
+
+The message subject must match `validation.commitMessage` (Conventional Commits
+by default), just like `factory commit`. An invalid message is asked for again
+interactively, and fails the command with `--yes`.
@@ -0,0 +1,98 @@
+// Package squash_test contains tests for the squash command.
+package squash_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/squash"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mergeBase = "0123456789abcdef0123456789abcdef01234567"
+
+// runSquash runs squash on a feature branch, answering prompts from input.
+func runSquash(t *testing.T, input string) (*exectest.Executor, string, error) {
+	t.Helper()
+
+	repoDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(repoDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	//nolint:exhaustruct // Recorded fields start empty.
+	mockExec := &exectest.Executor{
+		RepoDir: repoDir,
+		Responses: map[string]exectest.Response{
+			"git rev-parse --abbrev-ref HEAD":              {Stdout: "feature/login\n", Stderr: "", Err: nil},
+			"git merge-base origin/main HEAD":              {Stdout: mergeBase + "\n", Stderr: "", Err: nil},
+			"git rev-list --count " + mergeBase + "..HEAD": {Stdout: "3\n", Stderr: "", Err: nil},
+		},
+	}
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+	globals.AssumeYes = false
+
+	cmd := *squash.SquashCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs([]string{"-m", "feat(auth): add login"})
+
+	err = cmd.Execute()
+
+	return mockExec, outBuf.String() + errBuf.String(), err
+}
+
+// assertNothingRewritten checks that no step touched the branch.
+func assertNothingRewritten(t *testing.T, mockExec *exectest.Executor) {
+	t.Helper()
+
+	for _, command := range mockExec.Commands() {
+		assert.NotContains(t, command, "git reset", "declining must not reset the branch")
+		assert.NotContains(t, command, "git commit", "declining must not commit")
+		assert.NotContains(t, command, "git push", "declining must not push")
+	}
+}
+
+//nolint:paralleltest // SquashCmd uses global state which is not thread-safe.
+func TestSquashCmd_Declined(t *testing.T) {
+	//nolint:paralleltest // SquashCmd uses global state which is not thread-safe.
+	t.Run("answering no stops before any step runs", func(t *testing.T) {
+		mockExec, out, err := runSquash(t, "n\n")
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "Workflow aborted by user.")
+		assertNothingRewritten(t, mockExec)
+	})
+
+	//nolint:paralleltest // SquashCmd uses global state which is not thread-safe.
+	t.Run("--no stops before any step runs", func(t *testing.T) {
+		//nolint:exhaustruct // Only AssumeNo matters here.
+		ui.SetDefaultOptions(ui.Options{AssumeNo: true})
+		//nolint:exhaustruct // Restore the zero options.
+		t.Cleanup(func() { ui.SetDefaultOptions(ui.Options{}) })
+
+		mockExec, out, err := runSquash(t, "")
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "Workflow aborted by user.")
+		assertNothingRewritten(t, mockExec)
+	})
+}
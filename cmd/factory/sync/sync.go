@@ -2,15 +2,18 @@
 package sync
 
 import (
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/contextvibes/cli/internal/cmddocs"
 	"github.com/contextvibes/cli/internal/git"
 	"github.com/contextvibes/cli/internal/globals"
 	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +29,7 @@ var SyncCmd = &cobra.Command{
 	Args:    cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		presenter.SetInput(cmd.InOrStdin())
 		ctx := cmd.Context()
 
 		presenter.Summary("Synchronizing local branch with remote.")
@@ -37,8 +41,10 @@ var SyncCmd = &cobra.Command{
 
 		//nolint:exhaustruct // Partial config is sufficient.
 		gitCfg := git.GitClientConfig{
-			Logger:   globals.AppLogger,
-			Executor: globals.ExecClient.UnderlyingExecutor(),
+			Logger:                globals.AppLogger,
+			DefaultRemoteName:     globals.LoadedAppConfig.Git.DefaultRemote,
+			DefaultMainBranchName: globals.LoadedAppConfig.Git.DefaultMainBranch,
+			Executor:              globals.ExecClient.UnderlyingExecutor(),
 		}
 		client, err := git.NewClient(ctx, workDir, gitCfg)
 		if err != nil {
@@ -47,26 +53,75 @@ var SyncCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize git client: %w", err)
 		}
 
+		inRebase, err := client.IsRebaseInProgress(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check for a rebase in progress: %w", err)
+		}
+		if inRebase {
+			return resolveStoppedRebase(ctx, presenter, client)
+		}
+
 		isClean, err := client.IsWorkingDirClean(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to check working directory status: %w", err)
 		}
 		if !isClean {
 			presenter.Error("Working directory has uncommitted changes.")
-			presenter.Advice("Please commit or stash your changes before syncing.")
+			presenter.Advice("Commit them, or set them aside with 'git stash', before syncing.")
 
 			//nolint:err113 // Dynamic error is appropriate here.
 			return errors.New("working directory not clean")
 		}
 
-		currentBranch, _ := client.GetCurrentBranchName(ctx)
+		currentBranch, err := client.GetCurrentBranchName(ctx)
+		if errors.Is(err, git.ErrDetachedHead) {
+			workflow.AdviseDetachedHead(presenter, client.MainBranchName())
+
+			return fmt.Errorf("cannot sync: %w", err)
+		}
+
+		if err != nil {
+			presenter.Error("Cannot sync: %v", err)
+
+			return fmt.Errorf("failed to determine current branch: %w", err)
+		}
+
+		presenter.Step("Fetching from '%s'...", client.RemoteName())
+
+		err = client.Fetch(ctx)
+		if err != nil {
+			presenter.Error("Failed to fetch from '%s': %v", client.RemoteName(), err)
+
+			return fmt.Errorf("fetch failed: %w", err)
+		}
+
+		ahead, behind, err := client.GetAheadBehind(ctx)
+		if errors.Is(err, git.ErrNoUpstream) {
+			return publishBranch(ctx, presenter, client, currentBranch)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to compare with upstream: %w", err)
+		}
+
+		if ahead == 0 && behind == 0 {
+			presenter.Success("Branch '%s' is already up to date with its upstream.", currentBranch)
+
+			return nil
+		}
+
 		presenter.Newline()
+		presenter.Info("Branch '%s' is %d commit(s) ahead of and %d behind its upstream.", currentBranch, ahead, behind)
 		presenter.Info("Proposed Sync Actions:")
-		presenter.Detail(
-			"1. Update local branch '%s' from remote (git pull --rebase).",
-			currentBranch,
-		)
-		presenter.Detail("2. Push local changes to remote if ahead (git push).")
+		if behind > 0 {
+			presenter.Detail("- Rebase local commits onto the remote changes (git pull --rebase).")
+		}
+		if ahead > 0 {
+			presenter.Detail("- Push local commits to '%s' (git push).", client.RemoteName())
+		}
+		if currentBranch != client.MainBranchName() {
+			presenter.Detail("(This syncs '%s' with its own upstream; it does not bring in '%s'.)",
+				currentBranch, client.MainBranchName())
+		}
 		presenter.Newline()
 
 		if !globals.AssumeYes {
@@ -81,19 +136,24 @@ var SyncCmd = &cobra.Command{
 			}
 		}
 
-		err = client.PullRebase(ctx, currentBranch)
-		if err != nil {
-			presenter.Error("Error during 'git pull --rebase'. Resolve conflicts manually.")
+		if behind > 0 {
+			err = client.PullRebase(ctx, currentBranch)
+			if errors.Is(err, git.ErrRebaseConflict) {
+				presenter.Error("The rebase onto '%s/%s' stopped on conflicts.", client.RemoteName(), currentBranch)
+				presenter.Advice("Resolve the conflicts, 'git add' the files and run 'git rebase --continue',")
+				presenter.Advice("or run 'git rebase --abort' to return to where you started. Then run sync again.")
 
-			return fmt.Errorf("pull rebase failed: %w", err)
-		}
+				return fmt.Errorf("pull rebase failed: %w", err)
+			}
+			if err != nil {
+				presenter.Error("Error during 'git pull --rebase': %v", err)
 
-		isAhead, err := client.IsBranchAhead(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to check if branch is ahead: %w", err)
+				return fmt.Errorf("pull rebase failed: %w", err)
+			}
 		}
-		if isAhead {
-			err := client.Push(ctx, currentBranch)
+
+		if ahead > 0 {
+			err = client.Push(ctx, currentBranch)
 			if err != nil {
 				return fmt.Errorf("push failed: %w", err)
 			}
@@ -106,6 +166,87 @@ var SyncCmd = &cobra.Command{
 	},
 }
 
+// resolveStoppedRebase offers to continue or abort a rebase left behind by an
+// earlier sync that stopped on conflicts.
+func resolveStoppedRebase(ctx context.Context, presenter *ui.Presenter, client *git.GitClient) error {
+	presenter.Warning("A rebase is in progress, probably from an earlier sync that stopped on conflicts.")
+
+	if globals.AssumeYes {
+		presenter.Advice("Run 'git rebase --continue' once conflicts are resolved, or 'git rebase --abort'.")
+
+		//nolint:err113 // Dynamic error is appropriate here.
+		return errors.New("rebase in progress")
+	}
+
+	for {
+		answer, err := presenter.PromptForInput(
+			"[c]ontinue the rebase (conflicts resolved and staged) / [a]bort it / [q]uit")
+		if err != nil {
+			return fmt.Errorf("rebase choice failed: %w", err)
+		}
+
+		switch strings.ToLower(answer) {
+		case "c", "continue":
+			err = client.ContinueRebase(ctx)
+			if errors.Is(err, git.ErrRebaseConflict) {
+				presenter.Error("The next commit conflicts too.")
+				presenter.Advice("Resolve and stage those conflicts, then run sync again.")
+			}
+			if err != nil {
+				return fmt.Errorf("continuing the rebase failed: %w", err)
+			}
+
+			presenter.Success("Rebase completed. Run sync again to push.")
+
+			return nil
+		case "a", "abort":
+			err = client.AbortRebase(ctx)
+			if err != nil {
+				return fmt.Errorf("aborting the rebase failed: %w", err)
+			}
+
+			presenter.Success("Rebase aborted; the branch is back where it was.")
+
+			return nil
+		case "q", "quit":
+			presenter.Info("Leaving the rebase in progress.")
+
+			return nil
+		}
+
+		presenter.Warning("Invalid choice '%s'. Please enter c, a, or q.", answer)
+	}
+}
+
+// publishBranch handles a branch without an upstream, typically a new feature
+// branch: there is nothing to pull, so sync offers to push it and set the upstream.
+func publishBranch(ctx context.Context, presenter *ui.Presenter, client *git.GitClient, branch string) error {
+	presenter.Info("Branch '%s' has no upstream on '%s' yet.", branch, client.RemoteName())
+
+	if !globals.AssumeYes {
+		confirmed, err := presenter.PromptForConfirmation(
+			fmt.Sprintf("Push '%s' to '%s' and set its upstream?", branch, client.RemoteName()),
+		)
+		if err != nil {
+			return fmt.Errorf("confirmation failed: %w", err)
+		}
+		if !confirmed {
+			presenter.Info("Sync aborted by user.")
+
+			return nil
+		}
+	}
+
+	err := client.PushAndSetUpstream(ctx, branch)
+	if err != nil {
+		return fmt.Errorf("push failed: %w", err)
+	}
+
+	presenter.Success("Published '%s' to '%s'.", branch, client.RemoteName())
+
+	return nil
+}
+
 //nolint:gochecknoinits // Cobra requires init() for command registration.
 func init() {
 	desc, err := cmddocs.ParseAndExecute(syncLongDescription, nil)
@@ -1,6 +1,18 @@
 # Syncs the local branch with its remote counterpart.
 
 Workflow:
-1. Checks if the working directory is clean. Fails if dirty.
-2. Pulls the latest changes from the remote using a rebase strategy.
-3. Pushes local changes to the remote if the local branch is ahead.
+1. Checks that the working directory is clean. Fails if dirty; commit or
+   `git stash` your changes first.
+2. Fetches from the remote and compares the current branch with its upstream.
+3. Rebases local commits onto the remote changes when the branch is behind
+   (`git pull --rebase`).
+4. Pushes local commits when the branch is ahead.
+
+A branch with no upstream yet (a new feature branch) is pushed with
+`--set-upstream` instead. Feature branches are synced with their own upstream,
+not with the main branch.
+
+If the rebase stops on conflicts, sync stops too and explains how to continue
+(`git rebase --continue`) or back out (`git rebase --abort`).
+Running sync again while that rebase is still in progress offers to continue
+it (once the conflicts are resolved and staged) or abort it.
@@ -0,0 +1,209 @@
+// Package sync_test contains tests for the sync command.
+package sync_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/factory/sync"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errExit = errors.New("exit status 1")
+
+func runSync(t *testing.T, responses map[string]exectest.Response) (*exectest.Executor, string, error) {
+	t.Helper()
+
+	return runSyncIn(t, newSyncRepo(t), responses, "")
+}
+
+// newSyncRepo changes into a temporary directory that stands in for the repository root.
+func newSyncRepo(t *testing.T) string {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(tempDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	return tempDir
+}
+
+// runSyncIn runs sync in repoDir. Prompts are answered from input, or
+// skipped with --yes when input is empty.
+func runSyncIn(
+	t *testing.T,
+	repoDir string,
+	responses map[string]exectest.Response,
+	input string,
+) (*exectest.Executor, string, error) {
+	t.Helper()
+
+	if _, ok := responses["git rev-parse --abbrev-ref HEAD"]; !ok {
+		responses["git rev-parse --abbrev-ref HEAD"] = exectest.Response{Stdout: "main\n", Stderr: "", Err: nil}
+	}
+
+	//nolint:exhaustruct // Recorded fields start empty.
+	mockExec := &exectest.Executor{RepoDir: repoDir, Responses: responses}
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+	globals.AssumeYes = input == ""
+
+	t.Cleanup(func() { globals.AssumeYes = false })
+
+	cmd := *sync.SyncCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetIn(strings.NewReader(input))
+	cmd.SetArgs(nil)
+
+	err := cmd.Execute()
+
+	return mockExec, outBuf.String() + errBuf.String(), err
+}
+
+func aheadBehind(counts string) exectest.Response {
+	return exectest.Response{Stdout: counts + "\n", Stderr: "", Err: nil}
+}
+
+//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+func TestSyncCmd(t *testing.T) {
+	//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+	t.Run("fast-forwards a branch that is only behind", func(t *testing.T) {
+		mockExec, out, err := runSync(t, map[string]exectest.Response{
+			"git rev-list --left-right --count HEAD...@{upstream}": aheadBehind("0\t3"),
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, mockExec.Commands(), "git fetch origin")
+		assert.Contains(t, mockExec.Commands(), "git pull --rebase origin main")
+		assert.NotContains(t, mockExec.Commands(), "git push origin main", "nothing to push")
+		assert.Contains(t, out, "0 commit(s) ahead of and 3 behind")
+	})
+
+	//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+	t.Run("rebases and pushes a diverged branch", func(t *testing.T) {
+		mockExec, _, err := runSync(t, map[string]exectest.Response{
+			"git rev-parse --abbrev-ref HEAD":                      {Stdout: "feature/login\n", Stderr: "", Err: nil},
+			"git rev-list --left-right --count HEAD...@{upstream}": aheadBehind("2\t1"),
+		})
+		require.NoError(t, err)
+
+		pull := slices.Index(mockExec.Commands(), "git pull --rebase origin feature/login")
+		push := slices.Index(mockExec.Commands(), "git push origin feature/login")
+		require.NotEqual(t, -1, pull)
+		require.NotEqual(t, -1, push)
+		assert.Less(t, pull, push, "the rebase happens before the push")
+	})
+
+	//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+	t.Run("does nothing when up to date", func(t *testing.T) {
+		mockExec, out, err := runSync(t, map[string]exectest.Response{
+			"git rev-list --left-right --count HEAD...@{upstream}": aheadBehind("0\t0"),
+		})
+		require.NoError(t, err)
+
+		assert.NotContains(t, mockExec.Commands(), "git pull --rebase origin main")
+		assert.Contains(t, out, "already up to date")
+	})
+
+	//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+	t.Run("publishes a branch without upstream", func(t *testing.T) {
+		mockExec, _, err := runSync(t, map[string]exectest.Response{
+			"git rev-parse --abbrev-ref HEAD": {Stdout: "feature/new\n", Stderr: "", Err: nil},
+			"git rev-list --left-right --count HEAD...@{upstream}": {
+				Stdout: "",
+				Stderr: "fatal: no upstream configured for branch 'feature/new'",
+				Err:    errExit,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, mockExec.Commands(), "git push --set-upstream origin feature/new")
+	})
+
+	//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+	t.Run("refuses a dirty working directory", func(t *testing.T) {
+		mockExec, _, err := runSync(t, map[string]exectest.Response{
+			"git ls-files --others --exclude-standard": {Stdout: "scratch.txt\n", Stderr: "", Err: nil},
+		})
+		require.Error(t, err)
+
+		assert.NotContains(t, mockExec.Commands(), "git fetch origin")
+	})
+
+	//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+	t.Run("stops with guidance on rebase conflicts", func(t *testing.T) {
+		mockExec, out, err := runSync(t, map[string]exectest.Response{
+			"git rev-list --left-right --count HEAD...@{upstream}": aheadBehind("1\t1"),
+			"git pull --rebase origin main": {
+				Stdout: "CONFLICT (content): Merge conflict in main.go\n",
+				Stderr: "error: could not apply 1a2b3c4... Add feature\n",
+				Err:    errExit,
+			},
+		})
+		require.ErrorIs(t, err, git.ErrRebaseConflict)
+
+		assert.NotContains(t, mockExec.Commands(), "git push origin main")
+		assert.Contains(t, out, "git rebase --continue")
+		assert.Contains(t, out, "git rebase --abort")
+	})
+}
+
+//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+func TestSyncCmd_RebaseInProgress(t *testing.T) {
+	//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+	t.Run("offers to abort", func(t *testing.T) {
+		repoDir := newSyncRepo(t)
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".git", "rebase-merge"), 0o750))
+
+		mockExec, out, err := runSyncIn(t, repoDir, map[string]exectest.Response{}, "a\n")
+		require.NoError(t, err)
+
+		assert.Contains(t, mockExec.Commands(), "git rebase --abort")
+		assert.NotContains(t, mockExec.Commands(), "git fetch origin", "sync stops after resolving the rebase")
+		assert.Contains(t, out, "Rebase aborted")
+	})
+
+	//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+	t.Run("offers to continue", func(t *testing.T) {
+		repoDir := newSyncRepo(t)
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".git", "rebase-merge"), 0o750))
+
+		mockExec, _, err := runSyncIn(t, repoDir, map[string]exectest.Response{}, "c\n")
+		require.NoError(t, err)
+
+		assert.Contains(t, mockExec.Commands(), "git -c core.editor=true rebase --continue")
+	})
+
+	//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+	t.Run("refuses to guess with --yes", func(t *testing.T) {
+		repoDir := newSyncRepo(t)
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".git", "rebase-merge"), 0o750))
+
+		mockExec, _, err := runSyncIn(t, repoDir, map[string]exectest.Response{}, "")
+		require.Error(t, err)
+
+		assert.NotContains(t, mockExec.Commands(), "git rebase --abort")
+		assert.NotContains(t, mockExec.Commands(), "git -c core.editor=true rebase --continue")
+	})
+}
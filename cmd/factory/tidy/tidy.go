@@ -10,6 +10,7 @@ import (
 	"github.com/contextvibes/cli/internal/git"
 	"github.com/contextvibes/cli/internal/globals"
 	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -42,6 +43,10 @@ var TidyCmd = &cobra.Command{
 
 		mainBranch := gitClient.MainBranchName()
 		currentBranch, err := gitClient.GetCurrentBranchName(ctx)
+		if errors.Is(err, git.ErrDetachedHead) {
+			workflow.AdviseDetachedHead(presenter, mainBranch)
+		}
+
 		if err != nil {
 			return fmt.Errorf("failed to get current branch: %w", err)
 		}
@@ -14,17 +14,20 @@ import (
 //go:embed tools.md.tpl
 var toolsLongDescription string
 
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var forceInstall bool
+
 // ToolsCmd represents the tools command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
 var ToolsCmd = &cobra.Command{
 	Use:   "tools",
-	Short: "Force rebuilds and installs development tools (fixes Nix version mismatch).",
+	Short: "Installs missing or outdated development tools (fixes Nix version mismatch).",
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
 		ctx := cmd.Context()
 
-		runner := workflow.NewRunner(presenter, globals.AssumeYes)
+		runner := workflow.NewRunner(presenter, globals.AssumeYes, workflow.WithVerboseTiming(globals.Verbose))
 
 		return runner.Run(
 			ctx,
@@ -40,6 +43,8 @@ var ToolsCmd = &cobra.Command{
 			&workflow.InstallGoToolsStep{
 				ExecClient: globals.ExecClient,
 				Presenter:  presenter,
+				Tools:      workflow.DefaultGoTools,
+				Force:      forceInstall,
 			},
 		)
 	},
@@ -54,4 +59,6 @@ func init() {
 
 	ToolsCmd.Short = desc.Short
 	ToolsCmd.Long = desc.Long
+
+	ToolsCmd.Flags().BoolVar(&forceInstall, "force", false, "Reinstall every tool, even those already up to date.")
 }
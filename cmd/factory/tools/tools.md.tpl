@@ -1,4 +1,4 @@
-# Force rebuilds and installs development tools.
+# Installs missing or outdated development tools.
 
 This command addresses environment mismatches where tools provided by Nix (like
 `govulncheck` or `golangci-lint`) may be compiled with an older Go version than
@@ -7,4 +7,9 @@ the one currently active in the shell.
 It performs the following:
 1. Verifies the current Go environment.
 2. Ensures `$HOME/go/bin` is prepended to your `PATH` in `.bashrc` (to prioritize local tools).
-3. Force-reinstalls standard tools using `go install -a`, ensuring they are compiled with the current Go version.
+3. Installs the standard tools with `go install -a`, ensuring they are compiled with the current Go version.
+
+A tool is skipped when it is already on the `PATH`, meets its minimum version
+(for example `golangci-lint` 2.0.0 or later) and was built with the active Go
+version. Use `--force` to reinstall every tool regardless. The command finishes
+with a count of installed, skipped and failed tools, and fails if any install failed.
@@ -0,0 +1,35 @@
+package upgradecli
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/upgrade"
+)
+
+// TrackResolvers wraps the resolver factory and returns a counter of how often
+// UpgradeCLICmd called it.
+func TrackResolvers(t *testing.T) *int {
+	t.Helper()
+
+	calls := 0
+	original := newResolver
+	newResolver = func() *upgrade.Resolver {
+		calls++
+
+		return original()
+	}
+
+	t.Cleanup(func() { newResolver = original })
+
+	return &calls
+}
+
+// UseResolver makes UpgradeCLICmd use resolver.
+func UseResolver(t *testing.T, resolver *upgrade.Resolver) {
+	t.Helper()
+
+	original := newResolver
+	newResolver = func() *upgrade.Resolver { return resolver }
+
+	t.Cleanup(func() { newResolver = original })
+}
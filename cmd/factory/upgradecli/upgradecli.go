@@ -0,0 +1,109 @@
+// Package upgradecli provides the command to pin the environment to a new CLI release.
+package upgradecli
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/network"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/upgrade"
+	"github.com/spf13/cobra"
+)
+
+//go:embed upgradecli.md.tpl
+var upgradeCLILongDescription string
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	targetVersion string
+	nixFile       string
+)
+
+// newResolver builds the release resolver. It is a variable so tests can
+// observe it.
+//
+//nolint:gochecknoglobals // Replaceable factory for tests.
+var newResolver = upgrade.NewResolver
+
+// UpgradeCLICmd represents the upgrade-cli command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var UpgradeCLICmd = &cobra.Command{
+	Use: "upgrade-cli [--version vX.Y.Z]",
+	Example: `  contextvibes factory upgrade-cli
+  contextvibes factory upgrade-cli --version v0.6.0`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+
+		presenter.Summary("Upgrading the contextvibes pin in %s", nixFile)
+
+		if network.Offline(ctx) {
+			presenter.Info("Offline: skipping the release check; %s is unchanged.", nixFile)
+
+			return nil
+		}
+
+		if targetVersion == "" {
+			presenter.Step("Resolving the latest release...")
+		} else {
+			presenter.Step("Using release %s...", upgrade.NormalizeTag(targetVersion))
+		}
+
+		// Release downloads keep the resolver's longer default unless --timeout is given.
+		resolver := newResolver()
+		if network.HasTimeout(ctx) {
+			resolver.HTTPClient = network.HTTPClient(ctx)
+		}
+
+		result, err := resolver.UpgradeNixFile(ctx, nixFile, targetVersion)
+		if err != nil {
+			presenter.Error("Upgrade failed: %v", err)
+
+			return fmt.Errorf("failed to upgrade %s: %w", nixFile, err)
+		}
+
+		if !result.Changed {
+			presenter.Success("Already pinned to %s.", result.Version)
+
+			return nil
+		}
+
+		presenter.Detail("version: %s -> %s", result.PreviousVersion, result.Version)
+
+		if len(result.PendingHashes) > 0 {
+			presenter.Success("Updated %s.", nixFile)
+			presenter.Warning("Reset %s to a placeholder: a source build's hashes can only be computed by Nix.",
+				strings.Join(result.PendingHashes, " and "))
+			presenter.Advice("Rebuild the environment; each failing build reports the expected hash ('got: sha256-...').")
+			presenter.Advice("Paste it into %s and rebuild until the build succeeds.", nixFile)
+
+			return nil
+		}
+
+		presenter.Detail("hash:    %s", result.Hash)
+		presenter.Success("Updated %s.", nixFile)
+		presenter.Advice("Rebuild the environment to pick up the new binary.")
+
+		return nil
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(upgradeCLILongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	UpgradeCLICmd.Short = desc.Short
+	UpgradeCLICmd.Long = desc.Long
+	UpgradeCLICmd.Flags().
+		StringVar(&targetVersion, "version", "", "Release to pin (e.g. v0.6.0). Defaults to the latest release.")
+	UpgradeCLICmd.Flags().
+		StringVar(&nixFile, "file", upgrade.DefaultNixFile, "Path to the Nix file that pins the CLI.")
+}
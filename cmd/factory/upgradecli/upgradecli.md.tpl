@@ -0,0 +1,13 @@
+# Pins the development environment to a newer contextvibes release.
+
+Resolves the requested release (or the latest one when '--version' is not
+given), downloads its binary to compute the sha256, and rewrites the version,
+download URL and hash in '.idx/contextvibes.nix'. The file is written
+atomically, so an interrupted upgrade never leaves a half-written derivation.
+
+Rebuild the environment afterwards for the new binary to take effect.
+
+Derivations that build the CLI from source (with `srcHash` and `vendorHash`
+fields) are supported too: the version and a literal `rev` are updated, and
+both hashes are reset to Nix's placeholder hash. Nix reports the correct
+values on the next build; paste them in and rebuild.
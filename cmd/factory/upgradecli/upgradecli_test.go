@@ -0,0 +1,80 @@
+// Package upgradecli_test contains tests for the upgrade-cli command.
+package upgradecli_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/contextvibes/cli/cmd/factory/upgradecli"
+	"github.com/contextvibes/cli/internal/network"
+	"github.com/contextvibes/cli/internal/upgrade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // UpgradeCLICmd uses global state which is not thread-safe.
+func TestUpgradeCLICmd_Offline(t *testing.T) {
+	resolverCalls := upgradecli.TrackResolvers(t)
+
+	nixFile := filepath.Join(t.TempDir(), "contextvibes.nix")
+	original := []byte(`version = "v0.5.0";` + "\n")
+	require.NoError(t, os.WriteFile(nixFile, original, 0o600))
+
+	cmd := *upgradecli.UpgradeCLICmd
+	out := new(bytes.Buffer)
+	cmd.SetContext(network.WithOffline(context.Background(), true))
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs([]string{"--file", nixFile})
+
+	require.NoError(t, cmd.Execute())
+
+	assert.Zero(t, *resolverCalls, "no release resolver is constructed offline")
+	assert.Contains(t, out.String(), "skipping the release check")
+
+	content, err := os.ReadFile(nixFile)
+	require.NoError(t, err)
+	assert.Equal(t, original, content)
+}
+
+//nolint:paralleltest // UpgradeCLICmd uses global state which is not thread-safe.
+func TestUpgradeCLICmd_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context //nolint:containedctx // Each case runs under its own context.
+		want time.Duration
+	}{
+		{"keeps the resolver's default without --timeout", context.Background(), 2 * time.Minute},
+		{"uses an explicit --timeout", network.WithTimeout(context.Background(), 5*time.Second), 5 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := upgrade.NewResolver()
+			resolver.APIBaseURL = server.URL
+			upgradecli.UseResolver(t, resolver)
+
+			nixFile := filepath.Join(t.TempDir(), "contextvibes.nix")
+			require.NoError(t, os.WriteFile(nixFile, []byte(`version = "v0.5.0";`+"\n"), 0o600))
+
+			cmd := *upgradecli.UpgradeCLICmd
+			out := new(bytes.Buffer)
+			cmd.SetContext(tc.ctx)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs([]string{"--file", nixFile})
+
+			require.Error(t, cmd.Execute())
+			assert.Equal(t, tc.want, resolver.HTTPClient.Timeout)
+		})
+	}
+}
@@ -2,6 +2,7 @@
 package feedback
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
 	"errors"
@@ -14,6 +15,7 @@ import (
 	"github.com/contextvibes/cli/internal/cmddocs"
 	"github.com/contextvibes/cli/internal/github"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/tools"
 	"github.com/contextvibes/cli/internal/ui"
 	"github.com/contextvibes/cli/internal/workitem"
 	wigh "github.com/contextvibes/cli/internal/workitem/github"
@@ -23,6 +25,23 @@ import (
 //go:embed feedback.md.tpl
 var feedbackLongDescription string
 
+const (
+	defaultLogLines = 200
+	// maxLogBytes keeps the attached log well below GitHub's 65536-character body limit.
+	maxLogBytes = 32 * 1024
+)
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	repoFlag  string
+	attachLog bool
+	logLines  int
+	dryRun    bool
+)
+
+//nolint:gochecknoglobals // Static label set applied to every feedback issue.
+var feedbackLabels = []string{"feedback"}
+
 // newProviderForRepo creates a workitem.Provider for a specific owner/repo string.
 //
 
@@ -43,9 +62,11 @@ func newProviderForRepo(
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
 var FeedbackCmd = &cobra.Command{
-	Use:     "feedback [repo-alias] [title]",
-	Short:   "Submit feedback to a contextvibes repository.",
-	Example: `  contextvibes feedback "Tree command is slow"`,
+	Use:   "feedback [repo-alias] [title] [--repo <alias|owner/repo>]",
+	Short: "Submit feedback to a contextvibes repository.",
+	Example: `  contextvibes feedback "Tree command is slow"
+  contextvibes feedback --repo thea "Typo in the kickoff guide"
+  contextvibes feedback --repo my-org/my-fork "Found a bug"`,
 	//nolint:mnd // 2 arguments max.
 	Args: cobra.MaximumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -53,36 +74,34 @@ var FeedbackCmd = &cobra.Command{
 		ctx := cmd.Context()
 		cfg := globals.LoadedAppConfig.Feedback
 
-		var repoAlias, title, body string
-		repoAlias = cfg.DefaultRepository
+		var repoSelector, title, body string
+		repoSelector = repoFlag
 
-		if len(args) == 1 {
+		switch {
+		case repoFlag != "" && len(args) == 2: //nolint:mnd // 2 arguments check.
+			//nolint:err113 // Dynamic error is appropriate here.
+			return errors.New("use either --repo or a positional repository alias, not both")
+		case repoFlag != "" && len(args) == 1:
+			title = args[0]
+		case len(args) == 1:
 			if _, ok := cfg.Repositories[args[0]]; ok {
-				repoAlias = args[0]
+				repoSelector = args[0]
 			} else {
 				title = args[0]
 			}
-		} else if len(args) == 2 { //nolint:mnd // 2 arguments check.
-			repoAlias = args[0]
+		case len(args) == 2: //nolint:mnd // 2 arguments check.
+			repoSelector = args[0]
 			title = args[1]
 		}
 
-		targetRepo, ok := cfg.Repositories[repoAlias]
-		if !ok {
-			//nolint:err113 // Dynamic error is appropriate here.
-			return fmt.Errorf("repository alias '%s' not found in configuration", repoAlias)
-		}
-		repoParts := strings.Split(targetRepo, "/")
-		//nolint:mnd // Expecting owner/repo.
-		if len(repoParts) != 2 {
-			//nolint:err113 // Dynamic error is appropriate here.
-			return fmt.Errorf(
-				"invalid repository format for alias '%s': expected 'owner/repo', got '%s'",
-				repoAlias,
-				targetRepo,
-			)
+		owner, repo, err := cfg.ResolveRepository(repoSelector)
+		if err != nil {
+			presenter.Error("%v", err)
+
+			return fmt.Errorf("invalid feedback repository: %w", err)
 		}
-		owner, repo := repoParts[0], repoParts[1]
+
+		targetRepo := owner + "/" + repo
 
 		if title == "" {
 			form := huh.NewForm(
@@ -91,7 +110,7 @@ var FeedbackCmd = &cobra.Command{
 					huh.NewText().Title("Please provide more details (optional)").Value(&body),
 				),
 			)
-			err := form.Run()
+			err = form.Run()
 			if err != nil {
 				return fmt.Errorf("input form failed: %w", err)
 			}
@@ -101,6 +120,18 @@ var FeedbackCmd = &cobra.Command{
 			return errors.New("title cannot be empty")
 		}
 
+		if dryRun {
+			//nolint:exhaustruct // Partial initialization is valid for a preview.
+			preview := workitem.WorkItem{
+				Title:  title,
+				Body:   buildBody(presenter, body, "<your GitHub login>"),
+				Labels: feedbackLabels,
+			}
+			renderPreview(presenter, targetRepo, preview)
+
+			return nil
+		}
+
 		provider, err := newProviderForRepo(ctx, globals.AppLogger, owner, repo)
 		if err != nil {
 			presenter.Error("Failed to initialize provider for %s: %v", targetRepo, err)
@@ -114,20 +145,11 @@ var FeedbackCmd = &cobra.Command{
 			user = "unknown"
 		}
 
-		contextBlock := fmt.Sprintf(
-			"\n\n---\n**Context**\n- **CLI Version:** `%s`\n- **OS/Arch:** `%s/%s`\n- **Filed by:** @%s",
-			globals.AppVersion,
-			runtime.GOOS,
-			runtime.GOARCH,
-			user,
-		)
-		finalBody := body + contextBlock
-
 		//nolint:exhaustruct // Partial initialization is valid for creation.
 		newItem := workitem.WorkItem{
 			Title:  title,
-			Body:   finalBody,
-			Labels: []string{"feedback"},
+			Body:   buildBody(presenter, body, user),
+			Labels: feedbackLabels,
 		}
 
 		presenter.Summary("Submitting feedback to %s...", targetRepo)
@@ -156,4 +178,74 @@ func init() {
 	}
 
 	FeedbackCmd.Long = desc.Long
+	FeedbackCmd.Flags().
+		StringVar(&repoFlag, "repo", "", "Target repository: a configured alias or 'owner/repo'.")
+	FeedbackCmd.Flags().
+		BoolVar(&dryRun, "dry-run", false, "Print the issue that would be filed without contacting GitHub.")
+	FeedbackCmd.Flags().
+		BoolVar(&attachLog, "attach-log", false, "Attach the tail of the AI trace log (secrets redacted).")
+	FeedbackCmd.Flags().
+		IntVar(&logLines, "log-lines", defaultLogLines, "Number of trailing log lines to attach with --attach-log.")
+}
+
+// buildBody appends the diagnostic context (and, with --attach-log, the trace log)
+// to the user's description.
+func buildBody(presenter *ui.Presenter, body, user string) string {
+	contextBlock := fmt.Sprintf(
+		"\n\n---\n**Context**\n- **CLI Version:** `%s`\n- **OS/Arch:** `%s/%s`\n- **Filed by:** @%s",
+		globals.AppVersion,
+		runtime.GOOS,
+		runtime.GOARCH,
+		user,
+	)
+	finalBody := body + contextBlock
+
+	if attachLog {
+		finalBody += buildLogAttachment(presenter, globals.LoadedAppConfig.Logging.DefaultAILogFile)
+	}
+
+	return finalBody
+}
+
+// renderPreview prints the issue exactly as it would be submitted.
+func renderPreview(presenter *ui.Presenter, targetRepo string, item workitem.WorkItem) {
+	presenter.Summary("Dry run: feedback for %s (not submitted)", targetRepo)
+	presenter.Detail("Title:  %s", item.Title)
+	presenter.Detail("Labels: %s", strings.Join(item.Labels, ", "))
+	presenter.Newline()
+
+	//nolint:errcheck // Printing to stdout is best effort.
+	fmt.Fprintln(presenter.Out(), item.Body)
+
+	presenter.Newline()
+	presenter.Info("Re-run without --dry-run to submit this feedback.")
+}
+
+// buildLogAttachment renders the redacted tail of the AI trace log as a collapsible
+// Markdown section. Problems reading the log are reported as warnings, not errors.
+func buildLogAttachment(presenter *ui.Presenter, logPath string) string {
+	tail, truncated, err := tools.TailFile(logPath, logLines, maxLogBytes)
+	if err != nil {
+		presenter.Warning("Could not attach AI trace log '%s': %v", logPath, err)
+
+		return ""
+	}
+
+	if tail == "" {
+		presenter.Warning("AI trace log '%s' is empty; nothing attached.", logPath)
+
+		return ""
+	}
+
+	summary := fmt.Sprintf("AI trace log (last %d lines)", strings.Count(tail, "\n")+1)
+	if truncated {
+		summary += ", truncated"
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString("\n\n")
+	tools.AppendCollapsibleCodeBlock(&buf, summary, tools.RedactSecrets(tail), "json")
+
+	return buf.String()
 }
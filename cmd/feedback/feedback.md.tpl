@@ -4,7 +4,11 @@ Submits feedback, a bug report, or a feature request to a contextvibes repositor
 
 This command provides a low-friction way to file an issue directly from your terminal. It automatically includes diagnostic information like the CLI version and your OS in the issue body.
 
-The target repository can be specified using a short alias defined in your `.contextvibes.yaml` configuration file. If no alias is provided, it defaults to the 'cli' repository.
+The target repository can be specified with `--repo`, either as a short alias defined under `feedback.repositories` in your `.contextvibes.yaml` or as a literal `owner/repo`. A leading positional alias is still accepted. If no repository is given, `feedback.defaultRepository` (the 'cli' repository by default) is used. Unknown aliases are rejected with the list of available ones.
+
+Bug reports about AI workflows are easier to triage with the trace log. Use `--attach-log` to append the last lines (`--log-lines`, default 200) of the configured AI log file in a collapsible block. The attachment is capped at 32 KB, and obvious secrets such as tokens, API keys and passwords are redacted before submission.
+
+Use `--dry-run` to preview the exact issue (title, labels and body, including any attached log) without contacting GitHub. This is useful when offline or when you want to copy the report elsewhere.
 
 ### Examples
 
@@ -13,7 +17,16 @@ The target repository can be specified using a short alias defined in your `.con
 contextvibes feedback "The 'project board list' command is failing"
 
 # File a bug report for a different repository (e.g., 'thea')
-contextvibes feedback thea "Typo in the strategic kickoff guide"
+contextvibes feedback --repo thea "Typo in the strategic kickoff guide"
+
+# Target any repository directly
+contextvibes feedback --repo my-org/my-fork "Found a bug"
+
+# Include the tail of the AI trace log
+contextvibes feedback --attach-log "Commit message generation hangs"
+
+# Preview what would be submitted
+contextvibes feedback --dry-run --attach-log "Commit message generation hangs"
 
 # Start a fully interactive session to be guided through filing feedback
 contextvibes feedback
@@ -2,7 +2,6 @@
 package index
 
 import (
-	"bufio"
 	_ "embed"
 	"encoding/json"
 	"errors"
@@ -16,6 +15,7 @@ import (
 
 	"github.com/contextvibes/cli/internal/cmddocs"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/tools"
 	"github.com/contextvibes/cli/internal/ui"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -176,42 +176,18 @@ func parseFrontMatterAndDerive(
 	fileInfo fs.FileInfo,
 ) (*DocumentMetadata, error) {
 	//nolint:gosec // Reading file is intended.
-	file, err := os.Open(filePath)
+	content, err := os.ReadFile(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
-	//nolint:errcheck // Defer close is sufficient.
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-
-	var frontMatterLines []string
-
-	inFrontMatter := false
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.TrimSpace(line) == "---" {
-			if !inFrontMatter {
-				inFrontMatter = true
-
-				continue
-			}
-
-			break
-		}
-
-		if inFrontMatter {
-			frontMatterLines = append(frontMatterLines, line)
-		}
-	}
-
-	if !inFrontMatter || len(frontMatterLines) == 0 {
+	frontMatter, _, err := tools.SplitFrontMatter(string(content))
+	if errors.Is(err, tools.ErrNoFrontMatter) || strings.TrimSpace(frontMatter) == "" {
 		return nil, ErrSkipDocument
 	}
 
 	var fmData tempFrontMatter
-	if err := yaml.Unmarshal([]byte(strings.Join(frontMatterLines, "\n")), &fmData); err != nil {
+	if err := yaml.Unmarshal([]byte(frontMatter), &fmData); err != nil {
 		return nil, fmt.Errorf("failed to parse front matter: %w", err)
 	}
 
@@ -0,0 +1,142 @@
+package thea
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/network"
+	"github.com/contextvibes/cli/internal/thea"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed get_artifact.md.tpl
+var getArtifactLongDescription string
+
+const (
+	defaultManifestURL = "https://raw.githubusercontent.com/contextvibes/THEA/main/thea-manifest.json"
+	defaultContentURL  = "https://raw.githubusercontent.com/contextvibes/THEA"
+	defaultArtifactRef = "main"
+)
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	artifactVersion string
+	artifactOutput  string
+	artifactForce   bool
+)
+
+// GetArtifactCmd represents the thea get-artifact command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var GetArtifactCmd = &cobra.Command{
+	Use:     "get-artifact <artifact-id>",
+	Example: `  contextvibes library thea get-artifact playbooks/project_initiation/master_strategic_kickoff_prompt`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+		artifactID := args[0]
+
+		//nolint:exhaustruct // Cached copies never expire, so CacheTTL is not set.
+		client, err := thea.NewClient(ctx, &thea.ServiceConfig{
+			ManifestURL:        defaultManifestURL,
+			RawContentBaseURL:  defaultContentURL,
+			DefaultArtifactRef: defaultArtifactRef,
+			CacheDir:           thea.DefaultCacheDir(),
+			RequestTimeout:     network.Timeout(ctx),
+			Offline:            network.Offline(ctx),
+		}, globals.AppLogger)
+		if err != nil {
+			return fmt.Errorf("failed to initialize THEA client: %w", err)
+		}
+
+		outputPath := artifactOutput
+		if outputPath == "" {
+			manifest, err := client.LoadManifest(ctx)
+			if errors.Is(err, thea.ErrNotCached) {
+				reportNotCached(presenter, err)
+
+				return nil
+			}
+
+			if err != nil {
+				presenter.Error("Failed to load THEA manifest: %v", err)
+
+				return fmt.Errorf("failed to load manifest: %w", err)
+			}
+
+			artifact, err := manifest.GetArtifactByID(artifactID)
+			if err != nil {
+				//nolint:wrapcheck // Error is already descriptive.
+				return err
+			}
+
+			outputPath = artifact.DefaultTargetPath
+			if outputPath == "" {
+				outputPath = artifact.ID + artifact.FileExtension
+			}
+		}
+
+		if _, err := os.Stat(outputPath); err == nil && !artifactForce {
+			presenter.Error("Output file '%s' already exists.", outputPath)
+			presenter.Advice("Use --force to overwrite it.")
+
+			//nolint:err113 // Dynamic error is appropriate here.
+			return errors.New("output file already exists")
+		}
+
+		presenter.Summary("Fetching THEA artifact '%s'...", artifactID)
+
+		content, err := client.FetchArtifactContentByID(ctx, artifactID, artifactVersion)
+		if errors.Is(err, thea.ErrNotCached) {
+			reportNotCached(presenter, err)
+
+			return nil
+		}
+
+		if err != nil {
+			presenter.Error("Failed to fetch artifact: %v", err)
+
+			return fmt.Errorf("failed to fetch artifact: %w", err)
+		}
+
+		//nolint:mnd // 0600 is standard file permission.
+		err = os.WriteFile(outputPath, []byte(content), 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to write artifact to '%s': %w", outputPath, err)
+		}
+
+		presenter.Success("Artifact saved to %s.", outputPath)
+
+		return nil
+	},
+}
+
+// reportNotCached explains an offline miss, which is not treated as a failure.
+func reportNotCached(presenter *ui.Presenter, err error) {
+	presenter.Warning("Offline: %v.", err)
+	presenter.Advice("Run the command once without --offline to cache it.")
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(getArtifactLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	GetArtifactCmd.Short = desc.Short
+	GetArtifactCmd.Long = desc.Long
+
+	GetArtifactCmd.Flags().
+		StringVar(&artifactVersion, "version", "", "Version hint for the artifact (maps to a Git ref).")
+	GetArtifactCmd.Flags().
+		StringVarP(&artifactOutput, "output", "o", "", "Local path to save the artifact to.")
+	GetArtifactCmd.Flags().
+		BoolVarP(&artifactForce, "force", "f", false, "Overwrite the output file if it exists.")
+}
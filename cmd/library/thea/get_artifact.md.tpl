@@ -0,0 +1,5 @@
+# Fetches an artifact document from the THEA framework repository.
+
+Looks up the given artifact ID in the remote THEA manifest and downloads its
+content. Use '--version' to request a specific Git ref and '-o' to choose
+where the file is written.
@@ -0,0 +1,19 @@
+// Package thea provides commands to interact with the THEA framework repository.
+package thea
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// TheaCmd represents the base command for the 'thea' subcommand group.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var TheaCmd = &cobra.Command{
+	Use:   "thea",
+	Short: "Interact with the THEA framework artifacts.",
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	TheaCmd.AddCommand(GetArtifactCmd)
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -37,6 +38,26 @@ func (m *mockBuildExecutor) Execute(
 	return nil
 }
 
+func (m *mockBuildExecutor) ExecuteWithEnv(
+	ctx context.Context,
+	dir string,
+	_ map[string]string,
+	commandName string,
+	args ...string,
+) error {
+	return m.Execute(ctx, dir, commandName, args...)
+}
+
+func (m *mockBuildExecutor) ExecuteWithStdin(
+	ctx context.Context,
+	dir string,
+	_ io.Reader,
+	commandName string,
+	args ...string,
+) error {
+	return m.Execute(ctx, dir, commandName, args...)
+}
+
 func (m *mockBuildExecutor) CaptureOutput(
 	_ context.Context,
 	_ string,
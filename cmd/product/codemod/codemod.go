@@ -6,11 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"regexp"
+	"strings"
 
+	"github.com/contextvibes/cli/internal/apply"
 	"github.com/contextvibes/cli/internal/cmddocs"
 	"github.com/contextvibes/cli/internal/codemod"
+	"github.com/contextvibes/cli/internal/exitcode"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/tools"
 	"github.com/contextvibes/cli/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -19,7 +22,12 @@ import (
 var codemodLongDescription string
 
 //nolint:gochecknoglobals // Cobra flags require package-level variables.
-var codemodScriptPath string
+var (
+	codemodScriptPath string
+	requireClean      bool
+	detailedExitCode  bool
+	baseDir           string
+)
 
 // CodemodCmd represents the codemod command.
 //
@@ -31,6 +39,7 @@ var CodemodCmd = &cobra.Command{
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
 
 		scriptToLoad := codemodScriptPath
 		if scriptToLoad == "" {
@@ -49,28 +58,58 @@ var CodemodCmd = &cobra.Command{
 			return fmt.Errorf("failed to parse codemod script JSON: %w", err)
 		}
 
+		gitClient := apply.NewPreflightGitClient(ctx, globals.AppLogger, globals.ExecClient.UnderlyingExecutor())
+		base := apply.ResolveBaseDir(baseDir, gitClient)
+
+		targets := make([]string, 0, len(script))
+		for _, targetPath := range script.TargetPaths() {
+			target, err := codemod.ResolvePath(base, targetPath)
+			if err != nil {
+				presenter.Error("Refusing to modify '%s': %v", targetPath, err)
+
+				//nolint:wrapcheck // Path errors are already descriptive.
+				return err
+			}
+
+			targets = append(targets, target)
+		}
+
+		err = apply.CheckDirtyTargets(ctx, presenter, gitClient, targets, requireClean)
+		if err != nil {
+			//nolint:wrapcheck // Preflight errors are already descriptive.
+			return err
+		}
+
+		totalFilesModified := 0
+
 		for _, fileChangeSet := range script {
 			presenter.Header("Processing target: %s", fileChangeSet.FilePath)
 
-			contentBytes, err := os.ReadFile(fileChangeSet.FilePath)
+			target, err := codemod.ResolvePath(base, fileChangeSet.FilePath)
+			if err != nil {
+				//nolint:wrapcheck // Path errors are already descriptive.
+				return err
+			}
+
+			contentBytes, err := os.ReadFile(target)
 			if err != nil && !os.IsNotExist(err) {
 				return fmt.Errorf("failed to read target file: %w", err)
 			}
 			currentContent := string(contentBytes)
 
-			//nolint:varnamelen // 'op' is standard for operation.
-			for _, op := range fileChangeSet.Operations {
-				switch op.Type {
-				case "regex_replace":
-					re, err := regexp.Compile(op.FindRegex)
-					if err != nil {
-						return fmt.Errorf("invalid regex '%s': %w", op.FindRegex, err)
-					}
-					currentContent = re.ReplaceAllString(currentContent, op.ReplaceWith)
-					// Add other operations here
+			for _, operation := range fileChangeSet.Operations {
+				currentContent, err = operation.Apply(currentContent)
+				if err != nil {
+					return fmt.Errorf("failed to apply %s to %s: %w", operation.Type, fileChangeSet.FilePath, err)
 				}
 			}
 
+			if currentContent == string(contentBytes) {
+				presenter.Info("No changes needed.")
+
+				continue
+			}
+
 			if !globals.AssumeYes {
 				confirmed, err := presenter.PromptForConfirmation(
 					fmt.Sprintf("Write changes to %s?", fileChangeSet.FilePath),
@@ -80,11 +119,21 @@ var CodemodCmd = &cobra.Command{
 				}
 			}
 			//nolint:mnd // 0600 is standard file permission.
-			err = os.WriteFile(fileChangeSet.FilePath, []byte(currentContent), 0o600)
+			err = tools.ReplaceFile(target, strings.NewReader(currentContent), 0o600)
 			if err != nil {
 				return fmt.Errorf("failed to write file: %w", err)
 			}
 			globals.AppLogger.Info("Applied codemod", "file", fileChangeSet.FilePath)
+			totalFilesModified++
+		}
+
+		presenter.Summary("Codemod complete: %d file(s) modified.", totalFilesModified)
+
+		if detailedExitCode && totalFilesModified > 0 {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			return exitcode.New(exitcode.ChangesApplied)
 		}
 
 		return nil
@@ -102,4 +151,12 @@ func init() {
 	CodemodCmd.Long = desc.Long
 	CodemodCmd.Flags().
 		StringVarP(&codemodScriptPath, "script", "s", "", "Path to the JSON codemod script file")
+	CodemodCmd.Flags().
+		BoolVar(&requireClean, "require-clean", false, "Abort if any target file has uncommitted changes.")
+	CodemodCmd.Flags().
+		BoolVar(&detailedExitCode, "detailed-exitcode", false,
+			"Exit with code 2 when files were modified (0 = no changes, 1 = error).")
+	CodemodCmd.Flags().
+		StringVar(&baseDir, "base-dir", "",
+			"Directory that file paths are resolved against and confined to (default: repository root).")
 }
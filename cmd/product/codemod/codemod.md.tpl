@@ -4,3 +4,17 @@ Reads a JSON script file describing a series of operations to be applied to
 specified files in the codebase. This enables automated or AI-assisted refactoring and cleanup.
 
 If --script is not provided, it looks for 'codemod.json' in the current directory.
+
+Before writing, target files that already have uncommitted changes are
+reported. Use --require-clean to abort instead of continuing.
+
+With --detailed-exitcode the command exits with 2 when at least one file was
+modified and 0 when the script was a no-op (1 still means an error), so CI
+can tell whether anything changed.
+
+File paths in the script are resolved against the repository root (or the
+current directory outside a repository). Use --base-dir to choose another
+directory. Absolute paths and paths that lead outside the base directory, such
+as `../../etc/hosts` or a symlink pointing elsewhere, are refused before
+anything is written. Each file is replaced atomically and keeps its
+permissions.
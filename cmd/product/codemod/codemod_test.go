@@ -0,0 +1,154 @@
+// Package codemod_test contains tests for the codemod command.
+package codemod_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/product/codemod"
+	internalcodemod "github.com/contextvibes/cli/internal/codemod"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/exitcode"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const renameScript = `[
+	{
+		"file_path": "widget.txt",
+		"operations": [{"type": "regex_replace", "find_regex": "old", "replace_with": "new"}]
+	}
+]`
+
+func setupCodemodTest(t *testing.T, fileContent string) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(tempDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	require.NoError(t, os.WriteFile("codemod.json", []byte(renameScript), 0o600))
+	require.NoError(t, os.WriteFile("widget.txt", []byte(fileContent), 0o600))
+
+	globals.ExecClient = exec.NewClient(newNotARepoExecutor())
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.AssumeYes = true
+
+	t.Cleanup(func() { globals.AssumeYes = false })
+}
+
+func runCodemodCmd(t *testing.T, args ...string) error {
+	t.Helper()
+
+	cmd := *codemod.CodemodCmd
+	cmd.SetContext(context.Background())
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs(args)
+
+	_ = cmd.Flags().Set("detailed-exitcode", "false")
+	_ = cmd.Flags().Set("base-dir", "")
+
+	return cmd.Execute()
+}
+
+//nolint:paralleltest // CodemodCmd uses global flags and changes the working directory.
+func TestCodemodCmd_DetailedExitCode(t *testing.T) {
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("no-op script exits 0", func(t *testing.T) {
+		setupCodemodTest(t, "already new\n")
+
+		err := runCodemodCmd(t, "--detailed-exitcode")
+		require.NoError(t, err)
+		assert.Equal(t, exitcode.Success, exitcode.FromError(err))
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("modifying script exits 2", func(t *testing.T) {
+		setupCodemodTest(t, "old widget\n")
+
+		err := runCodemodCmd(t, "--detailed-exitcode")
+		require.Error(t, err)
+		assert.Equal(t, exitcode.ChangesApplied, exitcode.FromError(err))
+
+		content, readErr := os.ReadFile("widget.txt")
+		require.NoError(t, readErr)
+		assert.Equal(t, "new widget\n", string(content))
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("errors exit 1", func(t *testing.T) {
+		setupCodemodTest(t, "old widget\n")
+
+		err := runCodemodCmd(t, "--detailed-exitcode", "--script", "missing.json")
+		require.Error(t, err)
+		assert.Equal(t, exitcode.Failure, exitcode.FromError(err))
+	})
+}
+
+//nolint:paralleltest // CodemodCmd uses global flags and changes the working directory.
+func TestCodemodCmd_BaseDir(t *testing.T) {
+	writeScript := func(t *testing.T, filePath string) {
+		t.Helper()
+
+		script := `[{"file_path": "` + filePath + `", "operations": [{"type": "create_or_overwrite", "content": "x"}]}]`
+		require.NoError(t, os.WriteFile("escape.json", []byte(script), 0o600))
+	}
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("rejects paths that climb out", func(t *testing.T) {
+		setupCodemodTest(t, "old widget\n")
+		require.NoError(t, os.Mkdir("sub", 0o750))
+		writeScript(t, "../../etc/x")
+
+		err := runCodemodCmd(t, "--script", "escape.json", "--base-dir", "sub")
+		require.ErrorIs(t, err, internalcodemod.ErrPathOutsideBase)
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("rejects absolute paths", func(t *testing.T) {
+		setupCodemodTest(t, "old widget\n")
+		target := filepath.Join(t.TempDir(), "x")
+		writeScript(t, filepath.ToSlash(target))
+
+		err := runCodemodCmd(t, "--script", "escape.json")
+		require.ErrorIs(t, err, internalcodemod.ErrPathOutsideBase)
+		assert.NoFileExists(t, target)
+	})
+
+	//nolint:paralleltest // Subtests share the working directory.
+	t.Run("resolves paths against the base directory", func(t *testing.T) {
+		setupCodemodTest(t, "old widget\n")
+		require.NoError(t, os.Mkdir("sub", 0o750))
+		writeScript(t, "x.txt")
+
+		require.NoError(t, runCodemodCmd(t, "--script", "escape.json", "--base-dir", "sub"))
+
+		content, err := os.ReadFile(filepath.Join("sub", "x.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "x", string(content))
+	})
+}
+
+// newNotARepoExecutor reports that the directory is not a git repository,
+// which disables the dirty-target preflight.
+func newNotARepoExecutor() *exectest.Executor {
+	//nolint:exhaustruct // Recorded fields start empty.
+	return &exectest.Executor{
+		Handler: func(exectest.Call) exectest.Response {
+			//nolint:err113 // Dynamic error is appropriate here.
+			return exectest.Response{Stdout: "", Stderr: "fatal: not a git repository", Err: errors.New("exit status 128")}
+		},
+	}
+}
@@ -0,0 +1,20 @@
+// Package lint provides commands to lint non-Go project files such as Markdown.
+package lint
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// LintCmd represents the base command for the 'lint' subcommand group.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var LintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Lint project files that Go tooling does not cover.",
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	LintCmd.AddCommand(MarkdownCmd)
+	LintCmd.AddCommand(YAMLCmd)
+}
@@ -0,0 +1,99 @@
+package lint
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/lint"
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed markdown.md.tpl
+var markdownLongDescription string
+
+// MarkdownCmd represents the lint markdown command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var MarkdownCmd = &cobra.Command{
+	Use: "markdown [path]",
+	Example: `  contextvibes product lint markdown
+  contextvibes product lint markdown docs/`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+
+		configDir := root
+		if info, err := os.Stat(root); err == nil && !info.IsDir() {
+			configDir = filepath.Dir(root)
+		}
+
+		config, err := lint.LoadMarkdownConfig(configDir)
+		if err != nil {
+			presenter.Error("Invalid markdown lint configuration: %v", err)
+
+			return fmt.Errorf("failed to load markdown lint config: %w", err)
+		}
+
+		files, err := tools.FindFiles(root, ".md", ".markdown")
+		if err != nil {
+			return fmt.Errorf("failed to find markdown files: %w", err)
+		}
+
+		presenter.Summary("Linting %d Markdown file(s) in %s", len(files), root)
+
+		var findings []lint.Finding
+
+		for _, file := range files {
+			content, err := tools.ReadFileContent(file)
+			if err != nil {
+				return fmt.Errorf("failed to lint markdown: %w", err)
+			}
+
+			findings = append(findings, lint.LintMarkdown(file, content, config)...)
+		}
+
+		return reportFindings(presenter, findings, "Markdown")
+	},
+}
+
+// reportFindings prints each finding as file:line: rule message and fails when there are any.
+func reportFindings(presenter *ui.Presenter, findings []lint.Finding, kind string) error {
+	if len(findings) == 0 {
+		presenter.Success("✓ No %s issues found.", kind)
+
+		return nil
+	}
+
+	for _, finding := range findings {
+		presenter.Detail("%s", finding)
+	}
+
+	presenter.Newline()
+	presenter.Error("Found %d %s issue(s).", len(findings), kind)
+
+	//nolint:err113 // Dynamic error is appropriate here.
+	return fmt.Errorf("%d %s lint issue(s) found", len(findings), kind)
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(markdownLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	MarkdownCmd.Short = desc.Short
+	MarkdownCmd.Long = desc.Long
+}
@@ -0,0 +1,21 @@
+# Lint Markdown files.
+
+Recursively finds `.md` files under the given path (the current directory by default), skipping anything matched by `.gitignore`, and checks them against a small rule set:
+
+| Rule  | Alias                   | Checks                                                |
+|-------|-------------------------|-------------------------------------------------------|
+| MD001 | heading-increment       | Heading levels only increase one level at a time      |
+| MD009 | no-trailing-spaces      | Trailing whitespace (two spaces for a line break are allowed) |
+| MD010 | no-hard-tabs            | Hard tab characters                                   |
+| MD034 | no-bare-urls            | URLs not wrapped in `<>` or a link                    |
+| MD047 | single-trailing-newline | The file ends with a newline                          |
+
+Each finding is reported as `file:line: rule message`, and the command exits non-zero when any are found.
+
+Rules can be configured with a `.markdownlint.yaml`, `.markdownlint.yml` or `.markdownlint.json` file in the linted directory, using rule IDs or aliases:
+
+```yaml
+default: true
+MD034: false
+no-hard-tabs: false
+```
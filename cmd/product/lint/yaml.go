@@ -0,0 +1,66 @@
+package lint
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/lint"
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed yaml.md.tpl
+var yamlLongDescription string
+
+// YAMLCmd represents the lint yaml command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var YAMLCmd = &cobra.Command{
+	Use: "yaml [path]",
+	Example: `  contextvibes product lint yaml
+  contextvibes product lint yaml .idx/`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+
+		root := "."
+		if len(args) == 1 {
+			root = args[0]
+		}
+
+		files, err := tools.FindFiles(root, lint.YAMLExtensions...)
+		if err != nil {
+			return fmt.Errorf("failed to find YAML files: %w", err)
+		}
+
+		presenter.Summary("Linting %d YAML file(s) in %s", len(files), root)
+
+		var findings []lint.Finding
+
+		for _, file := range files {
+			content, err := tools.ReadFileContent(file)
+			if err != nil {
+				return fmt.Errorf("failed to lint YAML: %w", err)
+			}
+
+			findings = append(findings, lint.LintYAML(file, content)...)
+		}
+
+		return reportFindings(presenter, findings, "YAML")
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(yamlLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	YAMLCmd.Short = desc.Short
+	YAMLCmd.Long = desc.Long
+}
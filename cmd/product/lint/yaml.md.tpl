@@ -0,0 +1,13 @@
+# Lint YAML files.
+
+Recursively finds `.yaml` and `.yml` files under the given path (the current directory by default), skipping anything matched by `.gitignore`, and parses every document in them.
+
+| Rule           | Checks                                                                     |
+|----------------|----------------------------------------------------------------------------|
+| syntax         | The file parses; the error is reported at the line the parser stopped on   |
+| key-duplicates | A mapping does not define the same key twice                               |
+| indentation    | Nested mappings use the same indentation step throughout the file          |
+
+Each finding is reported as `file:line: rule message`, and the command exits non-zero when any are found.
+
+The same check runs as part of `contextvibes product quality` whenever the project contains YAML files.
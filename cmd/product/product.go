@@ -6,6 +6,7 @@ import (
 	"github.com/contextvibes/cli/cmd/product/clean"
 	"github.com/contextvibes/cli/cmd/product/codemod"
 	"github.com/contextvibes/cli/cmd/product/format"
+	"github.com/contextvibes/cli/cmd/product/lint"
 	"github.com/contextvibes/cli/cmd/product/quality"
 	"github.com/contextvibes/cli/cmd/product/run"
 	"github.com/contextvibes/cli/cmd/product/test"
@@ -29,4 +30,5 @@ func init() {
 	ProductCmd.AddCommand(clean.CleanCmd)
 	ProductCmd.AddCommand(run.RunCmd)
 	ProductCmd.AddCommand(codemod.CodemodCmd)
+	ProductCmd.AddCommand(lint.LintCmd)
 }
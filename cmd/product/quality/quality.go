@@ -4,16 +4,20 @@ package quality
 import (
 	"bytes"
 	_ "embed"
+	"errors"
 	"fmt"
 	"os"
-	"strings"
+	"path/filepath"
 	"time"
 
 	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/exec"
 	"github.com/contextvibes/cli/internal/globals"
-	"github.com/contextvibes/cli/internal/pipeline"
+	"github.com/contextvibes/cli/internal/lint"
 	"github.com/contextvibes/cli/internal/project"
+	"github.com/contextvibes/cli/internal/tools"
 	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workflow"
 	"github.com/spf13/cobra"
 )
 
@@ -22,12 +26,21 @@ var qualityLongDescription string
 
 const contextFile = "_contextvibes.md"
 
+// gitleaksConfigFile is passed to gitleaks when the project provides one.
+const gitleaksConfigFile = ".gitleaks.toml"
+
+const deadcodeStepDesc = "Find unreachable code (deadcode)"
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var fix bool
+
 // QualityCmd represents the quality command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
 var QualityCmd = &cobra.Command{
-	Use:           "quality",
-	Example:       `  contextvibes product quality`,
+	Use: "quality [--fix]",
+	Example: `  contextvibes product quality
+  contextvibes product quality --fix`,
 	Args:          cobra.NoArgs,
 	SilenceUsage:  true,
 	SilenceErrors: true,
@@ -35,8 +48,6 @@ var QualityCmd = &cobra.Command{
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
 		ctx := cmd.Context()
 
-		presenter.Summary("Running Code Quality Pipeline")
-
 		cwd, err := os.Getwd()
 		if err != nil {
 			return fmt.Errorf("failed to get working directory: %w", err)
@@ -49,84 +60,193 @@ var QualityCmd = &cobra.Command{
 		presenter.Info("Detected project type: %s", presenter.Highlight(string(projType)))
 		presenter.Newline()
 
-		// Initialize Pipeline Runner
-		runner := pipeline.NewRunner(presenter, globals.ExecClient)
-		var checks []pipeline.Check
+		var candidates []*workflow.CommandStep
 
-		// Assemble Pipeline based on Project Type
 		switch projType {
 		case project.Go:
-			checks = []pipeline.Check{
-				&pipeline.GoModTidyCheck{},
-				&pipeline.GoVetCheck{},
-				&pipeline.GolangCILintCheck{},
-				&pipeline.GoVulnCheck{},
-				&pipeline.GitleaksCheck{},
-				&pipeline.DeadcodeCheck{},
-			}
-		case project.Terraform, project.Pulumi, project.Python, project.Unknown:
+			candidates = goSteps(globals.ExecClient, presenter, cwd, fix)
+		case project.Terraform, project.Pulumi, project.Python, project.Node, project.Rust, project.Unknown:
 			fallthrough
 		default:
 			presenter.Info("No specific quality checks configured for %s.", projType)
+		}
+
+		steps := installedSteps(presenter, globals.ExecClient, candidates)
+
+		yamlFiles, err := tools.FindFiles(cwd, lint.YAMLExtensions...)
+		if err != nil {
+			return fmt.Errorf("failed to find YAML files: %w", err)
+		}
+
+		if len(yamlFiles) > 0 {
+			steps = append(steps, &workflow.YAMLLintStep{RootDir: cwd, Presenter: presenter})
+		}
+
+		if len(steps) == 0 {
+			presenter.Info("No quality checks to run.")
 
 			return nil
 		}
 
-		// Execute Pipeline
-		results, err := runner.Run(ctx, checks)
+		// The checks are independent, so run them all and report every failure at once.
+		// They are also safe to run unprompted: without --fix nothing is written.
+		runner := workflow.NewRunner(
+			presenter, true, workflow.WithContinueOnError(), workflow.WithVerboseTiming(globals.Verbose),
+		)
+		err = runner.Run(ctx, "Running Code Quality Pipeline", steps...)
+
+		deadcode := deadcodeFindings(candidates)
+		if deadcode != "" {
+			presenter.Warning("Unreachable code detected; the unused functions are listed above.")
+		}
 
 		// Logic: If issues found, generate report. If success, clean up stale report.
-		if err != nil || hasWarnings(results) {
-			if genErr := generateContextFile(results); genErr != nil {
+		if err != nil || deadcode != "" {
+			if genErr := generateContextFile(err, deadcode); genErr != nil {
 				presenter.Error("Failed to generate context file: %v", genErr)
 			} else {
 				presenter.Newline()
 				presenter.Info("Generated AI Context: %s", contextFile)
 				presenter.Advice("Pass this file to your AI to fix the issues.")
 			}
-		} else {
-			// Cleanup: If all passed, remove the stale context file if it exists.
-			// Uber Style: Inline check
-			if _, err := os.Stat(contextFile); err == nil {
-				if removeErr := os.Remove(contextFile); removeErr == nil {
-					presenter.Info("Removed stale AI Context file: %s (all checks passed)", contextFile)
-				}
-			}
 		}
 
 		if err != nil {
 			presenter.Error("Pipeline failed.")
 
-			//nolint:wrapcheck // Wrapping is handled by caller.
+			//nolint:wrapcheck // The workflow error already names every failed step.
 			return err
 		}
 
+		// Cleanup: If all passed, remove the stale context file if it exists.
+		if _, err := os.Stat(contextFile); err == nil && deadcode == "" {
+			if removeErr := os.Remove(contextFile); removeErr == nil {
+				presenter.Info("Removed stale AI Context file: %s (all checks passed)", contextFile)
+			}
+		}
+
 		presenter.Success("All quality checks passed.")
 
 		return nil
 	},
 }
 
-func hasWarnings(results []pipeline.Result) bool {
-	for _, result := range results {
-		switch result.Status {
-		case pipeline.StatusWarn, pipeline.StatusFail:
-			return true
-		case pipeline.StatusPass:
+// goSteps returns the ordered quality steps for a Go project. With fix, formatting
+// and go.mod are rewritten in place (goimports, gofmt -s, go mod tidy); otherwise
+// gofmt -d and go mod tidy -diff fail on anything that would change. Each step
+// captures its output so a failure can be written to the context file.
+func goSteps(
+	execClient *exec.ExecutorClient,
+	presenter *ui.Presenter,
+	dir string,
+	fix bool,
+) []*workflow.CommandStep {
+	newStep := func(desc, command string, args ...string) *workflow.CommandStep {
+		return &workflow.CommandStep{
+			ExecClient:    execClient,
+			Desc:          desc,
+			Dir:           dir,
+			Command:       command,
+			Args:          args,
+			CaptureOutput: true,
+			Presenter:     presenter,
+			Output:        "",
+		}
+	}
+
+	var steps []*workflow.CommandStep
+
+	if fix {
+		steps = append(steps,
+			newStep("Fix imports (goimports -w)", "goimports", "-w", "."),
+			newStep("Fix formatting (gofmt -s -w)", "gofmt", "-s", "-w", "."),
+			newStep("Tidy modules (go mod tidy)", "go", "mod", "tidy"),
+		)
+	} else {
+		steps = append(steps,
+			newStep("Check formatting (gofmt -s -d)", "gofmt", "-s", "-d", "."),
+			newStep("Check modules are tidy (go mod tidy -diff)", "go", "mod", "tidy", "-diff"),
+		)
+	}
+
+	gitleaksArgs := []string{"detect", "--no-git", "--verbose"}
+	if _, err := os.Stat(filepath.Join(dir, gitleaksConfigFile)); err == nil {
+		gitleaksArgs = append(gitleaksArgs, "-c", gitleaksConfigFile)
+	}
+
+	return append(steps,
+		newStep("Vet (go vet)", "go", "vet", "./..."),
+		newStep("Lint (golangci-lint)", "golangci-lint", "run", "./..."),
+		newStep("Test (go test)", "go", "test", "./..."),
+		newStep("Scan for vulnerabilities (govulncheck)", "govulncheck", "./..."),
+		newStep("Scan for secrets (gitleaks)", "gitleaks", gitleaksArgs...),
+		newStep(deadcodeStepDesc, "deadcode", "./..."),
+	)
+}
+
+// deadcodeFindings returns what the deadcode step reported. deadcode exits zero
+// when it finds unreachable functions, so its findings are a warning rather than
+// a failed step.
+func deadcodeFindings(steps []*workflow.CommandStep) string {
+	for _, step := range steps {
+		if step.Desc == deadcodeStepDesc {
+			return step.Output
+		}
+	}
+
+	return ""
+}
+
+// installedSteps drops the steps whose tool is not installed, warning about each one,
+// so a missing optional linter does not abort the whole run.
+func installedSteps(
+	presenter *ui.Presenter,
+	execClient *exec.ExecutorClient,
+	candidates []*workflow.CommandStep,
+) []workflow.Step {
+	steps := make([]workflow.Step, 0, len(candidates))
+
+	for _, step := range candidates {
+		if !execClient.CommandExists(step.Command) {
+			presenter.Warning("Skipping '%s': '%s' is not installed.", step.Description(), step.Command)
+
 			continue
 		}
+
+		steps = append(steps, step)
+	}
+
+	return steps
+}
+
+// failedSteps extracts the per-step failures from a continue-on-error workflow error.
+func failedSteps(err error) []*workflow.StepError {
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		return nil
+	}
+
+	var failures []*workflow.StepError
+
+	for _, inner := range joined.Unwrap() {
+		var stepErr *workflow.StepError
+		if errors.As(inner, &stepErr) {
+			failures = append(failures, stepErr)
+		}
 	}
 
-	return false
+	return failures
 }
 
-func generateContextFile(results []pipeline.Result) error {
+// generateContextFile writes the failed steps, with the output each one captured,
+// and any deadcode findings to the context file.
+func generateContextFile(runErr error, deadcode string) error {
 	var buf bytes.Buffer
 
 	// 1. The Prompt
 	buf.WriteString("# AI Task: Fix Quality Issues\n\n")
 	buf.WriteString("You are a senior software engineer. Analyze the quality report below.\n")
-	buf.WriteString("Your goal is to fix the **Linter Errors** and address the **Dead Code** warnings.\n\n")
+	buf.WriteString("Your goal is to fix the failed checks and address the **Dead Code** warnings.\n\n")
 	buf.WriteString("## Instructions\n")
 	buf.WriteString("1.  **Analyze**: Look at the specific error messages and file paths.\n")
 	buf.WriteString("2.  **Plan**: Create a plan to resolve each issue.\n")
@@ -136,40 +256,31 @@ func generateContextFile(results []pipeline.Result) error {
 	buf.WriteString("---\n\n")
 
 	// 2. The Report
-	buf.WriteString(fmt.Sprintf("# Quality Report (%s)\n\n", time.Now().Format(time.RFC3339)))
-
-	for _, result := range results {
-		// Use standard ContextVibes markers instead of icons
-		marker := "+"
-
-		// Use switch for status check (fixes staticcheck QF1003)
-		switch result.Status {
-		case pipeline.StatusFail:
-			marker = "!"
-		case pipeline.StatusWarn:
-			marker = "~"
-		case pipeline.StatusPass:
-			marker = "+" // Explicitly handle Pass to satisfy exhaustive
-		}
+	buf.WriteString(fmt.Sprintf("# Quality Report (%s)\n\n", globals.Clock.Now().Format(time.RFC3339)))
 
-		buf.WriteString(fmt.Sprintf("## %s %s\n", marker, result.Name))
-		buf.WriteString(fmt.Sprintf("**Status:** %s\n", statusToString(result.Status)))
+	failures := failedSteps(runErr)
+	if runErr != nil && len(failures) == 0 {
+		buf.WriteString(fmt.Sprintf("**System Error:** %v\n\n", runErr))
+	}
 
-		if result.Message != "" {
-			buf.WriteString(fmt.Sprintf("**Message:** %s\n", result.Message))
-		}
+	for _, failure := range failures {
+		buf.WriteString(fmt.Sprintf("## ! %s\n", failure.Description))
+		buf.WriteString("**Status:** Fail\n")
+		buf.WriteString(fmt.Sprintf("**Error:** %v\n", failure.Err))
 
-		if result.Details != "" {
-			buf.WriteString("\n**Details:**\n")
-			buf.WriteString("text\n")
-			buf.WriteString(strings.TrimSpace(result.Details))
-			buf.WriteString("\n\n")
+		var cmdErr *workflow.CommandError
+		if errors.As(failure.Err, &cmdErr) {
+			writeOutput(&buf, cmdErr.Output)
 		}
 
-		if result.Error != nil {
-			buf.WriteString(fmt.Sprintf("\n**System Error:** %v\n", result.Error))
-		}
+		buf.WriteString("\n")
+	}
 
+	if deadcode != "" {
+		buf.WriteString(fmt.Sprintf("## ~ %s\n", deadcodeStepDesc))
+		buf.WriteString("**Status:** Warning\n")
+		buf.WriteString("**Message:** Unreachable code detected\n")
+		writeOutput(&buf, deadcode)
 		buf.WriteString("\n")
 	}
 
@@ -181,17 +292,15 @@ func generateContextFile(results []pipeline.Result) error {
 	return nil
 }
 
-func statusToString(s pipeline.Status) string {
-	switch s {
-	case pipeline.StatusPass:
-		return "Pass"
-	case pipeline.StatusFail:
-		return "Fail"
-	case pipeline.StatusWarn:
-		return "Warning"
-	default:
-		return "Unknown"
+// writeOutput appends a tool's captured output as a fenced block.
+func writeOutput(buf *bytes.Buffer, output string) {
+	if output == "" {
+		return
 	}
+
+	buf.WriteString("\n**Output:**\n\n```text\n")
+	buf.WriteString(output)
+	buf.WriteString("\n```\n")
 }
 
 //nolint:gochecknoinits // Cobra requires init() for command registration.
@@ -203,4 +312,7 @@ func init() {
 
 	QualityCmd.Short = desc.Short
 	QualityCmd.Long = desc.Long
+	QualityCmd.Flags().
+		BoolVar(&fix, "fix", false,
+			"Rewrite formatting (goimports, gofmt -s) and go.mod (go mod tidy) instead of only reporting them.")
 }
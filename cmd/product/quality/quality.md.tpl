@@ -1,4 +1,14 @@
 # Running Code Quality Checks.
 
 Executes a suite of static analysis and quality checks appropriate for the detected project type.
-For Go projects, this includes `go mod tidy`, `go vet`, `golangci-lint`, and `govulncheck`.
+For Go projects, the checks run in order: formatting (`gofmt -s -d`), module tidiness (`go mod tidy -diff`), `go vet`, `golangci-lint`, `go test`, `govulncheck`, secret scanning (`gitleaks`, using `.gitleaks.toml` when present) and `deadcode`.
+Unreachable code found by `deadcode` is reported as a warning and does not fail the run.
+Every check runs even if an earlier one fails, and a timing summary is printed at the end.
+
+Tools that are not installed are skipped with a warning instead of failing the run.
+
+Use `--fix` to rewrite formatting in place (`goimports -w` when available, then `gofmt -s -w`) and run `go mod tidy` before the remaining checks.
+
+When any check fails or dead code is found, `_contextvibes.md` is written with each failed step and the output it produced, so it can be handed to an AI assistant.
+
+If the project contains `.yaml` or `.yml` files (outside `.gitignore`), a built-in YAML lint step also runs; see `contextvibes product lint yaml`.
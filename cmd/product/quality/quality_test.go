@@ -0,0 +1,202 @@
+// Package quality_test contains tests for the quality command.
+package quality_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/product/quality"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupQualityTest(t *testing.T, mockExec *exectest.Executor) *cobra.Command {
+	t.Helper()
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(t.TempDir()))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	require.NoError(t, os.WriteFile("go.mod", []byte("module example.com/test\n"), 0o600))
+
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+
+	cmd := *quality.QualityCmd
+	cmd.SetContext(context.Background())
+
+	return &cmd
+}
+
+func runQualityCmd(cmd *cobra.Command, args []string) (string, error) {
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+
+	_ = cmd.Flags().Set("fix", "false")
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+//nolint:paralleltest // QualityCmd uses global state which is not thread-safe.
+func TestQualityCmd(t *testing.T) {
+	//nolint:paralleltest // QualityCmd uses global state which is not thread-safe.
+	t.Run("go project runs the checks in order", func(t *testing.T) {
+		mockExec := newQualityExecutor(nil, "", nil)
+		cmd := setupQualityTest(t, mockExec)
+
+		out, err := runQualityCmd(cmd, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"gofmt -s -d .",
+			"go mod tidy -diff",
+			"go vet ./...",
+			"golangci-lint run ./...",
+			"go test ./...",
+			"govulncheck ./...",
+			"gitleaks detect --no-git --verbose",
+			"deadcode ./...",
+		}, mockExec.Commands())
+		assert.Contains(t, out, "All quality checks passed.")
+	})
+
+	//nolint:paralleltest // QualityCmd uses global state which is not thread-safe.
+	t.Run("fix rewrites formatting before checking", func(t *testing.T) {
+		mockExec := newQualityExecutor(nil, "", nil)
+		cmd := setupQualityTest(t, mockExec)
+
+		_, err := runQualityCmd(cmd, []string{"--fix"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"goimports -w .",
+			"gofmt -s -w .",
+			"go mod tidy",
+			"go vet ./...",
+			"golangci-lint run ./...",
+			"go test ./...",
+			"govulncheck ./...",
+			"gitleaks detect --no-git --verbose",
+			"deadcode ./...",
+		}, mockExec.Commands())
+	})
+
+	//nolint:paralleltest // QualityCmd uses global state which is not thread-safe.
+	t.Run("missing linter is skipped with a warning", func(t *testing.T) {
+		mockExec := newQualityExecutor(
+			map[string]bool{"golangci-lint": true, "govulncheck": true, "gitleaks": true, "deadcode": true},
+			"",
+			nil,
+		)
+		cmd := setupQualityTest(t, mockExec)
+
+		out, err := runQualityCmd(cmd, nil)
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "'golangci-lint' is not installed")
+		assert.Contains(t, out, "'deadcode' is not installed")
+		assert.Equal(t, []string{"gofmt -s -d .", "go mod tidy -diff", "go vet ./...", "go test ./..."}, mockExec.Commands())
+	})
+
+	//nolint:paralleltest // QualityCmd uses global state which is not thread-safe.
+	t.Run("failing check still runs the rest and writes the report", func(t *testing.T) {
+		mockExec := newQualityExecutor(
+			nil,
+			"golangci-lint",
+			map[string]string{"golangci-lint": "main.go:12:2: ineffectual assignment to err (ineffassign)\n"},
+		)
+		cmd := setupQualityTest(t, mockExec)
+
+		out, err := runQualityCmd(cmd, nil)
+		require.Error(t, err)
+
+		assert.Len(t, mockExec.Commands(), 8)
+		assert.Contains(t, out, "main.go:12:2: ineffectual assignment to err")
+
+		report, readErr := os.ReadFile("_contextvibes.md")
+		require.NoError(t, readErr)
+		assert.Contains(t, string(report), "## ! Lint (golangci-lint)")
+		assert.Contains(t, string(report), "```text\nmain.go:12:2: ineffectual assignment to err (ineffassign)\n```")
+	})
+
+	//nolint:paralleltest // QualityCmd uses global state which is not thread-safe.
+	t.Run("dead code is a warning listed in the report", func(t *testing.T) {
+		mockExec := newQualityExecutor(
+			nil,
+			"",
+			map[string]string{"deadcode": "internal/util.go:8:6: unreachable func: unused\n"},
+		)
+		cmd := setupQualityTest(t, mockExec)
+
+		out, err := runQualityCmd(cmd, nil)
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "Unreachable code detected")
+		assert.Contains(t, out, "All quality checks passed.")
+
+		report, readErr := os.ReadFile("_contextvibes.md")
+		require.NoError(t, readErr)
+		assert.Contains(t, string(report), "## ~ Find unreachable code (deadcode)")
+		assert.Contains(t, string(report), "internal/util.go:8:6: unreachable func: unused")
+	})
+
+	//nolint:paralleltest // QualityCmd uses global state which is not thread-safe.
+	t.Run("gitleaks uses the project config when present", func(t *testing.T) {
+		mockExec := newQualityExecutor(nil, "", nil)
+		cmd := setupQualityTest(t, mockExec)
+
+		require.NoError(t, os.WriteFile(".gitleaks.toml", []byte("[extend]\nuseDefault = true\n"), 0o600))
+
+		_, err := runQualityCmd(cmd, nil)
+		require.NoError(t, err)
+
+		assert.Contains(t, mockExec.Commands(), "gitleaks detect --no-git --verbose -c .gitleaks.toml")
+	})
+
+	//nolint:paralleltest // QualityCmd uses global state which is not thread-safe.
+	t.Run("yaml files add a lint step", func(t *testing.T) {
+		mockExec := newQualityExecutor(nil, "", nil)
+		cmd := setupQualityTest(t, mockExec)
+
+		require.NoError(t, os.WriteFile("config.yaml", []byte("name: a\nname: b\n"), 0o600))
+
+		out, err := runQualityCmd(cmd, nil)
+		require.Error(t, err)
+
+		assert.Contains(t, out, "Lint YAML files")
+		assert.Contains(t, out, "config.yaml:2: key-duplicates")
+		assert.Len(t, mockExec.Commands(), 8)
+	})
+}
+
+// newQualityExecutor reports the missing tools as not installed, fails every run
+// of failOn and answers captured runs from output, keyed by tool name.
+func newQualityExecutor(missing map[string]bool, failOn string, output map[string]string) *exectest.Executor {
+	//nolint:exhaustruct // Recorded fields start empty.
+	return &exectest.Executor{
+		Handler: func(call exectest.Call) exectest.Response {
+			if failOn != "" && call.Name == failOn {
+				//nolint:err113 // Dynamic error is appropriate here.
+				return exectest.Response{Stdout: output[call.Name], Stderr: "", Err: errors.New("exit status 1")}
+			}
+
+			return exectest.Response{Stdout: output[call.Name], Stderr: "", Err: nil}
+		},
+		Missing: missing,
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/contextvibes/cli/internal/cmddocs"
 	"github.com/contextvibes/cli/internal/config"
@@ -20,13 +22,22 @@ import (
 //go:embed run.md.tpl
 var runLongDescription string
 
+// ErrVerificationFailed is returned when an example's verification checks do not all pass.
+var ErrVerificationFailed = errors.New("verification failed")
+
+// ErrUnknownExample is returned when the requested example is neither in the
+// examples directory nor configured in run.examples.
+var ErrUnknownExample = errors.New("unknown example")
+
 // RunCmd represents the run command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
 var RunCmd = &cobra.Command{
-	Use:     "run",
-	Example: `  contextvibes product run`,
-	RunE: func(cmd *cobra.Command, _ []string) error {
+	Use: "run [example]",
+	Example: `  contextvibes product run
+  contextvibes product run hello-world  # Runs examples/hello-world without prompting`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
 		ctx := cmd.Context()
 
@@ -36,21 +47,30 @@ var RunCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if len(examples) == 0 {
-			presenter.Warning("No runnable examples found in the './examples' directory.")
 
-			return nil
-		}
+		var choice string
 
-		choice, err := presenter.PromptForSelect("Please select an example to run:", examples)
-		if err != nil || choice == "" {
-			return nil // User aborted
+		if len(args) == 1 {
+			choice, err = resolveExample(args[0], examples, globals.LoadedAppConfig)
+			if err != nil {
+				return err
+			}
+		} else {
+			if len(examples) == 0 {
+				presenter.Warning("No runnable examples found in the './examples' directory.")
+
+				return nil
+			}
+
+			choice, err = presenter.PromptForSelect("Please select an example to run:", examples)
+			if err != nil || choice == "" {
+				return nil // User aborted
+			}
 		}
 
 		err = runVerificationChecks(ctx, presenter, globals.ExecClient, globals.LoadedAppConfig, choice)
 		if err != nil {
-			//nolint:err113 // Dynamic error is appropriate here.
-			return errors.New("prerequisite verification failed")
+			return fmt.Errorf("prerequisite verification failed: %w", err)
 		}
 
 		presenter.Newline()
@@ -67,6 +87,29 @@ var RunCmd = &cobra.Command{
 	},
 }
 
+// resolveExample maps a name given on the command line, such as "hello-world" or
+// "examples/hello-world", to an example found on disk or configured in run.examples.
+func resolveExample(name string, examples []string, loadedAppConfig *config.Config) (string, error) {
+	name = filepath.ToSlash(filepath.Clean(name))
+	candidates := []string{name, "examples/" + name}
+
+	for _, candidate := range candidates {
+		if slices.Contains(examples, candidate) {
+			return candidate, nil
+		}
+
+		if _, ok := loadedAppConfig.Run.Examples[candidate]; ok {
+			return candidate, nil
+		}
+	}
+
+	if len(examples) == 0 {
+		return "", fmt.Errorf("%w '%s': no examples found in './examples'", ErrUnknownExample, name)
+	}
+
+	return "", fmt.Errorf("%w '%s': available examples are %s", ErrUnknownExample, name, strings.Join(examples, ", "))
+}
+
 func runVerificationChecks(
 	ctx context.Context,
 	presenter *ui.Presenter,
@@ -85,26 +128,39 @@ func runVerificationChecks(
 
 	presenter.Header("--- 🔍 Verifying Prerequisites for '%s' ---", examplePath)
 
-	allPassed := true
+	failed := 0
 
 	for _, check := range exampleSettings.Verify {
+		name := check.Name
+		if name == "" {
+			name = check.Command
+		}
+
+		presenter.Step("Checking %s...", name)
+
+		if check.Description != "" {
+			presenter.Detail("%s", check.Description)
+		}
+
 		_, stderr, err := execClient.CaptureOutput(ctx, ".", check.Command, check.Args...)
 		if err != nil {
-			allPassed = false
+			failed++
 
-			presenter.Error("  ❌ FAILED: Command '%s' failed.", check.Command)
+			presenter.Error("  ❌ FAILED: %s (command '%s' failed)", name, check.Command)
 
 			if stderr != "" {
 				presenter.Detail("    Stderr: %s", stderr)
 			}
 		} else {
-			presenter.Success("  ✅ PASSED")
+			presenter.Success("  ✅ PASSED: %s", name)
 		}
 	}
 
-	if !allPassed {
-		//nolint:err113 // Dynamic error is appropriate here.
-		return errors.New("verification failed")
+	total := len(exampleSettings.Verify)
+	presenter.Info("%d of %d check(s) passed.", total-failed, total)
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d check(s) failed", ErrVerificationFailed, failed, total)
 	}
 
 	return nil
@@ -2,3 +2,7 @@
 
 Discovers runnable Go applications in the `examples/` directory.
 It verifies configured prerequisites (defined in `.contextvibes.yaml`) before running the selected example.
+
+Name an example (for example `hello-world` or `examples/hello-world`) to run it
+without the selection prompt. Each `run.examples.<example-path>.verify` check is
+reported as passed or failed by name, and the example only runs when all of them pass.
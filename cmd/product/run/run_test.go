@@ -0,0 +1,99 @@
+// Package run_test contains tests for the run command.
+package run_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/product/run"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errExit = errors.New("exit status 1")
+
+// runExample runs the command with args in a temporary project holding
+// examples/hello-world, verified by checks.
+func runExample(t *testing.T, checks []config.VerificationCheck, args ...string) (*exectest.Executor, string, error) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "examples", "hello-world"), 0o750))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(tempDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	// Any command named "false" fails.
+	//nolint:exhaustruct // Recorded fields start empty.
+	mockExec := &exectest.Executor{Responses: map[string]exectest.Response{
+		"false": {Stdout: "", Stderr: "boom", Err: errExit},
+	}}
+	globals.ExecClient = exec.NewClient(mockExec)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+	globals.LoadedAppConfig.Run.Examples["examples/hello-world"] = config.ExampleSettings{Verify: checks}
+
+	cmd := *run.RunCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs(args)
+
+	err = cmd.Execute()
+
+	return mockExec, outBuf.String() + errBuf.String(), err
+}
+
+//nolint:paralleltest // RunCmd uses global state which is not thread-safe.
+func TestRunCmd_Verification(t *testing.T) {
+	//nolint:paralleltest // RunCmd uses global state which is not thread-safe.
+	t.Run("runs the example when every check passes", func(t *testing.T) {
+		mockExec, out, err := runExample(t, []config.VerificationCheck{
+			{Name: "go-version", Description: "Go is installed.", Command: "go", Args: []string{"version"}},
+			{Name: "gh-cli", Description: "", Command: "gh", Args: []string{"--version"}},
+		}, "hello-world")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"go version", "gh --version", "go run ./examples/hello-world"}, mockExec.Commands())
+		assert.Contains(t, out, "PASSED: go-version")
+		assert.Contains(t, out, "PASSED: gh-cli")
+		assert.Contains(t, out, "2 of 2 check(s) passed.")
+	})
+
+	//nolint:paralleltest // RunCmd uses global state which is not thread-safe.
+	t.Run("does not run the example when a check fails", func(t *testing.T) {
+		mockExec, out, err := runExample(t, []config.VerificationCheck{
+			{Name: "go-version", Description: "", Command: "go", Args: []string{"version"}},
+			{Name: "always-fails", Description: "", Command: "false", Args: nil},
+		}, "examples/hello-world")
+		require.ErrorIs(t, err, run.ErrVerificationFailed)
+
+		assert.NotContains(t, mockExec.Commands(), "go run ./examples/hello-world")
+		assert.Contains(t, out, "PASSED: go-version")
+		assert.Contains(t, out, "FAILED: always-fails (command 'false' failed)")
+		assert.Contains(t, out, "Stderr: boom")
+		assert.Contains(t, out, "1 of 2 check(s) passed.")
+	})
+
+	//nolint:paralleltest // RunCmd uses global state which is not thread-safe.
+	t.Run("rejects an unknown example", func(t *testing.T) {
+		mockExec, _, err := runExample(t, nil, "missing")
+		require.ErrorIs(t, err, run.ErrUnknownExample)
+		require.ErrorContains(t, err, "examples/hello-world")
+		assert.Empty(t, mockExec.Commands())
+	})
+}
@@ -62,7 +62,7 @@ var TestCmd = &cobra.Command{
 			presenter.Header("Python Project Tests")
 			testErr = executePythonTests(ctx, presenter, globals.ExecClient, cwd, args)
 			testExecuted = true
-		case project.Terraform, project.Pulumi, project.Unknown:
+		case project.Terraform, project.Pulumi, project.Node, project.Rust, project.Unknown:
 			fallthrough
 		default:
 			presenter.Info("No specific test execution logic for project type: %s", projType)
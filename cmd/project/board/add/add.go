@@ -43,7 +43,7 @@ func newGHClient(
 		return nil, fmt.Errorf("could not get remote URL for '%s': %w", cfg.Git.DefaultRemote, err)
 	}
 
-	owner, repo, err := github.ParseGitHubRemote(remoteURL)
+	remote, err := github.ParseRemote(remoteURL, cfg.Project.GitHubHost)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"could not parse owner/repo from remote URL '%s': %w",
@@ -52,7 +52,7 @@ func newGHClient(
 		)
 	}
 
-	client, err := github.NewClient(ctx, logger, owner, repo)
+	client, err := github.NewClientForHost(ctx, logger, remote.Host, remote.Owner, remote.Repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create github client: %w", err)
 	}
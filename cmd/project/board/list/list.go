@@ -39,7 +39,7 @@ func newGHClient(
 		return nil, fmt.Errorf("could not get remote URL for '%s': %w", cfg.Git.DefaultRemote, err)
 	}
 	// Use the shared parser from the internal/github package
-	owner, repo, err := github.ParseGitHubRemote(remoteURL)
+	remote, err := github.ParseRemote(remoteURL, cfg.Project.GitHubHost)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"could not parse owner/repo from remote URL '%s': %w",
@@ -48,7 +48,7 @@ func newGHClient(
 		)
 	}
 
-	client, err := github.NewClient(ctx, logger, owner, repo)
+	client, err := github.NewClientForHost(ctx, logger, remote.Host, remote.Owner, remote.Repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create github client: %w", err)
 	}
@@ -92,7 +92,7 @@ var ListCmd = &cobra.Command{
 		presenter.Header("--- Available Project Boards ---")
 		for _, project := range projects {
 			presenter.Step("#%d: %s", project.Number, project.Title)
-			presenter.Detail(project.URL)
+			presenter.Detail("%s", project.URL)
 		}
 
 		return nil
@@ -3,12 +3,15 @@ package describe
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/contextvibes/cli/internal/cmddocs"
@@ -27,23 +30,49 @@ var describeLongDescription string
 var (
 	describeOutputFile string
 	describePromptFlag string
+	treeDepthFlag      int
+	diffOnlyBase       string
+	clipboardFlag      bool
+	includeGlobs       []string
+	excludeGlobs       []string
 )
 
 const (
 	maxFileSizeKB = 500
+	// diffOnlyDefault is the --diff-only value used when no base is given; it
+	// resolves to the configured main branch.
+	diffOnlyDefault = "main-branch"
+	// stdoutOutput is the --output value that streams the Markdown to stdout.
+	stdoutOutput = "-"
+	// treeIgnorePattern lists names left out of the project tree, in `tree -I` syntax.
 	//nolint:lll // Pattern is long.
 	treeIgnorePattern = "vendor|.git|.terraform|.venv|venv|env|__pycache__|.pytest_cache|.DS_Store|.idx|.vscode|*.tfstate*|*.log|ai_context.txt|contextvibes.md|node_modules|build|dist"
 )
 
+// environmentTools are the tools whose versions describe reports.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var environmentTools = []string{"go", "git", "node", "python3", "terraform", "pulumi", "gcloud"}
+
 // DescribeCmd represents the describe command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
 var DescribeCmd = &cobra.Command{
-	Use:     "describe [-o <output_file>]",
-	Example: `  contextvibes project describe -o project_snapshot.md`,
-	Args:    cobra.NoArgs,
+	Use: "describe [-o <output_file>] [--diff-only[=<base>]]",
+	Example: `  contextvibes project describe -o project_snapshot.md
+  contextvibes project describe --diff-only
+  contextvibes project describe --diff-only=origin/main
+  contextvibes project describe -p "Explain the build" -o - | pbcopy`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, _ []string) error {
-		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		// With "-o -" stdout carries only the Markdown, so progress goes to stderr.
+		toStdout := describeOutputFile == stdoutOutput
+		progressOut := cmd.OutOrStdout()
+		if toStdout {
+			progressOut = cmd.ErrOrStderr()
+		}
+
+		presenter := ui.NewPresenter(progressOut, cmd.ErrOrStderr())
 		ctx := cmd.Context()
 
 		presenter.Summary("Generating project context description.")
@@ -95,6 +124,10 @@ var DescribeCmd = &cobra.Command{
 			excludeRes = append(excludeRes, re)
 		}
 
+		// --include/--exclude globs apply to this run only and take precedence over config.
+		cliIncludeRes := compileGlobs(includeGlobs)
+		cliExcludeRes := compileGlobs(excludeGlobs)
+
 		//nolint:mnd // 1024 is standard KB conversion.
 		maxSizeBytes := int64(maxFileSizeKB * 1024)
 
@@ -125,6 +158,8 @@ var DescribeCmd = &cobra.Command{
 
 		fmt.Fprintf(&outputBuffer, "### Prompt\n\n%s\n\n", userPrompt)
 
+		appendEnvironment(ctx, &outputBuffer)
+
 		gitStatus, _, statusErr := client.GetStatusShort(ctx)
 		if statusErr != nil {
 			gitStatus = "Failed to get git status."
@@ -132,57 +167,61 @@ var DescribeCmd = &cobra.Command{
 		tools.AppendSectionHeader(&outputBuffer, "Git Status (Summary)")
 		tools.AppendFencedCodeBlock(&outputBuffer, strings.TrimSpace(gitStatus), "")
 
-		treeOutput, _, treeErr := globals.ExecClient.CaptureOutput(
-			ctx,
-			workDir,
-			"tree",
-			"-L",
-			"2",
-			"-a",
-			"-I",
-			treeIgnorePattern,
-		)
+		treeDepth, err := globals.LoadedAppConfig.Describe.ResolveTreeDepth(treeDepthFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --tree-depth: %w", err)
+		}
+
+		treeOutput, treeErr := tools.GenerateTree(workDir, tools.TreeOptions{
+			MaxDepth:         treeDepth,
+			IgnoreNames:      strings.Split(treeIgnorePattern, "|"),
+			RespectGitignore: false,
+		})
 		if treeErr != nil {
-			treeOutput = "Could not generate tree view. 'tree' command may not be installed."
+			treeOutput = "Could not generate tree view: " + treeErr.Error()
 		}
 		tools.AppendSectionHeader(&outputBuffer, "Project Structure")
 		tools.AppendFencedCodeBlock(&outputBuffer, strings.TrimSpace(treeOutput), "")
 
-		tools.AppendSectionHeader(&outputBuffer, "Relevant Code Files")
+		var filesToList []string
+		if diffOnlyBase != "" {
+			base := diffOnlyBase
+			if base == diffOnlyDefault {
+				base = client.MainBranchName()
+			}
 
-		gitLsFilesOutput, _, err := client.ListTrackedAndCachedFiles(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to list git files: %w", err)
-		}
-		filesToList := strings.SplitSeq(strings.TrimSpace(gitLsFilesOutput), "\n")
+			diff, err := client.GetDiffFromBase(ctx, base)
+			if err != nil {
+				presenter.Error("Failed to diff against '%s': %v", base, err)
 
-		for file := range filesToList {
-			if file == "" {
-				continue
+				return fmt.Errorf("failed to diff against %s: %w", base, err)
 			}
+			tools.AppendSectionHeader(&outputBuffer, "Changes Since "+base)
+			tools.AppendFencedCodeBlock(&outputBuffer, strings.TrimSpace(diff), "diff")
 
-			// New configurable matching logic
-			isIncluded := false
-			for _, re := range includeRes {
-				if re.MatchString(file) {
-					isIncluded = true
-
-					break
-				}
+			filesToList, err = changedFiles(ctx, client, base)
+			if err != nil {
+				return err
 			}
-			if !isIncluded {
-				continue
+			presenter.Info("Embedding %d file(s) changed since '%s'.", len(filesToList), base)
+		} else {
+			gitLsFilesOutput, _, err := client.ListTrackedAndCachedFiles(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list git files: %w", err)
 			}
+			filesToList = strings.Split(strings.TrimSpace(gitLsFilesOutput), "\n")
+		}
+
+		tools.AppendSectionHeader(&outputBuffer, "Relevant Code Files")
 
-			isExcluded := false
-			for _, re := range excludeRes {
-				if re.MatchString(file) {
-					isExcluded = true
+		candidates := make([]string, 0, len(filesToList))
 
-					break
-				}
+		for _, file := range filesToList {
+			if file == "" {
+				continue
 			}
-			if isExcluded {
+
+			if !isSelected(file, cliIncludeRes, cliExcludeRes, includeRes, excludeRes) {
 				continue
 			}
 
@@ -199,25 +238,180 @@ var DescribeCmd = &cobra.Command{
 				continue
 			}
 
+			candidates = append(candidates, file)
+		}
+
+		markedBinary, err := client.FilesMarkedBinary(ctx, candidates)
+		if err != nil {
+			globals.AppLogger.DebugContext(ctx, "Could not read .gitattributes; relying on content sniffing",
+				slog.Any("error", err))
+		}
+
+		for _, file := range candidates {
+			if markedBinary[file] {
+				presenter.Warning("Skipping '%s': marked binary in .gitattributes.", file)
+
+				continue
+			}
+
 			content, readErr := tools.ReadFileContent(file)
-			if readErr == nil {
-				tools.AppendFileMarkerHeader(&outputBuffer, file)
-				outputBuffer.Write(content)
-				tools.AppendFileMarkerFooter(&outputBuffer, file)
+			if readErr != nil {
+				continue
+			}
+
+			if tools.IsBinaryContent(content) {
+				presenter.Warning("Skipping '%s': file looks binary.", file)
+
+				continue
 			}
+
+			tools.AppendFileMarkerHeader(&outputBuffer, file)
+			outputBuffer.Write(content)
+			tools.AppendFileMarkerFooter(&outputBuffer, file)
 		}
 
-		//nolint:noinlineerr // Inline check is standard.
-		if err := tools.WriteBufferToFile(describeOutputFile, &outputBuffer); err != nil {
-			return fmt.Errorf("failed to write output file: %w", err)
+		content := outputBuffer.String()
+		tokens := tools.EstimateTokens(content)
+
+		if toStdout {
+			_, err := outputBuffer.WriteTo(cmd.OutOrStdout())
+			if err != nil {
+				return fmt.Errorf("failed to write to stdout: %w", err)
+			}
+
+			presenter.Success("Successfully generated context on stdout (~%d tokens).", tokens)
+		} else {
+			//nolint:noinlineerr // Inline check is standard.
+			if err := tools.WriteBufferToFile(describeOutputFile, &outputBuffer); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+
+			presenter.Success("Successfully generated context file: %s (~%d tokens)", describeOutputFile, tokens)
 		}
 
-		presenter.Success("Successfully generated context file: %s", describeOutputFile)
+		warnIfOverTokenThreshold(presenter, tokens)
+
+		if clipboardFlag {
+			copyToClipboard(ctx, presenter, content)
+		}
 
 		return nil
 	},
 }
 
+// copyToClipboard copies the generated context, warning instead of failing when
+// no clipboard tool is available.
+func copyToClipboard(ctx context.Context, presenter *ui.Presenter, content string) {
+	tool, err := tools.CopyToClipboard(ctx, globals.ExecClient, content)
+	if err != nil {
+		presenter.Warning("Could not copy to the clipboard: %v", err)
+
+		return
+	}
+
+	presenter.Info("Copied to the clipboard with %s.", tool)
+}
+
+// appendEnvironment adds the versions of the installed development tools. Tools
+// that are missing or whose version cannot be read are left out.
+func appendEnvironment(ctx context.Context, buf *bytes.Buffer) {
+	var lines []string
+
+	for _, tool := range environmentTools {
+		if !globals.ExecClient.CommandExists(tool) {
+			continue
+		}
+
+		version, err := globals.ExecClient.CommandVersion(ctx, tool)
+		if err != nil {
+			globals.AppLogger.DebugContext(ctx, "Skipping tool version", slog.String("tool", tool), slog.Any("error", err))
+
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("- %s: %s", tool, version))
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	tools.AppendSectionHeader(buf, "Environment")
+	buf.WriteString(strings.Join(lines, "\n"))
+	buf.WriteString("\n\n")
+}
+
+// warnIfOverTokenThreshold warns when the estimated size of the generated context
+// exceeds describe.tokenWarningThreshold.
+func warnIfOverTokenThreshold(presenter *ui.Presenter, tokens int) {
+	threshold := globals.LoadedAppConfig.Describe.TokenWarningThreshold
+	if tools.ExceedsTokenThreshold(tokens, threshold) {
+		presenter.Warning(
+			"Estimated ~%d tokens exceeds the configured threshold of %d; consider narrowing the included files.",
+			tokens,
+			threshold,
+		)
+	}
+}
+
+// changedFiles returns the files changed since base plus untracked files, without
+// duplicates.
+func changedFiles(ctx context.Context, client *git.GitClient, base string) ([]string, error) {
+	files, err := client.ListChangedFiles(ctx, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed since %s: %w", base, err)
+	}
+
+	untracked, _, err := client.ListUntrackedFiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	for line := range strings.Lines(untracked) {
+		file := strings.TrimSpace(line)
+		if file != "" && !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// isSelected applies the include/exclude patterns to a file. A CLI exclude always
+// drops the file and a CLI include always keeps it; otherwise the config patterns
+// (which start from the defaults) decide.
+func isSelected(file string, cliInclude, cliExclude, include, exclude []*regexp.Regexp) bool {
+	if matchesAny(cliExclude, file) {
+		return false
+	}
+
+	if matchesAny(cliInclude, file) {
+		return true
+	}
+
+	return matchesAny(include, file) && !matchesAny(exclude, file)
+}
+
+func matchesAny(patterns []*regexp.Regexp, file string) bool {
+	return slices.ContainsFunc(patterns, func(re *regexp.Regexp) bool { return re.MatchString(file) })
+}
+
+// compileGlobs converts --include/--exclude globs to regexps. A glob without a
+// slash matches file names at any depth, so "*_test.go" works like in .gitignore.
+func compileGlobs(globs []string) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, 0, len(globs))
+
+	for _, glob := range globs {
+		if !strings.Contains(glob, "/") {
+			glob = "**/" + glob
+		}
+
+		patterns = append(patterns, tools.GlobToRegexp(glob))
+	}
+
+	return patterns
+}
+
 //nolint:gochecknoinits // Cobra requires init() for command registration.
 func init() {
 	desc, err := cmddocs.ParseAndExecute(describeLongDescription, nil)
@@ -228,8 +422,19 @@ func init() {
 	DescribeCmd.Short = desc.Short
 	DescribeCmd.Long = desc.Long
 	DescribeCmd.Flags().
-		StringVarP(&describeOutputFile, "output", "o", "contextvibes.md", "Path to write the context markdown file")
+		StringVarP(&describeOutputFile, "output", "o", "contextvibes.md", "Path to write the context markdown file ('-' for stdout)")
 	// THE FIX: This line defines the flag so Cobra knows about it.
 	DescribeCmd.Flags().
 		StringVarP(&describePromptFlag, "prompt", "p", "", "Provide the prompt text directly")
+	DescribeCmd.Flags().
+		IntVar(&treeDepthFlag, "tree-depth", 0, "Directory levels in the project tree (default: describe.treeDepth or 2)")
+	DescribeCmd.Flags().
+		StringVar(&diffOnlyBase, "diff-only", "", "Only embed files changed since this base (default: the main branch) and include the diff")
+	DescribeCmd.Flags().Lookup("diff-only").NoOptDefVal = diffOnlyDefault
+	DescribeCmd.Flags().
+		BoolVar(&clipboardFlag, "clipboard", false, "Also copy the generated context to the system clipboard")
+	DescribeCmd.Flags().
+		StringArrayVar(&includeGlobs, "include", nil, "Glob of files to include for this run, overriding config (repeatable)")
+	DescribeCmd.Flags().
+		StringArrayVar(&excludeGlobs, "exclude", nil, "Glob of files to exclude for this run, overriding config (repeatable)")
 }
@@ -3,3 +3,35 @@
 Gathers a full snapshot of the project (user prompt, environment, git status,
 structure, relevant files) and writes it to a Markdown file (default: contextvibes.md).
 This is the primary command for onboarding an AI to a new task.
+
+The project structure shows two directory levels by default. Use --tree-depth
+(or `describe.treeDepth` in .contextvibes.yaml) for a shallower or deeper view.
+
+Use --diff-only to focus on your current work: only files changed since the
+main branch (or `--diff-only=<base>`), plus untracked files, are embedded, and
+the diff itself is included in a "Changes Since" section. The usual include,
+exclude, `.aiexclude` and size filters still apply.
+
+Which files are embedded is decided by `describe.includePatterns` and
+`describe.excludePatterns` in .contextvibes.yaml (or the built-in defaults).
+For a one-off run, add --include and --exclude globs; both can be repeated and
+take precedence over the config: `--exclude '*_test.go'` drops every test file
+even if the config includes it, and `--include 'docs/**'` adds files the
+config would leave out. A glob without a slash matches file names at any
+depth, and `**` spans directories. `.aiexclude` is always respected.
+
+Use `-o -` to stream the Markdown to standard output instead of a file, for
+example to pipe it into another tool. Progress messages then go to standard
+error so the output stays clean.
+
+Add --clipboard to also copy the result to the system clipboard (using pbcopy,
+wl-copy or xclip, whichever is installed).
+
+The success message includes a rough token estimate of the output, so you can
+tell whether it fits your model's context window. Set
+`describe.tokenWarningThreshold` in .contextvibes.yaml to get a warning when
+the estimate exceeds it.
+
+Files that look binary (a NUL byte near the start) or that `.gitattributes`
+marks as `binary` or `-text` are skipped with a warning, so they cannot corrupt
+the Markdown.
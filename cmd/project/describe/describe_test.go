@@ -0,0 +1,220 @@
+// Package describe_test contains tests for the describe command.
+package describe_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/project/describe"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runDescribe runs describe in a temporary directory seeded with files and returns the artifact.
+func runDescribe(t *testing.T, responses map[string]string, args ...string) string {
+	t.Helper()
+
+	executeDescribe(t, responses, append([]string{"-o", "artifact.md"}, args...)...)
+
+	artifact, err := os.ReadFile("artifact.md")
+	require.NoError(t, err)
+
+	return string(artifact)
+}
+
+// executeDescribe runs describe in a temporary directory seeded with files and
+// returns what it wrote to stdout and stderr.
+func executeDescribe(t *testing.T, responses map[string]string, args ...string) (string, string) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(tempDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	files := map[string]string{
+		"main.go":          "package main // unchanged\n",
+		"internal/a/a.go":  "package a // changed\n",
+		"notes/new.md":     "# untracked notes\n",
+		"internal/b/b.go":  "package b // unchanged\n",
+		"internal/a/a.bin": "binary",
+		"data/dump.json":   "{\x00\x01\x02}",
+		"testdata/raw.txt": "text that .gitattributes marks binary\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o750))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	}
+
+	responses["git status --short"] = " M internal/a/a.go\n"
+
+	globals.ExecClient = exec.NewClient(newDescribeExecutor(tempDir, responses))
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+
+	cmd := *describe.DescribeCmd
+	cmd.SetContext(context.Background())
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs(append([]string{"-p", "Review my change"}, args...))
+
+	_ = cmd.Flags().Set("tree-depth", "0")
+	_ = cmd.Flags().Set("diff-only", "")
+
+	for _, name := range []string{"include", "exclude"} {
+		sliceFlag, ok := cmd.Flags().Lookup(name).Value.(pflag.SliceValue)
+		require.True(t, ok)
+		require.NoError(t, sliceFlag.Replace(nil))
+	}
+
+	require.NoError(t, cmd.Execute())
+
+	return outBuf.String(), errBuf.String()
+}
+
+//nolint:paralleltest // DescribeCmd uses global state which is not thread-safe.
+func TestDescribeCmd_AllFiles(t *testing.T) {
+	artifact := runDescribe(t, map[string]string{
+		"git ls-files -co --exclude-standard": "main.go\ninternal/a/a.go\ninternal/b/b.go\nnotes/new.md\n",
+	})
+
+	assert.Contains(t, artifact, "FILE: main.go")
+	assert.Contains(t, artifact, "FILE: internal/b/b.go")
+	assert.NotContains(t, artifact, "Changes Since")
+}
+
+//nolint:paralleltest // DescribeCmd uses global state which is not thread-safe.
+func TestDescribeCmd_IncludeExcludeFlags(t *testing.T) {
+	responses := map[string]string{
+		"git ls-files -co --exclude-standard": "main.go\ninternal/a/a.go\ninternal/a/a.bin\ninternal/b/b.go\nnotes/new.md\n",
+	}
+
+	artifact := runDescribe(t, responses)
+	require.Contains(t, artifact, "FILE: internal/b/b.go", "included by the default patterns")
+	require.NotContains(t, artifact, "FILE: internal/a/a.bin", "excluded by the default patterns")
+
+	artifact = runDescribe(t, responses, "--exclude", "internal/b/**", "--exclude", "main.go")
+	assert.NotContains(t, artifact, "FILE: internal/b/b.go", "a CLI exclude overrides the config include")
+	assert.NotContains(t, artifact, "FILE: main.go")
+	assert.Contains(t, artifact, "FILE: internal/a/a.go")
+
+	artifact = runDescribe(t, responses, "--include", "*.bin", "--exclude", "*.md")
+	assert.Contains(t, artifact, "FILE: internal/a/a.bin", "a CLI include overrides the config exclude")
+	assert.NotContains(t, artifact, "FILE: notes/new.md")
+}
+
+//nolint:paralleltest // DescribeCmd uses global state which is not thread-safe.
+func TestDescribeCmd_DiffOnly(t *testing.T) {
+	responses := map[string]string{
+		"git diff --name-only main...HEAD":         "internal/a/a.go\ninternal/a/a.bin\n",
+		"git diff main...HEAD":                     "diff --git a/internal/a/a.go b/internal/a/a.go\n+// changed\n",
+		"git ls-files --others --exclude-standard": "notes/new.md\n",
+	}
+
+	artifact := runDescribe(t, responses, "--diff-only")
+
+	assert.Contains(t, artifact, "### Changes Since main")
+	assert.Contains(t, artifact, "```diff\ndiff --git a/internal/a/a.go")
+	assert.Contains(t, artifact, "FILE: internal/a/a.go")
+	assert.Contains(t, artifact, "FILE: notes/new.md")
+	assert.NotContains(t, artifact, "FILE: main.go")
+	assert.NotContains(t, artifact, "FILE: internal/b/b.go")
+	assert.NotContains(t, artifact, "FILE: internal/a/a.bin", "include filters still apply")
+}
+
+//nolint:paralleltest // DescribeCmd uses global state which is not thread-safe.
+func TestDescribeCmd_DiffOnlyCustomBase(t *testing.T) {
+	responses := map[string]string{
+		"git diff --name-only origin/develop...HEAD": "main.go\n",
+		"git diff origin/develop...HEAD":             "diff --git a/main.go b/main.go\n",
+		"git ls-files --others --exclude-standard":   "",
+	}
+
+	artifact := runDescribe(t, responses, "--diff-only=origin/develop")
+
+	assert.Contains(t, artifact, "### Changes Since origin/develop")
+	assert.Contains(t, artifact, "FILE: main.go")
+	assert.NotContains(t, artifact, "FILE: internal/a/a.go")
+}
+
+//nolint:paralleltest // DescribeCmd uses global state which is not thread-safe.
+func TestDescribeCmd_SkipsBinaryFiles(t *testing.T) {
+	_, stderr := executeDescribe(t, map[string]string{
+		"git ls-files -co --exclude-standard":                                      "main.go\ndata/dump.json\ntestdata/raw.txt\n",
+		"git check-attr -z binary text -- main.go data/dump.json testdata/raw.txt": "testdata/raw.txt\x00binary\x00set\x00testdata/raw.txt\x00text\x00unset\x00",
+	}, "-o", "artifact.md")
+
+	artifact, err := os.ReadFile("artifact.md")
+	require.NoError(t, err)
+
+	assert.Contains(t, string(artifact), "FILE: main.go")
+	assert.NotContains(t, string(artifact), "FILE: data/dump.json")
+	assert.NotContains(t, string(artifact), "FILE: testdata/raw.txt")
+	assert.Contains(t, stderr, "Skipping 'data/dump.json': file looks binary.")
+	assert.Contains(t, stderr, "Skipping 'testdata/raw.txt': marked binary in .gitattributes.")
+}
+
+//nolint:paralleltest // DescribeCmd uses global state which is not thread-safe.
+func TestDescribeCmd_Stdout(t *testing.T) {
+	stdout, stderr := executeDescribe(t, map[string]string{
+		"git ls-files -co --exclude-standard": "main.go\n",
+	}, "-o", "-")
+
+	assert.True(t, strings.HasPrefix(stdout, "### Prompt\n\nReview my change"), stdout)
+	assert.Contains(t, stdout, "FILE: main.go")
+	assert.NotContains(t, stdout, "SUMMARY")
+	assert.NotContains(t, stdout, "Successfully")
+	assert.Contains(t, stderr, "Generating project context description.")
+	assert.Regexp(t, `Successfully generated context on stdout \(~\d+ tokens\)\.`, stderr)
+
+	_, err := os.Stat("-")
+	assert.True(t, os.IsNotExist(err), "no file named '-' is written")
+}
+
+//nolint:paralleltest // DescribeCmd uses global state which is not thread-safe.
+func TestDescribeCmd_Environment(t *testing.T) {
+	artifact := runDescribe(t, map[string]string{
+		"git ls-files -co --exclude-standard": "main.go\n",
+		"go version":                          "go version go1.25.5 linux/amd64\n",
+		"git --version":                       "git version 2.43.0\n",
+	})
+
+	assert.Contains(t, artifact, "## Environment")
+	assert.Contains(t, artifact, "- go: 1.25.5\n")
+	assert.Contains(t, artifact, "- git: 2.43.0\n")
+	assert.NotContains(t, artifact, "- terraform:", "tools whose version cannot be read are left out")
+}
+
+// newDescribeExecutor answers commands from responses, keyed by command line;
+// any other command fails.
+func newDescribeExecutor(repoDir string, responses map[string]string) *exectest.Executor {
+	scripted := make(map[string]exectest.Response, len(responses))
+	for command, stdout := range responses {
+		scripted[command] = exectest.Response{Stdout: stdout, Stderr: "", Err: nil}
+	}
+
+	//nolint:exhaustruct // Recorded fields start empty.
+	return &exectest.Executor{
+		RepoDir:   repoDir,
+		Responses: scripted,
+		Handler: func(exectest.Call) exectest.Response {
+			//nolint:err113 // Dynamic error is appropriate here.
+			return exectest.Response{Stdout: "", Stderr: "unexpected command", Err: errors.New("exit status 128")}
+		},
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/contextvibes/cli/internal/cmddocs"
 	"github.com/contextvibes/cli/internal/config"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/tools"
 	"github.com/contextvibes/cli/internal/ui"
 	"github.com/contextvibes/cli/internal/workitem"
 	"github.com/contextvibes/cli/internal/workitem/github"
@@ -26,6 +28,8 @@ var (
 	issueType  string
 	issueTitle string
 	issueBody  string
+	issueFile  string
+	dryRun     bool
 )
 
 // newProvider is a factory function that returns the configured work item provider.
@@ -61,44 +65,36 @@ func newProvider(
 var CreateCmd = &cobra.Command{
 	Use:     "create",
 	Aliases: []string{"new", "add"},
+	Example: `  contextvibes project issues create
+  contextvibes project issues create --title "Fix login" --type Bug
+  contextvibes project issues create --file docs/issues/login.md --dry-run`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
 		ctx := cmd.Context()
 
-		provider, err := newProvider(ctx, globals.AppLogger, globals.LoadedAppConfig)
-		if err != nil {
-			presenter.Error("Failed to initialize work item provider: %v", err)
+		var provider workitem.Provider
 
-			return err
-		}
+		if !dryRun {
+			var err error
 
-		if issueTitle == "" { // Interactive Mode
-			form := huh.NewForm(
-				huh.NewGroup(
-					//nolint:lll // Long line for options.
-					huh.NewSelect[string]().Title("What kind of issue is this?").
-						Options(huh.NewOption("Task", "Task"), huh.NewOption("Story", "Story"), huh.NewOption("Bug", "Bug"), huh.NewOption("Chore", "Chore")).
-						Value(&issueType),
-					huh.NewInput().Title("Title?").Value(&issueTitle),
-					huh.NewText().Title("Body?").Value(&issueBody),
-				),
-			)
-			err := form.Run()
+			provider, err = newProvider(ctx, globals.AppLogger, globals.LoadedAppConfig)
 			if err != nil {
-				return fmt.Errorf("input form failed: %w", err)
+				presenter.Error("Failed to initialize work item provider: %v", err)
+
+				return err
 			}
 		}
 
-		if issueTitle == "" {
-			//nolint:err113 // Dynamic error is appropriate here.
-			return errors.New("title cannot be empty")
+		newItem, err := buildItem(cmd)
+		if err != nil {
+			return err
 		}
 
-		//nolint:exhaustruct // Partial initialization is valid for creation.
-		newItem := workitem.WorkItem{
-			Title: issueTitle,
-			Body:  issueBody,
-			Type:  workitem.Type(issueType),
+		if dryRun {
+			presentItem(presenter, newItem)
+			presenter.Info("Dry run: no work item was created.")
+
+			return nil
 		}
 
 		presenter.Summary("Creating work item...")
@@ -115,6 +111,84 @@ var CreateCmd = &cobra.Command{
 	},
 }
 
+// buildItem assembles the new work item from --file, from the flags, or from an
+// interactive form when no title is given.
+func buildItem(cmd *cobra.Command) (workitem.WorkItem, error) {
+	if issueFile != "" {
+		content, err := tools.ReadFileContent(issueFile)
+		if err != nil {
+			return workitem.WorkItem{}, fmt.Errorf("failed to read issue file: %w", err)
+		}
+
+		item, err := workitem.ParseMarkdown(string(content))
+		if err != nil {
+			return workitem.WorkItem{}, fmt.Errorf("failed to parse issue file '%s': %w", issueFile, err)
+		}
+
+		// An explicit --type wins over the file's type.
+		if cmd.Flags().Changed("type") {
+			item.Type, err = workitem.ParseType(issueType)
+			if err != nil {
+				//nolint:wrapcheck // Type errors are already descriptive.
+				return workitem.WorkItem{}, err
+			}
+		}
+
+		return item, nil
+	}
+
+	if issueTitle == "" { // Interactive Mode
+		form := huh.NewForm(
+			huh.NewGroup(
+				//nolint:lll // Long line for options.
+				huh.NewSelect[string]().Title("What kind of issue is this?").
+					Options(huh.NewOption("Task", "Task"), huh.NewOption("Story", "Story"), huh.NewOption("Bug", "Bug"), huh.NewOption("Chore", "Chore")).
+					Value(&issueType),
+				huh.NewInput().Title("Title?").Value(&issueTitle),
+				huh.NewText().Title("Body?").Value(&issueBody),
+			),
+		)
+
+		err := form.Run()
+		if err != nil {
+			return workitem.WorkItem{}, fmt.Errorf("input form failed: %w", err)
+		}
+	}
+
+	if issueTitle == "" {
+		//nolint:err113 // Dynamic error is appropriate here.
+		return workitem.WorkItem{}, errors.New("title cannot be empty")
+	}
+
+	//nolint:exhaustruct // Partial initialization is valid for creation.
+	return workitem.WorkItem{
+		Title: issueTitle,
+		Body:  issueBody,
+		Type:  workitem.Type(issueType),
+	}, nil
+}
+
+func presentItem(presenter *ui.Presenter, item workitem.WorkItem) {
+	presenter.Summary("Work item to create:")
+	presenter.Detail("Title: %s", item.Title)
+	presenter.Detail("Type: %s", item.Type)
+
+	if len(item.Labels) > 0 {
+		presenter.Detail("Labels: %s", strings.Join(item.Labels, ", "))
+	}
+
+	if len(item.Assignees) > 0 {
+		presenter.Detail("Assignees: %s", strings.Join(item.Assignees, ", "))
+	}
+
+	if item.Body != "" {
+		presenter.Newline()
+		presenter.Detail("%s", item.Body)
+	}
+
+	presenter.Newline()
+}
+
 //nolint:gochecknoinits // Cobra requires init() for command registration.
 func init() {
 	desc, err := cmddocs.ParseAndExecute(createLongDescription, nil)
@@ -129,4 +203,9 @@ func init() {
 		StringVarP(&issueType, "type", "t", "Task", "Type of the issue (Task, Story, Bug, Chore)")
 	CreateCmd.Flags().StringVarP(&issueTitle, "title", "T", "", "Title of the issue")
 	CreateCmd.Flags().StringVarP(&issueBody, "body", "b", "", "Body of the issue")
+	CreateCmd.Flags().
+		StringVarP(&issueFile, "file", "f", "", "Markdown file with YAML front matter (title, type, labels, assignees)")
+	CreateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the work item that would be created without creating it")
+	CreateCmd.MarkFlagsMutuallyExclusive("file", "title")
+	CreateCmd.MarkFlagsMutuallyExclusive("file", "body")
 }
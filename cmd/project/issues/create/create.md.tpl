@@ -2,3 +2,19 @@
 
 Creates a new issue in the configured issue tracker (default: GitHub).
 Supports interactive mode (using forms) or flag-based input for title, body, and type.
+
+With `--file`, the issue is read from a Markdown file instead. Its YAML front matter
+sets `title` (required), `type` (Task, Story, Epic, Bug or Chore; default Task),
+`labels` and `assignees`, and the rest of the file becomes the body:
+
+```markdown
+---
+title: Login fails with expired session
+type: bug
+labels: [auth]
+assignees: [octocat]
+---
+Steps to reproduce...
+```
+
+Use `--dry-run` to preview the work item without contacting the issue tracker.
@@ -0,0 +1,52 @@
+package create_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/project/issues/create"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/workitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const issueFile = `---
+title: Login fails with expired session
+type: bug
+labels: [auth, p1]
+assignees: [octocat]
+---
+Sign in and wait for the session to expire.
+`
+
+//nolint:paralleltest // CreateCmd uses global state which is not thread-safe.
+func TestCreateCmd_FileDryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issue.md")
+	require.NoError(t, os.WriteFile(path, []byte(issueFile), 0o600))
+
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+
+	cmd := *create.CreateCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs([]string{"--file", path, "--type", "chore", "--dry-run"})
+
+	require.NoError(t, cmd.Execute())
+
+	out := outBuf.String() + errBuf.String()
+	assert.Contains(t, out, "Title: Login fails with expired session")
+	assert.Contains(t, out, "Type: "+string(workitem.TypeChore), "--type overrides the file")
+	assert.Contains(t, out, "Labels: auth, p1")
+	assert.Contains(t, out, "Assignees: octocat")
+	assert.Contains(t, out, "Sign in and wait for the session to expire.")
+	assert.Contains(t, out, "no work item was created")
+}
@@ -14,7 +14,7 @@ import (
 //
 //nolint:varnamelen // 'p' is standard for presenter.
 func DisplayWorkItem(p *ui.Presenter, item *workitem.WorkItem) {
-	p.Header(fmt.Sprintf("%s (#%d)", item.Title, item.Number))
+	p.Header("%s (#%d)", item.Title, item.Number)
 	p.Detail(
 		"State: %s, Author: %s, Created: %s",
 		item.State,
@@ -5,6 +5,7 @@ import (
 	closecmd "github.com/contextvibes/cli/cmd/project/issues/close" // Imports package closecmd
 	"github.com/contextvibes/cli/cmd/project/issues/create"
 	"github.com/contextvibes/cli/cmd/project/issues/list"
+	issueproject "github.com/contextvibes/cli/cmd/project/issues/project"
 	"github.com/contextvibes/cli/cmd/project/issues/tree"
 	"github.com/contextvibes/cli/cmd/project/issues/view"
 	"github.com/spf13/cobra"
@@ -26,4 +27,5 @@ func init() {
 	IssuesCmd.AddCommand(view.ViewCmd)
 	IssuesCmd.AddCommand(tree.TreeCmd)
 	IssuesCmd.AddCommand(closecmd.CloseCmd) // Updated reference
+	IssuesCmd.AddCommand(issueproject.ProjectCmd)
 }
@@ -0,0 +1,22 @@
+package list
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/workitem"
+)
+
+// SetProvider makes ListCmd use provider for the duration of the test.
+func SetProvider(t *testing.T, provider workitem.Provider) {
+	t.Helper()
+
+	original := newProvider
+	newProvider = func(context.Context, *slog.Logger, *config.Config) (workitem.Provider, error) {
+		return provider, nil
+	}
+
+	t.Cleanup(func() { newProvider = original })
+}
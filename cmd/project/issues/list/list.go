@@ -4,8 +4,13 @@ package list
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/contextvibes/cli/cmd/project/issues/internal"
 	"github.com/contextvibes/cli/internal/cmddocs"
@@ -23,16 +28,28 @@ var listLongDescription string
 //nolint:gochecknoglobals // Cobra flags require package-level variables.
 var (
 	issueAssignee string
-	issueLabel    string
+	issueLabels   []string
 	issueState    string
 	issueLimit    int
 	fullView      bool
+	jsonOutput    bool
 )
 
-// newProvider is a factory function that returns the configured work item provider.
+var (
+	// ErrInvalidState is returned when --state is not open, closed or all.
+	ErrInvalidState = errors.New("invalid state")
+	// ErrInvalidLimit is returned when --limit is not a positive number.
+	ErrInvalidLimit = errors.New("limit must be greater than zero")
+)
+
+// newProvider is the factory used to obtain the work item provider. It is a
+// variable so tests can substitute a fake provider.
 //
+//nolint:gochecknoglobals // Replaceable factory for tests.
+var newProvider = defaultProvider
 
-func newProvider(
+// defaultProvider returns the work item provider configured in .contextvibes.yaml.
+func defaultProvider(
 	ctx context.Context,
 	logger *slog.Logger,
 	cfg *config.Config,
@@ -64,10 +81,21 @@ func newProvider(
 var ListCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
+	Example: `  contextvibes project issues list
+  contextvibes project issues list --label bug --label p1 --assignee octocat
+  contextvibes project issues list --state all --limit 100 --json`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
 		ctx := cmd.Context()
 
+		listOpts, err := buildListOptions()
+		if err != nil {
+			presenter.Error("%v", err)
+
+			return err
+		}
+
 		provider, err := newProvider(ctx, globals.AppLogger, globals.LoadedAppConfig)
 		if err != nil {
 			presenter.Error("Failed to initialize work item provider: %v", err)
@@ -75,22 +103,10 @@ var ListCmd = &cobra.Command{
 			return err
 		}
 
-		//nolint:exhaustruct // Partial options are valid.
-		listOpts := workitem.ListOptions{
-			Limit:    issueLimit,
-			Assignee: issueAssignee,
-		}
-		if issueLabel != "" {
-			listOpts.Labels = []string{issueLabel}
-		}
-		switch issueState {
-		case "closed":
-			listOpts.State = workitem.StateClosed
-		default:
-			listOpts.State = workitem.StateOpen
+		if !jsonOutput {
+			presenter.Summary("Fetching Work Items...")
 		}
 
-		presenter.Summary("Fetching Work Items...")
 		items, err := provider.ListItems(ctx, listOpts)
 		if err != nil {
 			presenter.Error("Failed to list work items: %v", err)
@@ -98,6 +114,10 @@ var ListCmd = &cobra.Command{
 			return fmt.Errorf("failed to list items: %w", err)
 		}
 
+		if jsonOutput {
+			return writeJSON(presenter, items)
+		}
+
 		if len(items) == 0 {
 			presenter.Info("No work items found matching the criteria.")
 
@@ -114,17 +134,127 @@ var ListCmd = &cobra.Command{
 				}
 				internal.DisplayWorkItem(presenter, detailedItem)
 			}
-		} else {
-			for _, item := range items {
-				//nolint:errcheck // Printing to stdout is best effort.
-				fmt.Fprintf(presenter.Out(), "#%d [%s] %s\n", item.Number, item.State, item.Title)
-			}
+
+			return nil
 		}
 
-		return nil
+		return writeTable(presenter, items)
 	},
 }
 
+// buildListOptions translates the command flags into provider ListOptions.
+func buildListOptions() (workitem.ListOptions, error) {
+	if issueLimit <= 0 {
+		return workitem.ListOptions{}, fmt.Errorf("%w: %d", ErrInvalidLimit, issueLimit)
+	}
+
+	//nolint:exhaustruct // Pagination starts at the provider's first page.
+	listOpts := workitem.ListOptions{
+		Limit:    issueLimit,
+		Assignee: issueAssignee,
+		Labels:   issueLabels,
+	}
+
+	switch strings.ToLower(issueState) {
+	case "open":
+		listOpts.State = workitem.StateOpen
+	case "closed":
+		listOpts.State = workitem.StateClosed
+	case "all":
+		listOpts.State = workitem.StateAll
+	default:
+		return workitem.ListOptions{}, fmt.Errorf(
+			"%w '%s': expected open, closed or all",
+			ErrInvalidState,
+			issueState,
+		)
+	}
+
+	return listOpts, nil
+}
+
+// writeTable renders the items as an aligned table on the presenter's output.
+func writeTable(presenter *ui.Presenter, items []workitem.WorkItem) error {
+	//nolint:mnd // Standard tabwriter padding.
+	table := tabwriter.NewWriter(presenter.Out(), 0, 0, 2, ' ', 0)
+
+	//nolint:errcheck // Writes to the tabwriter buffer cannot fail.
+	fmt.Fprintln(table, "NUMBER\tSTATE\tTYPE\tTITLE\tLABELS\tASSIGNEES")
+
+	for _, item := range items {
+		//nolint:errcheck // Writes to the tabwriter buffer cannot fail.
+		fmt.Fprintf(
+			table,
+			"#%d\t%s\t%s\t%s\t%s\t%s\n",
+			item.Number,
+			item.State,
+			item.Type,
+			item.Title,
+			strings.Join(item.Labels, ", "),
+			strings.Join(item.Assignees, ", "),
+		)
+	}
+
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write issue table: %w", err)
+	}
+
+	return nil
+}
+
+// issueJSON is the machine-readable form of a listed work item.
+type issueJSON struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	State     string    `json:"state"`
+	Type      string    `json:"type"`
+	URL       string    `json:"url"`
+	Author    string    `json:"author"`
+	Labels    []string  `json:"labels"`
+	Assignees []string  `json:"assignees"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// writeJSON encodes the items as an indented JSON array, which is empty rather
+// than null when nothing matched.
+func writeJSON(presenter *ui.Presenter, items []workitem.WorkItem) error {
+	out := make([]issueJSON, 0, len(items))
+	for _, item := range items {
+		out = append(out, issueJSON{
+			Number:    item.Number,
+			Title:     item.Title,
+			State:     string(item.State),
+			Type:      string(item.Type),
+			URL:       item.URL,
+			Author:    item.Author,
+			Labels:    nonNil(item.Labels),
+			Assignees: nonNil(item.Assignees),
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+
+	encoder := json.NewEncoder(presenter.Out())
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(out)
+	if err != nil {
+		return fmt.Errorf("failed to encode work items as JSON: %w", err)
+	}
+
+	return nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
 //nolint:gochecknoinits // Cobra requires init() for command registration.
 func init() {
 	desc, err := cmddocs.ParseAndExecute(listLongDescription, nil)
@@ -136,11 +266,14 @@ func init() {
 	ListCmd.Long = desc.Long
 
 	ListCmd.Flags().StringVarP(&issueAssignee, "assignee", "a", "", "Filter by assignee")
-	ListCmd.Flags().StringVarP(&issueLabel, "label", "l", "", "Filter by label")
+	ListCmd.Flags().
+		StringSliceVarP(&issueLabels, "label", "l", nil, "Filter by label (repeatable; items must have all labels)")
 	ListCmd.Flags().
 		StringVarP(&issueState, "state", "s", "open", "Filter by state (open, closed, all)")
 	//nolint:mnd // 30 is a reasonable default limit.
 	ListCmd.Flags().IntVarP(&issueLimit, "limit", "L", 30, "Maximum number of issues to return")
 	ListCmd.Flags().
 		BoolVar(&fullView, "full", false, "Display the full details for each issue found")
+	ListCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the issues as JSON")
+	ListCmd.MarkFlagsMutuallyExclusive("json", "full")
 }
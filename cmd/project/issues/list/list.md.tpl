@@ -1,4 +1,11 @@
 # List project issues.
 
-Lists work items (issues) from the configured provider.
-Supports filtering by state, assignee, and labels.
+Lists work items (issues) from the configured provider as a table of number,
+state, type, title, labels and assignees.
+
+Filter with `--state` (open, closed or all), `--assignee` and `--label`; repeat
+`--label` (or pass a comma-separated list) to require several labels. `--limit`
+caps the number of items returned.
+
+Use `--json` for machine-readable output, or `--full` to show each issue's
+details and body.
@@ -0,0 +1,136 @@
+package list_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/project/issues/list"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/workitem"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider returns fixed items and records the options it was listed with.
+type fakeProvider struct {
+	workitem.Provider
+
+	items []workitem.WorkItem
+	opts  []workitem.ListOptions
+}
+
+func (f *fakeProvider) ListItems(_ context.Context, options workitem.ListOptions) ([]workitem.WorkItem, error) {
+	f.opts = append(f.opts, options)
+
+	return f.items, nil
+}
+
+//nolint:exhaustruct // Only the listed fields matter.
+var sampleItems = []workitem.WorkItem{
+	{
+		Number:    12,
+		Title:     "Login fails with expired session",
+		State:     workitem.StateOpen,
+		Type:      workitem.TypeBug,
+		Labels:    []string{"auth", "p1"},
+		Assignees: []string{"octocat"},
+	},
+	{Number: 7, Title: "Tidy docs", State: workitem.StateClosed, Type: workitem.TypeChore},
+}
+
+func runList(t *testing.T, provider *fakeProvider, args ...string) (string, error) {
+	t.Helper()
+
+	list.SetProvider(t, provider)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+
+	cmd := *list.ListCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs(args)
+
+	for _, name := range []string{"assignee", "state", "limit", "json", "full"} {
+		flag := cmd.Flags().Lookup(name)
+		require.NoError(t, flag.Value.Set(flag.DefValue))
+		flag.Changed = false
+	}
+
+	labels, ok := cmd.Flags().Lookup("label").Value.(pflag.SliceValue)
+	require.True(t, ok)
+	require.NoError(t, labels.Replace(nil))
+	cmd.Flags().Lookup("label").Changed = false
+
+	err := cmd.Execute()
+
+	return outBuf.String() + errBuf.String(), err
+}
+
+//nolint:paralleltest // ListCmd uses global state which is not thread-safe.
+func TestListCmd_ListOptions(t *testing.T) {
+	provider := &fakeProvider{Provider: nil, items: nil, opts: nil}
+
+	_, err := runList(t, provider,
+		"--label", "bug", "--label", "p1", "--assignee", "octocat", "--state", "all", "--limit", "5")
+	require.NoError(t, err)
+
+	_, err = runList(t, provider)
+	require.NoError(t, err)
+
+	require.Len(t, provider.opts, 2)
+	//nolint:exhaustruct // Page is left to the provider.
+	assert.Equal(t, workitem.ListOptions{
+		State:    workitem.StateAll,
+		Labels:   []string{"bug", "p1"},
+		Assignee: "octocat",
+		Limit:    5,
+	}, provider.opts[0])
+	//nolint:exhaustruct // Page is left to the provider.
+	assert.Equal(t, workitem.ListOptions{State: workitem.StateOpen, Limit: 30}, provider.opts[1])
+}
+
+//nolint:paralleltest // ListCmd uses global state which is not thread-safe.
+func TestListCmd_InvalidFlags(t *testing.T) {
+	provider := &fakeProvider{Provider: nil, items: nil, opts: nil}
+
+	_, err := runList(t, provider, "--state", "merged")
+	require.ErrorIs(t, err, list.ErrInvalidState)
+
+	_, err = runList(t, provider, "--limit", "0")
+	require.ErrorIs(t, err, list.ErrInvalidLimit)
+
+	assert.Empty(t, provider.opts, "the provider is not called with invalid flags")
+}
+
+//nolint:paralleltest // ListCmd uses global state which is not thread-safe.
+func TestListCmd_OutputFormats(t *testing.T) {
+	provider := &fakeProvider{Provider: nil, items: sampleItems, opts: nil}
+
+	table, err := runList(t, provider)
+	require.NoError(t, err)
+	assert.Regexp(t, `NUMBER\s+STATE\s+TYPE\s+TITLE\s+LABELS\s+ASSIGNEES`, table)
+	assert.Regexp(t, `#12\s+Open\s+Bug\s+Login fails with expired session\s+auth, p1\s+octocat`, table)
+	assert.Regexp(t, `#7\s+Closed\s+Chore\s+Tidy docs`, table)
+
+	out, err := runList(t, provider, "--json")
+	require.NoError(t, err)
+
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &decoded), "JSON output must be pure JSON")
+	require.Len(t, decoded, 2)
+	assert.InDelta(t, 12, decoded[0]["number"], 0)
+	assert.Equal(t, "Bug", decoded[0]["type"])
+	assert.Equal(t, []any{"auth", "p1"}, decoded[0]["labels"])
+	assert.Equal(t, []any{}, decoded[1]["labels"])
+
+	empty, err := runList(t, &fakeProvider{Provider: nil, items: nil, opts: nil}, "--json")
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", empty)
+}
@@ -0,0 +1,186 @@
+// Package add provides the command to add an issue to a GitHub Project.
+package add
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workitem"
+	wigithub "github.com/contextvibes/cli/internal/workitem/github"
+	"github.com/spf13/cobra"
+)
+
+//go:embed add.md.tpl
+var addLongDescription string
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var projectNumber int
+
+var (
+	// ErrInvalidIssueNumber is returned when the issue argument is not a positive number.
+	ErrInvalidIssueNumber = errors.New("invalid issue number")
+	// ErrInvalidProjectNumber is returned when --project is not a positive number.
+	ErrInvalidProjectNumber = errors.New("--project must be a positive project number")
+	// ErrMissingNodeID is returned when the provider returns an issue without a node ID.
+	ErrMissingNodeID = errors.New("issue has no node ID")
+)
+
+// newProvider and newGHClient are the factories used to reach the issue tracker.
+// They are variables so tests can substitute fakes.
+//
+//nolint:gochecknoglobals // Replaceable factories for tests.
+var (
+	newProvider = defaultProvider
+	newGHClient = defaultGHClient
+)
+
+// defaultProvider returns the work item provider configured in .contextvibes.yaml.
+func defaultProvider(
+	ctx context.Context,
+	logger *slog.Logger,
+	cfg *config.Config,
+) (workitem.Provider, error) {
+	switch cfg.Project.Provider {
+	case "github", "":
+		//nolint:wrapcheck // Factory function.
+		return wigithub.New(ctx, logger, cfg)
+	default:
+		//nolint:err113 // Dynamic error is appropriate here.
+		return nil, fmt.Errorf(
+			"unsupported work item provider '%s' specified in .contextvibes.yaml",
+			cfg.Project.Provider,
+		)
+	}
+}
+
+// defaultGHClient returns a GitHub client for the repository behind the default remote.
+func defaultGHClient(
+	ctx context.Context,
+	logger *slog.Logger,
+	cfg *config.Config,
+) (*github.Client, error) {
+	//nolint:exhaustruct // Partial config is sufficient for discovery.
+	gitClient, err := git.NewClient(ctx, ".", git.GitClientConfig{
+		Executor: globals.ExecClient.UnderlyingExecutor(),
+		Logger:   logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize git client for repo discovery: %w", err)
+	}
+
+	remoteURL, err := gitClient.GetRemoteURL(ctx, cfg.Git.DefaultRemote)
+	if err != nil {
+		return nil, fmt.Errorf("could not get remote URL for '%s': %w", cfg.Git.DefaultRemote, err)
+	}
+
+	remote, err := github.ParseRemote(remoteURL, cfg.Project.GitHubHost)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not parse owner/repo from remote URL '%s': %w",
+			remoteURL,
+			err,
+		)
+	}
+
+	client, err := github.NewClientForHost(ctx, logger, remote.Host, remote.Owner, remote.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create github client: %w", err)
+	}
+
+	return client, nil
+}
+
+// AddCmd represents the project issues project add command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var AddCmd = &cobra.Command{
+	Use:     "add <issue-number> --project <project-number>",
+	Example: `  contextvibes project issues project add 42 --project 3`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+
+		issueNumber, err := strconv.Atoi(args[0])
+		if err != nil || issueNumber <= 0 {
+			return fmt.Errorf("%w: '%s'", ErrInvalidIssueNumber, args[0])
+		}
+
+		if projectNumber <= 0 {
+			return ErrInvalidProjectNumber
+		}
+
+		provider, err := newProvider(ctx, globals.AppLogger, globals.LoadedAppConfig)
+		if err != nil {
+			presenter.Error("Failed to initialize work item provider: %v", err)
+
+			return err
+		}
+
+		client, err := newGHClient(ctx, globals.AppLogger, globals.LoadedAppConfig)
+		if err != nil {
+			presenter.Error("Failed to initialize GitHub client: %v", err)
+
+			return err
+		}
+
+		presenter.Summary("Adding issue #%d to project #%d...", issueNumber, projectNumber)
+
+		project, err := client.GetProjectByNumber(ctx, projectNumber)
+		if err != nil {
+			presenter.Error("Failed to find project #%d: %v", projectNumber, err)
+
+			return fmt.Errorf("failed to resolve project: %w", err)
+		}
+
+		item, err := provider.GetItem(ctx, issueNumber, false)
+		if err != nil {
+			presenter.Error("Failed to find issue #%d: %v", issueNumber, err)
+
+			return fmt.Errorf("failed to resolve issue: %w", err)
+		}
+
+		if item.ID == "" {
+			return fmt.Errorf("%w: #%d", ErrMissingNodeID, issueNumber)
+		}
+
+		err = client.AddIssueToProject(ctx, project.ID, item.ID)
+		if err != nil {
+			presenter.Error("Failed to add issue #%d to project '%s': %v", issueNumber, project.Title, err)
+
+			return fmt.Errorf("failed to add issue to project: %w", err)
+		}
+
+		presenter.Success("Added issue #%d to project '%s': %s", issueNumber, project.Title, project.URL)
+
+		return nil
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(addLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	AddCmd.Short = desc.Short
+	AddCmd.Long = desc.Long
+
+	AddCmd.Flags().IntVarP(&projectNumber, "project", "p", 0, "The number of the GitHub Project (required)")
+
+	//nolint:noinlineerr // Inline check is standard for flag marking.
+	if err := AddCmd.MarkFlagRequired("project"); err != nil {
+		panic(err)
+	}
+}
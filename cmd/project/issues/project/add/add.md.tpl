@@ -0,0 +1,10 @@
+# Add an issue to a GitHub Project.
+
+Adds an existing issue to a GitHub Project (V2) owned by the repository's
+organization. The project is given by its number, as shown in its URL
+(`https://github.com/orgs/<org>/projects/<number>`).
+
+The project's and the issue's GraphQL node IDs are resolved automatically; on
+success the project URL is printed.
+
+To pick the project and issues from a menu instead, use `contextvibes project board add`.
@@ -0,0 +1,130 @@
+package add_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/project/issues/project/add"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/workitem"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issueProvider returns issues with node IDs from a map keyed by number.
+type issueProvider struct {
+	workitem.Provider
+
+	nodeIDs map[int]string
+}
+
+func (p *issueProvider) GetItem(_ context.Context, number int, _ bool) (*workitem.WorkItem, error) {
+	//nolint:exhaustruct // Only the identifiers matter.
+	return &workitem.WorkItem{ID: p.nodeIDs[number], Number: number}, nil
+}
+
+// projectsAPI stubs the GraphQL endpoint: project #3 exists, and every
+// addProjectV2ItemById input is recorded.
+type projectsAPI struct {
+	added []map[string]any
+}
+
+func (a *projectsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	var data any
+
+	switch {
+	case strings.Contains(req.Query, "projectV2(number: $number)"):
+		var project any
+		if req.Variables["number"] == float64(3) {
+			project = map[string]any{
+				"id":     "PVT_kwDO123",
+				"title":  "Roadmap",
+				"number": 3,
+				"url":    "https://github.com/orgs/octo/projects/3",
+			}
+		}
+
+		data = map[string]any{"organization": map[string]any{"projectV2": project}}
+	case strings.Contains(req.Query, "addProjectV2ItemById"):
+		input, _ := req.Variables["input"].(map[string]any)
+		a.added = append(a.added, input)
+		data = map[string]any{"addProjectV2ItemById": map[string]any{"item": map[string]any{"id": "PVTI_1"}}}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	//nolint:errchkjson // Test fixture encoding cannot fail.
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func runAdd(t *testing.T, api *projectsAPI, args ...string) (string, error) {
+	t.Helper()
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client := github.NewClientWithAPIs(
+		nil,
+		githubv4.NewEnterpriseClient(server.URL, server.Client()),
+		slog.New(slog.DiscardHandler),
+		"octo",
+		"widgets",
+	)
+	add.SetClients(t, &issueProvider{Provider: nil, nodeIDs: map[int]string{42: "I_kwDO456"}}, client)
+
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+
+	cmd := *add.AddCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+
+	return outBuf.String() + errBuf.String(), err
+}
+
+//nolint:paralleltest // AddCmd uses global state which is not thread-safe.
+func TestAddCmd_ResolvesAndAdds(t *testing.T) {
+	api := &projectsAPI{added: nil}
+
+	out, err := runAdd(t, api, "42", "--project", "3")
+	require.NoError(t, err)
+
+	assert.Equal(t, []map[string]any{{"projectId": "PVT_kwDO123", "contentId": "I_kwDO456"}}, api.added)
+	assert.Contains(t, out, "Added issue #42 to project 'Roadmap': https://github.com/orgs/octo/projects/3")
+}
+
+//nolint:paralleltest // AddCmd uses global state which is not thread-safe.
+func TestAddCmd_Errors(t *testing.T) {
+	api := &projectsAPI{added: nil}
+
+	_, err := runAdd(t, api, "42", "--project", "9")
+	require.ErrorIs(t, err, github.ErrProjectNotFound)
+
+	_, err = runAdd(t, api, "7", "--project", "3")
+	require.ErrorIs(t, err, add.ErrMissingNodeID)
+
+	_, err = runAdd(t, api, "abc", "--project", "3")
+	require.ErrorIs(t, err, add.ErrInvalidIssueNumber)
+
+	assert.Empty(t, api.added, "nothing is added when resolution fails")
+}
@@ -0,0 +1,26 @@
+package add
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/workitem"
+)
+
+// SetClients makes AddCmd use provider and client for the duration of the test.
+func SetClients(t *testing.T, provider workitem.Provider, client *github.Client) {
+	t.Helper()
+
+	originalProvider, originalClient := newProvider, newGHClient
+	newProvider = func(context.Context, *slog.Logger, *config.Config) (workitem.Provider, error) {
+		return provider, nil
+	}
+	newGHClient = func(context.Context, *slog.Logger, *config.Config) (*github.Client, error) {
+		return client, nil
+	}
+
+	t.Cleanup(func() { newProvider, newGHClient = originalProvider, originalClient })
+}
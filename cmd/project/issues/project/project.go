@@ -0,0 +1,20 @@
+// Package project provides commands to manage the GitHub Projects an issue belongs to.
+package project
+
+import (
+	"github.com/contextvibes/cli/cmd/project/issues/project/add"
+	"github.com/spf13/cobra"
+)
+
+// ProjectCmd represents the base command for the 'issues project' subcommand group.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var ProjectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Manage the GitHub Projects issues belong to.",
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	ProjectCmd.AddCommand(add.AddCmd)
+}
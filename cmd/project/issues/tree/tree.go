@@ -160,11 +160,9 @@ func printFullTree(p *ui.Presenter, item *workitem.WorkItem, depth int) {
 		for _, comment := range item.Comments {
 			_, _ = p.Out().Write([]byte(indent))
 			p.Header(
-				fmt.Sprintf(
-					"Comment by %s on %s",
-					comment.Author,
-					comment.CreatedAt.Format("2006-01-02"),
-				),
+				"Comment by %s on %s",
+				comment.Author,
+				comment.CreatedAt.Format("2006-01-02"),
 			)
 			// Indent the body of the comment
 			for line := range strings.SplitSeq(comment.Body, "\n") {
@@ -96,11 +96,9 @@ var ViewCmd = &cobra.Command{
 			fmt.Fprintf(presenter.Out(), "\n--- Comments (%d) ---\n\n", len(item.Comments))
 			for _, comment := range item.Comments {
 				presenter.Header(
-					fmt.Sprintf(
-						"Comment by %s on %s",
-						comment.Author,
-						comment.CreatedAt.Format("2006-01-02"),
-					),
+					"Comment by %s on %s",
+					comment.Author,
+					comment.CreatedAt.Format("2006-01-02"),
 				)
 				//nolint:errcheck // Printing to stdout is best effort.
 				fmt.Fprintln(presenter.Out(), comment.Body)
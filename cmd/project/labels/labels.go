@@ -3,6 +3,7 @@ package labels
 
 import (
 	"github.com/contextvibes/cli/cmd/project/labels/create"
+	labelsync "github.com/contextvibes/cli/cmd/project/labels/sync"
 	"github.com/spf13/cobra"
 )
 
@@ -18,4 +19,5 @@ var LabelsCmd = &cobra.Command{
 //nolint:gochecknoinits // Cobra requires init() for command registration.
 func init() {
 	LabelsCmd.AddCommand(create.CreateCmd)
+	LabelsCmd.AddCommand(labelsync.SyncCmd)
 }
@@ -0,0 +1,134 @@
+// Package sync provides the command to create the configured issue labels.
+package sync
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workitem"
+	"github.com/contextvibes/cli/internal/workitem/github"
+	"github.com/spf13/cobra"
+)
+
+//go:embed sync.md.tpl
+var syncLongDescription string
+
+// ErrLabelSyncFailed is returned when one or more labels could not be created.
+var ErrLabelSyncFailed = errors.New("label sync failed")
+
+// newProvider is the factory used to obtain the work item provider. It is a
+// variable so tests can substitute a fake provider.
+//
+//nolint:gochecknoglobals // Replaceable factory for tests.
+var newProvider = defaultProvider
+
+// defaultProvider returns the work item provider configured in .contextvibes.yaml.
+func defaultProvider(
+	ctx context.Context,
+	logger *slog.Logger,
+	cfg *config.Config,
+) (workitem.Provider, error) {
+	switch cfg.Project.Provider {
+	case "github", "":
+		//nolint:wrapcheck // Factory function.
+		return github.New(ctx, logger, cfg)
+	default:
+		//nolint:err113 // Dynamic error is appropriate here.
+		return nil, fmt.Errorf(
+			"unsupported work item provider '%s' specified in .contextvibes.yaml",
+			cfg.Project.Provider,
+		)
+	}
+}
+
+// SyncCmd represents the project labels sync command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var SyncCmd = &cobra.Command{
+	Use:     "sync",
+	Example: `  contextvibes project labels sync`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+
+		desired := globals.LoadedAppConfig.Project.Labels
+		if len(desired) == 0 {
+			presenter.Info("No labels are defined in .contextvibes.yaml.")
+			presenter.Advice("Add a 'project.labels' list with a name, color and description for each label.")
+
+			return nil
+		}
+
+		provider, err := newProvider(ctx, globals.AppLogger, globals.LoadedAppConfig)
+		if err != nil {
+			presenter.Error("Failed to initialize work item provider: %v", err)
+
+			return err
+		}
+
+		presenter.Summary("Syncing %d label(s)...", len(desired))
+
+		var created, existing, failed int
+
+		for _, setting := range desired {
+			name := strings.TrimSpace(setting.Name)
+			if name == "" {
+				presenter.Error("Skipping a label with no name.")
+
+				failed++
+
+				continue
+			}
+
+			_, err := provider.CreateLabel(ctx, workitem.Label{
+				Name:        name,
+				Description: setting.Description,
+				Color:       strings.TrimPrefix(setting.Color, "#"),
+			})
+
+			switch {
+			case err == nil:
+				presenter.Success("Created: %s", name)
+
+				created++
+			case errors.Is(err, workitem.ErrLabelExists):
+				presenter.Detail("Exists: %s", name)
+
+				existing++
+			default:
+				presenter.Error("Failed: %s (%v)", name, err)
+
+				failed++
+			}
+		}
+
+		presenter.Newline()
+		presenter.Info("Labels: %d created, %d existing, %d failed.", created, existing, failed)
+
+		if failed > 0 {
+			return fmt.Errorf("%w: %d of %d label(s) could not be created", ErrLabelSyncFailed, failed, len(desired))
+		}
+
+		return nil
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(syncLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	SyncCmd.Short = desc.Short
+	SyncCmd.Long = desc.Long
+}
@@ -0,0 +1,18 @@
+# Create the labels defined in .contextvibes.yaml.
+
+Reads the desired issue labels from `project.labels` in `.contextvibes.yaml` and
+creates any that the issue tracker does not have yet. Labels that already exist
+are left untouched, so the command is safe to run repeatedly.
+
+```yaml
+project:
+  labels:
+    - name: bug
+      color: d73a4a
+      description: Something isn't working
+    - name: docs
+      color: 0075ca
+```
+
+Each label is reported as created, existing or failed; the command fails if any
+label could not be created.
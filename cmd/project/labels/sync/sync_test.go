@@ -0,0 +1,119 @@
+package sync_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	labelsync "github.com/contextvibes/cli/cmd/project/labels/sync"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/workitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errForbidden = errors.New("403 forbidden")
+
+// labelProvider treats existing labels as already defined, fails the labels in
+// failing, and records every label it is asked to create.
+type labelProvider struct {
+	workitem.Provider
+
+	existing  map[string]bool
+	failing   map[string]bool
+	requested []workitem.Label
+}
+
+func (p *labelProvider) CreateLabel(_ context.Context, label workitem.Label) (*workitem.Label, error) {
+	p.requested = append(p.requested, label)
+
+	switch {
+	case p.existing[label.Name]:
+		return nil, fmt.Errorf("%w: '%s'", workitem.ErrLabelExists, label.Name)
+	case p.failing[label.Name]:
+		return nil, errForbidden
+	}
+
+	return &label, nil
+}
+
+func runSync(t *testing.T, provider *labelProvider, labels []config.LabelSetting) (string, error) {
+	t.Helper()
+
+	labelsync.SetProvider(t, provider)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+	globals.LoadedAppConfig.Project.Labels = labels
+
+	cmd := *labelsync.SyncCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs(nil)
+
+	err := cmd.Execute()
+
+	return outBuf.String() + errBuf.String(), err
+}
+
+//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+func TestSyncCmd_MixedLabels(t *testing.T) {
+	provider := &labelProvider{
+		Provider:  nil,
+		existing:  map[string]bool{"bug": true},
+		failing:   nil,
+		requested: nil,
+	}
+
+	out, err := runSync(t, provider, []config.LabelSetting{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "docs", Color: "#0075ca", Description: "Documentation updates"},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "Exists: bug")
+	assert.Contains(t, out, "Created: docs")
+	assert.Contains(t, out, "Labels: 1 created, 1 existing, 0 failed.")
+	assert.Equal(t, []workitem.Label{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "docs", Color: "0075ca", Description: "Documentation updates"},
+	}, provider.requested, "colors are sent without a leading #")
+}
+
+//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+func TestSyncCmd_Failures(t *testing.T) {
+	provider := &labelProvider{
+		Provider:  nil,
+		existing:  nil,
+		failing:   map[string]bool{"p1": true},
+		requested: nil,
+	}
+
+	out, err := runSync(t, provider, []config.LabelSetting{
+		{Name: "p1", Color: "b60205", Description: ""},
+		{Name: "p2", Color: "fbca04", Description: ""},
+		{Name: " ", Color: "", Description: ""},
+	})
+	require.ErrorIs(t, err, labelsync.ErrLabelSyncFailed)
+
+	assert.Contains(t, out, "Failed: p1 (403 forbidden)")
+	assert.Contains(t, out, "Created: p2")
+	assert.Contains(t, out, "Labels: 1 created, 0 existing, 2 failed.")
+	assert.Len(t, provider.requested, 2, "a label with no name is not sent")
+}
+
+//nolint:paralleltest // SyncCmd uses global state which is not thread-safe.
+func TestSyncCmd_NoLabelsConfigured(t *testing.T) {
+	provider := &labelProvider{Provider: nil, existing: nil, failing: nil, requested: nil}
+
+	out, err := runSync(t, provider, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "No labels are defined")
+	assert.Empty(t, provider.requested)
+}
@@ -0,0 +1,35 @@
+package onboard
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	gh "github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/workitem"
+)
+
+// TrackClients replaces the provider and GitHub client factories with ones
+// that fail, and returns a counter of how often OnboardCmd called them.
+func TrackClients(t *testing.T) *int {
+	t.Helper()
+
+	calls := 0
+	originalProvider, originalGHClient := newProvider, newGHClient
+	newProvider = func(context.Context, *slog.Logger, *config.Config) (workitem.Provider, error) {
+		calls++
+
+		return nil, errors.New("provider constructed")
+	}
+	newGHClient = func(context.Context, *slog.Logger, *config.Config) (*gh.Client, error) {
+		calls++
+
+		return nil, errors.New("GitHub client constructed")
+	}
+
+	t.Cleanup(func() { newProvider, newGHClient = originalProvider, originalGHClient })
+
+	return &calls
+}
@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
-	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -16,7 +16,9 @@ import (
 	"github.com/contextvibes/cli/internal/cmddocs"
 	"github.com/contextvibes/cli/internal/config"
 	"github.com/contextvibes/cli/internal/git"
+	gh "github.com/contextvibes/cli/internal/github"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/network"
 	"github.com/contextvibes/cli/internal/tools"
 	"github.com/contextvibes/cli/internal/ui"
 	"github.com/contextvibes/cli/internal/workitem"
@@ -28,15 +30,24 @@ import (
 var onboardLongDescription string
 
 //nolint:gochecknoglobals // Cobra flags require package-level variables.
-var outputFlag string
+var (
+	outputFlag         string
+	includeOpenPRsFlag bool
+	treeDepthFlag      int
+	clipboardFlag      bool
+)
 
 const (
 	defaultSystemPromptPath = ".idx/airules.md"
 	maxFileSizeKB           = 500
 	concurrentFetches       = 3
-	maxTreeDepth            = 2
 )
 
+// treeIgnoreNames are always left out of the project tree, in addition to .gitignore'd paths.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var treeIgnoreNames = []string{".git", "vendor", "node_modules", ".terraform"}
+
 // OnboardCmd represents the project onboard command.
 //
 //nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
@@ -53,7 +64,7 @@ var OnboardCmd = &cobra.Command{
 
 		// --- Header ---
 		fmt.Fprintf(&finalBuffer, "# AI Session Initialization\n")
-		fmt.Fprintf(&finalBuffer, "Generated: %s\n\n", time.Now().Format(time.RFC3339))
+		fmt.Fprintf(&finalBuffer, "Generated: %s\n\n", globals.Clock.Now().Format(time.RFC3339))
 		//nolint:lll // Long instruction string.
 		fmt.Fprintf(&finalBuffer, "> **User Instruction:** I am initializing a new development session. Below is my System Persona (THEA), the current Project Status (Summary), and the Codebase Snapshot (Describe). Ingest this context, acknowledge you are ready, and await my instructions.\n\n")
 
@@ -71,13 +82,31 @@ var OnboardCmd = &cobra.Command{
 		// --- Layer 2: Strategic Context (Summary) ---
 		presenter.Step("Layer 2: Fetching Project Summary...")
 		summaryContent, err := generateSummary(ctx)
-		if err != nil {
+		if errors.Is(err, network.ErrOffline) {
+			presenter.Info("Offline: skipping the project summary.")
+			fmt.Fprintf(&finalBuffer, "## 2. Project Status (Morning Briefing)\n\n(offline)\n\n")
+		} else if err != nil {
 			presenter.Warning("Failed to generate summary: %v", err)
 			fmt.Fprintf(&finalBuffer, "## 2. Project Status (Morning Briefing)\n\n(Failed to fetch data)\n\n")
 		} else {
 			fmt.Fprintf(&finalBuffer, "## 2. Project Status (Morning Briefing)\n\n%s\n\n", summaryContent)
 		}
 
+		// --- Optional Layer: Work in Flight (Open PRs) ---
+		if includeOpenPRsFlag {
+			presenter.Step("Layer 2b: Fetching Open Pull Requests...")
+			prContent, err := generateOpenPRs(ctx)
+			if errors.Is(err, network.ErrOffline) {
+				presenter.Info("Offline: skipping open pull requests.")
+				fmt.Fprintf(&finalBuffer, "## Open Pull Requests (In Flight)\n\n(offline)\n\n")
+			} else if err != nil {
+				presenter.Warning("Failed to fetch open pull requests: %v", err)
+				fmt.Fprintf(&finalBuffer, "## Open Pull Requests (In Flight)\n\n(Failed to fetch data)\n\n")
+			} else {
+				fmt.Fprintf(&finalBuffer, "## Open Pull Requests (In Flight)\n\n%s\n", prContent)
+			}
+		}
+
 		// --- Layer 3: Technical Context (Describe) ---
 		presenter.Step("Layer 3: Snapshotting Codebase...")
 		describeContent, err := generateDescribe(ctx)
@@ -92,15 +121,37 @@ var OnboardCmd = &cobra.Command{
 			return fmt.Errorf("failed to write output file: %w", err)
 		}
 
-		presenter.Success("Onboarding artifact generated: %s", outputFlag)
+		content := finalBuffer.String()
+		tokens := tools.EstimateTokens(content)
+
+		presenter.Success("Onboarding artifact generated: %s (~%d tokens)", outputFlag, tokens)
+
+		threshold := globals.LoadedAppConfig.Describe.TokenWarningThreshold
+		if tools.ExceedsTokenThreshold(tokens, threshold) {
+			presenter.Warning("Estimated ~%d tokens exceeds the configured threshold of %d.", tokens, threshold)
+		}
+
 		presenter.Info("Upload this file to your AI to start the session.")
 
+		if clipboardFlag {
+			tool, err := tools.CopyToClipboard(ctx, globals.ExecClient, content)
+			if err != nil {
+				presenter.Warning("Could not copy to the clipboard: %v", err)
+			} else {
+				presenter.Info("Copied to the clipboard with %s.", tool)
+			}
+		}
+
 		return nil
 	},
 }
 
 // generateSummary fetches issues and formats them as Markdown.
 func generateSummary(ctx context.Context) (string, error) {
+	if network.Offline(ctx) {
+		return "", network.ErrOffline
+	}
+
 	provider, err := newProvider(ctx, globals.AppLogger, globals.LoadedAppConfig)
 	if err != nil {
 		return "", err
@@ -142,6 +193,41 @@ func generateSummary(ctx context.Context) (string, error) {
 	return buf.String(), nil
 }
 
+// generateOpenPRs lists the repository's open pull requests as Markdown.
+func generateOpenPRs(ctx context.Context) (string, error) {
+	if network.Offline(ctx) {
+		return "", network.ErrOffline
+	}
+
+	client, err := newGHClient(ctx, globals.AppLogger, globals.LoadedAppConfig)
+	if err != nil {
+		return "", err
+	}
+
+	prs, err := client.ListPullRequests(ctx, "open")
+	if err != nil {
+		//nolint:wrapcheck // Client errors are already descriptive.
+		return "", err
+	}
+
+	var buf bytes.Buffer
+
+	if len(prs) == 0 {
+		buf.WriteString("_None found._\n")
+	}
+
+	for _, pr := range prs {
+		draft := ""
+		if pr.Draft {
+			draft = " (draft)"
+		}
+
+		fmt.Fprintf(&buf, "- [#%d] %s — @%s%s\n", pr.Number, pr.Title, pr.Author, draft)
+	}
+
+	return buf.String(), nil
+}
+
 func formatSection(buf *bytes.Buffer, title string, items []workitem.WorkItem, err error) {
 	buf.WriteString("### " + title + "\n")
 
@@ -193,7 +279,16 @@ func generateDescribe(ctx context.Context) (string, error) {
 	// Tree (Native Implementation)
 	tools.AppendSectionHeader(&buf, "Project Structure")
 
-	treeOutput, err := generateNativeTree(workDir)
+	treeDepth, err := globals.LoadedAppConfig.Describe.ResolveTreeDepth(treeDepthFlag)
+	if err != nil {
+		return "", fmt.Errorf("invalid --tree-depth: %w", err)
+	}
+
+	treeOutput, err := tools.GenerateTree(workDir, tools.TreeOptions{
+		MaxDepth:         treeDepth,
+		IgnoreNames:      treeIgnoreNames,
+		RespectGitignore: true,
+	})
 	if err != nil {
 		treeOutput = "Error generating tree: " + err.Error()
 	}
@@ -252,74 +347,55 @@ func shouldIncludeFile(file string) bool {
 	return !isBinaryExt(ext)
 }
 
-// generateNativeTree walks the directory and produces a tree-like string.
-func generateNativeTree(root string) (string, error) {
-	var buf bytes.Buffer
-
-	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
-		if err != nil {
-			return err // Propagate error
-		}
-
-		relPath, _ := filepath.Rel(root, path)
-		if relPath == "." {
-			return nil
-		}
-
-		if isIgnoredDir(entry) {
-			return fs.SkipDir
-		}
-
-		depth := strings.Count(relPath, string(os.PathSeparator))
-		if depth > maxTreeDepth {
-			if entry.IsDir() {
-				return fs.SkipDir
-			}
-
-			return nil
-		}
-
-		indent := strings.Repeat("  ", depth)
-
-		marker := "|-"
-		if depth == 0 {
-			marker = ""
-		}
+func isBinaryExt(ext string) bool {
+	switch ext {
+	case ".png", ".jpg", ".jpeg", ".gif", ".ico", ".pdf", ".exe", ".bin", ".dll", ".so", ".dylib", ".zip", ".tar", ".gz":
+		return true
+	default:
+		return false
+	}
+}
 
-		fmt.Fprintf(&buf, "%s%s %s\n", indent, marker, entry.Name())
+// newProvider and newGHClient build the network clients for Layer 2. They are
+// variables so tests can substitute fakes.
+//
+//nolint:gochecknoglobals // Replaceable factories for tests.
+var (
+	newProvider = defaultProvider
+	newGHClient = defaultGHClient
+)
 
-		return nil
+// defaultGHClient discovers the repository from the configured remote and returns a GitHub client.
+func defaultGHClient(ctx context.Context, logger *slog.Logger, cfg *config.Config) (*gh.Client, error) {
+	//nolint:exhaustruct // Partial config is sufficient for discovery.
+	gitClient, err := git.NewClient(ctx, ".", git.GitClientConfig{
+		Executor: globals.ExecClient.UnderlyingExecutor(),
+		Logger:   logger,
 	})
 	if err != nil {
-		return "", fmt.Errorf("error walking directory: %w", err)
+		return nil, fmt.Errorf("could not initialize git client for repo discovery: %w", err)
 	}
 
-	return buf.String(), nil
-}
-
-func isIgnoredDir(entry fs.DirEntry) bool {
-	if !entry.IsDir() {
-		return false
+	remoteURL, err := gitClient.GetRemoteURL(ctx, cfg.Git.DefaultRemote)
+	if err != nil {
+		return nil, fmt.Errorf("could not get remote URL for '%s': %w", cfg.Git.DefaultRemote, err)
 	}
 
-	name := entry.Name()
-
-	return name == ".git" || name == "vendor" || name == "node_modules" || name == ".terraform"
-}
+	remote, err := gh.ParseRemote(remoteURL, cfg.Project.GitHubHost)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse owner/repo from remote URL '%s': %w", remoteURL, err)
+	}
 
-func isBinaryExt(ext string) bool {
-	switch ext {
-	case ".png", ".jpg", ".jpeg", ".gif", ".ico", ".pdf", ".exe", ".bin", ".dll", ".so", ".dylib", ".zip", ".tar", ".gz":
-		return true
-	default:
-		return false
+	client, err := gh.NewClientForHost(ctx, logger, remote.Host, remote.Owner, remote.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create github client: %w", err)
 	}
-}
 
-// newProvider factory (duplicated to avoid circular deps or complex refactor).
-//
+	return client, nil
+}
 
-func newProvider(
+// defaultProvider factory (duplicated to avoid circular deps or complex refactor).
+func defaultProvider(
 	ctx context.Context,
 	logger *slog.Logger,
 	cfg *config.Config,
@@ -347,4 +423,10 @@ func init() {
 	OnboardCmd.Short = desc.Short
 	OnboardCmd.Long = desc.Long
 	OnboardCmd.Flags().StringVarP(&outputFlag, "output", "o", "_contextvibes.md", "Output file path")
+	OnboardCmd.Flags().
+		BoolVar(&includeOpenPRsFlag, "include-open-prs", false, "Include a list of open pull requests")
+	OnboardCmd.Flags().
+		IntVar(&treeDepthFlag, "tree-depth", 0, "Directory levels in the project tree (default: describe.treeDepth or 2)")
+	OnboardCmd.Flags().
+		BoolVar(&clipboardFlag, "clipboard", false, "Also copy the artifact to the system clipboard")
 }
@@ -0,0 +1,19 @@
+# Generates a complete 'Session Initialization Artifact' for AI onboarding.
+
+Combines the system persona, the project status summary, and a snapshot of the
+codebase into a single Markdown file that can be uploaded to start an AI session.
+
+Use --include-open-prs to add a list of open pull requests, so the AI knows
+what work is already in flight. This layer is skipped with a warning when no
+GitHub token is available.
+
+The project structure shows two directory levels by default, skipping paths
+ignored by .gitignore. Use --tree-depth (or `describe.treeDepth` in
+.contextvibes.yaml) for a shallower or deeper view.
+
+Add --clipboard to also copy the artifact to the system clipboard (using
+pbcopy, wl-copy or xclip, whichever is installed).
+
+The success message includes a rough token estimate of the artifact. Set
+`describe.tokenWarningThreshold` in .contextvibes.yaml to get a warning when
+the estimate exceeds it.
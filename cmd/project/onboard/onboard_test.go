@@ -0,0 +1,193 @@
+// Package onboard_test contains tests for the onboard command.
+package onboard_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/contextvibes/cli/cmd/project/onboard"
+	"github.com/contextvibes/cli/internal/clock"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runOnboard(t *testing.T, args ...string) (string, string) {
+	t.Helper()
+
+	return runOnboardWithFiles(t, nil, args...)
+}
+
+// runOnboardWithFiles runs onboard in a temporary directory seeded with the given files.
+func runOnboardWithFiles(t *testing.T, files map[string]string, args ...string) (string, string) {
+	t.Helper()
+
+	return runOnboardInContext(t, context.Background(), files, args...)
+}
+
+// runOnboardInContext runs onboard like runOnboardWithFiles with the given command context.
+func runOnboardInContext(
+	t *testing.T,
+	ctx context.Context,
+	files map[string]string,
+	args ...string,
+) (string, string) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(tempDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	require.NoError(t, os.WriteFile("main.go", []byte("package main\n"), 0o600))
+
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o750))
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	}
+
+	globals.ExecClient = exec.NewClient(newOnboardExecutor(tempDir))
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+
+	cmd := *onboard.OnboardCmd
+	cmd.SetContext(ctx)
+
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs(append([]string{"-o", "artifact.md"}, args...))
+
+	_ = cmd.Flags().Set("tree-depth", "0")
+
+	require.NoError(t, cmd.Execute())
+
+	artifact, err := os.ReadFile("artifact.md")
+	require.NoError(t, err)
+
+	return string(artifact), errBuf.String()
+}
+
+//nolint:paralleltest // OnboardCmd uses global flags and changes the working directory.
+func TestOnboardCmd_GeneratedTimestamp(t *testing.T) {
+	globals.Clock = clock.Fixed{Time: time.Date(2026, time.March, 4, 5, 6, 7, 0, time.UTC)}
+	t.Cleanup(func() { globals.Clock = clock.Real{} })
+
+	artifact, _ := runOnboard(t)
+
+	assert.Contains(t, artifact, "# AI Session Initialization\nGenerated: 2026-03-04T05:06:07Z\n")
+}
+
+//nolint:paralleltest // OnboardCmd uses global flags and changes the working directory.
+func TestOnboardCmd_OpenPRsLayer(t *testing.T) {
+	//nolint:paralleltest // OnboardCmd uses global flags and changes the working directory.
+	t.Run("layer is omitted when the flag is off", func(t *testing.T) {
+		artifact, _ := runOnboard(t, "--include-open-prs=false")
+
+		assert.Contains(t, artifact, "## 3. Technical Context")
+		assert.NotContains(t, artifact, "Open Pull Requests")
+	})
+
+	//nolint:paralleltest // OnboardCmd uses global flags and changes the working directory.
+	t.Run("layer degrades gracefully when GitHub is unavailable", func(t *testing.T) {
+		artifact, errOut := runOnboard(t, "--include-open-prs")
+
+		assert.Contains(t, artifact, "## Open Pull Requests (In Flight)\n\n(Failed to fetch data)")
+		assert.Contains(t, artifact, "## 3. Technical Context")
+		assert.Contains(t, errOut, "Failed to fetch open pull requests")
+	})
+}
+
+//nolint:paralleltest // OnboardCmd uses global flags and changes the working directory.
+func TestOnboardCmd_Offline(t *testing.T) {
+	clientCalls := onboard.TrackClients(t)
+	ctx := network.WithOffline(context.Background(), true)
+
+	artifact, errOut := runOnboardInContext(t, ctx, nil, "--include-open-prs")
+
+	assert.Zero(t, *clientCalls, "no network client is constructed offline")
+	assert.Contains(t, artifact, "## 2. Project Status (Morning Briefing)\n\n(offline)")
+	assert.Contains(t, artifact, "## Open Pull Requests (In Flight)\n\n(offline)")
+	assert.Contains(t, artifact, "## 3. Technical Context")
+	assert.NotContains(t, errOut, "Failed to")
+}
+
+//nolint:paralleltest // OnboardCmd uses global flags and changes the working directory.
+func TestOnboardCmd_TreeRespectsGitignore(t *testing.T) {
+	artifact, _ := runOnboardWithFiles(t, map[string]string{
+		".gitignore":          "dist/\n*.log\n",
+		"dist/bundle.js":      "// built\n",
+		"debug.log":           "noise\n",
+		"internal/app/app.go": "package app\n",
+	}, "--include-open-prs=false")
+
+	tree := projectTree(artifact)
+
+	assert.Contains(t, tree, " main.go")
+	assert.Contains(t, tree, "|- app")
+	assert.NotContains(t, tree, "dist")
+	assert.NotContains(t, tree, "bundle.js")
+	assert.NotContains(t, tree, "debug.log")
+}
+
+//nolint:paralleltest // OnboardCmd uses global flags and changes the working directory.
+func TestOnboardCmd_TreeDepth(t *testing.T) {
+	files := map[string]string{
+		"internal/app/handlers/health.go": "package handlers\n",
+		"internal/app/app.go":             "package app\n",
+		"docs/guide/intro.md":             "# Intro\n",
+	}
+
+	shallow, _ := runOnboardWithFiles(t, files, "--include-open-prs=false", "--tree-depth", "1")
+	deep, _ := runOnboardWithFiles(t, files, "--include-open-prs=false", "--tree-depth", "3")
+
+	shallowTree, deepTree := projectTree(shallow), projectTree(deep)
+
+	assert.Less(t, len(shallowTree), len(deepTree))
+	assert.Contains(t, shallowTree, " internal")
+	assert.NotContains(t, shallowTree, "app")
+	assert.Contains(t, deepTree, "|- app.go")
+	assert.Contains(t, deepTree, "|- handlers")
+	assert.NotContains(t, deepTree, "health.go")
+}
+
+// projectTree returns the Project Structure section of an onboarding artifact.
+func projectTree(artifact string) string {
+	start := strings.Index(artifact, "### Project Structure")
+	end := strings.Index(artifact, "### Relevant Code Files")
+
+	return artifact[start:end]
+}
+
+// newOnboardExecutor stubs a clean repository tracking main.go and without remotes.
+func newOnboardExecutor(repoDir string) *exectest.Executor {
+	//nolint:exhaustruct // Recorded fields start empty.
+	return &exectest.Executor{
+		RepoDir: repoDir,
+		Handler: func(call exectest.Call) exectest.Response {
+			switch {
+			case len(call.Args) > 0 && call.Args[0] == "ls-files":
+				return exectest.Response{Stdout: "main.go\n", Stderr: "", Err: nil}
+			case len(call.Args) > 0 && call.Args[0] == "status":
+				return exectest.Response{Stdout: "", Stderr: "", Err: nil}
+			}
+
+			//nolint:err113 // Dynamic error is appropriate here.
+			return exectest.Response{Stdout: "", Stderr: "", Err: errors.New("no such remote")}
+		},
+	}
+}
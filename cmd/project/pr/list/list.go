@@ -0,0 +1,140 @@
+// Package list provides the command to list pull requests.
+package list
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed list.md.tpl
+var listLongDescription string
+
+//nolint:gochecknoglobals // Cobra flags require package-level variables.
+var (
+	prState    string
+	jsonOutput bool
+)
+
+// newGHClient is a factory function that returns a configured GitHub client.
+func newGHClient(
+	ctx context.Context,
+	logger *slog.Logger,
+	cfg *config.Config,
+) (*github.Client, error) {
+	//nolint:exhaustruct // Partial config is sufficient for discovery.
+	gitClient, err := git.NewClient(ctx, ".", git.GitClientConfig{
+		Executor: globals.ExecClient.UnderlyingExecutor(),
+		Logger:   logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize git client for repo discovery: %w", err)
+	}
+
+	remoteURL, err := gitClient.GetRemoteURL(ctx, cfg.Git.DefaultRemote)
+	if err != nil {
+		return nil, fmt.Errorf("could not get remote URL for '%s': %w", cfg.Git.DefaultRemote, err)
+	}
+
+	remote, err := github.ParseRemote(remoteURL, cfg.Project.GitHubHost)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"could not parse owner/repo from remote URL '%s': %w",
+			remoteURL,
+			err,
+		)
+	}
+
+	client, err := github.NewClientForHost(ctx, logger, remote.Host, remote.Owner, remote.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create github client: %w", err)
+	}
+
+	return client, nil
+}
+
+// ListCmd represents the project pr list command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var ListCmd = &cobra.Command{
+	Use:     "list [--state open|closed|all] [--json]",
+	Aliases: []string{"ls"},
+	Example: `  contextvibes project pr list
+  contextvibes project pr list --state all --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+
+		client, err := newGHClient(ctx, globals.AppLogger, globals.LoadedAppConfig)
+		if err != nil {
+			presenter.Error("Failed to initialize GitHub client: %v", err)
+
+			return err
+		}
+
+		prs, err := client.ListPullRequests(ctx, prState)
+		if err != nil {
+			presenter.Error("Failed to list pull requests: %v", err)
+
+			return fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		if jsonOutput {
+			encoder := json.NewEncoder(presenter.Out())
+			encoder.SetIndent("", "  ")
+
+			err = encoder.Encode(prs)
+			if err != nil {
+				return fmt.Errorf("failed to encode pull requests as JSON: %w", err)
+			}
+
+			return nil
+		}
+
+		if len(prs) == 0 {
+			presenter.Info("No pull requests found matching the criteria.")
+
+			return nil
+		}
+
+		presenter.Summary("Pull Requests (%s): %d", prState, len(prs))
+
+		for _, pr := range prs {
+			state := pr.State
+			if pr.Draft {
+				state += ", draft"
+			}
+
+			//nolint:errcheck // Printing to stdout is best effort.
+			fmt.Fprintf(presenter.Out(), "#%d [%s] %s (@%s)\n", pr.Number, state, pr.Title, pr.Author)
+		}
+
+		return nil
+	},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(listLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	ListCmd.Short = desc.Short
+	ListCmd.Long = desc.Long
+
+	ListCmd.Flags().
+		StringVarP(&prState, "state", "s", "open", "Filter by state (open, closed, all)")
+	ListCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the pull requests as JSON")
+}
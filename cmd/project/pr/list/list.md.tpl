@@ -0,0 +1,5 @@
+# List pull requests.
+
+Lists pull requests for the repository behind the configured git remote.
+Use --state to choose open, closed, or all pull requests, and --json for
+machine-readable output.
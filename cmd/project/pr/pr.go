@@ -0,0 +1,21 @@
+// Package pr provides commands to inspect pull requests.
+package pr
+
+import (
+	"github.com/contextvibes/cli/cmd/project/pr/list"
+	"github.com/spf13/cobra"
+)
+
+// PRCmd represents the base command for the 'pr' subcommand group.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var PRCmd = &cobra.Command{
+	Use:     "pr",
+	Short:   "Inspect pull requests.",
+	Aliases: []string{"prs", "pulls"},
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	PRCmd.AddCommand(list.ListCmd)
+}
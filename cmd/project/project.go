@@ -8,6 +8,7 @@ import (
 	"github.com/contextvibes/cli/cmd/project/labels"
 	"github.com/contextvibes/cli/cmd/project/onboard" // Added
 	"github.com/contextvibes/cli/cmd/project/plan"
+	"github.com/contextvibes/cli/cmd/project/pr"
 	"github.com/contextvibes/cli/cmd/project/summary"
 	"github.com/spf13/cobra"
 )
@@ -29,4 +30,5 @@ func init() {
 	ProjectCmd.AddCommand(board.BoardCmd)
 	ProjectCmd.AddCommand(summary.SummaryCmd)
 	ProjectCmd.AddCommand(onboard.OnboardCmd) // Added
+	ProjectCmd.AddCommand(pr.PRCmd)
 }
@@ -0,0 +1,29 @@
+package summary
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/workitem"
+)
+
+// TrackProviders replaces the provider factory with one that fails, and returns
+// a counter of how often SummaryCmd called it.
+func TrackProviders(t *testing.T) *int {
+	t.Helper()
+
+	calls := 0
+	original := newProvider
+	newProvider = func(context.Context, *slog.Logger, *config.Config) (workitem.Provider, error) {
+		calls++
+
+		return nil, errors.New("provider constructed")
+	}
+
+	t.Cleanup(func() { newProvider = original })
+
+	return &calls
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/contextvibes/cli/internal/cmddocs"
 	"github.com/contextvibes/cli/internal/config"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/network"
 	"github.com/contextvibes/cli/internal/ui"
 	"github.com/contextvibes/cli/internal/workitem"
 	"github.com/contextvibes/cli/internal/workitem/github"
@@ -24,6 +25,10 @@ const (
 	maxBugsToList     = 5
 	maxTasksToList    = 10
 	maxEpicsToList    = 5
+
+	bugsHeader  = "[!] Urgent Attention (Bugs)"
+	tasksHeader = "[@] On Your Plate (Assigned to You)"
+	epicsHeader = "[#] Strategic Context (Active Epics)"
 )
 
 // SummaryCmd represents the project summary command.
@@ -36,6 +41,13 @@ var SummaryCmd = &cobra.Command{
 		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
 		ctx := cmd.Context()
 
+		if network.Offline(ctx) {
+			presenter.Summary("Project Morning Briefing")
+			printOfflinePlaceholder(presenter)
+
+			return nil
+		}
+
 		provider, err := newProvider(ctx, globals.AppLogger, globals.LoadedAppConfig)
 		if err != nil {
 			presenter.Error("Failed to initialize work item provider: %v", err)
@@ -77,7 +89,7 @@ var SummaryCmd = &cobra.Command{
 		waitGroup.Wait()
 
 		// --- Render: Urgent Attention ---
-		presenter.Header("[!] Urgent Attention (Bugs)")
+		presenter.Header(bugsHeader)
 		if errBugs != nil {
 			presenter.Warning("Could not fetch bugs: %v", errBugs)
 		} else if len(bugs) == 0 {
@@ -93,7 +105,7 @@ var SummaryCmd = &cobra.Command{
 		presenter.Newline()
 
 		// --- Render: On Your Plate ---
-		presenter.Header("[@] On Your Plate (Assigned to You)")
+		presenter.Header(tasksHeader)
 		if errTasks != nil {
 			presenter.Warning("Could not fetch your tasks: %v", errTasks)
 		} else if len(myTasks) == 0 {
@@ -109,7 +121,7 @@ var SummaryCmd = &cobra.Command{
 		presenter.Newline()
 
 		// --- Render: Strategic Context ---
-		presenter.Header("[#] Strategic Context (Active Epics)")
+		presenter.Header(epicsHeader)
 		if errEpics != nil {
 			presenter.Warning("Could not fetch epics: %v", errEpics)
 		} else if len(epics) == 0 {
@@ -125,6 +137,17 @@ var SummaryCmd = &cobra.Command{
 	},
 }
 
+// printOfflinePlaceholder renders the briefing's sections without fetching them.
+func printOfflinePlaceholder(presenter *ui.Presenter) {
+	for _, header := range []string{bugsHeader, tasksHeader, epicsHeader} {
+		presenter.Header("%s", header)
+		presenter.Info("(offline)")
+		presenter.Newline()
+	}
+
+	presenter.Advice("Run without --offline to fetch the briefing from the work item provider.")
+}
+
 func printItem(p *ui.Presenter, item workitem.WorkItem) {
 	//nolint:errcheck // Printing to stdout is best effort.
 	fmt.Fprintf(p.Out(), "  - [#%d] %s\n", item.Number, item.Title)
@@ -138,10 +161,14 @@ func limit(items []workitem.WorkItem, limitCount int) []workitem.WorkItem {
 	return items
 }
 
-// newProvider is a factory function (duplicated from other cmds, ideally refactored later).
+// newProvider is the factory used to obtain the work item provider. It is a
+// variable so tests can substitute a fake provider.
 //
+//nolint:gochecknoglobals // Replaceable factory for tests.
+var newProvider = defaultProvider
 
-func newProvider(
+// defaultProvider is a factory function (duplicated from other cmds, ideally refactored later).
+func defaultProvider(
 	ctx context.Context,
 	logger *slog.Logger,
 	cfg *config.Config,
@@ -0,0 +1,36 @@
+// Package summary_test contains tests for the project summary command.
+package summary_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/project/summary"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // SummaryCmd uses global state which is not thread-safe.
+func TestSummaryCmd_Offline(t *testing.T) {
+	providerCalls := summary.TrackProviders(t)
+	globals.AppLogger = slog.New(slog.DiscardHandler)
+	globals.LoadedAppConfig = config.GetDefaultConfig()
+
+	cmd := *summary.SummaryCmd
+	out := new(bytes.Buffer)
+	cmd.SetContext(network.WithOffline(context.Background(), true))
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(nil)
+
+	require.NoError(t, cmd.Execute())
+
+	assert.Zero(t, *providerCalls, "no work item provider is constructed offline")
+	assert.Contains(t, out.String(), "Urgent Attention (Bugs)")
+	assert.Contains(t, out.String(), "(offline)")
+}
@@ -2,21 +2,30 @@
 package cmd
 
 import (
-	"io"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
-	"strings"
+	"strconv"
+	"time"
 
+	configcmd "github.com/contextvibes/cli/cmd/config"
 	"github.com/contextvibes/cli/cmd/craft"
 	"github.com/contextvibes/cli/cmd/factory"
 	"github.com/contextvibes/cli/cmd/feedback"
 	"github.com/contextvibes/cli/cmd/library"
 	"github.com/contextvibes/cli/cmd/product"
 	"github.com/contextvibes/cli/cmd/project"
+	"github.com/contextvibes/cli/cmd/security"
 	"github.com/contextvibes/cli/cmd/version"
 	"github.com/contextvibes/cli/internal/config"
 	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exitcode"
 	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/logging"
+	"github.com/contextvibes/cli/internal/network"
+	"github.com/contextvibes/cli/internal/profiling"
+	"github.com/contextvibes/cli/internal/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -30,25 +39,42 @@ var rootCmd = &cobra.Command{
 	Use:   "contextvibes",
 	Short: "Manages project tasks: AI context generation, Git workflow, IaC, etc.",
 	Long:  `ContextVibes: Your Project Development Assistant CLI.`,
-	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		session, err := profiling.Start(cpuProfilePath, memProfilePath)
+		if err != nil {
+			return fmt.Errorf("failed to start profiling: %w", err)
+		}
+		profilingSession = session
+
 		bootstrapOSExecutor := exec.NewOSCommandExecutor(slog.New(slog.DiscardHandler))
 		bootstrapExecClient := exec.NewClient(bootstrapOSExecutor)
 
-		defaultCfg := config.GetDefaultConfig()
-		repoConfigPath, _ := config.FindRepoRootConfigPath(bootstrapExecClient)
-		if repoConfigPath != "" {
-			loadedUserConfig, _ := config.LoadConfig(repoConfigPath)
-			if loadedUserConfig != nil {
-				globals.LoadedAppConfig = config.MergeWithDefaults(loadedUserConfig, defaultCfg)
-			} else {
-				globals.LoadedAppConfig = defaultCfg
+		globals.ConfigPath = configPathValue
+		var configSource string
+
+		globals.LoadedAppConfig, configSource, err = config.LoadEffectiveConfig(bootstrapExecClient, configPathValue)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		logLevel, err := resolveLogLevel(logLevelValue, globals.LoadedAppConfig.Logging.Level)
+		if err != nil {
+			return err
+		}
+
+		fileLevel := logLevel
+		if logLevelAIValue != "" {
+			fileLevel, err = resolveLogLevel(logLevelAIValue, "")
+			if err != nil {
+				return err
 			}
-		} else {
-			globals.LoadedAppConfig = defaultCfg
 		}
 
-		aiLevel := parseLogLevel(logLevelAIValue, slog.LevelDebug)
-		aiOut := io.Discard
+		//nolint:exhaustruct // Sinks are filled in below.
+		logOptions := logging.Options{
+			ConsoleLevel: logLevel,
+			FileLevel:    fileLevel,
+		}
 		loggingEnabled := (globals.LoadedAppConfig.Logging.Enable != nil && *globals.LoadedAppConfig.Logging.Enable) ||
 			aiLogFileFlagValue != ""
 		if loggingEnabled {
@@ -63,71 +89,188 @@ var rootCmd = &cobra.Command{
 				filePermUserRW,
 			)
 			if errLogFile == nil {
-				aiOut = logFileHandle
+				logOptions.File = logFileHandle
+			}
+
+			// With dual output, records written to the AI trace log are also shown
+			// on stderr as text; dualOutput: false keeps them in the file only.
+			if globals.LoadedAppConfig.Behavior.DualOutput {
+				logOptions.Console = os.Stderr
 			}
 		}
-		globals.AppLogger = slog.New(
-			slog.NewJSONHandler(aiOut, &slog.HandlerOptions{
-				Level:       aiLevel,
-				AddSource:   false,
-				ReplaceAttr: nil,
-			}),
-		)
+		globals.AppLogger = logging.NewLogger(logOptions)
 
 		mainOSExecutor := exec.NewOSCommandExecutor(globals.AppLogger)
 		globals.ExecClient = exec.NewClient(mainOSExecutor)
 		globals.AssumeYes = assumeYes
+		globals.Verbose = verbose
 
-		return nil
+		if networkTimeout < 0 {
+			//nolint:err113 // Dynamic error is appropriate here.
+			return errors.New("--timeout cannot be negative")
+		}
+
+		offlineEnv, _ := strconv.ParseBool(os.Getenv(network.OfflineEnvVar))
+		ctx := network.WithOffline(cmd.Context(), offline || offlineEnv)
+
+		// Only an explicit --timeout is carried, so clients with a longer default keep it.
+		if cmd.Flags().Changed("timeout") {
+			ctx = network.WithTimeout(ctx, networkTimeout)
+		}
+
+		cmd.SetContext(ctx)
+
+		if assumeYes && assumeNo {
+			//nolint:err113 // Dynamic error is appropriate here.
+			return errors.New("--yes and --no cannot be used together")
+		}
+
+		events, _ := strconv.ParseBool(os.Getenv(ui.EventsEnvVar))
+		//nolint:exhaustruct // NonInteractive is detected per prompt.
+		ui.SetDefaultOptions(ui.Options{
+			Quiet:     quiet,
+			NoColor:   noColor,
+			Events:    outputEvents || events,
+			AssumeYes: assumeYes,
+			AssumeNo:  assumeNo,
+		})
+
+		return checkConfigFile(ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr()), configSource)
 	},
 }
 
+// checkConfigFile warns about a config file written by a newer CLI and about keys
+// that match no setting. With --strict, unknown keys are an error.
+func checkConfigFile(presenter *ui.Presenter, configSource string) error {
+	err := config.CheckSchemaVersion(globals.LoadedAppConfig)
+	if err != nil {
+		presenter.Warning("%v; consider upgrading contextvibes.", err)
+	}
+
+	if configSource == "" {
+		return nil
+	}
+
+	unknown, err := config.FindUnknownKeys(configSource)
+	if err != nil {
+		return fmt.Errorf("failed to check config file: %w", err)
+	}
+
+	for _, key := range unknown {
+		presenter.Warning("Unknown key in %s: %s", configSource, key)
+	}
+
+	if strict && len(unknown) > 0 {
+		return fmt.Errorf("%w: %d in %s", config.ErrUnknownKeys, len(unknown), configSource)
+	}
+
+	return nil
+}
+
 // Execute runs the root command and handles exit codes.
 func Execute() {
 	err := rootCmd.Execute()
+
+	stopErr := profilingSession.Stop()
+	if stopErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", stopErr)
+	}
+
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exitcode.FromError(err))
 	}
 }
 
 //nolint:gochecknoglobals // Cobra flags require package-level variables.
 var (
+	configPathValue    string
+	logLevelValue      string
 	logLevelAIValue    string
 	aiLogFileFlagValue string
 	assumeYes          bool
+	assumeNo           bool
+	quiet              bool
+	noColor            bool
+	outputEvents       bool
+	strict             bool
+	cpuProfilePath     string
+	memProfilePath     string
+	networkTimeout     time.Duration
+	offline            bool
+	verbose            bool
 )
 
+// profilingSession is started before the selected command runs and stopped by Execute.
+//
+//nolint:gochecknoglobals // Shared between the pre-run hook and Execute.
+var profilingSession *profiling.Session
+
 //nolint:gochecknoinits // Cobra requires init() for command registration.
 func init() {
 	// Set the version for the --version flag
 	rootCmd.Version = globals.AppVersion
 
 	rootCmd.PersistentFlags().
-		StringVar(&logLevelAIValue, "log-level-ai", "debug", "AI (JSON) file log level")
+		StringVar(&configPathValue, "config", "",
+			"Path to a config file to use instead of the repository's "+config.DefaultConfigFileName)
+	rootCmd.PersistentFlags().
+		StringVar(&logLevelValue, "log-level", "",
+			"Log level for all sinks: debug|info|warn|error (default: logging.level or info)")
+	rootCmd.PersistentFlags().
+		StringVar(&logLevelAIValue, "log-level-ai", "", "AI (JSON) file log level, overriding --log-level for the file")
 	rootCmd.PersistentFlags().
 		StringVar(&aiLogFileFlagValue, "ai-log-file", "", "AI (JSON) log file path")
 	rootCmd.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Assume 'yes' to all prompts")
+	rootCmd.PersistentFlags().BoolVar(&assumeNo, "no", false, "Assume 'no' to all confirmation prompts")
+	rootCmd.PersistentFlags().
+		BoolVarP(&quiet, "quiet", "q", false, "Only print errors, warnings and final results")
+	rootCmd.PersistentFlags().
+		BoolVar(&noColor, "no-color", false, "Disable colored output (automatic when output is not a terminal)")
+	rootCmd.PersistentFlags().
+		BoolVar(&outputEvents, "output-events", false,
+			"Print output as newline-delimited JSON events (also "+ui.EventsEnvVar+"=1)")
+	rootCmd.PersistentFlags().
+		BoolVar(&strict, "strict", false, "Fail instead of warning when the config file has unknown keys")
+	rootCmd.PersistentFlags().
+		StringVar(&cpuProfilePath, "cpuprofile", "", "Write a pprof CPU profile of the command to this file")
+	rootCmd.PersistentFlags().
+		StringVar(&memProfilePath, "memprofile", "", "Write a pprof heap profile to this file when the command exits")
+	rootCmd.PersistentFlags().
+		DurationVar(&networkTimeout, "timeout", network.DefaultTimeout,
+			"Abort requests to GitHub, THEA and other remote services after this long (0 lifts the limit)")
+	rootCmd.PersistentFlags().
+		BoolVar(&offline, "offline", false,
+			"Skip network operations, using cached data where available (also "+network.OfflineEnvVar+"=1)")
+	rootCmd.PersistentFlags().
+		BoolVar(&verbose, "verbose", false, "Report each workflow step's elapsed time as soon as it finishes")
 
 	rootCmd.AddCommand(project.ProjectCmd)
 	rootCmd.AddCommand(product.ProductCmd)
 	rootCmd.AddCommand(factory.FactoryCmd)
 	rootCmd.AddCommand(library.LibraryCmd)
 	rootCmd.AddCommand(craft.CraftCmd)
+	rootCmd.AddCommand(security.SecurityCmd)
 	rootCmd.AddCommand(feedback.FeedbackCmd)
+	rootCmd.AddCommand(configcmd.ConfigCmd)
 	rootCmd.AddCommand(version.VersionCmd)
 }
 
-func parseLogLevel(levelStr string, defaultLevel slog.Level) slog.Level {
-	switch strings.ToLower(levelStr) {
-	case "debug":
-		return slog.LevelDebug
-	case "info":
-		return slog.LevelInfo
-	case "warn":
-		return slog.LevelWarn
-	case "error":
-		return slog.LevelError
-	default:
-		return defaultLevel
+// resolveLogLevel parses the flag value when set, otherwise the configured level,
+// otherwise config.DefaultLogLevel.
+func resolveLogLevel(flagValue, configValue string) (slog.Level, error) {
+	name := flagValue
+	if name == "" {
+		name = configValue
+	}
+
+	if name == "" {
+		name = config.DefaultLogLevel
 	}
+
+	level, err := logging.ParseLevel(name)
+	if err != nil {
+		return level, fmt.Errorf("failed to configure logging: %w", err)
+	}
+
+	return level, nil
 }
@@ -0,0 +1,139 @@
+// Package scan provides the command to scan the project for known vulnerabilities.
+package scan
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/cmddocs"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/project"
+	"github.com/contextvibes/cli/internal/security"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+//go:embed scan.md.tpl
+var scanLongDescription string
+
+// ScanCmd represents the security scan command.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var ScanCmd = &cobra.Command{
+	Use:           "scan",
+	Example:       `  contextvibes security scan`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		presenter := ui.NewPresenter(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		ctx := cmd.Context()
+
+		presenter.Summary("Scanning the project for known vulnerabilities.")
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+
+		types, err := project.DetectAll(cwd)
+		if err != nil {
+			return fmt.Errorf("failed to detect project type: %w", err)
+		}
+
+		if !slices.Contains(types, project.Go) {
+			presenter.Info("No Go project detected (found: %s); nothing to scan.", project.JoinTypes(types))
+
+			return nil
+		}
+
+		if !globals.ExecClient.CommandExists("govulncheck") {
+			presenter.Warning("Skipping vulnerability scan: 'govulncheck' is not installed.")
+			presenter.Advice("Install it with 'contextvibes factory tools' or " +
+				"'go install golang.org/x/vuln/cmd/govulncheck@latest'.")
+
+			return nil
+		}
+
+		presenter.Step("Running govulncheck...")
+
+		// With -json, govulncheck exits zero even when it finds vulnerabilities, so
+		// an error means the scan itself failed.
+		stdout, stderr, err := globals.ExecClient.CaptureOutput(ctx, cwd, "govulncheck", "-json", "./...")
+		if err != nil {
+			if msg := strings.TrimSpace(stderr); msg != "" {
+				presenter.Detail("%s", msg)
+			}
+
+			return fmt.Errorf("govulncheck failed: %w", err)
+		}
+
+		report, err := security.ParseGovulncheckJSON(strings.NewReader(stdout))
+		if err != nil {
+			return fmt.Errorf("failed to read govulncheck report: %w", err)
+		}
+
+		return presentReport(presenter, report)
+	},
+}
+
+func presentReport(presenter *ui.Presenter, report *security.Report) error {
+	if len(report.Vulnerabilities) == 0 {
+		presenter.Success("✓ No known vulnerabilities found.")
+
+		return nil
+	}
+
+	for _, vuln := range report.Vulnerabilities {
+		presenter.Newline()
+
+		location := vuln.Module
+		if vuln.Package != "" {
+			location = vuln.Package
+		}
+
+		presenter.Warning("[%s] %s: %s@%s", vuln.Severity, vuln.ID, location, vuln.FoundVersion)
+
+		if vuln.Summary != "" {
+			presenter.Detail("%s", vuln.Summary)
+		}
+
+		if vuln.Symbol != "" {
+			presenter.Detail("Called: %s", vuln.Symbol)
+		}
+
+		if vuln.FixedVersion != "" {
+			presenter.Detail("Fixed in: %s@%s", vuln.Module, vuln.FixedVersion)
+		} else {
+			presenter.Detail("Fixed in: no fix available")
+		}
+
+		if vuln.URL != "" {
+			presenter.Detail("More info: %s", vuln.URL)
+		}
+	}
+
+	presenter.Newline()
+	presenter.Error("Found %d known vulnerabilities (%d high, %d medium, %d low).",
+		len(report.Vulnerabilities),
+		report.Count(security.SeverityHigh),
+		report.Count(security.SeverityMedium),
+		report.Count(security.SeverityLow))
+	presenter.Advice("Upgrade the affected modules with 'go get <module>@<fixed version>', then run 'go mod tidy'.")
+
+	return fmt.Errorf("%w: %d", security.ErrVulnerabilitiesFound, len(report.Vulnerabilities))
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	desc, err := cmddocs.ParseAndExecute(scanLongDescription, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	ScanCmd.Short = desc.Short
+	ScanCmd.Long = desc.Long
+}
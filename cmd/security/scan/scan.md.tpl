@@ -0,0 +1,18 @@
+# Scan the project for known vulnerabilities.
+
+Runs `govulncheck -json ./...` in Go projects and reports every known vulnerability
+in the project's dependencies and Go standard library, with the affected module,
+the version in use and the version that fixes it.
+
+The Go vulnerability database does not score vulnerabilities, so each finding is
+ranked by how directly the project reaches the vulnerable code:
+
+| Severity | Meaning                                                             |
+|----------|---------------------------------------------------------------------|
+| high     | The project calls a vulnerable function                             |
+| medium   | The project imports a vulnerable package but not the affected code  |
+| low      | A vulnerable module is in the dependency graph but not imported     |
+
+The command exits non-zero when any vulnerability is found. Projects without Go
+code are skipped, and so is the scan when `govulncheck` is not installed; run
+`contextvibes factory tools` to install it.
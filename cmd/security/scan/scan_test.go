@@ -0,0 +1,106 @@
+// Package scan_test contains tests for the security scan command.
+package scan_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/cmd/security/scan"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/globals"
+	"github.com/contextvibes/cli/internal/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runScan runs the command in a temporary directory holding files.
+func runScan(t *testing.T, mockExec *exectest.Executor, files ...string) (string, error) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	for _, file := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, file), []byte("module example.com/app\n"), 0o600))
+	}
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	//nolint:usetesting // os.Chdir is required for test setup.
+	require.NoError(t, os.Chdir(tempDir))
+	//nolint:usetesting // os.Chdir is required for test setup.
+	t.Cleanup(func() { require.NoError(t, os.Chdir(originalWd)) })
+
+	globals.ExecClient = exec.NewClient(mockExec)
+
+	cmd := *scan.ScanCmd
+	outBuf, errBuf := new(bytes.Buffer), new(bytes.Buffer)
+	cmd.SetContext(context.Background())
+	cmd.SetOut(outBuf)
+	cmd.SetErr(errBuf)
+	cmd.SetArgs(nil)
+
+	err = cmd.Execute()
+
+	return outBuf.String() + errBuf.String(), err
+}
+
+//nolint:paralleltest // ScanCmd uses global state which is not thread-safe.
+func TestScanCmd(t *testing.T) {
+	//nolint:paralleltest // ScanCmd uses global state which is not thread-safe.
+	t.Run("reports vulnerabilities and fails", func(t *testing.T) {
+		mockExec := newScanExecutor(true, `{"osv":{"id":"GO-2023-1988","summary":"Improper rendering of text nodes"}}
+{"finding":{"osv":"GO-2023-1988","fixed_version":"v0.13.0","trace":[{"module":"golang.org/x/net","version":"v0.10.0","package":"golang.org/x/net/html","function":"Render"}]}}
+`)
+
+		out, err := runScan(t, mockExec, "go.mod")
+		require.ErrorIs(t, err, security.ErrVulnerabilitiesFound)
+		assert.Equal(t, []string{"govulncheck -json ./..."}, mockExec.Commands())
+		assert.Contains(t, out, "[high] GO-2023-1988: golang.org/x/net/html@v0.10.0")
+		assert.Contains(t, out, "Fixed in: golang.org/x/net@v0.13.0")
+		assert.Contains(t, out, "Found 1 known vulnerabilities (1 high, 0 medium, 0 low).")
+	})
+
+	//nolint:paralleltest // ScanCmd uses global state which is not thread-safe.
+	t.Run("passes without findings", func(t *testing.T) {
+		mockExec := newScanExecutor(true, `{"config":{"scanner_name":"govulncheck"}}`)
+
+		out, err := runScan(t, mockExec, "go.mod")
+		require.NoError(t, err)
+		assert.Contains(t, out, "No known vulnerabilities found.")
+	})
+
+	//nolint:paralleltest // ScanCmd uses global state which is not thread-safe.
+	t.Run("skips when govulncheck is missing", func(t *testing.T) {
+		mockExec := newScanExecutor(false, "")
+
+		out, err := runScan(t, mockExec, "go.mod")
+		require.NoError(t, err)
+		assert.Empty(t, mockExec.Commands())
+		assert.Contains(t, out, "'govulncheck' is not installed")
+	})
+
+	//nolint:paralleltest // ScanCmd uses global state which is not thread-safe.
+	t.Run("skips projects without Go", func(t *testing.T) {
+		mockExec := newScanExecutor(true, "")
+
+		out, err := runScan(t, mockExec)
+		require.NoError(t, err)
+		assert.Empty(t, mockExec.Commands())
+		assert.Contains(t, out, "No Go project detected")
+	})
+}
+
+// newScanExecutor stubs govulncheck: missing unless installed, otherwise
+// answering every run with output.
+func newScanExecutor(installed bool, output string) *exectest.Executor {
+	//nolint:exhaustruct // Recorded fields start empty.
+	return &exectest.Executor{
+		Handler: func(exectest.Call) exectest.Response {
+			return exectest.Response{Stdout: output, Stderr: "", Err: nil}
+		},
+		Missing: map[string]bool{"govulncheck": !installed},
+	}
+}
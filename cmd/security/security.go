@@ -0,0 +1,20 @@
+// Package security groups commands that check the project for security issues.
+package security
+
+import (
+	"github.com/contextvibes/cli/cmd/security/scan"
+	"github.com/spf13/cobra"
+)
+
+// SecurityCmd represents the base command for the 'security' subcommand group.
+//
+//nolint:exhaustruct,gochecknoglobals // Cobra commands are defined with partial structs and globals by design.
+var SecurityCmd = &cobra.Command{
+	Use:   "security",
+	Short: "Commands that check the project for security issues.",
+}
+
+//nolint:gochecknoinits // Cobra requires init() for command registration.
+func init() {
+	SecurityCmd.AddCommand(scan.ScanCmd)
+}
@@ -0,0 +1,139 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxRemotePlanBytes caps the size of a plan or script downloaded with FetchPlan.
+	MaxRemotePlanBytes = 1 << 20
+	// remotePlanTimeout bounds the whole download, redirects included.
+	remotePlanTimeout = 30 * time.Second
+	// maxRedirects matches the net/http default.
+	maxRedirects = 10
+)
+
+var (
+	// ErrInsecureURL is returned when a plan URL, or a redirect it leads to, is not HTTPS.
+	ErrInsecureURL = errors.New("plan URL must use https")
+	// ErrPlanTooLarge is returned when a downloaded plan exceeds MaxRemotePlanBytes.
+	ErrPlanTooLarge = errors.New("downloaded plan is too large")
+	// ErrUnexpectedContentType is returned when the server answers with something
+	// other than JSON or plain text, such as an HTML page instead of a raw file.
+	ErrUnexpectedContentType = errors.New("unexpected content type for a plan")
+)
+
+// FetchPlan downloads a Change Plan or script over HTTPS. Redirects are followed
+// only to other HTTPS URLs, the body is capped at MaxRemotePlanBytes, and the
+// response must be JSON or text. A nil client uses a default one; the client's
+// own redirect policy is replaced either way.
+func FetchPlan(ctx context.Context, client *http.Client, rawURL string) ([]byte, error) {
+	err := requireHTTPS(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:exhaustruct // Transport defaults are fine.
+	httpClient := &http.Client{}
+	if client != nil {
+		clientCopy := *client
+		httpClient = &clientCopy
+	}
+
+	if httpClient.Timeout == 0 {
+		httpClient.Timeout = remotePlanTimeout
+	}
+
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			//nolint:err113 // Dynamic error is appropriate here.
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		return requireHTTPS(req.URL.String())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", rawURL, err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download plan from %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		//nolint:err113 // Dynamic error is appropriate here.
+		return nil, fmt.Errorf("failed to download plan from %s: %s", rawURL, resp.Status)
+	}
+
+	err = checkPlanContentType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.ContentLength > MaxRemotePlanBytes {
+		return nil, fmt.Errorf("%w: %d bytes (limit %d)", ErrPlanTooLarge, resp.ContentLength, MaxRemotePlanBytes)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxRemotePlanBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan from %s: %w", rawURL, err)
+	}
+
+	if len(body) > MaxRemotePlanBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrPlanTooLarge, MaxRemotePlanBytes)
+	}
+
+	return body, nil
+}
+
+func requireHTTPS(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid plan URL '%s': %w", rawURL, err)
+	}
+
+	if parsed.Scheme != "https" {
+		return fmt.Errorf("%w: '%s'", ErrInsecureURL, rawURL)
+	}
+
+	return nil
+}
+
+// checkPlanContentType accepts JSON, shell scripts, any text type except HTML, and
+// generic binary streams, which is how some paste services serve raw files.
+func checkPlanContentType(contentType string) error {
+	if contentType == "" {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("%w: '%s'", ErrUnexpectedContentType, contentType)
+	}
+
+	switch {
+	case mediaType == "text/html":
+		return fmt.Errorf("%w: '%s' (is this a web page rather than the raw file?)", ErrUnexpectedContentType, mediaType)
+	case mediaType == "application/json",
+		mediaType == "application/x-sh",
+		mediaType == "application/x-shellscript",
+		mediaType == "application/octet-stream",
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasPrefix(mediaType, "text/"):
+		return nil
+	default:
+		return fmt.Errorf("%w: '%s'", ErrUnexpectedContentType, mediaType)
+	}
+}
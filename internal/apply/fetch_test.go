@@ -0,0 +1,110 @@
+package apply_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/apply"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const remotePlan = `{"steps":[{"type":"command_execution","command":"go","args":["test"]}]}`
+
+func TestFetchPlan(t *testing.T) {
+	t.Parallel()
+
+	plainServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(remotePlan))
+	}))
+	t.Cleanup(plainServer.Close)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/plan.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(remotePlan))
+	})
+	mux.HandleFunc("/raw.sh", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("echo hello\n"))
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html></html>"))
+	})
+	mux.HandleFunc("/huge", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(strings.Repeat("x", apply.MaxRemotePlanBytes+1)))
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/plan.json", http.StatusFound)
+	})
+	mux.HandleFunc("/downgrade", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plainServer.URL+"/plan.json", http.StatusFound)
+	})
+
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("json plan", func(t *testing.T) {
+		t.Parallel()
+
+		body, err := apply.FetchPlan(t.Context(), server.Client(), server.URL+"/plan.json")
+		require.NoError(t, err)
+		assert.JSONEq(t, remotePlan, string(body))
+	})
+
+	t.Run("plain text script", func(t *testing.T) {
+		t.Parallel()
+
+		body, err := apply.FetchPlan(t.Context(), server.Client(), server.URL+"/raw.sh")
+		require.NoError(t, err)
+		assert.Equal(t, "echo hello\n", string(body))
+	})
+
+	t.Run("https redirect is followed", func(t *testing.T) {
+		t.Parallel()
+
+		body, err := apply.FetchPlan(t.Context(), server.Client(), server.URL+"/moved")
+		require.NoError(t, err)
+		assert.JSONEq(t, remotePlan, string(body))
+	})
+
+	t.Run("plain http is refused", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := apply.FetchPlan(t.Context(), plainServer.Client(), plainServer.URL+"/plan.json")
+		require.ErrorIs(t, err, apply.ErrInsecureURL)
+	})
+
+	t.Run("redirect to plain http is refused", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := apply.FetchPlan(t.Context(), server.Client(), server.URL+"/downgrade")
+		require.ErrorIs(t, err, apply.ErrInsecureURL)
+	})
+
+	t.Run("html page is refused", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := apply.FetchPlan(t.Context(), server.Client(), server.URL+"/page")
+		require.ErrorIs(t, err, apply.ErrUnexpectedContentType)
+	})
+
+	t.Run("oversized body is refused", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := apply.FetchPlan(t.Context(), server.Client(), server.URL+"/huge")
+		require.ErrorIs(t, err, apply.ErrPlanTooLarge)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := apply.FetchPlan(t.Context(), server.Client(), server.URL+"/missing")
+		require.ErrorContains(t, err, "404 Not Found")
+	})
+}
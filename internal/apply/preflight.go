@@ -0,0 +1,93 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/git"
+)
+
+// ErrDirtyTargets is returned when target files have uncommitted changes and a clean tree is required.
+var ErrDirtyTargets = errors.New("target files have uncommitted changes")
+
+// Warner is the subset of the presenter used to report preflight findings.
+type Warner interface {
+	Warning(format string, a ...any)
+	Detail(format string, a ...any)
+	Advice(format string, a ...any)
+}
+
+// NewPreflightGitClient returns a git client for the current directory, or nil when
+// the directory is not a repository; CheckDirtyTargets then skips its check.
+func NewPreflightGitClient(ctx context.Context, logger *slog.Logger, executor exec.CommandExecutor) *git.GitClient {
+	//nolint:exhaustruct // Partial config is sufficient.
+	gitClient, err := git.NewClient(ctx, ".", git.GitClientConfig{
+		Logger:   logger,
+		Executor: executor,
+	})
+	if err != nil {
+		logger.DebugContext(ctx, "Not in a git repository; skipping the dirty-target preflight", slog.Any("error", err))
+
+		return nil
+	}
+
+	return gitClient
+}
+
+// ResolveBaseDir returns the directory plan paths are confined to: flagValue when
+// set, otherwise the repository root, or the current directory outside a repository.
+func ResolveBaseDir(flagValue string, gitClient *git.GitClient) string {
+	if flagValue != "" {
+		return flagValue
+	}
+
+	if gitClient != nil {
+		return gitClient.Path()
+	}
+
+	return "."
+}
+
+// CheckDirtyTargets reports target files that already have uncommitted changes, so
+// automated edits do not get silently entangled with manual ones. It only warns,
+// unless requireClean is set, in which case it returns ErrDirtyTargets.
+// A nil gitClient (e.g. outside a repository) skips the check.
+func CheckDirtyTargets(
+	ctx context.Context,
+	warner Warner,
+	gitClient *git.GitClient,
+	paths []string,
+	requireClean bool,
+) error {
+	if gitClient == nil || len(paths) == 0 {
+		return nil
+	}
+
+	dirty, err := gitClient.DirtyPaths(ctx, paths...)
+	if err != nil {
+		return fmt.Errorf("failed to check target files for uncommitted changes: %w", err)
+	}
+
+	if len(dirty) == 0 {
+		return nil
+	}
+
+	warner.Warning("%d target file(s) already have uncommitted changes:", len(dirty))
+
+	for _, path := range dirty {
+		warner.Detail("%s", path)
+	}
+
+	if requireClean {
+		warner.Advice("Commit or stash these changes first, or re-run without --require-clean.")
+
+		return ErrDirtyTargets
+	}
+
+	warner.Advice("Automated edits will be mixed with your manual changes in these files.")
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package apply_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/apply"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStatusExecutor answers git status with statusOutput and fails any other
+// command outside repository discovery.
+func newStatusExecutor(repoDir, statusOutput string) *exectest.Executor {
+	//nolint:exhaustruct // Recorded fields start empty.
+	return &exectest.Executor{
+		RepoDir: repoDir,
+		Handler: func(call exectest.Call) exectest.Response {
+			if call.Name == "git" && len(call.Args) > 0 && call.Args[0] == "status" {
+				return exectest.Response{Stdout: statusOutput, Stderr: "", Err: nil}
+			}
+
+			//nolint:err113 // Dynamic error is appropriate here.
+			return exectest.Response{Stdout: "", Stderr: "", Err: errors.New("unexpected git command in mock")}
+		},
+	}
+}
+
+func newTestGitClient(t *testing.T, statusOutput string) (*git.GitClient, string) {
+	t.Helper()
+
+	repoDir := t.TempDir()
+
+	//nolint:exhaustruct // Partial config is sufficient for test.
+	client, err := git.NewClient(context.Background(), repoDir, git.GitClientConfig{
+		Logger:   slog.New(slog.DiscardHandler),
+		Executor: newStatusExecutor(repoDir, statusOutput),
+	})
+	require.NoError(t, err)
+
+	return client, repoDir
+}
+
+func TestCheckDirtyTargets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("clean targets produce no warning", func(t *testing.T) {
+		t.Parallel()
+
+		client, repoDir := newTestGitClient(t, "")
+		out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+
+		err := apply.CheckDirtyTargets(
+			context.Background(),
+			ui.NewPresenter(out, errOut),
+			client,
+			[]string{filepath.Join(repoDir, "main.go")},
+			true,
+		)
+		require.NoError(t, err)
+		assert.Empty(t, errOut.String())
+	})
+
+	t.Run("dirty target triggers a warning", func(t *testing.T) {
+		t.Parallel()
+
+		client, repoDir := newTestGitClient(t, " M main.go\x00")
+		target := filepath.Join(repoDir, "main.go")
+		out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+
+		err := apply.CheckDirtyTargets(
+			context.Background(),
+			ui.NewPresenter(out, errOut),
+			client,
+			[]string{target, filepath.Join(repoDir, "other.go")},
+			false,
+		)
+		require.NoError(t, err)
+		assert.Contains(t, errOut.String(), "1 target file(s) already have uncommitted changes")
+		assert.Contains(t, out.String(), target)
+		assert.NotContains(t, out.String(), "other.go")
+	})
+
+	t.Run("dirty target aborts with require-clean", func(t *testing.T) {
+		t.Parallel()
+
+		client, repoDir := newTestGitClient(t, "?? docs/new.md\x00")
+		out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+
+		err := apply.CheckDirtyTargets(
+			context.Background(),
+			ui.NewPresenter(out, errOut),
+			client,
+			[]string{filepath.Join(repoDir, "docs", "new.md")},
+			true,
+		)
+		require.ErrorIs(t, err, apply.ErrDirtyTargets)
+		assert.Contains(t, out.String(), "--require-clean")
+	})
+
+	t.Run("paths git would quote still match", func(t *testing.T) {
+		t.Parallel()
+
+		client, repoDir := newTestGitClient(t, " M docs/my notes.md\x00?? docs/café \"draft\".md\x00R  b.go\x00a.go\x00")
+		targets := []string{
+			filepath.Join(repoDir, "docs", "my notes.md"),
+			filepath.Join(repoDir, "docs", `café "draft".md`),
+			filepath.Join(repoDir, "b.go"),
+			filepath.Join(repoDir, "a.go"),
+		}
+		out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+
+		err := apply.CheckDirtyTargets(context.Background(), ui.NewPresenter(out, errOut), client, targets, false)
+		require.NoError(t, err)
+		assert.Contains(t, errOut.String(), "3 target file(s) already have uncommitted changes")
+		assert.NotContains(t, out.String(), targets[3], "the source of a rename is not a target")
+	})
+
+	t.Run("nil git client skips the check", func(t *testing.T) {
+		t.Parallel()
+
+		err := apply.CheckDirtyTargets(
+			context.Background(),
+			ui.NewPresenter(new(bytes.Buffer), new(bytes.Buffer)),
+			nil,
+			[]string{"main.go"},
+			true,
+		)
+		require.NoError(t, err)
+	})
+}
+
+func TestNewPreflightGitClient(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.DiscardHandler)
+	repoDir := t.TempDir()
+
+	client := apply.NewPreflightGitClient(context.Background(), logger, newStatusExecutor(repoDir, ""))
+	require.NotNil(t, client)
+	assert.Equal(t, repoDir, apply.ResolveBaseDir("", client))
+	assert.Equal(t, "plans", apply.ResolveBaseDir("plans", client))
+
+	//nolint:exhaustruct // Every command goes to the handler.
+	outside := &exectest.Executor{
+		Handler: func(exectest.Call) exectest.Response {
+			//nolint:err113 // Dynamic error is appropriate here.
+			return exectest.Response{Stdout: "", Stderr: "fatal: not a git repository", Err: errors.New("exit status 128")}
+		},
+	}
+	assert.Nil(t, apply.NewPreflightGitClient(context.Background(), logger, outside))
+	assert.Equal(t, ".", apply.ResolveBaseDir("", nil))
+}
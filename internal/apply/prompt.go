@@ -0,0 +1,13 @@
+package apply
+
+import (
+	_ "embed"
+)
+
+//go:embed assets/change_plan_prompt.md
+var changePlanPrompt string
+
+// GetChangePlanPrompt returns the prompt that teaches an AI the Change Plan JSON schema.
+func GetChangePlanPrompt() string {
+	return changePlanPrompt
+}
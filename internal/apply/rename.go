@@ -0,0 +1,205 @@
+package apply
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/contextvibes/cli/internal/tools"
+)
+
+// ErrRenameConflict is returned when a bulk rename would move two files to the
+// same path, or onto a tracked file that is not itself being moved.
+var ErrRenameConflict = errors.New("bulk rename conflict")
+
+// Rename is a single file move planned by a bulk_rename step.
+type Rename struct {
+	From string
+	To   string
+}
+
+// PlanRenames returns the moves a bulk_rename step makes among the tracked
+// paths, in the order given. Paths outside PathGlob, and paths the regex leaves
+// unchanged, are not moved.
+func (s Step) PlanRenames(trackedPaths []string) ([]Rename, error) {
+	find, err := regexp.Compile(s.FindRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid find_regex: %w", err)
+	}
+
+	var scope *regexp.Regexp
+	if s.PathGlob != "" {
+		scope = tools.GlobToRegexp(s.PathGlob)
+	}
+
+	var renames []Rename
+
+	moving := make(map[string]bool)
+
+	for _, trackedPath := range trackedPaths {
+		if scope != nil && !scope.MatchString(trackedPath) {
+			continue
+		}
+
+		newPath := path.Clean(find.ReplaceAllString(trackedPath, s.ReplaceWith))
+		if newPath == trackedPath || !find.MatchString(trackedPath) {
+			continue
+		}
+
+		renames = append(renames, Rename{From: trackedPath, To: newPath})
+		moving[trackedPath] = true
+	}
+
+	return renames, checkRenameConflicts(renames, trackedPaths, moving)
+}
+
+func checkRenameConflicts(renames []Rename, trackedPaths []string, moving map[string]bool) error {
+	tracked := make(map[string]bool, len(trackedPaths))
+	for _, trackedPath := range trackedPaths {
+		tracked[trackedPath] = true
+	}
+
+	targets := make(map[string]string, len(renames))
+
+	for _, rename := range renames {
+		if other, ok := targets[rename.To]; ok {
+			return fmt.Errorf("%w: both '%s' and '%s' would become '%s'", ErrRenameConflict, other, rename.From, rename.To)
+		}
+
+		if tracked[rename.To] && !moving[rename.To] {
+			return fmt.Errorf("%w: '%s' would overwrite '%s'", ErrRenameConflict, rename.From, rename.To)
+		}
+
+		targets[rename.To] = rename.From
+	}
+
+	return nil
+}
+
+// RewriteReferences replaces mentions of renamed paths in content. Besides the
+// file paths themselves, a directory whose tracked files all moved to the same
+// new directory is rewritten too, which updates import paths such as
+// "example.com/app/internal/old". A mention only counts when it is not part of
+// a longer name, so renaming "internal/old" leaves "internal/older" alone.
+func RewriteReferences(content string, renames []Rename, trackedPaths []string) string {
+	replacements := referenceReplacements(renames, trackedPaths)
+	if len(replacements) == 0 {
+		return content
+	}
+
+	froms := make([]string, 0, len(replacements))
+	for from := range replacements {
+		froms = append(froms, regexp.QuoteMeta(from))
+	}
+
+	// Longest first, so a file path wins over the directory containing it.
+	slices.SortFunc(froms, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+	pattern := regexp.MustCompile(strings.Join(froms, "|"))
+
+	var result strings.Builder
+
+	last := 0
+
+	for _, match := range pattern.FindAllStringIndex(content, -1) {
+		start, end := match[0], match[1]
+		if !isReferenceBoundary(content, start-1) || !isReferenceEnd(content, end) {
+			continue
+		}
+
+		result.WriteString(content[last:start])
+		result.WriteString(replacements[content[start:end]])
+		last = end
+	}
+
+	result.WriteString(content[last:])
+
+	return result.String()
+}
+
+// referenceReplacements maps each old path to its new one: every renamed file,
+// plus each directory that moved as a whole.
+func referenceReplacements(renames []Rename, trackedPaths []string) map[string]string {
+	replacements := make(map[string]string, len(renames))
+	newPathOf := make(map[string]string, len(renames))
+
+	for _, rename := range renames {
+		replacements[rename.From] = rename.To
+		newPathOf[rename.From] = rename.To
+	}
+
+	for _, rename := range renames {
+		fromDir, toDir := movedDirectory(rename)
+		if fromDir == "" || replacements[fromDir] != "" {
+			continue
+		}
+
+		if directoryMovedWhole(fromDir, toDir, trackedPaths, newPathOf) {
+			replacements[fromDir] = toDir
+		}
+	}
+
+	return replacements
+}
+
+// movedDirectory strips the path components a rename leaves unchanged at the
+// end, e.g. internal/old/sub/a.go -> internal/new/sub/a.go gives internal/old
+// and internal/new. It returns empty strings when the file name itself changed.
+func movedDirectory(rename Rename) (string, string) {
+	fromParts := strings.Split(rename.From, "/")
+	toParts := strings.Split(rename.To, "/")
+
+	common := 0
+	for common < min(len(fromParts), len(toParts))-1 &&
+		fromParts[len(fromParts)-1-common] == toParts[len(toParts)-1-common] {
+		common++
+	}
+
+	if common == 0 {
+		return "", ""
+	}
+
+	return strings.Join(fromParts[:len(fromParts)-common], "/"), strings.Join(toParts[:len(toParts)-common], "/")
+}
+
+func directoryMovedWhole(fromDir, toDir string, trackedPaths []string, newPathOf map[string]string) bool {
+	for _, trackedPath := range trackedPaths {
+		rest, ok := strings.CutPrefix(trackedPath, fromDir+"/")
+		if !ok {
+			continue
+		}
+
+		if newPathOf[trackedPath] != toDir+"/"+rest {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isReferenceBoundary reports whether the byte at index i can border a path
+// mention: the ends of the content, a slash, or anything that cannot be part
+// of a file name.
+func isReferenceBoundary(content string, index int) bool {
+	if index < 0 || index >= len(content) {
+		return true
+	}
+
+	char := rune(content[index])
+
+	return !(unicode.IsLetter(char) || unicode.IsDigit(char) || strings.ContainsRune("_.-", char))
+}
+
+// isReferenceEnd is isReferenceBoundary for the byte after a mention, where a
+// period that ends a sentence, rather than starting an extension, also counts.
+func isReferenceEnd(content string, index int) bool {
+	if index < len(content) && content[index] == '.' {
+		return isReferenceBoundary(content, index+1)
+	}
+
+	return isReferenceBoundary(content, index)
+}
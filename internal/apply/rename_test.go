@@ -0,0 +1,84 @@
+package apply_test
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/apply"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func renameStep(findRegex, replaceWith, pathGlob string) apply.Step {
+	//nolint:exhaustruct // Only the bulk_rename fields matter.
+	return apply.Step{
+		Type:        apply.StepTypeBulkRename,
+		FindRegex:   findRegex,
+		ReplaceWith: replaceWith,
+		PathGlob:    pathGlob,
+	}
+}
+
+func TestStep_PlanRenames(t *testing.T) {
+	t.Parallel()
+
+	tracked := []string{
+		"internal/old/a.go",
+		"internal/old/sub/b.go",
+		"internal/old/README.md",
+		"internal/older/c.go",
+		"cmd/main.go",
+	}
+
+	renames, err := renameStep(`^internal/old/`, "internal/widget/", "").PlanRenames(tracked)
+	require.NoError(t, err)
+	assert.Equal(t, []apply.Rename{
+		{From: "internal/old/a.go", To: "internal/widget/a.go"},
+		{From: "internal/old/sub/b.go", To: "internal/widget/sub/b.go"},
+		{From: "internal/old/README.md", To: "internal/widget/README.md"},
+	}, renames)
+
+	renames, err = renameStep(`\.go$`, ".golden", "internal/**/*.go").PlanRenames(tracked)
+	require.NoError(t, err)
+	assert.Len(t, renames, 3, "the glob keeps cmd/main.go out of scope")
+
+	_, err = renameStep(`^internal/old/(sub/)?`, "internal/flat/", "").PlanRenames(
+		[]string{"internal/old/a.go", "internal/old/sub/a.go"},
+	)
+	require.ErrorIs(t, err, apply.ErrRenameConflict)
+
+	_, err = renameStep(`^a\.go$`, "b.go", "").PlanRenames([]string{"a.go", "b.go"})
+	require.ErrorIs(t, err, apply.ErrRenameConflict, "an existing tracked file is not overwritten")
+}
+
+func TestRewriteReferences(t *testing.T) {
+	t.Parallel()
+
+	tracked := []string{"internal/old/a.go", "internal/old/b.go", "internal/older/c.go", "docs/guide.md"}
+	renames := []apply.Rename{
+		{From: "internal/old/a.go", To: "internal/widget/a.go"},
+		{From: "internal/old/b.go", To: "internal/widget/b.go"},
+	}
+
+	content := `import (
+	"example.com/app/internal/old"
+	"example.com/app/internal/older"
+)
+
+// See internal/old/a.go and internal/old/b.go.
+`
+
+	assert.Equal(t, `import (
+	"example.com/app/internal/widget"
+	"example.com/app/internal/older"
+)
+
+// See internal/widget/a.go and internal/widget/b.go.
+`, apply.RewriteReferences(content, renames, tracked))
+
+	partial := renames[:1]
+	assert.Equal(t,
+		`"example.com/app/internal/old" internal/widget/a.go`,
+		apply.RewriteReferences(`"example.com/app/internal/old" internal/old/a.go`, partial, tracked),
+		"a directory is only rewritten when all of its files moved",
+	)
+}
@@ -1,11 +1,28 @@
 package apply
 
-import "github.com/contextvibes/cli/internal/codemod"
+import (
+	"maps"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/codemod"
+)
+
+// Supported step types.
+const (
+	// StepTypeFileModification applies a codemod ChangeScript.
+	StepTypeFileModification = "file_modification"
+	// StepTypeCommandExecution runs an external command.
+	StepTypeCommandExecution = "command_execution"
+	// StepTypeBulkRename moves every tracked file whose path matches a regex.
+	StepTypeBulkRename = "bulk_rename"
+)
 
 // ChangePlan defines the top-level structure for a declarative plan.
 type ChangePlan struct {
 	Description string `json:"description"`
-	Steps       []Step `json:"steps"`
+	// Vars are the values available to templated content, alongside the environment.
+	Vars  map[string]string `json:"vars,omitempty"`
+	Steps []Step            `json:"steps"`
 }
 
 // Step represents a single action in the ChangePlan.
@@ -19,4 +36,66 @@ type Step struct {
 	// Fields for "command_execution" type
 	Command string   `json:"command,omitempty"`
 	Args    []string `json:"args,omitempty"`
+	// WorkingDir is where the command runs, relative to the repo root (or --base-dir).
+	//nolint:tagliatelle // JSON keys are fixed by schema.
+	WorkingDir string `json:"working_dir,omitempty"`
+	// Env holds variables merged over the inherited environment.
+	Env map[string]string `json:"env,omitempty"`
+
+	// Fields for "bulk_rename" type
+	// FindRegex is matched against each tracked path (slash-separated, relative
+	// to the repo root); ReplaceWith gives the new path and may use $1-style groups.
+	//nolint:tagliatelle // JSON keys are fixed by schema.
+	FindRegex string `json:"find_regex,omitempty"`
+	//nolint:tagliatelle // JSON keys are fixed by schema.
+	ReplaceWith string `json:"replace_with,omitempty"`
+	// PathGlob limits the rename to matching paths; "**" matches any number of directories.
+	//nolint:tagliatelle // JSON keys are fixed by schema.
+	PathGlob string `json:"path_glob,omitempty"`
+	// UpdateReferences rewrites mentions of the old paths, including import
+	// paths of renamed directories, in the other tracked text files.
+	//nolint:tagliatelle // JSON keys are fixed by schema.
+	UpdateReferences bool `json:"update_references,omitempty"`
+}
+
+// ResolveWorkingDir returns the directory a command_execution step should run in.
+// An empty WorkingDir means baseDir; other paths are resolved against, and
+// confined to, baseDir like file paths are (see codemod.ResolvePath).
+func (s Step) ResolveWorkingDir(baseDir string) (string, error) {
+	if s.WorkingDir == "" {
+		return baseDir, nil
+	}
+
+	//nolint:wrapcheck // Path errors are already descriptive.
+	return codemod.ResolvePath(baseDir, s.WorkingDir)
+}
+
+// TargetPaths returns the unique file paths modified by the plan's file_modification steps.
+func (p ChangePlan) TargetPaths() []string {
+	var script codemod.ChangeScript
+
+	for _, step := range p.Steps {
+		if step.Type == StepTypeFileModification {
+			script = append(script, step.Changes...)
+		}
+	}
+
+	return script.TargetPaths()
+}
+
+// TemplateData returns the values templated content is rendered with: the
+// environment (as KEY=value entries, like os.Environ) overlaid with the plan's vars.
+func (p ChangePlan) TemplateData(environ []string) map[string]string {
+	data := make(map[string]string, len(environ)+len(p.Vars))
+
+	for _, entry := range environ {
+		key, value, ok := strings.Cut(entry, "=")
+		if ok {
+			data[key] = value
+		}
+	}
+
+	maps.Copy(data, p.Vars)
+
+	return data
 }
@@ -0,0 +1,53 @@
+package apply_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/apply"
+	"github.com/contextvibes/cli/internal/codemod"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStep_ResolveWorkingDir(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+
+	for _, tc := range []struct {
+		name       string
+		workingDir string
+		want       string
+	}{
+		{"empty means the base", "", base},
+		{"relative paths join the base", "services/api", filepath.Join(base, "services", "api")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			//nolint:exhaustruct // Only the working directory matters here.
+			dir, err := apply.Step{WorkingDir: tc.workingDir}.ResolveWorkingDir(base)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, dir)
+		})
+	}
+
+	for _, workingDir := range []string{"..", "../sibling", "/tmp"} {
+		t.Run("refuses "+workingDir, func(t *testing.T) {
+			t.Parallel()
+
+			//nolint:exhaustruct // Only the working directory matters here.
+			_, err := apply.Step{WorkingDir: workingDir}.ResolveWorkingDir(base)
+			require.ErrorIs(t, err, codemod.ErrPathOutsideBase)
+		})
+	}
+}
+
+func TestGetChangePlanPrompt(t *testing.T) {
+	t.Parallel()
+
+	prompt := apply.GetChangePlanPrompt()
+	assert.Contains(t, prompt, `"working_dir": "services/api"`)
+	assert.Contains(t, prompt, "`env` entries are added to")
+}
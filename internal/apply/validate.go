@@ -0,0 +1,165 @@
+package apply
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/codemod"
+)
+
+// ErrInvalidPlan is the sentinel wrapped by every ValidationError.
+var ErrInvalidPlan = errors.New("invalid change plan")
+
+// ValidationError lists every problem found in a ChangePlan.
+type ValidationError struct {
+	Problems []string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidPlan, strings.Join(e.Problems, "; "))
+}
+
+// Unwrap allows errors.Is(err, ErrInvalidPlan).
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidPlan
+}
+
+// Validate checks the whole plan before anything is executed, so that a bad
+// step is not discovered halfway through. It returns a *ValidationError
+// describing all problems, or nil if the plan is valid.
+func (p ChangePlan) Validate() error {
+	var problems []string
+
+	for i, step := range p.Steps {
+		prefix := fmt.Sprintf("step %d", i+1)
+
+		switch step.Type {
+		case StepTypeFileModification:
+			if len(step.Changes) == 0 {
+				problems = append(problems, prefix+": file_modification has no changes")
+			}
+
+			problems = append(problems, validateChanges(prefix, step.Changes)...)
+		case StepTypeCommandExecution:
+			if strings.TrimSpace(step.Command) == "" {
+				problems = append(problems, prefix+": command_execution requires a command")
+			}
+		case StepTypeBulkRename:
+			problems = append(problems, validateBulkRename(prefix, step)...)
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unknown step type '%s'", prefix, step.Type))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	return nil
+}
+
+func validateChanges(prefix string, changes codemod.ChangeScript) []string {
+	var problems []string
+
+	for j, changeSet := range changes {
+		setPrefix := fmt.Sprintf("%s, change %d", prefix, j+1)
+		if strings.TrimSpace(changeSet.FilePath) == "" {
+			problems = append(problems, setPrefix+": file_path is required")
+		}
+
+		for k, operation := range changeSet.Operations {
+			opPrefix := fmt.Sprintf("%s, operation %d", setPrefix, k+1)
+
+			if operation.Template && operation.Content != nil {
+				_, err := operation.ParseContentTemplate()
+				if err != nil {
+					problems = append(problems, fmt.Sprintf("%s: %v", opPrefix, err))
+				}
+			}
+
+			switch operation.Type {
+			case codemod.OpCreateOrOverwrite, codemod.OpAppendToFile, codemod.OpPrependToFile:
+				if operation.Content == nil {
+					problems = append(problems, fmt.Sprintf("%s: %s requires content", opPrefix, operation.Type))
+				}
+			case codemod.OpRegexReplace:
+				_, err := regexp.Compile(operation.FindRegex)
+				if err != nil {
+					problems = append(problems, fmt.Sprintf("%s: invalid find_regex: %v", opPrefix, err))
+				}
+			case codemod.OpInsertAfter, codemod.OpInsertBefore:
+				problems = append(problems, validateInsert(opPrefix, operation)...)
+			default:
+				problems = append(
+					problems,
+					fmt.Sprintf("%s: unknown operation type '%s'", opPrefix, operation.Type),
+				)
+			}
+		}
+	}
+
+	return problems
+}
+
+func validateInsert(opPrefix string, operation codemod.Operation) []string {
+	var problems []string
+
+	if operation.Content == nil {
+		problems = append(problems, fmt.Sprintf("%s: %s requires content", opPrefix, operation.Type))
+	}
+
+	if operation.AnchorRegex == "" {
+		return append(problems, fmt.Sprintf("%s: %s requires anchor_regex", opPrefix, operation.Type))
+	}
+
+	_, err := regexp.Compile(operation.AnchorRegex)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("%s: invalid anchor_regex: %v", opPrefix, err))
+	}
+
+	return problems
+}
+
+// CheckTemplates renders every templated operation against data, so a missing
+// variable is reported before anything is executed. It returns a
+// *ValidationError listing every operation that failed to render.
+func (p ChangePlan) CheckTemplates(data map[string]string) error {
+	var problems []string
+
+	for i, step := range p.Steps {
+		if step.Type != StepTypeFileModification {
+			continue
+		}
+
+		for j, changeSet := range step.Changes {
+			for k, operation := range changeSet.Operations {
+				_, err := operation.RenderContent(data)
+				if err != nil {
+					problems = append(problems, fmt.Sprintf("step %d, change %d, operation %d: %v", i+1, j+1, k+1, err))
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	return nil
+}
+
+func validateBulkRename(prefix string, step Step) []string {
+	if step.FindRegex == "" {
+		return []string{prefix + ": bulk_rename requires find_regex"}
+	}
+
+	_, err := regexp.Compile(step.FindRegex)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: invalid find_regex: %v", prefix, err)}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package apply_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/apply"
+	"github.com/contextvibes/cli/internal/codemod"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fileStep(changes ...codemod.FileChangeSet) apply.Step {
+	//nolint:exhaustruct // Partial step is sufficient for test.
+	return apply.Step{Type: apply.StepTypeFileModification, Changes: changes}
+}
+
+func TestChangePlan_Validate(t *testing.T) {
+	t.Parallel()
+
+	content := "package main\n"
+
+	testCases := []struct {
+		name         string
+		plan         apply.ChangePlan
+		wantProblems []string
+	}{
+		{
+			name: "valid plan",
+			plan: apply.ChangePlan{Description: "ok", Steps: []apply.Step{
+				fileStep(codemod.FileChangeSet{FilePath: "main.go", Operations: []codemod.Operation{
+					//nolint:exhaustruct // Partial operation is sufficient for test.
+					{Type: codemod.OpCreateOrOverwrite, Content: &content},
+					//nolint:exhaustruct // Partial operation is sufficient for test.
+					{Type: codemod.OpRegexReplace, FindRegex: "main", ReplaceWith: "app"},
+				}}),
+				//nolint:exhaustruct // Partial step is sufficient for test.
+				{Type: apply.StepTypeCommandExecution, Command: "go", Args: []string{"fmt"}},
+			}},
+			wantProblems: nil,
+		},
+		{
+			name: "unknown step type",
+			//nolint:exhaustruct // Partial step is sufficient for test.
+			plan:         apply.ChangePlan{Steps: []apply.Step{{Type: "teleport"}}},
+			wantProblems: []string{"step 1: unknown step type 'teleport'"},
+		},
+		{
+			name: "unknown operation type",
+			plan: apply.ChangePlan{Steps: []apply.Step{
+				fileStep(codemod.FileChangeSet{FilePath: "a.go", Operations: []codemod.Operation{
+					//nolint:exhaustruct // Partial operation is sufficient for test.
+					{Type: "explode"},
+				}}),
+			}},
+			wantProblems: []string{"step 1, change 1, operation 1: unknown operation type 'explode'"},
+		},
+		{
+			name: "missing file path",
+			plan: apply.ChangePlan{Steps: []apply.Step{
+				fileStep(codemod.FileChangeSet{FilePath: " ", Operations: []codemod.Operation{
+					//nolint:exhaustruct // Partial operation is sufficient for test.
+					{Type: codemod.OpCreateOrOverwrite, Content: &content},
+				}}),
+			}},
+			wantProblems: []string{"step 1, change 1: file_path is required"},
+		},
+		{
+			name: "create_or_overwrite without content",
+			plan: apply.ChangePlan{Steps: []apply.Step{
+				fileStep(codemod.FileChangeSet{FilePath: "a.go", Operations: []codemod.Operation{
+					//nolint:exhaustruct // Partial operation is sufficient for test.
+					{Type: codemod.OpCreateOrOverwrite},
+				}}),
+			}},
+			wantProblems: []string{"step 1, change 1, operation 1: create_or_overwrite requires content"},
+		},
+		{
+			name: "invalid regex",
+			plan: apply.ChangePlan{Steps: []apply.Step{
+				fileStep(codemod.FileChangeSet{FilePath: "a.go", Operations: []codemod.Operation{
+					//nolint:exhaustruct // Partial operation is sufficient for test.
+					{Type: codemod.OpRegexReplace, FindRegex: "("},
+				}}),
+			}},
+			wantProblems: []string{
+				"step 1, change 1, operation 1: invalid find_regex: error parsing regexp: missing closing ): `(`",
+			},
+		},
+		{
+			name: "insert without anchor or content",
+			plan: apply.ChangePlan{Steps: []apply.Step{
+				fileStep(codemod.FileChangeSet{FilePath: "a.go", Operations: []codemod.Operation{
+					//nolint:exhaustruct // Partial operation is sufficient for test.
+					{Type: codemod.OpInsertAfter},
+					//nolint:exhaustruct // Partial operation is sufficient for test.
+					{Type: codemod.OpInsertBefore, AnchorRegex: "[", Content: &content},
+				}}),
+			}},
+			wantProblems: []string{
+				"step 1, change 1, operation 1: insert_after requires content",
+				"step 1, change 1, operation 1: insert_after requires anchor_regex",
+				"step 1, change 1, operation 2: invalid anchor_regex: error parsing regexp: missing closing ]: `[`",
+			},
+		},
+		{
+			name: "all problems are reported at once",
+			plan: apply.ChangePlan{Steps: []apply.Step{
+				//nolint:exhaustruct // Partial step is sufficient for test.
+				{Type: "bogus"},
+				//nolint:exhaustruct // Partial step is sufficient for test.
+				{Type: apply.StepTypeCommandExecution},
+				fileStep(),
+			}},
+			wantProblems: []string{
+				"step 1: unknown step type 'bogus'",
+				"step 2: command_execution requires a command",
+				"step 3: file_modification has no changes",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.plan.Validate()
+			if testCase.wantProblems == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, apply.ErrInvalidPlan)
+
+			var validationErr *apply.ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, testCase.wantProblems, validationErr.Problems)
+		})
+	}
+}
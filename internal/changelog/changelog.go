@@ -0,0 +1,199 @@
+// Package changelog renders Markdown release notes from Conventional Commits.
+package changelog
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/github"
+)
+
+// shortHashLength is the number of hash characters shown for each entry.
+const shortHashLength = 7
+
+// otherSection collects commits that do not follow the Conventional Commits format.
+const otherSection = "Other Changes"
+
+//nolint:gochecknoglobals // Static regex compilation.
+var (
+	conventionalSubject = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?:\s+(.+)$`)
+	issueReference      = regexp.MustCompile(`(^|[^\w/\[])#(\d+)\b`)
+)
+
+// sections maps commit types to headings, in the order they are rendered.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var sections = []struct {
+	commitType string
+	heading    string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance Improvements"},
+	{"refactor", "Code Refactoring"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build System"},
+	{"ci", "Continuous Integration"},
+	{"style", "Styles"},
+	{"chore", "Chores"},
+	{"revert", "Reverts"},
+}
+
+// Entry is a parsed commit ready to be rendered.
+type Entry struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+	Hash        string
+}
+
+// Options controls how the changelog is rendered.
+type Options struct {
+	// Title is rendered as the level-2 heading, e.g. "v1.3.0" or "Unreleased".
+	Title string
+	// RepoURL is the web URL of the repository (e.g. https://github.com/owner/repo).
+	// When set, issue references and commit hashes become links.
+	RepoURL string
+}
+
+// RepoURL returns the GitHub web URL of the client's default remote, or "" when
+// the remote is missing or not hosted on GitHub (links are then omitted).
+func RepoURL(ctx context.Context, gitClient *git.GitClient) string {
+	remote, err := gitClient.GetRemoteURL(ctx, gitClient.RemoteName())
+	if err != nil {
+		return ""
+	}
+
+	owner, repo, err := github.ParseGitHubRemote(remote)
+	if err != nil {
+		return ""
+	}
+
+	return "https://github.com/" + owner + "/" + repo
+}
+
+// ParseCommit extracts the Conventional Commit parts of a commit. Commits that do
+// not follow the format get an empty Type and the subject as the description.
+func ParseCommit(commit git.Commit) Entry {
+	entry := Entry{
+		Type:        "",
+		Scope:       "",
+		Description: strings.TrimSpace(commit.Subject),
+		Breaking:    strings.Contains(commit.Body, "BREAKING CHANGE"),
+		Hash:        commit.Hash,
+	}
+
+	matches := conventionalSubject.FindStringSubmatch(entry.Description)
+	if matches == nil {
+		return entry
+	}
+
+	entry.Type = strings.ToLower(matches[1])
+	entry.Scope = matches[2]
+	entry.Breaking = entry.Breaking || matches[3] == "!" || entry.Type == "breaking"
+	entry.Description = matches[4]
+
+	return entry
+}
+
+// Render groups the commits by type and renders them as Markdown. Breaking
+// changes are listed first, then each known type, then everything else.
+func Render(commits []git.Commit, opts Options) string {
+	grouped := map[string][]Entry{}
+	breaking := []Entry{}
+
+	for _, commit := range commits {
+		entry := ParseCommit(commit)
+		if entry.Breaking {
+			breaking = append(breaking, entry)
+
+			continue
+		}
+
+		grouped[sectionFor(entry.Type)] = append(grouped[sectionFor(entry.Type)], entry)
+	}
+
+	var builder strings.Builder
+
+	if opts.Title != "" {
+		fmt.Fprintf(&builder, "## %s\n\n", opts.Title)
+	}
+
+	if len(commits) == 0 {
+		builder.WriteString("No changes.\n")
+
+		return builder.String()
+	}
+
+	writeSection(&builder, "⚠ Breaking Changes", breaking, opts.RepoURL)
+
+	for _, section := range sections {
+		writeSection(&builder, section.heading, grouped[section.heading], opts.RepoURL)
+	}
+
+	writeSection(&builder, otherSection, grouped[otherSection], opts.RepoURL)
+
+	return strings.TrimRight(builder.String(), "\n") + "\n"
+}
+
+func sectionFor(commitType string) string {
+	for _, section := range sections {
+		if section.commitType == commitType {
+			return section.heading
+		}
+	}
+
+	return otherSection
+}
+
+func writeSection(builder *strings.Builder, heading string, entries []Entry, repoURL string) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(builder, "### %s\n\n", heading)
+
+	for _, entry := range entries {
+		builder.WriteString("- ")
+
+		if entry.Scope != "" {
+			fmt.Fprintf(builder, "**%s:** ", entry.Scope)
+		}
+
+		builder.WriteString(linkIssues(entry.Description, repoURL))
+
+		if hash := shortHash(entry.Hash); hash != "" {
+			if repoURL != "" {
+				fmt.Fprintf(builder, " ([%s](%s/commit/%s))", hash, repoURL, entry.Hash)
+			} else {
+				fmt.Fprintf(builder, " (%s)", hash)
+			}
+		}
+
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString("\n")
+}
+
+// linkIssues turns "#123" references into links to the repository's issues.
+func linkIssues(text, repoURL string) string {
+	if repoURL == "" {
+		return text
+	}
+
+	return issueReference.ReplaceAllString(text, "${1}[#${2}]("+repoURL+"/issues/${2})")
+}
+
+func shortHash(hash string) string {
+	if len(hash) > shortHashLength {
+		return hash[:shortHashLength]
+	}
+
+	return hash
+}
@@ -0,0 +1,97 @@
+package changelog_test
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/changelog"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func fixtureCommits() []git.Commit {
+	return []git.Commit{
+		{Hash: "1111111aaaa", Subject: "feat(ui): add quiet mode (#12)"},
+		{Hash: "2222222bbbb", Subject: "fix: handle empty config, closes #15"},
+		{Hash: "3333333cccc", Subject: "docs: update README"},
+		{Hash: "4444444dddd", Subject: "feat!: drop the legacy kickoff flags"},
+		{Hash: "5555555eeee", Subject: "refactor(git): extract runGit", Body: "BREAKING CHANGE: GitClient.Run is removed."},
+		{Hash: "6666666ffff", Subject: "Merge branch 'main' into feature/x"},
+		{Hash: "7777777gggg", Subject: "feat: support --from and --to"},
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	want := `## v1.3.0
+
+### ⚠ Breaking Changes
+
+- drop the legacy kickoff flags (4444444)
+- **git:** extract runGit (5555555)
+
+### Features
+
+- **ui:** add quiet mode (#12) (1111111)
+- support --from and --to (7777777)
+
+### Bug Fixes
+
+- handle empty config, closes #15 (2222222)
+
+### Documentation
+
+- update README (3333333)
+
+### Other Changes
+
+- Merge branch 'main' into feature/x (6666666)
+`
+
+	got := changelog.Render(fixtureCommits(), changelog.Options{Title: "v1.3.0", RepoURL: ""})
+	assert.Equal(t, want, got)
+}
+
+func TestRender_Links(t *testing.T) {
+	t.Parallel()
+
+	commits := []git.Commit{
+		{Hash: "1111111aaaa", Subject: "feat(ui): add quiet mode (#12)"},
+		{Hash: "2222222bbbb", Subject: "fix: see owner/repo#3 and [#4](elsewhere)"},
+	}
+
+	want := `### Features
+
+- **ui:** add quiet mode ([#12](https://github.com/acme/cli/issues/12)) ([1111111](https://github.com/acme/cli/commit/1111111aaaa))
+
+### Bug Fixes
+
+- see owner/repo#3 and [#4](elsewhere) ([2222222](https://github.com/acme/cli/commit/2222222bbbb))
+`
+
+	got := changelog.Render(commits, changelog.Options{Title: "", RepoURL: "https://github.com/acme/cli"})
+	assert.Equal(t, want, got)
+}
+
+func TestRender_NoCommits(t *testing.T) {
+	t.Parallel()
+
+	got := changelog.Render(nil, changelog.Options{Title: "Unreleased", RepoURL: ""})
+	assert.Equal(t, "## Unreleased\n\nNo changes.\n", got)
+}
+
+func TestParseCommit(t *testing.T) {
+	t.Parallel()
+
+	entry := changelog.ParseCommit(git.Commit{Hash: "abc", Subject: "Fix(API)!: rename endpoint"})
+
+	assert.Equal(t, "fix", entry.Type)
+	assert.Equal(t, "API", entry.Scope)
+	assert.Equal(t, "rename endpoint", entry.Description)
+	assert.True(t, entry.Breaking)
+
+	plain := changelog.ParseCommit(git.Commit{Hash: "def", Subject: "Initial commit"})
+	assert.Empty(t, plain.Type)
+	assert.Equal(t, "Initial commit", plain.Description)
+	assert.False(t, plain.Breaking)
+}
@@ -0,0 +1,24 @@
+// Package clock supplies the current time through an interface, so code that
+// stamps dates into files and artifacts can be tested with a fixed time.
+package clock
+
+import "time"
+
+// Clock reports the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the Clock backed by the system time.
+type Real struct{}
+
+// Now returns time.Now().
+func (Real) Now() time.Time { return time.Now() }
+
+// Fixed is a Clock that always reports the same instant.
+type Fixed struct {
+	Time time.Time
+}
+
+// Now returns the fixed time.
+func (f Fixed) Now() time.Time { return f.Time }
@@ -17,9 +17,12 @@ These types are typically unmarshalled from a JSON file (e.g., the default
 `contextvibes codemod` command. The command then interprets these structures
 to apply the requested changes to the project's files.
 
-This package itself does not contain the execution logic for applying the
-codemods; that logic resides in the `cmd` package (specifically `cmd/codemod.go`).
-The primary role of `internal/codemod` is to provide the clear, typed
-representation of the modification instructions.
+Operation.Apply runs a single operation against a file's content in memory.
+Reading and writing the files, and confirming with the user, is left to the
+commands that use it: `contextvibes product codemod` and `contextvibes factory
+apply`.
+
+ResolvePath confines a FileChangeSet's FilePath to a base directory (by default
+the repository root), refusing absolute paths and paths that escape it.
 */
 package codemod
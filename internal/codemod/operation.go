@@ -0,0 +1,128 @@
+package codemod
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrAnchorNotFound is returned by insert operations when no line matches
+	// AnchorRegex and AllowMissing is not set.
+	ErrAnchorNotFound = errors.New("anchor not found")
+	// ErrUnknownOperation is returned for an operation type this package does not implement.
+	ErrUnknownOperation = errors.New("unknown operation type")
+	// ErrMissingContent is returned when an operation that writes Content has none.
+	ErrMissingContent = errors.New("operation requires content")
+)
+
+// Apply runs the operation against a file's content and returns the result.
+func (op Operation) Apply(content string) (string, error) {
+	switch op.Type {
+	case OpRegexReplace:
+		re, err := regexp.Compile(op.FindRegex)
+		if err != nil {
+			return "", fmt.Errorf("invalid find_regex '%s': %w", op.FindRegex, err)
+		}
+
+		return re.ReplaceAllString(content, op.ReplaceWith), nil
+	case OpCreateOrOverwrite:
+		if op.Content == nil {
+			return "", fmt.Errorf("%w: %s", ErrMissingContent, op.Type)
+		}
+
+		return *op.Content, nil
+	case OpInsertAfter, OpInsertBefore:
+		return op.insertAtAnchor(content)
+	case OpAppendToFile, OpPrependToFile:
+		return op.addToFile(content)
+	default:
+		return "", fmt.Errorf("%w: '%s'", ErrUnknownOperation, op.Type)
+	}
+}
+
+// insertAtAnchor inserts Content as whole lines next to the first line matching
+// AnchorRegex, or next to every matching line when AllMatches is set.
+func (op Operation) insertAtAnchor(content string) (string, error) {
+	if op.Content == nil {
+		return "", fmt.Errorf("%w: %s", ErrMissingContent, op.Type)
+	}
+
+	anchor, err := regexp.Compile(op.AnchorRegex)
+	if err != nil {
+		return "", fmt.Errorf("invalid anchor_regex '%s': %w", op.AnchorRegex, err)
+	}
+
+	insertion := *op.Content
+	if !strings.HasSuffix(insertion, "\n") {
+		insertion += "\n"
+	}
+
+	var (
+		result  strings.Builder
+		matched bool
+	)
+
+	for line := range strings.Lines(content) {
+		if (!matched || op.AllMatches) && anchor.MatchString(strings.TrimRight(line, "\r\n")) {
+			matched = true
+
+			if op.Type == OpInsertBefore {
+				result.WriteString(insertion)
+				result.WriteString(line)
+
+				continue
+			}
+
+			result.WriteString(line)
+
+			if !strings.HasSuffix(line, "\n") {
+				result.WriteString("\n")
+			}
+
+			result.WriteString(insertion)
+
+			continue
+		}
+
+		result.WriteString(line)
+	}
+
+	if !matched {
+		if op.AllowMissing {
+			return content, nil
+		}
+
+		return "", fmt.Errorf("%w: no line matches '%s'", ErrAnchorNotFound, op.AnchorRegex)
+	}
+
+	return result.String(), nil
+}
+
+// addToFile appends or prepends Content, unless the file already contains
+// SkipIfContains.
+func (op Operation) addToFile(content string) (string, error) {
+	if op.Content == nil {
+		return "", fmt.Errorf("%w: %s", ErrMissingContent, op.Type)
+	}
+
+	if op.SkipIfContains != "" && strings.Contains(content, op.SkipIfContains) {
+		return content, nil
+	}
+
+	addition := *op.Content
+	if op.EnsureNewline && addition != "" && !strings.HasSuffix(addition, "\n") {
+		addition += "\n"
+	}
+
+	if op.Type == OpPrependToFile {
+		return addition + content, nil
+	}
+
+	if op.EnsureNewline && content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	return content + addition, nil
+}
@@ -0,0 +1,150 @@
+package codemod_test
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/codemod"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importsFile = `package main
+
+import "fmt"
+import "os"
+
+func main() {}
+`
+
+func insertOp(opType, anchor, content string, allMatches bool) codemod.Operation {
+	//nolint:exhaustruct // Only the insert fields matter.
+	return codemod.Operation{Type: opType, AnchorRegex: anchor, Content: &content, AllMatches: allMatches}
+}
+
+func TestOperation_Insert(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		op   codemod.Operation
+		want string
+	}{
+		{
+			name: "after the first match",
+			op:   insertOp(codemod.OpInsertAfter, `^import `, `import "strings"`, false),
+			want: "package main\n\nimport \"fmt\"\nimport \"strings\"\nimport \"os\"\n\nfunc main() {}\n",
+		},
+		{
+			name: "after all matches",
+			op:   insertOp(codemod.OpInsertAfter, `^import `, "// imported", true),
+			want: "package main\n\nimport \"fmt\"\n// imported\nimport \"os\"\n// imported\n\nfunc main() {}\n",
+		},
+		{
+			name: "before the first match",
+			op:   insertOp(codemod.OpInsertBefore, `^func main`, "// main runs the program.\n", false),
+			want: "package main\n\nimport \"fmt\"\nimport \"os\"\n\n// main runs the program.\nfunc main() {}\n",
+		},
+		{
+			name: "before all matches with multi-line content",
+			op:   insertOp(codemod.OpInsertBefore, `^import `, "// a\n// b", true),
+			want: "package main\n\n// a\n// b\nimport \"fmt\"\n// a\n// b\nimport \"os\"\n\nfunc main() {}\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testCase.op.Apply(importsFile)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestOperation_InsertAfterLastLineWithoutNewline(t *testing.T) {
+	t.Parallel()
+
+	got, err := insertOp(codemod.OpInsertAfter, "^b$", "c", false).Apply("a\nb")
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb\nc\n", got)
+}
+
+func TestOperation_InsertMissingAnchor(t *testing.T) {
+	t.Parallel()
+
+	op := insertOp(codemod.OpInsertAfter, `^import "log"`, "x", false)
+
+	_, err := op.Apply(importsFile)
+	require.ErrorIs(t, err, codemod.ErrAnchorNotFound)
+
+	op.AllowMissing = true
+	got, err := op.Apply(importsFile)
+	require.NoError(t, err)
+	assert.Equal(t, importsFile, got, "allow_missing leaves the file unchanged")
+}
+
+func TestOperation_UnknownType(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // Only the type matters.
+	_, err := codemod.Operation{Type: "explode"}.Apply(importsFile)
+	require.ErrorIs(t, err, codemod.ErrUnknownOperation)
+}
+
+func addOp(opType, content string) codemod.Operation {
+	//nolint:exhaustruct // Only the append/prepend fields matter.
+	return codemod.Operation{Type: opType, Content: &content}
+}
+
+func TestOperation_AppendAndPrepend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("append to a new file", func(t *testing.T) {
+		t.Parallel()
+
+		op := addOp(codemod.OpAppendToFile, "bin/")
+		op.EnsureNewline = true
+
+		got, err := op.Apply("")
+		require.NoError(t, err)
+		assert.Equal(t, "bin/\n", got)
+	})
+
+	t.Run("append to existing content", func(t *testing.T) {
+		t.Parallel()
+
+		op := addOp(codemod.OpAppendToFile, "bin/")
+
+		got, err := op.Apply("vendor/")
+		require.NoError(t, err)
+		assert.Equal(t, "vendor/bin/", got, "content is added verbatim without ensure_newline")
+
+		op.EnsureNewline = true
+		got, err = op.Apply("vendor/")
+		require.NoError(t, err)
+		assert.Equal(t, "vendor/\nbin/\n", got)
+	})
+
+	t.Run("prepend", func(t *testing.T) {
+		t.Parallel()
+
+		op := addOp(codemod.OpPrependToFile, "// Code generated. DO NOT EDIT.")
+		op.EnsureNewline = true
+
+		got, err := op.Apply("package main\n")
+		require.NoError(t, err)
+		assert.Equal(t, "// Code generated. DO NOT EDIT.\npackage main\n", got)
+	})
+
+	t.Run("skip if already present", func(t *testing.T) {
+		t.Parallel()
+
+		op := addOp(codemod.OpAppendToFile, "bin/\n")
+		op.SkipIfContains = "bin/"
+
+		got, err := op.Apply("vendor/\nbin/\n")
+		require.NoError(t, err)
+		assert.Equal(t, "vendor/\nbin/\n", got)
+	})
+}
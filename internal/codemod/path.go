@@ -0,0 +1,95 @@
+package codemod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrPathOutsideBase is returned when a target path is absolute or leads out of
+// the base directory that file operations are confined to.
+var ErrPathOutsideBase = errors.New("path is outside the base directory")
+
+// ResolvePath joins a script's file path to baseDir and returns the absolute
+// result. Absolute paths, and paths that leave baseDir with ".." or through a
+// symlink, are refused with ErrPathOutsideBase.
+func ResolvePath(baseDir, filePath string) (string, error) {
+	if filepath.IsAbs(filePath) || filepath.VolumeName(filePath) != "" {
+		return "", fmt.Errorf("%w: absolute path '%s' is not allowed", ErrPathOutsideBase, filePath)
+	}
+
+	base, err := filepath.Abs(baseDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve base directory '%s': %w", baseDir, err)
+	}
+
+	resolved := filepath.Join(base, filePath)
+
+	err = EnsureWithin(base, resolved)
+	if err != nil {
+		return "", err
+	}
+
+	return resolved, nil
+}
+
+// EnsureWithin checks that target is baseDir or inside it, after following any
+// symlinks in the part of target that already exists. Both paths must be absolute.
+func EnsureWithin(baseDir, target string) error {
+	if !isWithin(baseDir, target) {
+		return fmt.Errorf("%w: '%s' is not inside '%s'", ErrPathOutsideBase, target, baseDir)
+	}
+
+	realBase, err := filepath.EvalSymlinks(baseDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve base directory '%s': %w", baseDir, err)
+	}
+
+	realTarget, err := evalExistingSymlinks(target)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path '%s': %w", target, err)
+	}
+
+	if !isWithin(realBase, realTarget) {
+		return fmt.Errorf("%w: '%s' leads to '%s' through a symlink", ErrPathOutsideBase, target, realTarget)
+	}
+
+	return nil
+}
+
+// evalExistingSymlinks resolves symlinks in the longest existing prefix of path
+// and appends the rest unchanged, so files that are about to be created can be checked.
+func evalExistingSymlinks(path string) (string, error) {
+	missing := ""
+
+	for {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			return filepath.Join(resolved, missing), nil
+		}
+
+		if !os.IsNotExist(err) {
+			//nolint:wrapcheck // Wrapped by the caller.
+			return "", err
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return filepath.Join(path, missing), nil
+		}
+
+		missing = filepath.Join(filepath.Base(path), missing)
+		path = parent
+	}
+}
+
+func isWithin(baseDir, target string) bool {
+	rel, err := filepath.Rel(baseDir, target)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
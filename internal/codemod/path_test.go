@@ -0,0 +1,38 @@
+package codemod_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/codemod"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePath(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	outside := t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(base, "escape")))
+	require.NoError(t, os.Mkdir(filepath.Join(base, "src"), 0o750))
+
+	resolved, err := codemod.ResolvePath(base, "src/new/main.go")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(base, "src", "new", "main.go"), resolved)
+
+	resolved, err = codemod.ResolvePath(base, "src/../README.md")
+	require.NoError(t, err, "'..' that stays inside the base is allowed")
+	assert.Equal(t, filepath.Join(base, "README.md"), resolved)
+
+	for _, filePath := range []string{
+		"../../etc/x",
+		"src/../../x",
+		filepath.Join(outside, "x"),
+		"escape/x",
+	} {
+		_, err = codemod.ResolvePath(base, filePath)
+		require.ErrorIs(t, err, codemod.ErrPathOutsideBase, filePath)
+	}
+}
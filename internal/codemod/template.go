@@ -0,0 +1,49 @@
+package codemod
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// ParseContentTemplate parses Content as a text/template. Missing keys are
+// errors when the template is executed, so a plan cannot silently render
+// "<no value>" into a file.
+func (op Operation) ParseContentTemplate() (*template.Template, error) {
+	if op.Content == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMissingContent, op.Type)
+	}
+
+	tmpl, err := template.New("content").Option("missingkey=error").Parse(*op.Content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// RenderContent returns a copy of the operation with Content rendered as a
+// text/template against data. Operations without Template set are returned
+// unchanged.
+func (op Operation) RenderContent(data map[string]string) (Operation, error) {
+	if !op.Template {
+		return op, nil
+	}
+
+	tmpl, err := op.ParseContentTemplate()
+	if err != nil {
+		return op, err
+	}
+
+	var rendered strings.Builder
+
+	err = tmpl.Execute(&rendered, data)
+	if err != nil {
+		return op, fmt.Errorf("failed to render content template: %w", err)
+	}
+
+	content := rendered.String()
+	op.Content = &content
+
+	return op, nil
+}
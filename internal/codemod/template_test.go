@@ -0,0 +1,31 @@
+package codemod_test
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/codemod"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperation_RenderContent(t *testing.T) {
+	t.Parallel()
+
+	content := "module {{.MODULE}}\n\ngo {{.GO_VERSION}}\n"
+	//nolint:exhaustruct // Only the content fields matter.
+	op := codemod.Operation{Type: codemod.OpCreateOrOverwrite, Content: &content, Template: true}
+
+	rendered, err := op.RenderContent(map[string]string{"MODULE": "example.com/app", "GO_VERSION": "1.25"})
+	require.NoError(t, err)
+	assert.Equal(t, "module example.com/app\n\ngo 1.25\n", *rendered.Content)
+	assert.Equal(t, "module {{.MODULE}}\n\ngo {{.GO_VERSION}}\n", *op.Content, "the original is left alone")
+
+	_, err = op.RenderContent(map[string]string{"MODULE": "example.com/app"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `map has no entry for key "GO_VERSION"`)
+
+	op.Template = false
+	literal, err := op.RenderContent(nil)
+	require.NoError(t, err)
+	assert.Equal(t, content, *literal.Content, "content is literal unless template is set")
+}
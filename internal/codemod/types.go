@@ -1,5 +1,21 @@
 package codemod
 
+// Supported operation types.
+const (
+	// OpRegexReplace replaces all matches of FindRegex with ReplaceWith.
+	OpRegexReplace = "regex_replace"
+	// OpCreateOrOverwrite replaces the whole file with Content.
+	OpCreateOrOverwrite = "create_or_overwrite"
+	// OpInsertAfter inserts Content as new lines after the line matching AnchorRegex.
+	OpInsertAfter = "insert_after"
+	// OpInsertBefore inserts Content as new lines before the line matching AnchorRegex.
+	OpInsertBefore = "insert_before"
+	// OpAppendToFile adds Content at the end of the file, creating it if missing.
+	OpAppendToFile = "append_to_file"
+	// OpPrependToFile adds Content at the start of the file, creating it if missing.
+	OpPrependToFile = "prepend_to_file"
+)
+
 // Operation defines a single modification to be performed on a file.
 type Operation struct {
 	// Type indicates the kind of operation (e.g., "regex_replace", "add_import").
@@ -15,8 +31,34 @@ type Operation struct {
 	//nolint:tagliatelle // JSON keys are fixed by schema.
 	ReplaceWith string `json:"replace_with,omitempty"`
 
-	// --- Fields for "create_or_overwrite" ---
+	// --- Fields for "create_or_overwrite", the insert and the append/prepend types ---
 	Content *string `json:"content,omitempty"` // Pointer to distinguish empty from not-set
+	// Template renders Content as a Go text/template before it is used. Values
+	// come from the plan's vars and the environment, as in {{.APP_NAME}}.
+	Template bool `json:"template,omitempty"`
+
+	// --- Fields for "insert_after" and "insert_before" ---
+	// AnchorRegex selects the line to insert next to; only the first match is used
+	// unless AllMatches is set.
+	//nolint:tagliatelle // JSON keys are fixed by schema.
+	AnchorRegex string `json:"anchor_regex,omitempty"`
+	// AllMatches inserts Content next to every matching line.
+	//nolint:tagliatelle // JSON keys are fixed by schema.
+	AllMatches bool `json:"all_matches,omitempty"`
+	// AllowMissing leaves the file unchanged instead of failing when no line matches.
+	//nolint:tagliatelle // JSON keys are fixed by schema.
+	AllowMissing bool `json:"allow_missing,omitempty"`
+
+	// --- Fields for "append_to_file" and "prepend_to_file" ---
+	// EnsureNewline keeps Content on lines of its own: a newline is added between
+	// it and the existing content, and after it, when missing.
+	//nolint:tagliatelle // JSON keys are fixed by schema.
+	EnsureNewline bool `json:"ensure_newline,omitempty"`
+	// SkipIfContains leaves the file unchanged when it already contains this text,
+	// which makes the operation safe to run twice.
+	//nolint:tagliatelle // JSON keys are fixed by schema.
+	SkipIfContains string `json:"skip_if_contains,omitempty"`
+
 	// LineNumber can be used to target a specific line for some operations (not used by basic regex_replace yet).
 	//nolint:tagliatelle // JSON keys are fixed by schema.
 	LineNumber *int `json:"line_number,omitempty"`
@@ -31,3 +73,20 @@ type FileChangeSet struct {
 
 // ChangeScript is the top-level structure, representing a list of changes for multiple files.
 type ChangeScript []FileChangeSet
+
+// TargetPaths returns the unique file paths touched by the script, in order of first appearance.
+func (s ChangeScript) TargetPaths() []string {
+	seen := make(map[string]bool, len(s))
+	paths := make([]string, 0, len(s))
+
+	for _, changeSet := range s {
+		if changeSet.FilePath == "" || seen[changeSet.FilePath] {
+			continue
+		}
+
+		seen[changeSet.FilePath] = true
+		paths = append(paths, changeSet.FilePath)
+	}
+
+	return paths
+}
@@ -7,6 +7,8 @@ import (
 	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/contextvibes/cli/internal/exec"
@@ -24,6 +26,8 @@ const (
 	StrategicKickoffFilename = "docs/strategic_kickoff_protocol.md"
 	// DefaultBranchNamePattern is the default regex for branch validation.
 	DefaultBranchNamePattern = `^((feature|fix|docs|format)/.+)$`
+	// DefaultBranchNameTemplate is the default template kickoff uses to propose branch names.
+	DefaultBranchNameTemplate = "feature/ISSUE-{ticket}-{slug}"
 	// DefaultCommitMessagePattern is the default regex for commit message validation.
 	//nolint:lll // Regex pattern is long by necessity.
 	DefaultCommitMessagePattern = `^(BREAKING|feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([a-zA-Z0-9\-_/]+\))?:\s.+`
@@ -31,6 +35,10 @@ const (
 	DefaultGitRemote = "origin"
 	// DefaultGitMainBranch is the default main branch name.
 	DefaultGitMainBranch = "main"
+	// DefaultTreeDepth is the number of directory levels shown in project trees.
+	DefaultTreeDepth = 2
+	// DefaultLogLevel is the slog level used when neither --log-level nor logging.level is set.
+	DefaultLogLevel = "info"
 	// UltimateDefaultAILogFilename is the fallback log file name.
 	UltimateDefaultAILogFilename = "contextvibes_ai_trace.log"
 
@@ -46,12 +54,45 @@ var (
 	ErrNotGitRepo = errors.New("git rev-parse --show-toplevel returned an empty or invalid path, not in a git repository")
 	// ErrNilConfigSave is returned when attempting to save a nil config.
 	ErrNilConfigSave = errors.New("cannot save a nil config to file")
+	// ErrUnknownRepoAlias is returned when a feedback repository alias is not configured.
+	ErrUnknownRepoAlias = errors.New("unknown repository alias")
+	// ErrInvalidRepoFormat is returned when a repository is not in 'owner/repo' form.
+	ErrInvalidRepoFormat = errors.New("invalid repository format, expected 'owner/repo'")
+	// ErrInvalidTreeDepth is returned when a project tree depth is less than one.
+	ErrInvalidTreeDepth = errors.New("tree depth must be at least 1")
+	// ErrInvalidValidationPattern is returned when a validation rule's regex does not compile.
+	ErrInvalidValidationPattern = errors.New("invalid validation regex")
+	// ErrInvalidCommitMessage is returned when a commit subject does not match the commit message rule.
+	ErrInvalidCommitMessage = errors.New("invalid commit message format")
+	// ErrConfigNotFound is returned when an explicitly requested config file does not exist.
+	ErrConfigNotFound = errors.New("config file not found")
+
+	ownerRepoRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*/[A-Za-z0-9._-]+$`)
 )
 
 // GitSettings configures git behavior.
 type GitSettings struct {
 	DefaultRemote     string `yaml:"defaultRemote,omitempty"`
 	DefaultMainBranch string `yaml:"defaultMainBranch,omitempty"`
+	// BranchNameTemplate builds proposed branch names from the {ticket} and {slug}
+	// placeholders, e.g. "feature/{ticket}-{slug}".
+	BranchNameTemplate string `yaml:"branchNameTemplate,omitempty"`
+	// BranchProtection is the rule set 'factory protect-branch' applies.
+	BranchProtection BranchProtectionSettings `yaml:"branchProtection,omitempty"`
+}
+
+// BranchProtectionSettings configures the protection rules applied to a branch.
+type BranchProtectionSettings struct {
+	// RequiredApprovingReviews is the number of approvals (1-6) a pull request
+	// needs; zero leaves pull request reviews optional.
+	RequiredApprovingReviews int  `yaml:"requiredApprovingReviews,omitempty"`
+	DismissStaleReviews      bool `yaml:"dismissStaleReviews,omitempty"`
+	RequireCodeOwnerReviews  bool `yaml:"requireCodeOwnerReviews,omitempty"`
+	// RequiredStatusChecks lists the check names that must pass before merging.
+	RequiredStatusChecks []string `yaml:"requiredStatusChecks,omitempty"`
+	// StrictStatusChecks requires branches to be up to date before merging.
+	StrictStatusChecks bool `yaml:"strictStatusChecks,omitempty"`
+	EnforceAdmins      bool `yaml:"enforceAdmins,omitempty"`
 }
 
 // ValidationRule defines a validation rule with an enable flag and a regex pattern.
@@ -60,11 +101,58 @@ type ValidationRule struct {
 	Pattern string `yaml:"pattern,omitempty"`
 }
 
+// Enabled reports whether the rule applies. Rules are enabled unless explicitly disabled.
+func (r ValidationRule) Enabled() bool {
+	return r.Enable == nil || *r.Enable
+}
+
+// PatternOrDefault returns the configured pattern, or defaultPattern when none is set.
+func (r ValidationRule) PatternOrDefault(defaultPattern string) string {
+	if r.Pattern == "" {
+		return defaultPattern
+	}
+
+	return r.Pattern
+}
+
+// CommitSubject returns the first line of a commit message, which is the part
+// validated by the commit message rule.
+func CommitSubject(message string) string {
+	subject, _, _ := strings.Cut(message, "\n")
+
+	return subject
+}
+
+// ValidateCommitMessage checks the subject of message against the commit message
+// rule (DefaultCommitMessagePattern when no pattern is configured). A disabled
+// rule accepts any message.
+func ValidateCommitMessage(rule ValidationRule, message string) error {
+	if !rule.Enabled() {
+		return nil
+	}
+
+	pattern := rule.PatternOrDefault(DefaultCommitMessagePattern)
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidValidationPattern, pattern, err)
+	}
+
+	subject := CommitSubject(message)
+	if !re.MatchString(subject) {
+		return fmt.Errorf("%w: subject %q does not match %s", ErrInvalidCommitMessage, subject, pattern)
+	}
+
+	return nil
+}
+
 // LoggingSettings configures application logging.
 type LoggingSettings struct {
 	Enable *bool `yaml:"enable,omitempty"`
 	//nolint:tagliatelle // Keep camelCase for backward compatibility.
 	DefaultAILogFile string `yaml:"defaultAILogFile,omitempty"`
+	// Level is the slog level (debug, info, warn or error) for every log sink.
+	Level string `yaml:"level,omitempty"`
 }
 
 // SystemPromptSettings configures system prompt generation.
@@ -78,6 +166,13 @@ type ProjectState struct {
 	LastStrategicKickoffDate  string `yaml:"lastStrategicKickoffDate,omitempty"`
 }
 
+// KickoffSettings stores the answers given during the strategic kickoff so later
+// runs can offer them as defaults.
+type KickoffSettings struct {
+	ProjectName string `yaml:"projectName,omitempty"`
+	ProjectType string `yaml:"projectType,omitempty"`
+}
+
 // AICollaborationPreferences defines how the AI should interact with the user.
 type AICollaborationPreferences struct {
 	CodeProvisioningStyle string `yaml:"codeProvisioningStyle,omitempty"`
@@ -113,23 +208,67 @@ type RunSettings struct {
 // ExportSettings configures the 'export' command.
 type ExportSettings struct {
 	ExcludePatterns []string `yaml:"excludePatterns,omitempty"`
+	// ExtendPatterns appends ExcludePatterns to the defaults instead of replacing them.
+	ExtendPatterns bool `yaml:"extendPatterns,omitempty"`
 }
 
 // DescribeSettings configures the 'describe' command.
 type DescribeSettings struct {
 	IncludePatterns []string `yaml:"includePatterns,omitempty"`
 	ExcludePatterns []string `yaml:"excludePatterns,omitempty"`
+	// ExtendPatterns appends IncludePatterns and ExcludePatterns to the defaults
+	// instead of replacing them.
+	ExtendPatterns bool `yaml:"extendPatterns,omitempty"`
+	// TreeDepth is the number of directory levels in the project structure
+	// section of describe and onboard.
+	TreeDepth int `yaml:"treeDepth,omitempty"`
+	// TokenWarningThreshold makes describe and onboard warn when the estimated
+	// token count of their output exceeds it. Zero disables the warning.
+	TokenWarningThreshold int `yaml:"tokenWarningThreshold,omitempty"`
+}
+
+// ResolveTreeDepth returns the tree depth to use: flagValue when it was set
+// (non-zero), otherwise the configured depth, otherwise DefaultTreeDepth.
+func (d DescribeSettings) ResolveTreeDepth(flagValue int) (int, error) {
+	depth := flagValue
+	if depth == 0 {
+		depth = d.TreeDepth
+	}
+
+	if depth == 0 {
+		depth = DefaultTreeDepth
+	}
+
+	if depth < 1 {
+		return 0, fmt.Errorf("%w: got %d", ErrInvalidTreeDepth, depth)
+	}
+
+	return depth, nil
 }
 
 // ProjectSettings configures project-wide settings.
 type ProjectSettings struct {
-	Provider        string   `yaml:"provider,omitempty"`
+	Provider string `yaml:"provider,omitempty"`
+	// GitHubHost is the GitHub Enterprise Server host, e.g. "github.example.com".
+	// Remotes on github.com are always accepted.
+	GitHubHost      string   `yaml:"githubHost,omitempty"`
 	UpstreamModules []string `yaml:"upstreamModules,omitempty"`
+	// Labels is the issue label set that 'project labels sync' creates in the tracker.
+	Labels []LabelSetting `yaml:"labels,omitempty"`
+}
+
+// LabelSetting describes one issue label kept in sync with the tracker.
+type LabelSetting struct {
+	Name        string `yaml:"name"`
+	Color       string `yaml:"color,omitempty"`
+	Description string `yaml:"description,omitempty"`
 }
 
 // BehaviorSettings configures general CLI behavior.
 type BehaviorSettings struct {
 	DualOutput bool `yaml:"dualOutput,omitempty"`
+	// ScanSecretsBeforeCommit runs gitleaks on the staged changes before committing.
+	ScanSecretsBeforeCommit bool `yaml:"scanSecretsBeforeCommit,omitempty"`
 }
 
 // FeedbackSettings configures the 'feedback' command.
@@ -138,16 +277,59 @@ type FeedbackSettings struct {
 	Repositories      map[string]string `yaml:"repositories,omitempty"`
 }
 
+// Aliases returns the configured repository aliases, sorted.
+func (f FeedbackSettings) Aliases() []string {
+	return slices.Sorted(maps.Keys(f.Repositories))
+}
+
+// ResolveRepository maps a repository selector to its owner and name. The selector
+// is either an alias from Repositories or a literal 'owner/repo'; an empty selector
+// uses DefaultRepository.
+//
+//nolint:nonamedreturns // Named returns are used for clarity in return signature.
+func (f FeedbackSettings) ResolveRepository(selector string) (owner, repo string, err error) {
+	if selector == "" {
+		selector = f.DefaultRepository
+	}
+
+	target := selector
+
+	if !strings.Contains(selector, "/") {
+		aliased, ok := f.Repositories[selector]
+		if !ok {
+			return "", "", fmt.Errorf(
+				"%w '%s' (available: %s)",
+				ErrUnknownRepoAlias,
+				selector,
+				strings.Join(f.Aliases(), ", "),
+			)
+		}
+
+		target = aliased
+	}
+
+	if !ownerRepoRegex.MatchString(target) {
+		return "", "", fmt.Errorf("%w: got '%s'", ErrInvalidRepoFormat, target)
+	}
+
+	owner, repo, _ = strings.Cut(target, "/")
+
+	return owner, repo, nil
+}
+
 // Config is the top-level configuration structure.
 type Config struct {
-	Git          GitSettings          `yaml:"git,omitempty"`
-	Logging      LoggingSettings      `yaml:"logging,omitempty"`
-	SystemPrompt SystemPromptSettings `yaml:"systemPrompt,omitempty"`
-	Validation   struct {
+	// SchemaVersion is the layout version of the file; see CurrentSchemaVersion.
+	SchemaVersion int                  `yaml:"schemaVersion,omitempty"`
+	Git           GitSettings          `yaml:"git,omitempty"`
+	Logging       LoggingSettings      `yaml:"logging,omitempty"`
+	SystemPrompt  SystemPromptSettings `yaml:"systemPrompt,omitempty"`
+	Validation    struct {
 		BranchName    ValidationRule `yaml:"branchName,omitempty"`
 		CommitMessage ValidationRule `yaml:"commitMessage,omitempty"`
 	} `yaml:"validation,omitempty"`
 	ProjectState ProjectState     `yaml:"projectState,omitempty"`
+	Kickoff      KickoffSettings  `yaml:"kickoff,omitempty"`
 	AI           AISettings       `yaml:"ai,omitempty"`
 	Run          RunSettings      `yaml:"run,omitempty"`
 	Export       ExportSettings   `yaml:"export,omitempty"`
@@ -165,13 +347,16 @@ func GetDefaultConfig() *Config {
 	defaultFalse := false
 
 	cfg := &Config{
+		SchemaVersion: CurrentSchemaVersion,
 		Git: GitSettings{
-			DefaultRemote:     DefaultGitRemote,
-			DefaultMainBranch: DefaultGitMainBranch,
+			DefaultRemote:      DefaultGitRemote,
+			DefaultMainBranch:  DefaultGitMainBranch,
+			BranchNameTemplate: DefaultBranchNameTemplate,
 		},
 		Logging: LoggingSettings{
 			Enable:           &defaultFalse,
 			DefaultAILogFile: UltimateDefaultAILogFilename,
+			Level:            DefaultLogLevel,
 		},
 		SystemPrompt: SystemPromptSettings{
 			DefaultOutputFiles: map[string]string{
@@ -223,13 +408,15 @@ func GetDefaultConfig() *Config {
 				`(\.tfstate|\.tfplan|^secrets?/|\.auto\.tfvars|ai_context\.txt|crash.*\.log|contextvibes\.md)$`,
 				`\.(exe|bin|dll|so|jar|class|o|a|zip|tar\.gz|rar|7z|jpg|jpeg|png|gif|svg|ico|woff|woff2|ttf|eot)$`,
 			},
+			TreeDepth: DefaultTreeDepth,
 		},
 		Project: ProjectSettings{
 			Provider:        "github",
 			UpstreamModules: nil,
 		},
 		Behavior: BehaviorSettings{
-			DualOutput: true,
+			DualOutput:              true,
+			ScanSecretsBeforeCommit: false,
 		},
 		Feedback: FeedbackSettings{
 			DefaultRepository: "cli",
@@ -261,6 +448,11 @@ func LoadConfig(filePath string) (*Config, error) {
 		return nil, ErrEmptyConfig
 	}
 
+	data, err = migrateDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML config file '%s': %w", filePath, err)
+	}
+
 	var cfg Config
 
 	err = yaml.Unmarshal(data, &cfg)
@@ -277,9 +469,45 @@ func FindRepoRootConfigPath(execClient *exec.ExecutorClient) (string, error) {
 		return "", ErrNoExecutor
 	}
 
-	ctx := context.Background()
+	repoRoot, err := findRepoRoot(execClient)
+	if err != nil {
+		return "", err
+	}
+
+	configPath := filepath.Join(repoRoot, DefaultConfigFileName)
+
+	_, statErr := os.Stat(configPath)
+	if os.IsNotExist(statErr) {
+		return "", nil
+	} else if statErr != nil {
+		return "", fmt.Errorf("error checking for config file at '%s': %w", configPath, statErr)
+	}
+
+	return configPath, nil
+}
+
+// TargetConfigPath returns the config file that commands writing settings should
+// update: explicitPath when set (the --config flag), otherwise the config file in
+// the repository root, which may not exist yet.
+func TargetConfigPath(execClient *exec.ExecutorClient, explicitPath string) (string, error) {
+	if explicitPath != "" {
+		return explicitPath, nil
+	}
+
+	if execClient == nil {
+		return "", ErrNoExecutor
+	}
+
+	repoRoot, err := findRepoRoot(execClient)
+	if err != nil {
+		return "", err
+	}
 
-	stdout, stderr, err := execClient.CaptureOutput(ctx, ".", "git", "rev-parse", "--show-toplevel")
+	return filepath.Join(repoRoot, DefaultConfigFileName), nil
+}
+
+func findRepoRoot(execClient *exec.ExecutorClient) (string, error) {
+	stdout, stderr, err := execClient.CaptureOutput(context.Background(), ".", "git", "rev-parse", "--show-toplevel")
 	if err != nil {
 		return "", fmt.Errorf(
 			"failed to determine git repository root (is this a git repo, or is 'git' not in PATH? details: %s): %w",
@@ -293,16 +521,44 @@ func FindRepoRootConfigPath(execClient *exec.ExecutorClient) (string, error) {
 		return "", ErrNotGitRepo
 	}
 
-	configPath := filepath.Join(repoRoot, DefaultConfigFileName)
+	return repoRoot, nil
+}
 
-	_, statErr := os.Stat(configPath)
-	if os.IsNotExist(statErr) {
-		return "", nil
-	} else if statErr != nil {
-		return "", fmt.Errorf("error checking for config file at '%s': %w", configPath, statErr)
+// LoadEffectiveConfig returns the configuration the CLI runs with: the config file
+// merged with the defaults, with environment variable overrides (see
+// ApplyEnvOverrides) and path expansion (see ExpandPaths) applied last. It also returns the path of the config file
+// that was used, or "" when running on defaults. When explicitPath is set that
+// file is used directly and must exist; otherwise the file is discovered with
+// FindRepoRootConfigPath, and a missing or unreadable discovered file falls back
+// to the defaults.
+func LoadEffectiveConfig(execClient *exec.ExecutorClient, explicitPath string) (*Config, string, error) {
+	defaultCfg := GetDefaultConfig()
+
+	if explicitPath != "" {
+		_, err := os.Stat(explicitPath)
+		if os.IsNotExist(err) {
+			return nil, "", fmt.Errorf("%w: %s", ErrConfigNotFound, explicitPath)
+		}
+
+		loadedCfg, err := LoadConfig(explicitPath)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return applyProcessEnv(MergeWithDefaults(loadedCfg, defaultCfg)), explicitPath, nil
 	}
 
-	return configPath, nil
+	repoConfigPath, _ := FindRepoRootConfigPath(execClient)
+	if repoConfigPath == "" {
+		return applyProcessEnv(defaultCfg), "", nil
+	}
+
+	loadedCfg, _ := LoadConfig(repoConfigPath)
+	if loadedCfg == nil {
+		return applyProcessEnv(defaultCfg), "", nil
+	}
+
+	return applyProcessEnv(MergeWithDefaults(loadedCfg, defaultCfg)), repoConfigPath, nil
 }
 
 // MergeWithDefaults merges a loaded config with the default config.
@@ -319,6 +575,10 @@ func MergeWithDefaults(loadedCfg *Config, defaultConfig *Config) *Config {
 
 	finalCfg := *defaultConfig
 
+	if loadedCfg.SchemaVersion != 0 {
+		finalCfg.SchemaVersion = loadedCfg.SchemaVersion
+	}
+
 	if loadedCfg.Git.DefaultRemote != "" {
 		finalCfg.Git.DefaultRemote = loadedCfg.Git.DefaultRemote
 	}
@@ -327,6 +587,13 @@ func MergeWithDefaults(loadedCfg *Config, defaultConfig *Config) *Config {
 		finalCfg.Git.DefaultMainBranch = loadedCfg.Git.DefaultMainBranch
 	}
 
+	if loadedCfg.Git.BranchNameTemplate != "" {
+		finalCfg.Git.BranchNameTemplate = loadedCfg.Git.BranchNameTemplate
+	}
+
+	// There are no built-in protection rules, so the file's rules apply as written.
+	finalCfg.Git.BranchProtection = loadedCfg.Git.BranchProtection
+
 	if loadedCfg.Logging.Enable != nil {
 		finalCfg.Logging.Enable = loadedCfg.Logging.Enable
 	}
@@ -335,10 +602,15 @@ func MergeWithDefaults(loadedCfg *Config, defaultConfig *Config) *Config {
 		finalCfg.Logging.DefaultAILogFile = loadedCfg.Logging.DefaultAILogFile
 	}
 
-	if loadedCfg.SystemPrompt.DefaultOutputFiles != nil {
-		finalCfg.SystemPrompt.DefaultOutputFiles = loadedCfg.SystemPrompt.DefaultOutputFiles
+	if loadedCfg.Logging.Level != "" {
+		finalCfg.Logging.Level = loadedCfg.Logging.Level
 	}
 
+	finalCfg.SystemPrompt.DefaultOutputFiles = mergeMaps(
+		defaultConfig.SystemPrompt.DefaultOutputFiles,
+		loadedCfg.SystemPrompt.DefaultOutputFiles,
+	)
+
 	if loadedCfg.Validation.BranchName.Enable != nil {
 		finalCfg.Validation.BranchName.Enable = loadedCfg.Validation.BranchName.Enable
 	}
@@ -372,6 +644,14 @@ func MergeWithDefaults(loadedCfg *Config, defaultConfig *Config) *Config {
 		finalCfg.ProjectState.LastStrategicKickoffDate = loadedCfg.ProjectState.LastStrategicKickoffDate
 	}
 
+	if loadedCfg.Kickoff.ProjectName != "" {
+		finalCfg.Kickoff.ProjectName = loadedCfg.Kickoff.ProjectName
+	}
+
+	if loadedCfg.Kickoff.ProjectType != "" {
+		finalCfg.Kickoff.ProjectType = loadedCfg.Kickoff.ProjectType
+	}
+
 	userAICollabPrefs := loadedCfg.AI.CollaborationPreferences
 	if userAICollabPrefs.CodeProvisioningStyle != "" {
 		finalCfg.AI.CollaborationPreferences.CodeProvisioningStyle = userAICollabPrefs.CodeProvisioningStyle
@@ -393,52 +673,118 @@ func MergeWithDefaults(loadedCfg *Config, defaultConfig *Config) *Config {
 		finalCfg.AI.CollaborationPreferences.AIProactivity = loadedCfg.AI.CollaborationPreferences.AIProactivity
 	}
 
-	if loadedCfg.Run.Examples != nil {
-		finalCfg.Run.Examples = loadedCfg.Run.Examples
-	}
+	finalCfg.Run.Examples = mergeMaps(defaultConfig.Run.Examples, loadedCfg.Run.Examples)
 
-	if loadedCfg.Export.ExcludePatterns != nil {
-		finalCfg.Export.ExcludePatterns = loadedCfg.Export.ExcludePatterns
-	}
+	finalCfg.Export.ExcludePatterns = mergePatterns(
+		defaultConfig.Export.ExcludePatterns,
+		loadedCfg.Export.ExcludePatterns,
+		loadedCfg.Export.ExtendPatterns,
+	)
+	finalCfg.Export.ExtendPatterns = loadedCfg.Export.ExtendPatterns
+
+	finalCfg.Describe.IncludePatterns = mergePatterns(
+		defaultConfig.Describe.IncludePatterns,
+		loadedCfg.Describe.IncludePatterns,
+		loadedCfg.Describe.ExtendPatterns,
+	)
+	finalCfg.Describe.ExcludePatterns = mergePatterns(
+		defaultConfig.Describe.ExcludePatterns,
+		loadedCfg.Describe.ExcludePatterns,
+		loadedCfg.Describe.ExtendPatterns,
+	)
+	finalCfg.Describe.ExtendPatterns = loadedCfg.Describe.ExtendPatterns
 
-	if loadedCfg.Describe.IncludePatterns != nil {
-		finalCfg.Describe.IncludePatterns = loadedCfg.Describe.IncludePatterns
+	if loadedCfg.Describe.TreeDepth != 0 {
+		finalCfg.Describe.TreeDepth = loadedCfg.Describe.TreeDepth
 	}
 
-	if loadedCfg.Describe.ExcludePatterns != nil {
-		finalCfg.Describe.ExcludePatterns = loadedCfg.Describe.ExcludePatterns
+	if loadedCfg.Describe.TokenWarningThreshold != 0 {
+		finalCfg.Describe.TokenWarningThreshold = loadedCfg.Describe.TokenWarningThreshold
 	}
 
 	if loadedCfg.Project.Provider != "" {
 		finalCfg.Project.Provider = loadedCfg.Project.Provider
 	}
 
+	if loadedCfg.Project.GitHubHost != "" {
+		finalCfg.Project.GitHubHost = loadedCfg.Project.GitHubHost
+	}
+
 	if loadedCfg.Project.UpstreamModules != nil {
 		finalCfg.Project.UpstreamModules = loadedCfg.Project.UpstreamModules
 	}
 
+	if loadedCfg.Project.Labels != nil {
+		finalCfg.Project.Labels = loadedCfg.Project.Labels
+	}
+
 	if loadedCfg.Behavior.DualOutput != defaultConfig.Behavior.DualOutput {
 		finalCfg.Behavior.DualOutput = loadedCfg.Behavior.DualOutput
 	}
 
+	if loadedCfg.Behavior.ScanSecretsBeforeCommit != defaultConfig.Behavior.ScanSecretsBeforeCommit {
+		finalCfg.Behavior.ScanSecretsBeforeCommit = loadedCfg.Behavior.ScanSecretsBeforeCommit
+	}
+
 	if loadedCfg.Feedback.DefaultRepository != "" {
 		finalCfg.Feedback.DefaultRepository = loadedCfg.Feedback.DefaultRepository
 	}
 
-	if loadedCfg.Feedback.Repositories != nil {
-		maps.Copy(finalCfg.Feedback.Repositories, loadedCfg.Feedback.Repositories)
-	}
+	finalCfg.Feedback.Repositories = mergeMaps(defaultConfig.Feedback.Repositories, loadedCfg.Feedback.Repositories)
 
 	return &finalCfg
 }
 
+// mergePatterns returns the user's patterns in place of the defaults, or, when
+// extend is set, the defaults followed by any user patterns not already present.
+// Without user patterns the defaults are kept.
+func mergePatterns(defaults, user []string, extend bool) []string {
+	if user == nil {
+		return defaults
+	}
+
+	if !extend {
+		return user
+	}
+
+	merged := slices.Clone(defaults)
+
+	for _, pattern := range user {
+		if !slices.Contains(merged, pattern) {
+			merged = append(merged, pattern)
+		}
+	}
+
+	return merged
+}
+
+// mergeMaps returns a new map holding the defaults with the user's entries laid
+// over them key by key, so adding one entry keeps the built-in ones. The inputs
+// are not modified.
+func mergeMaps[V any](defaults, overrides map[string]V) map[string]V {
+	if defaults == nil && overrides == nil {
+		return nil
+	}
+
+	merged := make(map[string]V, len(defaults)+len(overrides))
+	maps.Copy(merged, defaults)
+	maps.Copy(merged, overrides)
+
+	return merged
+}
+
 // UpdateAndSaveConfig writes the configuration to the specified file path.
 func UpdateAndSaveConfig(cfgToSave *Config, filePath string) error {
 	if cfgToSave == nil {
 		return ErrNilConfigSave
 	}
 
-	yamlData, err := yaml.Marshal(cfgToSave)
+	stamped := *cfgToSave
+	if stamped.SchemaVersion == 0 {
+		stamped.SchemaVersion = CurrentSchemaVersion
+	}
+
+	yamlData, err := yaml.Marshal(&stamped)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config to YAML for saving: %w", err)
 	}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -46,6 +48,28 @@ func (m *mockExecutor) Execute(
 	return errors.New("Execute not implemented in mock")
 }
 
+func (m *mockExecutor) ExecuteWithEnv(
+	_ context.Context,
+	_ string,
+	_ map[string]string,
+	_ string,
+	_ ...string,
+) error {
+	//nolint:err113 // Dynamic error is appropriate here.
+	return errors.New("ExecuteWithEnv not implemented in mock")
+}
+
+func (m *mockExecutor) ExecuteWithStdin(
+	_ context.Context,
+	_ string,
+	_ io.Reader,
+	_ string,
+	_ ...string,
+) error {
+	//nolint:err113 // Dynamic error is appropriate here.
+	return errors.New("ExecuteWithStdin not implemented in mock")
+}
+
 func (m *mockExecutor) CommandExists(_ string) bool {
 	return false
 }
@@ -293,6 +317,89 @@ func TestMergeWithDefaults(t *testing.T) {
 			merged.ProjectState.LastStrategicKickoffDate,
 		)
 	})
+
+	t.Run("add one system prompt output target", func(t *testing.T) {
+		t.Parallel()
+		//nolint:exhaustruct // Testing partial config.
+		loaded := &config.Config{SystemPrompt: config.SystemPromptSettings{
+			DefaultOutputFiles: map[string]string{"cursor": ".cursorrules", "idx": "custom/airules.md"},
+		}}
+		merged := config.MergeWithDefaults(loaded, defaults)
+		assert.Equal(t, map[string]string{
+			"idx":      "custom/airules.md",
+			"aistudio": "contextvibes_aistudio_prompt.md",
+			"cursor":   ".cursorrules",
+		}, merged.SystemPrompt.DefaultOutputFiles)
+		assert.NotContains(t, defaults.SystemPrompt.DefaultOutputFiles, "cursor", "defaults are not modified")
+	})
+
+	t.Run("add one run example", func(t *testing.T) {
+		t.Parallel()
+
+		withExample := config.GetDefaultConfig()
+		withExample.Run.Examples["basic"] = config.ExampleSettings{
+			Verify: []config.VerificationCheck{{Name: "build", Description: "", Command: "go", Args: nil}},
+		}
+		//nolint:exhaustruct // Testing partial config.
+		loaded := &config.Config{Run: config.RunSettings{
+			Examples: map[string]config.ExampleSettings{"advanced": {Verify: nil}},
+		}}
+		merged := config.MergeWithDefaults(loaded, withExample)
+		assert.Len(t, merged.Run.Examples, 2)
+		assert.Contains(t, merged.Run.Examples, "basic")
+		assert.Contains(t, merged.Run.Examples, "advanced")
+		assert.Len(t, withExample.Run.Examples, 1, "defaults are not modified")
+	})
+
+	t.Run("describe patterns replace defaults", func(t *testing.T) {
+		t.Parallel()
+		//nolint:exhaustruct // Testing partial config.
+		loaded := &config.Config{Describe: config.DescribeSettings{
+			IncludePatterns: []string{`\.proto$`},
+		}}
+		merged := config.MergeWithDefaults(loaded, defaults)
+		assert.Equal(t, []string{`\.proto$`}, merged.Describe.IncludePatterns)
+		assert.Equal(t, defaults.Describe.ExcludePatterns, merged.Describe.ExcludePatterns)
+	})
+
+	t.Run("describe and export patterns extend defaults", func(t *testing.T) {
+		t.Parallel()
+		//nolint:exhaustruct // Testing partial config.
+		loaded := &config.Config{
+			Describe: config.DescribeSettings{
+				IncludePatterns: []string{`\.proto$`},
+				ExcludePatterns: []string{`^generated/`},
+				ExtendPatterns:  true,
+			},
+			Export: config.ExportSettings{
+				ExcludePatterns: []string{"vendor/", "third_party/"},
+				ExtendPatterns:  true,
+			},
+		}
+		merged := config.MergeWithDefaults(loaded, defaults)
+		assert.Equal(t,
+			append(slices.Clone(defaults.Describe.IncludePatterns), `\.proto$`),
+			merged.Describe.IncludePatterns,
+		)
+		assert.Equal(t,
+			append(slices.Clone(defaults.Describe.ExcludePatterns), `^generated/`),
+			merged.Describe.ExcludePatterns,
+		)
+		assert.Equal(t, []string{"vendor/", "third_party/"}, merged.Export.ExcludePatterns, "duplicates are skipped")
+		assert.Len(t, defaults.Describe.IncludePatterns, 2, "defaults are not modified")
+	})
+
+	t.Run("add one feedback repository", func(t *testing.T) {
+		t.Parallel()
+		//nolint:exhaustruct // Testing partial config.
+		loaded := &config.Config{Feedback: config.FeedbackSettings{
+			Repositories: map[string]string{"fork": "me/cli"},
+		}}
+		merged := config.MergeWithDefaults(loaded, defaults)
+		assert.Equal(t, "me/cli", merged.Feedback.Repositories["fork"])
+		assert.Equal(t, "contextvibes/cli", merged.Feedback.Repositories["cli"])
+		assert.NotContains(t, defaults.Feedback.Repositories, "fork", "defaults are not modified")
+	})
 }
 
 func TestUpdateAndSaveConfig(t *testing.T) {
@@ -436,3 +543,175 @@ func runFindRepoRootTest(t *testing.T, testCase findRepoRootTestCase) {
 		assert.Equal(t, testCase.expectedPath, configPath)
 	}
 }
+
+func TestLoadEffectiveConfig(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(repoDir, config.DefaultConfigFileName),
+		[]byte("git:\n  defaultRemote: discovered\n"),
+		0o600,
+	))
+
+	explicitPath := filepath.Join(t.TempDir(), "alternate.yaml")
+	require.NoError(t, os.WriteFile(explicitPath, []byte("git:\n  defaultRemote: explicit\n"), 0o600))
+
+	client := exec.NewClient(&mockExecutor{
+		CaptureOutputFunc: func(_ context.Context, _ string, _ string, _ ...string) (string, string, error) {
+			return repoDir + "\n", "", nil
+		},
+	})
+
+	t.Run("discovered config", func(t *testing.T) {
+		t.Parallel()
+
+		cfg, sourcePath, err := config.LoadEffectiveConfig(client, "")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(repoDir, config.DefaultConfigFileName), sourcePath)
+		assert.Equal(t, "discovered", cfg.Git.DefaultRemote)
+	})
+
+	t.Run("explicit path overrides discovered config", func(t *testing.T) {
+		t.Parallel()
+
+		cfg, sourcePath, err := config.LoadEffectiveConfig(client, explicitPath)
+		require.NoError(t, err)
+		assert.Equal(t, explicitPath, sourcePath)
+		assert.Equal(t, "explicit", cfg.Git.DefaultRemote)
+		assert.Equal(t, config.DefaultGitMainBranch, cfg.Git.DefaultMainBranch, "defaults are still merged")
+	})
+
+	t.Run("missing explicit path", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := config.LoadEffectiveConfig(client, filepath.Join(repoDir, "missing.yaml"))
+		require.ErrorIs(t, err, config.ErrConfigNotFound)
+	})
+}
+
+func TestFeedbackSettings_ResolveRepository(t *testing.T) {
+	t.Parallel()
+
+	settings := config.GetDefaultConfig().Feedback
+
+	testCases := []struct {
+		name      string
+		selector  string
+		wantOwner string
+		wantRepo  string
+		wantErr   error
+	}{
+		{name: "default alias", selector: "", wantOwner: "contextvibes", wantRepo: "cli"},
+		{name: "named alias", selector: "thea", wantOwner: "contextvibes", wantRepo: "thea"},
+		{name: "raw owner/repo", selector: "octo-org/widgets.go", wantOwner: "octo-org", wantRepo: "widgets.go"},
+		{name: "unknown alias", selector: "nope", wantErr: config.ErrUnknownRepoAlias},
+		{name: "malformed owner/repo", selector: "octo/widgets/extra", wantErr: config.ErrInvalidRepoFormat},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			owner, repo, err := settings.ResolveRepository(testCase.selector)
+			if testCase.wantErr != nil {
+				require.ErrorIs(t, err, testCase.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantOwner, owner)
+			assert.Equal(t, testCase.wantRepo, repo)
+		})
+	}
+
+	t.Run("unknown alias lists available aliases", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := settings.ResolveRepository("nope")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "available: cli, thea")
+	})
+
+	t.Run("misconfigured alias target", func(t *testing.T) {
+		t.Parallel()
+
+		broken := config.FeedbackSettings{
+			DefaultRepository: "bad",
+			Repositories:      map[string]string{"bad": "not-a-repo"},
+		}
+
+		_, _, err := broken.ResolveRepository("")
+		require.ErrorIs(t, err, config.ErrInvalidRepoFormat)
+	})
+}
+
+func TestDescribeSettings_ResolveTreeDepth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		configured int
+		flag       int
+		want       int
+		wantErr    bool
+	}{
+		{name: "default", configured: 0, flag: 0, want: config.DefaultTreeDepth, wantErr: false},
+		{name: "config value", configured: 4, flag: 0, want: 4, wantErr: false},
+		{name: "flag overrides config", configured: 4, flag: 1, want: 1, wantErr: false},
+		{name: "negative flag", configured: 0, flag: -1, want: 0, wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			//nolint:exhaustruct // Only the tree depth matters here.
+			settings := config.DescribeSettings{TreeDepth: testCase.configured}
+
+			got, err := settings.ResolveTreeDepth(testCase.flag)
+			if testCase.wantErr {
+				require.ErrorIs(t, err, config.ErrInvalidTreeDepth)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestValidateCommitMessage(t *testing.T) {
+	t.Parallel()
+
+	disabled := false
+
+	tests := []struct {
+		name    string
+		rule    config.ValidationRule
+		message string
+		wantErr error
+	}{
+		{"default accepts conventional subject", config.ValidationRule{}, "feat(cli): Add x\n\nBody", nil},
+		{"default rejects free-form subject", config.ValidationRule{}, "Add x", config.ErrInvalidCommitMessage},
+		{"only the subject is validated", config.ValidationRule{}, "Add x\n\nfeat: body", config.ErrInvalidCommitMessage},
+		{"disabled rule accepts anything", config.ValidationRule{Enable: &disabled}, "Add x", nil},
+		{"custom pattern", config.ValidationRule{Pattern: `^JIRA-\d+`}, "JIRA-1 Add x", nil},
+		{"broken pattern", config.ValidationRule{Pattern: `(`}, "feat: x", config.ErrInvalidValidationPattern},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := config.ValidateCommitMessage(tc.rule, tc.message)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -18,6 +18,10 @@ The primary components are:
     file by searching upwards from the current directory to the Git repository root.
   - MergeWithDefaults(loadedCfg *Config, defaultConfig *Config): Merges a loaded
     user configuration with the default configuration, giving precedence to user-defined values.
+  - LoadEffectiveConfig(execClient *exec.ExecutorClient, explicitPath string): Combines
+    the above, loading either an explicit config file (the --config flag) or the
+    discovered one and merging it with the defaults. It also returns the file used.
+  - FindUnknownKeys(filePath string): Reports keys that match no setting, with their lines.
 
 Constants are also defined for default filenames (e.g., DefaultConfigFileName,
 DefaultCodemodFilename, DefaultDescribeOutputFile, UltimateDefaultAILogFilename)
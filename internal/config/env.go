@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment variables that override config values. They take precedence over
+// the config file, which takes precedence over the defaults.
+const (
+	// EnvGitRemote overrides git.defaultRemote.
+	EnvGitRemote = "CONTEXTVIBES_GIT_REMOTE"
+	// EnvMainBranch overrides git.defaultMainBranch.
+	EnvMainBranch = "CONTEXTVIBES_MAIN_BRANCH"
+	// EnvProvider overrides project.provider.
+	EnvProvider = "CONTEXTVIBES_PROVIDER"
+	// EnvLogLevel overrides logging.level.
+	EnvLogLevel = "CONTEXTVIBES_LOG_LEVEL"
+)
+
+// envOverrides maps each supported environment variable to the field it sets.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var envOverrides = []struct {
+	name  string
+	field func(cfg *Config) *string
+}{
+	{EnvGitRemote, func(cfg *Config) *string { return &cfg.Git.DefaultRemote }},
+	{EnvMainBranch, func(cfg *Config) *string { return &cfg.Git.DefaultMainBranch }},
+	{EnvProvider, func(cfg *Config) *string { return &cfg.Project.Provider }},
+	{EnvLogLevel, func(cfg *Config) *string { return &cfg.Logging.Level }},
+}
+
+// ApplyEnvOverrides sets the config fields whose environment variables are set to
+// a non-blank value, looking them up with lookupEnv (os.LookupEnv in the CLI).
+func ApplyEnvOverrides(cfg *Config, lookupEnv func(key string) (string, bool)) {
+	for _, override := range envOverrides {
+		value, ok := lookupEnv(override.name)
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		*override.field(cfg) = strings.TrimSpace(value)
+	}
+}
+
+// ExpandPaths expands environment variables and a leading "~" in the config
+// values that hold file paths: logging.defaultAILogFile and the
+// systemPrompt.defaultOutputFiles values. Patterns and other values are left
+// untouched since they may contain a literal "$". See ExpandPath.
+func ExpandPaths(cfg *Config, lookupEnv func(key string) (string, bool), homeDir string) {
+	cfg.Logging.DefaultAILogFile = ExpandPath(cfg.Logging.DefaultAILogFile, lookupEnv, homeDir)
+
+	for key, value := range cfg.SystemPrompt.DefaultOutputFiles {
+		cfg.SystemPrompt.DefaultOutputFiles[key] = ExpandPath(value, lookupEnv, homeDir)
+	}
+}
+
+// ExpandPath expands $VAR and ${VAR} references using lookupEnv and replaces a
+// leading "~" with homeDir. References to undefined variables are kept as
+// ${VAR}, so a missing variable cannot turn a relative path into an absolute one.
+// A "~" is left alone when homeDir is empty.
+func ExpandPath(value string, lookupEnv func(key string) (string, bool), homeDir string) string {
+	value = os.Expand(value, func(name string) string {
+		if expanded, ok := lookupEnv(name); ok {
+			return expanded
+		}
+
+		return "${" + name + "}"
+	})
+
+	if homeDir == "" {
+		return value
+	}
+
+	if value == "~" {
+		return homeDir
+	}
+
+	if rest, ok := strings.CutPrefix(value, "~/"); ok {
+		return filepath.Join(homeDir, rest)
+	}
+
+	if rest, ok := strings.CutPrefix(value, "~"+string(filepath.Separator)); ok {
+		return filepath.Join(homeDir, rest)
+	}
+
+	return value
+}
+
+// applyProcessEnv applies overrides from the process environment and expands
+// the path values against it.
+func applyProcessEnv(cfg *Config) *Config {
+	ApplyEnvOverrides(cfg, os.LookupEnv)
+
+	homeDir, _ := os.UserHomeDir()
+	ExpandPaths(cfg, os.LookupEnv, homeDir)
+
+	return cfg
+}
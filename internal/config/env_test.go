@@ -0,0 +1,117 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyEnvOverrides(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		config.EnvGitRemote:  "upstream",
+		config.EnvMainBranch: " trunk ",
+		config.EnvProvider:   "",
+	}
+	lookup := func(key string) (string, bool) {
+		value, ok := env[key]
+
+		return value, ok
+	}
+
+	cfg := config.GetDefaultConfig()
+	config.ApplyEnvOverrides(cfg, lookup)
+
+	assert.Equal(t, "upstream", cfg.Git.DefaultRemote)
+	assert.Equal(t, "trunk", cfg.Git.DefaultMainBranch)
+	assert.Equal(t, "github", cfg.Project.Provider, "blank values are ignored")
+	assert.Equal(t, config.DefaultLogLevel, cfg.Logging.Level, "unset variables are ignored")
+}
+
+//nolint:paralleltest // t.Setenv cannot be used in parallel tests.
+func TestLoadEffectiveConfig_EnvOverridesFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), config.DefaultConfigFileName)
+	fileContent := "git:\n  defaultRemote: from-file\n  defaultMainBranch: develop\n" +
+		"project:\n  provider: gitlab\nlogging:\n  level: warn\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(fileContent), 0o600))
+
+	t.Setenv(config.EnvGitRemote, "from-env")
+	t.Setenv(config.EnvProvider, "jira")
+	t.Setenv(config.EnvLogLevel, "debug")
+
+	cfg, _, err := config.LoadEffectiveConfig(exec.NewClient(&mockExecutor{
+		CaptureOutputFunc: func(_ context.Context, _ string, _ string, _ ...string) (string, string, error) {
+			return filepath.Dir(configPath) + "\n", "", nil
+		},
+	}), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "from-env", cfg.Git.DefaultRemote)
+	assert.Equal(t, "develop", cfg.Git.DefaultMainBranch, "file value kept without an override")
+	assert.Equal(t, "jira", cfg.Project.Provider)
+	assert.Equal(t, "debug", cfg.Logging.Level)
+}
+
+func TestExpandPath(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{"HOME": "/home/dev", "LOG_DIR": "/var/log/cv"}
+	lookup := func(key string) (string, bool) {
+		value, ok := env[key]
+
+		return value, ok
+	}
+
+	testCases := []struct {
+		value string
+		want  string
+	}{
+		{value: "~", want: "/home/dev"},
+		{value: "~/logs/trace.log", want: "/home/dev/logs/trace.log"},
+		{value: "${HOME}/trace.log", want: "/home/dev/trace.log"},
+		{value: "$LOG_DIR/trace.log", want: "/var/log/cv/trace.log"},
+		{value: "${UNDEFINED}/trace.log", want: "${UNDEFINED}/trace.log"},
+		{value: "$UNDEFINED/trace.log", want: "${UNDEFINED}/trace.log"},
+		{value: "~other/trace.log", want: "~other/trace.log"},
+		{value: "logs/~/trace.log", want: "logs/~/trace.log"},
+		{value: "trace.log", want: "trace.log"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.want, config.ExpandPath(testCase.value, lookup, "/home/dev"), testCase.value)
+	}
+
+	assert.Equal(t, "~/trace.log", config.ExpandPath("~/trace.log", lookup, ""), "no home directory to expand to")
+}
+
+//nolint:paralleltest // t.Setenv cannot be used in parallel tests.
+func TestLoadEffectiveConfig_ExpandsPaths(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), config.DefaultConfigFileName)
+	fileContent := "logging:\n  defaultAILogFile: ${CV_TEST_LOG_DIR}/trace.log\n" +
+		"systemPrompt:\n  defaultOutputFiles:\n    idx: ~/prompts/idx.md\n" +
+		"validation:\n  commitMessage:\n    pattern: '^feat: .+$'\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(fileContent), 0o600))
+
+	t.Setenv("CV_TEST_LOG_DIR", "/tmp/cv-logs")
+
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	cfg, _, err := config.LoadEffectiveConfig(exec.NewClient(&mockExecutor{
+		CaptureOutputFunc: func(_ context.Context, _ string, _ string, _ ...string) (string, string, error) {
+			return filepath.Dir(configPath) + "\n", "", nil
+		},
+	}), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/tmp/cv-logs/trace.log", cfg.Logging.DefaultAILogFile)
+	assert.Equal(t, filepath.Join(homeDir, "prompts", "idx.md"), cfg.SystemPrompt.DefaultOutputFiles["idx"])
+	assert.Equal(t, "^feat: .+$", cfg.Validation.CommitMessage.Pattern, "patterns are not expanded")
+}
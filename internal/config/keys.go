@@ -0,0 +1,245 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrUnknownConfigKey is returned when a dotted key does not name a config field.
+	ErrUnknownConfigKey = errors.New("unknown config key")
+	// ErrUnsupportedConfigKey is returned when a key names a field that cannot be set from a string.
+	ErrUnsupportedConfigKey = errors.New("config key cannot be set from the command line")
+	// ErrInvalidConfigValue is returned when a value does not fit the field it is set on.
+	ErrInvalidConfigValue = errors.New("invalid config value")
+)
+
+// allowedValues lists the accepted values for enum-like string keys.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var allowedValues = map[string][]string{
+	"logging.level":    {"debug", "info", "warn", "error"},
+	"project.provider": {"github"},
+}
+
+// regexKeys are the string keys whose values must be valid regular expressions.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var regexKeys = []string{
+	"validation.branchName.pattern",
+	"validation.commitMessage.pattern",
+}
+
+// GetValue returns the value at a dotted key such as "git.defaultMainBranch", using
+// the YAML field names. Scalars are returned as plain text, other values as YAML.
+// An unset optional value is returned as an empty string.
+func GetValue(cfg *Config, key string) (string, error) {
+	value, err := resolveKey(cfg, key)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "", nil
+		}
+
+		value = value.Elem()
+	}
+
+	switch value.Kind() { //nolint:exhaustive // Other kinds are rendered as YAML.
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int:
+		return strconv.FormatInt(value.Int(), 10), nil
+	default:
+		out, err := yaml.Marshal(value.Interface())
+		if err != nil {
+			return "", fmt.Errorf("failed to render '%s': %w", key, err)
+		}
+
+		return strings.TrimSuffix(string(out), "\n"), nil
+	}
+}
+
+// SetValue validates value and stores it at a dotted key. Strings, booleans,
+// integers and string lists (comma-separated) can be set, as can entries of string
+// maps such as "feedback.repositories.<alias>".
+func SetValue(cfg *Config, key, value string) error {
+	err := validateValue(key, value)
+	if err != nil {
+		return err
+	}
+
+	parentKey, last := splitLastSegment(key)
+	if parentKey != "" {
+		parent, err := resolveKey(cfg, parentKey)
+		if err != nil {
+			return err
+		}
+
+		if parent.Kind() == reflect.Map {
+			return setMapEntry(parent, key, last, value)
+		}
+	}
+
+	field, err := resolveKey(cfg, key)
+	if err != nil {
+		return err
+	}
+
+	return setField(field, key, value)
+}
+
+// resolveKey walks a dotted key through the YAML field names of cfg. Map entries
+// are looked up by key, so the returned value is only settable for struct fields.
+func resolveKey(cfg *Config, key string) (reflect.Value, error) {
+	current := reflect.ValueOf(cfg).Elem()
+
+	for segment := range strings.SplitSeq(key, ".") {
+		switch current.Kind() { //nolint:exhaustive // Only structs and maps have children.
+		case reflect.Struct:
+			field, ok := fieldByYAMLName(current, segment)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("%w: %s", ErrUnknownConfigKey, key)
+			}
+
+			current = field
+		case reflect.Map:
+			entry := current.MapIndex(reflect.ValueOf(segment))
+			if !entry.IsValid() {
+				return reflect.Value{}, fmt.Errorf("%w: %s", ErrUnknownConfigKey, key)
+			}
+
+			current = entry
+		default:
+			return reflect.Value{}, fmt.Errorf("%w: %s", ErrUnknownConfigKey, key)
+		}
+	}
+
+	return current, nil
+}
+
+func fieldByYAMLName(structValue reflect.Value, name string) (reflect.Value, bool) {
+	structType := structValue.Type()
+
+	for i := range structType.NumField() {
+		tagName, _, _ := strings.Cut(structType.Field(i).Tag.Get("yaml"), ",")
+		if tagName == name {
+			return structValue.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+func setField(field reflect.Value, key, value string) error {
+	switch field.Kind() { //nolint:exhaustive // Only these kinds can be set from a string.
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := parseBool(key, value)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(parsed)
+	case reflect.Int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%w for %s: expected an integer, got '%s'", ErrInvalidConfigValue, key, value)
+		}
+
+		field.SetInt(int64(parsed))
+	case reflect.Pointer:
+		if field.Type().Elem().Kind() != reflect.Bool {
+			return fmt.Errorf("%w: %s", ErrUnsupportedConfigKey, key)
+		}
+
+		parsed, err := parseBool(key, value)
+		if err != nil {
+			return err
+		}
+
+		field.Set(reflect.ValueOf(&parsed))
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("%w: %s", ErrUnsupportedConfigKey, key)
+		}
+
+		field.Set(reflect.ValueOf(splitList(value)))
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedConfigKey, key)
+	}
+
+	return nil
+}
+
+func setMapEntry(mapValue reflect.Value, key, entryKey, value string) error {
+	if mapValue.Type().Elem().Kind() != reflect.String {
+		return fmt.Errorf("%w: %s", ErrUnsupportedConfigKey, key)
+	}
+
+	if mapValue.IsNil() {
+		mapValue.Set(reflect.MakeMap(mapValue.Type()))
+	}
+
+	mapValue.SetMapIndex(reflect.ValueOf(entryKey), reflect.ValueOf(value))
+
+	return nil
+}
+
+func validateValue(key, value string) error {
+	if allowed, ok := allowedValues[key]; ok && !slices.Contains(allowed, value) {
+		return fmt.Errorf("%w for %s: '%s' (allowed: %s)",
+			ErrInvalidConfigValue, key, value, strings.Join(allowed, ", "))
+	}
+
+	if slices.Contains(regexKeys, key) {
+		_, err := regexp.Compile(value)
+		if err != nil {
+			return fmt.Errorf("%w for %s: %w", ErrInvalidConfigValue, key, err)
+		}
+	}
+
+	return nil
+}
+
+func parseBool(key, value string) (bool, error) {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w for %s: expected true or false, got '%s'", ErrInvalidConfigValue, key, value)
+	}
+
+	return parsed, nil
+}
+
+func splitLastSegment(key string) (string, string) {
+	index := strings.LastIndex(key, ".")
+	if index == -1 {
+		return "", key
+	}
+
+	return key[:index], key[index+1:]
+}
+
+func splitList(value string) []string {
+	items := []string{}
+
+	for item := range strings.SplitSeq(value, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,88 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetValue(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultConfig()
+
+	testCases := []struct {
+		key  string
+		want string
+	}{
+		{key: "git.defaultMainBranch", want: config.DefaultGitMainBranch},
+		{key: "validation.branchName.enable", want: "true"},
+		{key: "describe.treeDepth", want: "2"},
+		{key: "feedback.repositories.thea", want: "contextvibes/thea"},
+		{key: "git", want: "defaultRemote: origin\ndefaultMainBranch: main\nbranchNameTemplate: feature/ISSUE-{ticket}-{slug}"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.key, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := config.GetValue(cfg, testCase.key)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+
+	_, err := config.GetValue(cfg, "git.doesNotExist")
+	require.ErrorIs(t, err, config.ErrUnknownConfigKey)
+}
+
+func TestSetValue(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // Starting from an empty on-disk config.
+	cfg := &config.Config{}
+
+	require.NoError(t, config.SetValue(cfg, "git.defaultMainBranch", "trunk"))
+	require.NoError(t, config.SetValue(cfg, "behavior.dualOutput", "true"))
+	require.NoError(t, config.SetValue(cfg, "validation.commitMessage.enable", "false"))
+	require.NoError(t, config.SetValue(cfg, "describe.treeDepth", "3"))
+	require.NoError(t, config.SetValue(cfg, "export.excludePatterns", "vendor/, dist/"))
+	require.NoError(t, config.SetValue(cfg, "feedback.repositories.fork", "me/cli"))
+
+	assert.Equal(t, "trunk", cfg.Git.DefaultMainBranch)
+	assert.True(t, cfg.Behavior.DualOutput)
+	require.NotNil(t, cfg.Validation.CommitMessage.Enable)
+	assert.False(t, *cfg.Validation.CommitMessage.Enable)
+	assert.Equal(t, 3, cfg.Describe.TreeDepth)
+	assert.Equal(t, []string{"vendor/", "dist/"}, cfg.Export.ExcludePatterns)
+	assert.Equal(t, map[string]string{"fork": "me/cli"}, cfg.Feedback.Repositories)
+}
+
+func TestSetValue_Invalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr error
+	}{
+		{name: "not a boolean", key: "behavior.dualOutput", value: "maybe", wantErr: config.ErrInvalidConfigValue},
+		{name: "not an integer", key: "describe.treeDepth", value: "deep", wantErr: config.ErrInvalidConfigValue},
+		{name: "unknown log level", key: "logging.level", value: "loud", wantErr: config.ErrInvalidConfigValue},
+		{name: "invalid regex", key: "validation.branchName.pattern", value: "(", wantErr: config.ErrInvalidConfigValue},
+		{name: "unknown key", key: "git.nope", value: "x", wantErr: config.ErrUnknownConfigKey},
+		{name: "section", key: "run.examples", value: "x", wantErr: config.ErrUnsupportedConfigKey},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := config.SetValue(config.GetDefaultConfig(), testCase.key, testCase.value)
+			require.ErrorIs(t, err, testCase.wantErr)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSchemaVersion is the layout of .contextvibes.yaml this build reads and writes.
+// Files without a schemaVersion are version 1.
+const CurrentSchemaVersion = 2
+
+const (
+	schemaVersionKey    = "schemaVersion"
+	legacySchemaVersion = 1
+)
+
+// ErrUnsupportedSchemaVersion is returned for config files written by a newer CLI.
+var ErrUnsupportedSchemaVersion = errors.New("config file uses a newer schema version than this CLI supports")
+
+// migrations upgrades a raw config document from the version it is keyed by to the
+// next. Version 1 files use the same keys as version 2 and only lack schemaVersion,
+// so they need no entry.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var migrations = map[int]func(doc map[string]any){}
+
+// CheckSchemaVersion returns ErrUnsupportedSchemaVersion when cfg comes from a file
+// with a schema version newer than CurrentSchemaVersion. Such files are still
+// loaded, but unknown keys in them are ignored.
+func CheckSchemaVersion(cfg *Config) error {
+	if cfg != nil && cfg.SchemaVersion > CurrentSchemaVersion {
+		return fmt.Errorf("%w: file has version %d, supported up to %d",
+			ErrUnsupportedSchemaVersion, cfg.SchemaVersion, CurrentSchemaVersion)
+	}
+
+	return nil
+}
+
+// MigrateConfigFile rewrites the config file at filePath in the current schema when
+// it uses an older one, and reports whether it did. Missing files are left alone.
+func MigrateConfigFile(filePath string) (bool, error) {
+	//nolint:gosec // Reading config file is intended behavior.
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file '%s': %w", filePath, err)
+	}
+
+	var doc map[string]any
+
+	err = yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse YAML config file '%s': %w", filePath, err)
+	}
+
+	if schemaVersionOf(doc) >= CurrentSchemaVersion {
+		return false, nil
+	}
+
+	cfg, err := LoadConfig(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	err = UpdateAndSaveConfig(cfg, filePath)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// migrateDocument upgrades a raw config document to CurrentSchemaVersion. Documents
+// from a newer version are returned unchanged.
+func migrateDocument(data []byte) ([]byte, error) {
+	var doc map[string]any
+
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config document: %w", err)
+	}
+
+	version := schemaVersionOf(doc)
+	if version >= CurrentSchemaVersion {
+		return data, nil
+	}
+
+	if doc == nil {
+		doc = map[string]any{}
+	}
+
+	for ; version < CurrentSchemaVersion; version++ {
+		if migrate, ok := migrations[version]; ok {
+			migrate(doc)
+		}
+	}
+
+	doc[schemaVersionKey] = CurrentSchemaVersion
+
+	migrated, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode migrated config document: %w", err)
+	}
+
+	return migrated, nil
+}
+
+func schemaVersionOf(doc map[string]any) int {
+	version, ok := doc[schemaVersionKey].(int)
+	if !ok || version < legacySchemaVersion {
+		return legacySchemaVersion
+	}
+
+	return version
+}
@@ -0,0 +1,73 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// v1Fixture is a config file from before schemaVersion existed.
+const v1Fixture = `git:
+  defaultRemote: upstream
+logging:
+  enable: true
+  defaultAILogFile: old_trace.log
+  level: debug
+`
+
+func TestLoadConfig_MigratesV1(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), config.DefaultConfigFileName)
+	require.NoError(t, os.WriteFile(filePath, []byte(v1Fixture), 0o600))
+
+	cfg, err := config.LoadConfig(filePath)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, config.CurrentSchemaVersion, cfg.SchemaVersion)
+	assert.Equal(t, "upstream", cfg.Git.DefaultRemote)
+	require.NotNil(t, cfg.Logging.Enable)
+	assert.True(t, *cfg.Logging.Enable)
+	assert.Equal(t, "old_trace.log", cfg.Logging.DefaultAILogFile)
+	assert.Equal(t, "debug", cfg.Logging.Level)
+}
+
+func TestMigrateConfigFile(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), config.DefaultConfigFileName)
+	require.NoError(t, os.WriteFile(filePath, []byte(v1Fixture), 0o600))
+
+	migrated, err := config.MigrateConfigFile(filePath)
+	require.NoError(t, err)
+	assert.True(t, migrated)
+
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "schemaVersion: 2")
+	assert.Contains(t, string(data), "defaultAILogFile: old_trace.log")
+	assert.Contains(t, string(data), "level: debug")
+
+	migrated, err = config.MigrateConfigFile(filePath)
+	require.NoError(t, err)
+	assert.False(t, migrated, "an up-to-date file is left alone")
+}
+
+func TestCheckSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), config.DefaultConfigFileName)
+	require.NoError(t, os.WriteFile(filePath, []byte("schemaVersion: 99\ngit:\n  defaultRemote: x\n"), 0o600))
+
+	cfg, err := config.LoadConfig(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, 99, cfg.SchemaVersion)
+	require.ErrorIs(t, config.CheckSchemaVersion(cfg), config.ErrUnsupportedSchemaVersion)
+
+	require.NoError(t, config.CheckSchemaVersion(config.GetDefaultConfig()))
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownKeys is returned in strict mode when a config file has keys that match no setting.
+var ErrUnknownKeys = errors.New("config file has unknown keys")
+
+// unknownFieldPattern matches the yaml.v3 message for a key with no matching field.
+//
+//nolint:gochecknoglobals // Static regex compilation.
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (\S+) not found in type`)
+
+// UnknownKey is a key in a config file that does not match any setting, usually a typo.
+type UnknownKey struct {
+	Key  string
+	Line int
+}
+
+// String formats the key with its line, e.g. "defualtRemote (line 3)".
+func (u UnknownKey) String() string {
+	if u.Line == 0 {
+		return u.Key
+	}
+
+	return fmt.Sprintf("%s (line %d)", u.Key, u.Line)
+}
+
+// FindUnknownKeys decodes the config file strictly and returns the keys that do
+// not match any setting. A missing file has no unknown keys.
+func FindUnknownKeys(filePath string) ([]UnknownKey, error) {
+	//nolint:gosec // Reading config file is intended behavior.
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file '%s': %w", filePath, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var cfg Config
+
+	err = decoder.Decode(&cfg)
+
+	var typeErr *yaml.TypeError
+	if err == nil || !errors.As(err, &typeErr) {
+		return nil, nil //nolint:nilerr // Other decode problems are reported by LoadConfig.
+	}
+
+	var unknown []UnknownKey
+
+	for _, message := range typeErr.Errors {
+		match := unknownFieldPattern.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+
+		line, _ := strconv.Atoi(match[1])
+		unknown = append(unknown, UnknownKey{Key: match[2], Line: line})
+	}
+
+	return unknown, nil
+}
@@ -0,0 +1,31 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), config.DefaultConfigFileName)
+	content := "schemaVersion: 2\ngit:\n  defaultRemote: upstream\n  defualtMainBranch: develop\nloging:\n  level: debug\n"
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+
+	unknown, err := config.FindUnknownKeys(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, []config.UnknownKey{
+		{Key: "defualtMainBranch", Line: 4},
+		{Key: "loging", Line: 5},
+	}, unknown)
+	assert.Equal(t, "defualtMainBranch (line 4)", unknown[0].String())
+
+	cfg, err := config.LoadConfig(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "upstream", cfg.Git.DefaultRemote, "recognized keys still load")
+}
@@ -0,0 +1,64 @@
+package exec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxCaptureBytes is the most output CaptureOutput keeps from each of
+// stdout and stderr unless the executor is configured otherwise.
+const DefaultMaxCaptureBytes = 64 << 20
+
+// ErrOutputTruncated is returned by CaptureOutput when a command printed more than
+// the capture limit. The output returned alongside it is cut short and marked.
+var ErrOutputTruncated = errors.New("command output exceeded the capture limit")
+
+// Options configures an OSCommandExecutor.
+type Options struct {
+	// MaxCaptureBytes caps how much of each output stream CaptureOutput keeps.
+	// Zero or less means DefaultMaxCaptureBytes.
+	MaxCaptureBytes int
+}
+
+// limitedBuffer keeps the first limit bytes written to it and counts the rest.
+// It never fails a write, so a chatty command runs to completion instead of
+// dying on a broken pipe.
+type limitedBuffer struct {
+	buf     bytes.Buffer
+	limit   int
+	dropped int
+}
+
+func newLimitedBuffer(limit int) *limitedBuffer {
+	return &limitedBuffer{buf: bytes.Buffer{}, limit: limit, dropped: 0}
+}
+
+func (b *limitedBuffer) Write(data []byte) (int, error) {
+	room := b.limit - b.buf.Len()
+	if room >= len(data) {
+		return b.buf.Write(data) //nolint:wrapcheck // bytes.Buffer writes do not fail.
+	}
+
+	if room > 0 {
+		b.buf.Write(data[:room])
+	}
+
+	b.dropped += len(data) - max(room, 0)
+
+	return len(data), nil
+}
+
+// Truncated reports whether any output was dropped.
+func (b *limitedBuffer) Truncated() bool {
+	return b.dropped > 0
+}
+
+// String returns the kept output, followed by a marker when some was dropped.
+func (b *limitedBuffer) String() string {
+	if !b.Truncated() {
+		return b.buf.String()
+	}
+
+	return fmt.Sprintf("%s\n... [output truncated: %d more bytes not captured]\n", b.buf.String(), b.dropped)
+}
@@ -2,9 +2,19 @@ package exec
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
 )
 
+// ErrInvalidWorkDir is returned when a command's working directory does not exist
+// or is not a directory.
+var ErrInvalidWorkDir = errors.New("invalid working directory")
+
 // ExecutorClient provides a high-level interface for running external commands.
 // It uses an underlying CommandExecutor for the actual execution.
 type ExecutorClient struct {
@@ -23,8 +33,47 @@ func (c *ExecutorClient) Execute(
 	commandName string,
 	args ...string,
 ) error {
+	resolved, err := resolveDir(dir, commandName)
+	if err != nil {
+		return err
+	}
+
+	//nolint:wrapcheck // Wrapping is handled by caller or executor.
+	return c.executor.Execute(ctx, resolved, commandName, args...)
+}
+
+// ExecuteWithEnv runs a command with extra environment variables. See CommandExecutor.ExecuteWithEnv.
+func (c *ExecutorClient) ExecuteWithEnv(
+	ctx context.Context,
+	dir string,
+	env map[string]string,
+	commandName string,
+	args ...string,
+) error {
+	resolved, err := resolveDir(dir, commandName)
+	if err != nil {
+		return err
+	}
+
+	//nolint:wrapcheck // Wrapping is handled by caller or executor.
+	return c.executor.ExecuteWithEnv(ctx, resolved, env, commandName, args...)
+}
+
+// ExecuteWithStdin runs a command with stdin read from the given reader. See CommandExecutor.ExecuteWithStdin.
+func (c *ExecutorClient) ExecuteWithStdin(
+	ctx context.Context,
+	dir string,
+	stdin io.Reader,
+	commandName string,
+	args ...string,
+) error {
+	resolved, err := resolveDir(dir, commandName)
+	if err != nil {
+		return err
+	}
+
 	//nolint:wrapcheck // Wrapping is handled by caller or executor.
-	return c.executor.Execute(ctx, dir, commandName, args...)
+	return c.executor.ExecuteWithStdin(ctx, resolved, stdin, commandName, args...)
 }
 
 // CaptureOutput runs a command and captures its stdout and stderr. See CommandExecutor.CaptureOutput.
@@ -34,8 +83,13 @@ func (c *ExecutorClient) CaptureOutput(
 	commandName string,
 	args ...string,
 ) (string, string, error) {
+	resolved, err := resolveDir(dir, commandName)
+	if err != nil {
+		return "", "", err
+	}
+
 	//nolint:wrapcheck // Wrapping is handled by caller or executor.
-	return c.executor.CaptureOutput(ctx, dir, commandName, args...)
+	return c.executor.CaptureOutput(ctx, resolved, commandName, args...)
 }
 
 // CommandExists checks if a command is available. See CommandExecutor.CommandExists.
@@ -43,11 +97,59 @@ func (c *ExecutorClient) CommandExists(commandName string) bool {
 	return c.executor.CommandExists(commandName)
 }
 
+// EnsureCommands checks every named command and returns the ones that are not
+// available, in the order given. An empty result means all of them were found.
+func (c *ExecutorClient) EnsureCommands(names ...string) []string {
+	var missing []string
+
+	for _, name := range names {
+		if slices.Contains(missing, name) {
+			continue
+		}
+
+		if !c.executor.CommandExists(name) {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
+
+// CommandExistsAll reports whether every named command is available.
+func (c *ExecutorClient) CommandExistsAll(names ...string) bool {
+	return len(c.EnsureCommands(names...)) == 0
+}
+
 // Logger returns the logger from the underlying executor.
 func (c *ExecutorClient) Logger() *slog.Logger {
 	return c.executor.Logger()
 }
 
+// resolveDir checks that dir is an existing directory and returns its absolute
+// path, so the executor logs show where a command really ran. An empty dir means
+// the current directory.
+func resolveDir(dir, commandName string) (string, error) {
+	if dir == "" {
+		dir = "."
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("%w: cannot run '%s' in '%s': %w", ErrInvalidWorkDir, commandName, dir, err)
+	}
+
+	info, err := os.Stat(absDir)
+	if err != nil {
+		return "", fmt.Errorf("%w: cannot run '%s' in '%s': %w", ErrInvalidWorkDir, commandName, dir, err)
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("%w: cannot run '%s' in '%s': not a directory", ErrInvalidWorkDir, commandName, dir)
+	}
+
+	return absDir, nil
+}
+
 // UnderlyingExecutor returns the CommandExecutor used by this client.
 // This allows passing the raw executor to other components if needed.
 func (c *ExecutorClient) UnderlyingExecutor() CommandExecutor { // New Exported Getter
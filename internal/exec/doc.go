@@ -29,5 +29,10 @@ Usage:
 		// handle error
 	}
 	fmt.Printf("Go version: %s", stdout)
+
+CaptureOutput keeps at most DefaultMaxCaptureBytes of each stream; use
+NewOSCommandExecutorWithOptions to change the limit. Output beyond it is
+dropped, the kept part ends with a truncation marker, and the error wraps
+ErrOutputTruncated.
 */
 package exec
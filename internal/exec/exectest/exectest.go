@@ -0,0 +1,202 @@
+// Package exectest provides a scriptable exec.CommandExecutor for tests, so
+// packages do not each re-implement the interface.
+//
+// An Executor records every command it is asked to run and answers it from
+// Responses, keyed by the full command line ("git status --porcelain"). Commands
+// without an entry go to Handler when one is set and otherwise succeed with no
+// output:
+//
+//	//nolint:exhaustruct // Recorded fields start empty.
+//	fake := &exectest.Executor{
+//		RepoDir: repoDir,
+//		Responses: map[string]exectest.Response{
+//			"git symbolic-ref --quiet HEAD": {Err: errExitStatus},
+//		},
+//	}
+//	client := exec.NewClient(fake)
+//	...
+//	assert.Equal(t, []string{"git symbolic-ref --quiet HEAD"}, fake.Commands())
+package exectest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// Call is one command the Executor was asked to run.
+type Call struct {
+	Dir   string
+	Name  string
+	Args  []string
+	Env   map[string]string
+	Stdin string
+	// Captured reports whether the command ran through CaptureOutput.
+	Captured bool
+}
+
+// String returns the command line, the key used to look up Responses.
+func (c Call) String() string {
+	return strings.Join(append([]string{c.Name}, c.Args...), " ")
+}
+
+// Response is the scripted result of a command. Execute and its variants only
+// report Err; CaptureOutput returns all three fields.
+type Response struct {
+	Stdout string
+	Stderr string
+	Err    error
+}
+
+// Executor is a fake exec.CommandExecutor. Its zero value runs nothing, reports
+// every command as installed and answers every command with success.
+type Executor struct {
+	// RepoDir, when set, answers git's repository discovery (rev-parse
+	// --show-toplevel and --git-dir) so a git.GitClient can be created. These
+	// calls are not recorded unless Responses scripts them.
+	RepoDir string
+	// Responses maps a command line to its result.
+	Responses map[string]Response
+	// Handler answers commands that have no entry in Responses.
+	Handler func(call Call) Response
+	// Missing names the commands CommandExists reports as not installed.
+	Missing map[string]bool
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Execute records the command and returns its scripted error.
+func (e *Executor) Execute(_ context.Context, dir string, commandName string, args ...string) error {
+	//nolint:exhaustruct // No environment or stdin.
+	return e.run(Call{Dir: dir, Name: commandName, Args: args}).Err
+}
+
+// ExecuteWithEnv records the command with its environment and returns its scripted error.
+func (e *Executor) ExecuteWithEnv(
+	_ context.Context,
+	dir string,
+	env map[string]string,
+	commandName string,
+	args ...string,
+) error {
+	//nolint:exhaustruct // No stdin.
+	return e.run(Call{Dir: dir, Name: commandName, Args: args, Env: env}).Err
+}
+
+// ExecuteWithStdin records the command with everything read from stdin and
+// returns its scripted error.
+func (e *Executor) ExecuteWithStdin(
+	_ context.Context,
+	dir string,
+	stdin io.Reader,
+	commandName string,
+	args ...string,
+) error {
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return err //nolint:wrapcheck // The reader's error is reported as is.
+	}
+
+	//nolint:exhaustruct // No environment.
+	return e.run(Call{Dir: dir, Name: commandName, Args: args, Stdin: string(data)}).Err
+}
+
+// CaptureOutput records the command and returns its scripted output.
+func (e *Executor) CaptureOutput(
+	_ context.Context,
+	dir string,
+	commandName string,
+	args ...string,
+) (string, string, error) {
+	//nolint:exhaustruct // No environment or stdin.
+	response := e.run(Call{Dir: dir, Name: commandName, Args: args, Captured: true})
+
+	return response.Stdout, response.Stderr, response.Err
+}
+
+// CommandExists reports every command not listed in Missing as installed.
+func (e *Executor) CommandExists(commandName string) bool { return !e.Missing[commandName] }
+
+// Logger returns a logger that discards everything.
+func (e *Executor) Logger() *slog.Logger { return slog.New(slog.DiscardHandler) }
+
+// Calls returns the recorded calls in order.
+func (e *Executor) Calls() []Call {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]Call(nil), e.calls...)
+}
+
+// Executed returns the recorded calls that did not capture their output, which
+// are usually the ones that change something.
+func (e *Executor) Executed() []Call {
+	var executed []Call
+
+	for _, call := range e.Calls() {
+		if !call.Captured {
+			executed = append(executed, call)
+		}
+	}
+
+	return executed
+}
+
+// Commands returns the command lines of the recorded calls in order.
+func (e *Executor) Commands() []string {
+	calls := e.Calls()
+	commands := make([]string, 0, len(calls))
+
+	for _, call := range calls {
+		commands = append(commands, call.String())
+	}
+
+	return commands
+}
+
+// LastCall returns the most recent call, or the zero Call when none was made.
+func (e *Executor) LastCall() Call {
+	calls := e.Calls()
+	if len(calls) == 0 {
+		return Call{} //nolint:exhaustruct // The zero Call means no call was made.
+	}
+
+	return calls[len(calls)-1]
+}
+
+func (e *Executor) run(call Call) Response {
+	key := call.String()
+
+	if response, ok := e.Responses[key]; ok {
+		e.record(call)
+
+		return response
+	}
+
+	if e.RepoDir != "" {
+		switch key {
+		case "git rev-parse --show-toplevel":
+			return Response{Stdout: e.RepoDir + "\n", Stderr: "", Err: nil}
+		case "git rev-parse --git-dir":
+			return Response{Stdout: ".git\n", Stderr: "", Err: nil}
+		}
+	}
+
+	e.record(call)
+
+	if e.Handler != nil {
+		return e.Handler(call)
+	}
+
+	return Response{Stdout: "", Stderr: "", Err: nil}
+}
+
+func (e *Executor) record(call Call) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.calls = append(e.calls, call)
+}
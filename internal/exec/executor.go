@@ -2,6 +2,7 @@ package exec
 
 import (
 	"context"
+	"io"
 	"log/slog"
 )
 
@@ -15,6 +16,27 @@ type CommandExecutor interface {
 	// Returns an error if execution fails.
 	Execute(ctx context.Context, dir string, commandName string, args ...string) error
 
+	// ExecuteWithEnv runs a command like Execute, with env merged over
+	// (and taking precedence over) the inherited environment.
+	ExecuteWithEnv(
+		ctx context.Context,
+		dir string,
+		env map[string]string,
+		commandName string,
+		args ...string,
+	) error
+
+	// ExecuteWithStdin runs a command like Execute, but reads its stdin from the
+	// given reader instead of the parent process. Use it to feed secrets or
+	// scripted answers to a command without going through a shell.
+	ExecuteWithStdin(
+		ctx context.Context,
+		dir string,
+		stdin io.Reader,
+		commandName string,
+		args ...string,
+	) error
+
 	// CaptureOutput runs a command, capturing its stdout and stderr.
 	// dir: the working directory for the command.
 	// commandName: the name or path of the command to run.
@@ -1,30 +1,43 @@
 package exec
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
 	"os"
 	"os/exec" // Standard library exec
+	"slices"
 	"strings"
 )
 
 // OSCommandExecutor is the default implementation of CommandExecutor using the os/exec package.
 type OSCommandExecutor struct {
-	logger *slog.Logger
+	logger          *slog.Logger
+	maxCaptureBytes int
 }
 
-// NewOSCommandExecutor creates a new OSCommandExecutor.
+// NewOSCommandExecutor creates a new OSCommandExecutor with default options.
 // If logger is nil, a discard logger will be used.
 func NewOSCommandExecutor(logger *slog.Logger) CommandExecutor {
+	return NewOSCommandExecutorWithOptions(logger, Options{MaxCaptureBytes: 0})
+}
+
+// NewOSCommandExecutorWithOptions creates an OSCommandExecutor with explicit options.
+func NewOSCommandExecutorWithOptions(logger *slog.Logger, opts Options) CommandExecutor {
 	log := logger
 	if log == nil {
 		log = slog.New(slog.DiscardHandler) // Default to discard if no logger provided
 	}
 
-	return &OSCommandExecutor{logger: log}
+	maxCapture := opts.MaxCaptureBytes
+	if maxCapture <= 0 {
+		maxCapture = DefaultMaxCaptureBytes
+	}
+
+	return &OSCommandExecutor{logger: log, maxCaptureBytes: maxCapture}
 }
 
 // Logger returns the logger associated with this executor.
@@ -51,6 +64,69 @@ func (e *OSCommandExecutor) Execute(
 	cmd.Stderr = os.Stderr // Pipe directly
 	cmd.Stdin = os.Stdin   // Pipe directly
 
+	return e.runPiped(ctx, cmd, commandName, args)
+}
+
+// ExecuteWithEnv runs a command like Execute, with env merged over the inherited environment.
+func (e *OSCommandExecutor) ExecuteWithEnv(
+	ctx context.Context,
+	dir string,
+	env map[string]string,
+	commandName string,
+	args ...string,
+) error {
+	e.logger.DebugContext(ctx, "Executing command with environment overrides",
+		slog.String("component", "OSCommandExecutor"),
+		slog.String("command", commandName),
+		slog.Any("args", args),
+		slog.String("dir", dir),
+		slog.Any("env_keys", slices.Sorted(maps.Keys(env))))
+
+	cmd := exec.CommandContext(ctx, commandName, args...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		cmd.Env = append(cmd.Env, key+"="+env[key])
+	}
+
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	return e.runPiped(ctx, cmd, commandName, args)
+}
+
+// ExecuteWithStdin runs a command like Execute, reading its stdin from the given reader.
+func (e *OSCommandExecutor) ExecuteWithStdin(
+	ctx context.Context,
+	dir string,
+	stdin io.Reader,
+	commandName string,
+	args ...string,
+) error {
+	e.logger.DebugContext(ctx, "Executing command with piped stdin",
+		slog.String("component", "OSCommandExecutor"),
+		slog.String("command", commandName),
+		slog.Any("args", args),
+		slog.String("dir", dir))
+
+	cmd := exec.CommandContext(ctx, commandName, args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = stdin
+
+	return e.runPiped(ctx, cmd, commandName, args)
+}
+
+// runPiped runs a prepared command whose stdio is connected to the parent process.
+func (e *OSCommandExecutor) runPiped(
+	ctx context.Context,
+	cmd *exec.Cmd,
+	commandName string,
+	args []string,
+) error {
 	err := cmd.Run()
 	if err != nil {
 		var exitErr *exec.ExitError
@@ -106,12 +182,13 @@ func (e *OSCommandExecutor) CaptureOutput(
 		slog.Any("args", args),
 		slog.String("dir", dir))
 
-	var stdoutBuf, stderrBuf bytes.Buffer
+	stdoutBuf := newLimitedBuffer(e.maxCaptureBytes)
+	stderrBuf := newLimitedBuffer(e.maxCaptureBytes)
 
 	cmd := exec.CommandContext(ctx, commandName, args...)
 	cmd.Dir = dir
-	cmd.Stdout = &stdoutBuf
-	cmd.Stderr = &stderrBuf
+	cmd.Stdout = stdoutBuf
+	cmd.Stderr = stderrBuf
 
 	err := cmd.Run()
 	stdoutStr := stdoutBuf.String()
@@ -147,7 +224,25 @@ func (e *OSCommandExecutor) CaptureOutput(
 			slog.String("detailed_error", errMsg)) // Log the detailed constructed error
 
 		//nolint:err113 // Dynamic error is appropriate here.
-		return stdoutStr, stderrStr, fmt.Errorf(errMsg+": %w", err) // Wrap original error
+		return stdoutStr, stderrStr, fmt.Errorf("%s: %w", errMsg, err) // Wrap original error
+	}
+
+	if stdoutBuf.Truncated() || stderrBuf.Truncated() {
+		e.logger.WarnContext(ctx, "Command output truncated",
+			slog.String("component", "OSCommandExecutor"),
+			slog.String("command", commandName),
+			slog.Any("args", args),
+			slog.Int("limit_bytes", e.maxCaptureBytes),
+			slog.Int("stdout_dropped_bytes", stdoutBuf.dropped),
+			slog.Int("stderr_dropped_bytes", stderrBuf.dropped))
+
+		return stdoutStr, stderrStr, fmt.Errorf(
+			"%w: '%s %s' printed more than %d bytes",
+			ErrOutputTruncated,
+			commandName,
+			strings.Join(args, " "),
+			e.maxCaptureBytes,
+		)
 	}
 
 	e.logger.DebugContext(ctx, "Command capture successful",
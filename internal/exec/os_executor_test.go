@@ -0,0 +1,96 @@
+package exec_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSCommandExecutor_ExecuteWithStdin(t *testing.T) {
+	t.Parallel()
+
+	executor := exec.NewOSCommandExecutor(nil)
+	if !executor.CommandExists("sh") {
+		t.Skip("sh not available")
+	}
+
+	dir := t.TempDir()
+	input := "line one\n\"quoted\" $HOME `tick`\n"
+
+	// The payload goes through stdin and is never interpreted by the shell.
+	err := exec.NewClient(executor).ExecuteWithStdin(
+		t.Context(), dir, strings.NewReader(input),
+		"sh", "-c", "cat > received.txt",
+	)
+	require.NoError(t, err)
+
+	received, err := os.ReadFile(filepath.Join(dir, "received.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, input, string(received))
+}
+
+func TestExecutorClient_InvalidWorkDir(t *testing.T) {
+	t.Parallel()
+
+	client := exec.NewClient(exec.NewOSCommandExecutor(nil))
+	missingDir := filepath.Join(t.TempDir(), "missing")
+
+	err := client.Execute(t.Context(), missingDir, "true")
+	require.ErrorIs(t, err, exec.ErrInvalidWorkDir)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	assert.Contains(t, err.Error(), "cannot run 'true' in '"+missingDir+"'")
+
+	filePath := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("not a dir"), 0o600))
+
+	_, _, err = client.CaptureOutput(t.Context(), filePath, "ls")
+	require.ErrorIs(t, err, exec.ErrInvalidWorkDir)
+	assert.Contains(t, err.Error(), "cannot run 'ls' in '"+filePath+"': not a directory")
+}
+
+func TestExecutorClient_ResolvesRelativeDir(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // Recorded fields start empty.
+	executor := &exectest.Executor{}
+	client := exec.NewClient(executor)
+
+	for _, dir := range []string{"", ".", "../exec"} {
+		_, _, err := client.CaptureOutput(t.Context(), dir, "pwd")
+		require.NoError(t, err)
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	for _, call := range executor.Calls() {
+		assert.Equal(t, wd, call.Dir)
+	}
+}
+
+func TestOSCommandExecutor_CaptureOutputLimit(t *testing.T) {
+	t.Parallel()
+
+	executor := exec.NewOSCommandExecutorWithOptions(nil, exec.Options{MaxCaptureBytes: 16})
+	if !executor.CommandExists("sh") {
+		t.Skip("sh not available")
+	}
+
+	stdout, stderr, err := executor.CaptureOutput(
+		t.Context(), t.TempDir(),
+		"sh", "-c", "i=0; while [ $i -lt 100 ]; do echo 0123456789; i=$((i+1)); done; echo oops >&2",
+	)
+	require.ErrorIs(t, err, exec.ErrOutputTruncated)
+	assert.True(t, strings.HasPrefix(stdout, "0123456789\n01234\n"), stdout)
+	assert.Contains(t, stdout, "[output truncated: 1084 more bytes not captured]")
+	assert.Equal(t, "oops\n", stderr, "streams under the limit are kept whole")
+
+	stdout, _, err = executor.CaptureOutput(t.Context(), t.TempDir(), "echo", "short")
+	require.NoError(t, err)
+	assert.Equal(t, "short\n", stdout)
+}
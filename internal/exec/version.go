@@ -0,0 +1,126 @@
+package exec
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrVersionNotFound is returned when a tool's version output has no recognizable version.
+var ErrVersionNotFound = errors.New("no version found in output")
+
+// versionParser describes how to ask a tool for its version and where the version
+// is in the answer.
+type versionParser struct {
+	args    []string
+	pattern *regexp.Regexp
+}
+
+// versionParsers holds the tools whose version output needs specific handling.
+// Other tools are asked with --version and the first dotted number is used.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var versionParsers = map[string]versionParser{
+	"go":        {args: []string{"version"}, pattern: regexp.MustCompile(`go version go(\d+(?:\.\d+)*\S*)`)},
+	"git":       {args: []string{"--version"}, pattern: regexp.MustCompile(`git version (\d+(?:\.\d+)+)`)},
+	"gcloud":    {args: []string{"version"}, pattern: regexp.MustCompile(`Google Cloud SDK (\d+(?:\.\d+)+)`)},
+	"terraform": {args: []string{"version"}, pattern: regexp.MustCompile(`Terraform v(\d+(?:\.\d+)+)`)},
+	"pulumi":    {args: []string{"version"}, pattern: regexp.MustCompile(`v?(\d+(?:\.\d+)+\S*)`)},
+	"node":      {args: []string{"--version"}, pattern: regexp.MustCompile(`v(\d+(?:\.\d+)+)`)},
+	"python3":   {args: []string{"--version"}, pattern: regexp.MustCompile(`Python (\d+(?:\.\d+)+)`)},
+}
+
+//nolint:gochecknoglobals // Static regex compilation.
+var genericVersionPattern = regexp.MustCompile(`(\d+(?:\.\d+)+)`)
+
+// CommandVersion runs a tool's version command and returns the bare version, e.g.
+// "1.25.5" for go. Tools without a registered parser are asked with --version.
+func (c *ExecutorClient) CommandVersion(ctx context.Context, name string) (string, error) {
+	args := []string{"--version"}
+	if parser, ok := versionParsers[name]; ok {
+		args = parser.args
+	}
+
+	stdout, stderr, err := c.CaptureOutput(ctx, ".", name, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s version: %w", name, err)
+	}
+
+	// Some tools (older pythons, for example) print their version to stderr.
+	return ParseVersion(name, stdout+"\n"+stderr)
+}
+
+// ParseVersion extracts the version from a tool's version output using the parser
+// registered for name, or the first dotted number for other tools.
+func ParseVersion(name, output string) (string, error) {
+	pattern := genericVersionPattern
+	if parser, ok := versionParsers[name]; ok {
+		pattern = parser.pattern
+	}
+
+	match := pattern.FindStringSubmatch(output)
+	if match == nil {
+		return "", fmt.Errorf("%w for %s: %q", ErrVersionNotFound, name, firstLine(output))
+	}
+
+	return match[1], nil
+}
+
+// CompareVersions compares dotted versions such as "1.25.5" and "v1.26" number by
+// number, ignoring a leading "v" and anything after the numbers ("rc1", "-beta").
+// Missing numbers count as zero. It returns -1, 0 or 1.
+func CompareVersions(a, b string) int {
+	partsA, partsB := versionNumbers(a), versionNumbers(b)
+
+	for i := range max(len(partsA), len(partsB)) {
+		var numA, numB int
+		if i < len(partsA) {
+			numA = partsA[i]
+		}
+
+		if i < len(partsB) {
+			numB = partsB[i]
+		}
+
+		if numA != numB {
+			return cmp.Compare(numA, numB)
+		}
+	}
+
+	return 0
+}
+
+func versionNumbers(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+
+	var numbers []int
+
+	for part := range strings.SplitSeq(version, ".") {
+		end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		if end == 0 {
+			break
+		}
+
+		if end > 0 {
+			number, _ := strconv.Atoi(part[:end])
+			numbers = append(numbers, number)
+
+			break
+		}
+
+		number, _ := strconv.Atoi(part)
+		numbers = append(numbers, number)
+	}
+
+	return numbers
+}
+
+func firstLine(output string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+
+	return line
+}
@@ -0,0 +1,92 @@
+package exec_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		tool   string
+		output string
+		want   string
+	}{
+		{tool: "go", output: "go version go1.25.5 linux/amd64\n", want: "1.25.5"},
+		{tool: "go", output: "go version go1.26rc1 darwin/arm64\n", want: "1.26rc1"},
+		{tool: "git", output: "git version 2.43.0\n", want: "2.43.0"},
+		{tool: "git", output: "git version 2.39.3 (Apple Git-146)\n", want: "2.39.3"},
+		{
+			tool:   "gcloud",
+			output: "Google Cloud SDK 467.0.0\nalpha 2024.02.29\nbq 2.0.101\ncore 2024.02.29\n",
+			want:   "467.0.0",
+		},
+		{tool: "terraform", output: "Terraform v1.7.4\non linux_amd64\n", want: "1.7.4"},
+		{tool: "pulumi", output: "v3.108.1\n", want: "3.108.1"},
+		{tool: "node", output: "v20.11.1\n", want: "20.11.1"},
+		{tool: "python3", output: "Python 3.12.2\n", want: "3.12.2"},
+		{tool: "jq", output: "jq-1.7.1\n", want: "1.7.1"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.tool+" "+testCase.want, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := exec.ParseVersion(testCase.tool, testCase.output)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+
+	_, err := exec.ParseVersion("git", "command not found")
+	require.ErrorIs(t, err, exec.ErrVersionNotFound)
+}
+
+func TestExecutorClient_CommandVersion(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // Every command gets the same output.
+	executor := &exectest.Executor{Handler: func(exectest.Call) exectest.Response {
+		return exectest.Response{Stdout: "go version go1.25.5 linux/amd64\n", Stderr: "", Err: nil}
+	}}
+	version, err := exec.NewClient(executor).CommandVersion(context.Background(), "go")
+	require.NoError(t, err)
+	assert.Equal(t, "1.25.5", version)
+	assert.Equal(t, "go version", executor.LastCall().String())
+
+	//nolint:exhaustruct // Every command gets the same output.
+	executor = &exectest.Executor{Handler: func(exectest.Call) exectest.Response {
+		return exectest.Response{Stdout: "", Stderr: "Python 2.7.18\n", Err: nil}
+	}}
+	version, err = exec.NewClient(executor).CommandVersion(context.Background(), "python")
+	require.NoError(t, err)
+	assert.Equal(t, "2.7.18", version, "versions printed to stderr are found")
+	assert.Equal(t, "python --version", executor.LastCall().String())
+}
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.25.5", b: "1.25.5", want: 0},
+		{a: "v1.25.5", b: "1.25.5", want: 0},
+		{a: "1.25", b: "1.25.0", want: 0},
+		{a: "1.9.0", b: "1.10.0", want: -1},
+		{a: "2.1.6", b: "2.0.0", want: 1},
+		{a: "1.26rc1", b: "1.26", want: 0},
+		{a: "1.26rc1", b: "1.25.5", want: 1},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.want, exec.CompareVersions(testCase.a, testCase.b), "%s vs %s", testCase.a, testCase.b)
+	}
+}
@@ -0,0 +1,10 @@
+/*
+Package exitcode lets commands report a specific process exit code through
+cobra's error return path.
+
+Most failures simply exit with status 1. Some commands, however, need to
+signal a non-failure outcome to scripts and CI, in the style of
+`terraform plan -detailed-exitcode`: for example, exiting with ChangesApplied
+(2) when a codemod or Change Plan actually modified the project.
+*/
+package exitcode
@@ -0,0 +1,46 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// Success is the exit code for a successful run with nothing to report.
+	Success = 0
+	// Failure is the exit code for any ordinary error.
+	Failure = 1
+	// ChangesApplied is the exit code used with --detailed-exitcode when the
+	// command changed the project.
+	ChangesApplied = 2
+)
+
+// Error carries a process exit code. It is not necessarily a failure: commands
+// return it to make the CLI exit with a meaningful status.
+type Error struct {
+	Code int
+}
+
+// New returns an Error carrying the given exit code.
+func New(code int) *Error {
+	return &Error{Code: code}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// FromError maps an error returned by a command to a process exit code:
+// Success for nil, the carried code for an *Error, and Failure otherwise.
+func FromError(err error) int {
+	if err == nil {
+		return Success
+	}
+
+	var codeErr *Error
+	if errors.As(err, &codeErr) {
+		return codeErr.Code
+	}
+
+	return Failure
+}
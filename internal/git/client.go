@@ -8,11 +8,57 @@ import (
 	"os"
 	osexec "os/exec" // Alias for standard library exec.ExitError
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/contextvibes/cli/internal/exec" // Use the new executor
 )
 
+var (
+	// ErrEmptyRef is returned when a git reference argument is empty.
+	ErrEmptyRef = errors.New("git reference must not be empty")
+	// ErrNoMergeBase is returned when two refs share no common history.
+	ErrNoMergeBase = errors.New("no merge base")
+	// ErrProtectedBranch is returned when asked to delete the current or main branch.
+	ErrProtectedBranch = errors.New("refusing to delete protected branch")
+	// ErrInvalidSemverTag is returned for release tags that are not semantic versions.
+	ErrInvalidSemverTag = errors.New("tag is not a semantic version")
+	// ErrNoUpstream is returned when the current branch has no upstream branch configured.
+	ErrNoUpstream = errors.New("branch has no upstream")
+	// ErrRebaseConflict is returned when a rebase stops because of conflicts.
+	ErrRebaseConflict = errors.New("rebase stopped on conflicts")
+	// ErrDetachedHead is returned when an operation needs a branch but HEAD is detached.
+	ErrDetachedHead = errors.New("HEAD is detached")
+	// ErrConfigNotSet is returned by GetConfig and GetGlobalConfig when the key has no value.
+	ErrConfigNotSet = errors.New("git config key is not set")
+	// ErrEmptyConfigKey is returned when a git config key argument is empty.
+	ErrEmptyConfigKey = errors.New("git config key must not be empty")
+)
+
+//nolint:gochecknoglobals // Static regex compilation.
+var semverTagRegex = regexp.MustCompile(
+	`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`,
+)
+
+// Field and record separators for GetLog's --format; they cannot appear in commit text.
+const (
+	logFieldSeparator  = "\x1f"
+	logRecordSeparator = "\x1e"
+	logFieldCount      = 5
+)
+
+// Commit is one entry of the commit history returned by GetLog.
+type Commit struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Subject string
+	Body    string
+}
+
 // GitClient provides methods for interacting with a Git repository.
 //
 //nolint:revive // GitClient is the established name.
@@ -162,11 +208,108 @@ func (c *GitClient) GetRemoteURL(ctx context.Context, remoteName string) (string
 	return strings.TrimSpace(stdout), nil
 }
 
+// AddRemote adds a remote with the given name and URL. It fails if the remote already exists.
+func (c *GitClient) AddRemote(ctx context.Context, remoteName, url string) error {
+	if remoteName == "" {
+		//nolint:err113 // Dynamic error is appropriate here.
+		return errors.New("remote name cannot be empty")
+	}
+
+	_, stderr, err := c.captureGitOutput(ctx, "remote", "add", remoteName, url)
+	if err != nil {
+		return gitError("git remote add "+remoteName, err, stderr)
+	}
+
+	return nil
+}
+
+// GetConfig returns the effective value of a git config key such as
+// "user.email", as resolved from the repository, global and system files.
+// It returns ErrConfigNotSet when the key has no value.
+func (c *GitClient) GetConfig(ctx context.Context, key string) (string, error) {
+	return getConfig(ctx, c.executor, c.repoPath, c.config.GitExecutable, key, false)
+}
+
+// SetConfig sets a git config key in the repository's config file, or in the
+// user's global config file when global is true.
+func (c *GitClient) SetConfig(ctx context.Context, key, value string, global bool) error {
+	return setConfig(ctx, c.executor, c.repoPath, c.config.GitExecutable, key, value, global)
+}
+
+// GetGlobalConfig returns a key from the user's global git config. Unlike
+// GitClient.GetConfig it needs no repository, and it ignores any value a
+// repository overrides. It returns ErrConfigNotSet when the key has no value.
+func GetGlobalConfig(ctx context.Context, executor exec.CommandExecutor, key string) (string, error) {
+	return getConfig(ctx, executor, ".", "git", key, true)
+}
+
+// SetGlobalConfig sets a key in the user's global git config without needing a repository.
+func SetGlobalConfig(ctx context.Context, executor exec.CommandExecutor, key, value string) error {
+	return setConfig(ctx, executor, ".", "git", key, value, true)
+}
+
+func getConfig(
+	ctx context.Context,
+	executor exec.CommandExecutor,
+	dir, gitExecutable, key string,
+	global bool,
+) (string, error) {
+	args := []string{"config"}
+	if global {
+		args = append(args, "--global")
+	}
+
+	args = append(args, "--get")
+	action := "git " + strings.Join(args, " ")
+
+	if strings.TrimSpace(key) == "" {
+		return "", fmt.Errorf("%s: %w", action, ErrEmptyConfigKey)
+	}
+
+	stdout, stderr, err := executor.CaptureOutput(ctx, dir, gitExecutable, append(args, key)...)
+	if err != nil {
+		// A missing key fails without printing anything.
+		if strings.TrimSpace(stderr) == "" {
+			return "", fmt.Errorf("%w: %s", ErrConfigNotSet, key)
+		}
+
+		return "", gitError(action+" "+key, err, stderr)
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
+
+func setConfig(
+	ctx context.Context,
+	executor exec.CommandExecutor,
+	dir, gitExecutable, key, value string,
+	global bool,
+) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("git config: %w", ErrEmptyConfigKey)
+	}
+
+	args := []string{"config"}
+	if global {
+		args = append(args, "--global")
+	}
+
+	args = append(args, key, value)
+
+	_, stderr, err := executor.CaptureOutput(ctx, dir, gitExecutable, args...)
+	if err != nil {
+		return gitError("git config "+key, err, stderr)
+	}
+
+	return nil
+}
+
 // --- Public Git Operation Methods ---
 // (These methods remain largely the same but now internally call c.executor methods
 //  which are of type exec.CommandExecutor)
 
-// GetCurrentBranchName returns the name of the current branch.
+// GetCurrentBranchName returns the name of the current branch. It returns
+// ErrDetachedHead when no branch is checked out.
 func (c *GitClient) GetCurrentBranchName(ctx context.Context) (string, error) {
 	stdout, stderr, err := c.captureGitOutput(ctx, "rev-parse", "--abbrev-ref", "HEAD")
 	if err != nil {
@@ -184,8 +327,7 @@ func (c *GitClient) GetCurrentBranchName(ctx context.Context) (string, error) {
 
 	branch := strings.TrimSpace(stdout)
 	if branch == "HEAD" {
-		//nolint:err113 // Dynamic error is appropriate here.
-		return "", errors.New("currently in detached HEAD state")
+		return "", fmt.Errorf("could not determine current branch name: %w", ErrDetachedHead)
 	}
 
 	if branch == "" {
@@ -196,6 +338,22 @@ func (c *GitClient) GetCurrentBranchName(ctx context.Context) (string, error) {
 	return branch, nil
 }
 
+// IsDetachedHead reports whether HEAD points at a commit rather than a branch,
+// as after checking out a tag or during a rebase.
+func (c *GitClient) IsDetachedHead(ctx context.Context) (bool, error) {
+	_, stderr, err := c.captureGitOutput(ctx, "symbolic-ref", "--quiet", "HEAD")
+	if err != nil {
+		// With --quiet, a detached HEAD fails without printing anything.
+		if strings.TrimSpace(stderr) == "" {
+			return true, nil
+		}
+
+		return false, gitError("git symbolic-ref HEAD", err, stderr)
+	}
+
+	return false, nil
+}
+
 // AddAll stages all changes.
 func (c *GitClient) AddAll(ctx context.Context) error {
 	err := c.runGit(ctx, "add", ".")
@@ -276,6 +434,71 @@ func (c *GitClient) ListUntrackedFiles(ctx context.Context) (string, string, err
 	return c.captureGitOutput(ctx, "ls-files", "--others", "--exclude-standard")
 }
 
+// ListChangedFiles returns the files changed on HEAD since it diverged from base
+// ("git diff --name-only base...HEAD").
+func (c *GitClient) ListChangedFiles(ctx context.Context, base string) ([]string, error) {
+	if strings.TrimSpace(base) == "" {
+		return nil, fmt.Errorf("git diff --name-only: %w", ErrEmptyRef)
+	}
+
+	stdout, stderr, err := c.captureGitOutput(ctx, "diff", "--name-only", base+"...HEAD")
+	if err != nil {
+		return nil, gitError("git diff --name-only "+base+"...HEAD", err, stderr)
+	}
+
+	files := []string{}
+
+	for line := range strings.Lines(stdout) {
+		if file := strings.TrimSpace(line); file != "" {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// GetDiffFromBase returns the diff of HEAD since it diverged from base
+// ("git diff base...HEAD").
+func (c *GitClient) GetDiffFromBase(ctx context.Context, base string) (string, error) {
+	if strings.TrimSpace(base) == "" {
+		return "", fmt.Errorf("git diff: %w", ErrEmptyRef)
+	}
+
+	stdout, stderr, err := c.captureGitOutput(ctx, "diff", base+"...HEAD")
+	if err != nil {
+		return "", gitError("git diff "+base+"...HEAD", err, stderr)
+	}
+
+	return stdout, nil
+}
+
+// FilesMarkedBinary returns the subset of paths that .gitattributes marks as
+// binary, either with the "binary" macro or with "-text".
+func (c *GitClient) FilesMarkedBinary(ctx context.Context, paths []string) (map[string]bool, error) {
+	marked := map[string]bool{}
+	if len(paths) == 0 {
+		return marked, nil
+	}
+
+	args := append([]string{"check-attr", "-z", "binary", "text", "--"}, paths...)
+
+	stdout, stderr, err := c.captureGitOutput(ctx, args...)
+	if err != nil {
+		return nil, gitError("git check-attr", err, stderr)
+	}
+
+	// With -z, each record is "<path>NUL<attribute>NUL<info>NUL".
+	fields := strings.Split(stdout, "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		path, attribute, info := fields[i], fields[i+1], fields[i+2]
+		if (attribute == "binary" && info == "set") || (attribute == "text" && info == "unset") {
+			marked[path] = true
+		}
+	}
+
+	return marked, nil
+}
+
 // IsWorkingDirClean checks if the working directory is clean.
 func (c *GitClient) IsWorkingDirClean(ctx context.Context) (bool, error) {
 	_, _, errDiff := c.captureGitOutput(ctx, "diff", "--quiet")
@@ -309,18 +532,194 @@ func (c *GitClient) IsWorkingDirClean(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
-// PullRebase pulls changes from the remote and rebases.
+// DirtyPaths returns the subset of the given paths that have uncommitted changes
+// (staged, unstaged, or untracked). Paths may be absolute or relative to the
+// current working directory; they are returned exactly as given.
+func (c *GitClient) DirtyPaths(ctx context.Context, paths ...string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	absByInput := make(map[string]string, len(paths))
+	// -z prints paths verbatim, without the quoting and C-escapes git applies to
+	// names with spaces, quotes or non-ASCII characters.
+	args := []string{"status", "--porcelain", "-z", "--untracked-files=all", "--"}
+
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve path '%s': %w", path, err)
+		}
+
+		absByInput[path] = absPath
+		args = append(args, absPath)
+	}
+
+	stdout, _, err := c.captureGitOutput(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status of target paths: %w", err)
+	}
+
+	dirtyAbs := make(map[string]bool)
+	renameSource := false
+
+	for entry := range strings.SplitSeq(stdout, "\x00") {
+		// A rename or copy entry is followed by a field holding its source path.
+		if renameSource {
+			renameSource = false
+
+			continue
+		}
+
+		//nolint:mnd // Porcelain format is "XY <path>".
+		if len(entry) < 4 {
+			continue
+		}
+
+		renameSource = strings.ContainsAny(entry[:2], "RC")
+		dirtyAbs[filepath.Join(c.repoPath, entry[3:])] = true
+	}
+
+	var dirty []string
+
+	for _, path := range paths {
+		if dirtyAbs[absByInput[path]] {
+			dirty = append(dirty, path)
+		}
+	}
+
+	return dirty, nil
+}
+
+// PullRebase pulls changes from the remote and rebases. When the rebase stops on
+// conflicts the repository is left mid-rebase and ErrRebaseConflict is returned,
+// so callers can explain how to continue or abort.
 func (c *GitClient) PullRebase(ctx context.Context, branch string) error {
 	remote := c.RemoteName()
 
-	err := c.runGit(ctx, "pull", "--rebase", remote, branch)
+	stdout, stderr, err := c.captureGitOutput(ctx, "pull", "--rebase", remote, branch)
+	if err != nil {
+		inProgress, _ := c.IsRebaseInProgress(ctx)
+		if inProgress || isRebaseConflictOutput(stdout+"\n"+stderr) {
+			return fmt.Errorf("%w while running git pull --rebase %s %s", ErrRebaseConflict, remote, branch)
+		}
+
+		return gitError("git pull --rebase "+remote+" "+branch, err, stderr)
+	}
+
+	return nil
+}
+
+// rebaseConflictMarkers are the messages git prints when a rebase stops on a conflict.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var rebaseConflictMarkers = []string{
+	"CONFLICT (",
+	"could not apply",
+	"Resolve all conflicts manually",
+}
+
+func isRebaseConflictOutput(output string) bool {
+	for _, marker := range rebaseConflictMarkers {
+		if strings.Contains(output, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsRebaseInProgress reports whether a rebase has stopped part way, for example
+// on conflicts, by looking for git's rebase state directory under GitDir.
+func (c *GitClient) IsRebaseInProgress(_ context.Context) (bool, error) {
+	for _, name := range []string{"rebase-merge", "rebase-apply"} {
+		_, err := os.Stat(filepath.Join(c.gitDir, name))
+		if err == nil {
+			return true, nil
+		}
+
+		if !os.IsNotExist(err) {
+			return false, fmt.Errorf("failed to check for rebase state in '%s': %w", c.gitDir, err)
+		}
+	}
+
+	return false, nil
+}
+
+// AbortRebase stops a rebase in progress and restores the branch to where it was
+// before the rebase started.
+func (c *GitClient) AbortRebase(ctx context.Context) error {
+	_, stderr, err := c.captureGitOutput(ctx, "rebase", "--abort")
+	if err != nil {
+		return gitError("git rebase --abort", err, stderr)
+	}
+
+	return nil
+}
+
+// ContinueRebase resumes a rebase after conflicts have been resolved and staged,
+// keeping each commit's message without opening an editor. It returns
+// ErrRebaseConflict when a later commit conflicts too.
+func (c *GitClient) ContinueRebase(ctx context.Context) error {
+	stdout, stderr, err := c.captureGitOutput(ctx, "-c", "core.editor=true", "rebase", "--continue")
+	if err != nil {
+		if isRebaseConflictOutput(stdout + "\n" + stderr) {
+			return fmt.Errorf("%w while running git rebase --continue", ErrRebaseConflict)
+		}
+
+		return gitError("git rebase --continue", err, stderr)
+	}
+
+	return nil
+}
+
+// Fetch downloads branches and tags from the default remote without changing the working tree.
+func (c *GitClient) Fetch(ctx context.Context) error {
+	remote := c.RemoteName()
+
+	_, stderr, err := c.captureGitOutput(ctx, "fetch", remote)
 	if err != nil {
-		return fmt.Errorf("git pull --rebase %s %s failed: %w", remote, branch, err)
+		return gitError("git fetch "+remote, err, stderr)
 	}
 
 	return nil
 }
 
+// GetAheadBehind counts the commits on HEAD that its upstream does not have
+// (ahead) and the commits on the upstream that HEAD does not have (behind), as
+// of the last fetch. It returns ErrNoUpstream when the branch has no upstream.
+//
+//nolint:nonamedreturns // Named returns tell the two counts apart.
+func (c *GitClient) GetAheadBehind(ctx context.Context) (ahead, behind int, err error) {
+	stdout, stderr, err := c.captureGitOutput(ctx, "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
+	if err != nil {
+		if strings.Contains(strings.ToLower(stderr), "no upstream") {
+			return 0, 0, ErrNoUpstream
+		}
+
+		return 0, 0, gitError("git rev-list --left-right --count HEAD...@{upstream}", err, stderr)
+	}
+
+	fields := strings.Fields(stdout)
+	//nolint:mnd // rev-list --left-right --count prints two numbers.
+	if len(fields) != 2 {
+		//nolint:err113 // Dynamic error is appropriate here.
+		return 0, 0, fmt.Errorf("unexpected output from git rev-list: %q", strings.TrimSpace(stdout))
+	}
+
+	ahead, err = strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse ahead count %q: %w", fields[0], err)
+	}
+
+	behind, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse behind count %q: %w", fields[1], err)
+	}
+
+	return ahead, behind, nil
+}
+
 // IsBranchAhead checks if the local branch is ahead of the remote.
 func (c *GitClient) IsBranchAhead(ctx context.Context) (bool, error) {
 	stdout, _, err := c.captureGitOutput(ctx, "status", "-sb")
@@ -428,6 +827,25 @@ func (c *GitClient) ListTrackedAndCachedFiles(ctx context.Context) (string, stri
 	return c.captureGitOutput(ctx, "ls-files", "-co", "--exclude-standard")
 }
 
+// ListTrackedFiles returns the paths of the files in the index, relative to the
+// repository root and slash-separated. Untracked files are not included.
+func (c *GitClient) ListTrackedFiles(ctx context.Context) ([]string, error) {
+	stdout, _, err := c.captureGitOutput(ctx, "ls-files", "-z", "--full-name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked files: %w", err)
+	}
+
+	var paths []string
+
+	for entry := range strings.SplitSeq(stdout, "\x00") {
+		if entry != "" {
+			paths = append(paths, entry)
+		}
+	}
+
+	return paths, nil
+}
+
 // GetLogAndDiffFromMergeBase finds the common ancestor with a branch and returns the log and diff since that point.
 //
 //nolint:nonamedreturns // Named returns are used for clarity in return signature.
@@ -470,6 +888,320 @@ func (c *GitClient) GetLogAndDiffFromMergeBase(
 	return log, diff, nil
 }
 
+// GetMergeBase returns the commit hash of the best common ancestor of ref and HEAD.
+// It returns ErrNoMergeBase when the histories are unrelated.
+func (c *GitClient) GetMergeBase(ctx context.Context, ref string) (string, error) {
+	if strings.TrimSpace(ref) == "" {
+		return "", fmt.Errorf("git merge-base: %w", ErrEmptyRef)
+	}
+
+	stdout, stderr, err := c.captureGitOutput(ctx, "merge-base", ref, "HEAD")
+	if err != nil {
+		// git exits 1 without output when the refs share no history.
+		if strings.TrimSpace(stdout) == "" && strings.TrimSpace(stderr) == "" {
+			return "", fmt.Errorf("%w between '%s' and HEAD", ErrNoMergeBase, ref)
+		}
+
+		return "", gitError("git merge-base "+ref+" HEAD", err, stderr)
+	}
+
+	mergeBase := strings.TrimSpace(stdout)
+	if mergeBase == "" {
+		return "", fmt.Errorf("%w between '%s' and HEAD", ErrNoMergeBase, ref)
+	}
+
+	return mergeBase, nil
+}
+
+// GetCommitCount returns the number of commits reachable from HEAD but not from base.
+// An empty base counts every commit on HEAD; a repository without commits yields 0.
+func (c *GitClient) GetCommitCount(ctx context.Context, base string) (int, error) {
+	revRange := "HEAD"
+	if base != "" {
+		revRange = base + "..HEAD"
+	}
+
+	stdout, stderr, err := c.captureGitOutput(ctx, "rev-list", "--count", revRange)
+	if err != nil {
+		if base == "" && strings.Contains(err.Error()+stderr, "ambiguous argument 'HEAD'") {
+			return 0, nil
+		}
+
+		return 0, gitError("git rev-list --count "+revRange, err, stderr)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(stdout))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected output from git rev-list --count: %q: %w", stdout, err)
+	}
+
+	return count, nil
+}
+
+// RefExists reports whether ref (a branch, remote-tracking branch, tag or hash)
+// resolves to a commit.
+func (c *GitClient) RefExists(ctx context.Context, ref string) (bool, error) {
+	if strings.TrimSpace(ref) == "" {
+		return false, fmt.Errorf("git rev-parse: %w", ErrEmptyRef)
+	}
+
+	_, stderr, err := c.captureGitOutput(ctx, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		// With --quiet, a missing ref fails without printing anything.
+		if strings.TrimSpace(stderr) == "" {
+			return false, nil
+		}
+
+		return false, gitError("git rev-parse --verify "+ref, err, stderr)
+	}
+
+	return true, nil
+}
+
+// ResetSoft moves HEAD to ref, keeping all changes staged.
+func (c *GitClient) ResetSoft(ctx context.Context, ref string) error {
+	if strings.TrimSpace(ref) == "" {
+		return fmt.Errorf("git reset --soft: %w", ErrEmptyRef)
+	}
+
+	_, stderr, err := c.captureGitOutput(ctx, "reset", "--soft", ref)
+	if err != nil {
+		return gitError("git reset --soft "+ref, err, stderr)
+	}
+
+	return nil
+}
+
+// ForcePushLease force-pushes branch to the default remote with --force-with-lease,
+// which refuses to overwrite remote commits the local repository has not seen.
+// An empty branch pushes the current branch's configured upstream.
+func (c *GitClient) ForcePushLease(ctx context.Context, branch string) error {
+	args := []string{"push", "--force-with-lease", c.RemoteName()}
+	if branch != "" {
+		args = append(args, branch)
+	}
+
+	_, stderr, err := c.captureGitOutput(ctx, args...)
+	if err != nil {
+		return gitError("git "+strings.Join(args, " "), err, stderr)
+	}
+
+	return nil
+}
+
+// GetLog returns the commits in revRange (e.g. "v1.2.0..HEAD"), newest first.
+// An empty revRange lists the history of HEAD, and a positive limit caps the
+// number of commits. A repository without commits yields an empty slice.
+func (c *GitClient) GetLog(ctx context.Context, revRange string, limit int) ([]Commit, error) {
+	args := []string{"log", "--format=%H%x1f%an%x1f%aI%x1f%s%x1f%b%x1e"}
+	if limit > 0 {
+		args = append(args, "--max-count="+strconv.Itoa(limit))
+	}
+
+	if revRange != "" {
+		args = append(args, revRange)
+	}
+
+	stdout, stderr, err := c.captureGitOutput(ctx, args...)
+	if err != nil {
+		if revRange == "" && strings.Contains(stderr, "does not have any commits yet") {
+			return []Commit{}, nil
+		}
+
+		return nil, gitError("git log "+revRange, err, stderr)
+	}
+
+	return parseLog(stdout)
+}
+
+func parseLog(output string) ([]Commit, error) {
+	commits := []Commit{}
+
+	for record := range strings.SplitSeq(output, logRecordSeparator) {
+		record = strings.TrimLeft(record, "\n")
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, logFieldSeparator, logFieldCount)
+		if len(fields) != logFieldCount {
+			//nolint:err113 // Dynamic error is appropriate here.
+			return nil, fmt.Errorf("unexpected git log record: %q", record)
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected date in git log record %s: %w", fields[0], err)
+		}
+
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Date:    date,
+			Subject: fields[3],
+			Body:    strings.TrimSpace(fields[4]),
+		})
+	}
+
+	return commits, nil
+}
+
+// ValidateSemverTag checks that tag is a semantic version such as v1.2.3,
+// 1.2.3-rc.1 or v2.0.0+build.5.
+func ValidateSemverTag(tag string) error {
+	if !semverTagRegex.MatchString(tag) {
+		return fmt.Errorf("%w: '%s' (expected e.g. v1.2.3)", ErrInvalidSemverTag, tag)
+	}
+
+	return nil
+}
+
+// ListTags returns the repository's tags, highest version first.
+func (c *GitClient) ListTags(ctx context.Context) ([]string, error) {
+	stdout, stderr, err := c.captureGitOutput(ctx, "tag", "--list", "--sort=-v:refname")
+	if err != nil {
+		return nil, gitError("git tag --list", err, stderr)
+	}
+
+	tags := []string{}
+
+	for line := range strings.Lines(stdout) {
+		if tag := strings.TrimSpace(line); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// CreateTag creates an annotated tag on HEAD, or a GPG-signed tag when signed is
+// set. An empty message uses the tag name. Lines starting with '#' are kept, so
+// Markdown release notes survive.
+func (c *GitClient) CreateTag(ctx context.Context, name, message string, signed bool) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("git tag: %w", ErrEmptyRef)
+	}
+
+	if message == "" {
+		message = name
+	}
+
+	flag := "-a"
+	if signed {
+		flag = "-s"
+	}
+
+	_, stderr, err := c.captureGitOutput(ctx, "tag", flag, "--cleanup=whitespace", name, "-m", message)
+	if err != nil {
+		return gitError("git tag "+flag+" "+name, err, stderr)
+	}
+
+	return nil
+}
+
+// PushTag pushes a single tag to the default remote.
+func (c *GitClient) PushTag(ctx context.Context, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("git push: %w", ErrEmptyRef)
+	}
+
+	remote := c.RemoteName()
+
+	_, stderr, err := c.captureGitOutput(ctx, "push", remote, "refs/tags/"+name)
+	if err != nil {
+		return gitError("git push "+remote+" "+name, err, stderr)
+	}
+
+	return nil
+}
+
+// GetLatestTag returns the most recent tag reachable from HEAD, or an empty string
+// when the repository has no tags.
+func (c *GitClient) GetLatestTag(ctx context.Context) (string, error) {
+	stdout, stderr, err := c.captureGitOutput(ctx, "describe", "--tags", "--abbrev=0")
+	if err != nil {
+		if strings.Contains(stderr, "No names found") || strings.Contains(stderr, "No tags can describe") {
+			return "", nil
+		}
+
+		return "", gitError("git describe --tags --abbrev=0", err, stderr)
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
+
+// ListMergedBranches returns the local branches whose tips are reachable from base.
+// The base itself, the main branch and the current branch are never included.
+func (c *GitClient) ListMergedBranches(ctx context.Context, base string) ([]string, error) {
+	if strings.TrimSpace(base) == "" {
+		return nil, fmt.Errorf("git branch --merged: %w", ErrEmptyRef)
+	}
+
+	stdout, stderr, err := c.captureGitOutput(
+		ctx,
+		"branch",
+		"--merged",
+		base,
+		"--format=%(HEAD) %(refname:short)",
+	)
+	if err != nil {
+		return nil, gitError("git branch --merged "+base, err, stderr)
+	}
+
+	branches := []string{}
+
+	for line := range strings.Lines(stdout) {
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			continue
+		}
+
+		isCurrent := strings.HasPrefix(line, "*")
+		name := strings.TrimSpace(line[1:])
+
+		if isCurrent || name == "" || name == base || name == c.MainBranchName() {
+			continue
+		}
+
+		branches = append(branches, name)
+	}
+
+	return branches, nil
+}
+
+// DeleteLocalBranch deletes a local branch with 'git branch -d', or '-D' when force
+// is set. It returns ErrProtectedBranch for the main branch and the current branch.
+func (c *GitClient) DeleteLocalBranch(ctx context.Context, name string, force bool) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("git branch -d: %w", ErrEmptyRef)
+	}
+
+	if name == c.MainBranchName() {
+		return fmt.Errorf("%w: '%s' is the main branch", ErrProtectedBranch, name)
+	}
+
+	current, err := c.GetCurrentBranchName(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot verify branch '%s' is not checked out: %w", name, err)
+	}
+
+	if name == current {
+		return fmt.Errorf("%w: '%s' is the current branch", ErrProtectedBranch, name)
+	}
+
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+
+	_, stderr, err := c.captureGitOutput(ctx, "branch", flag, name)
+	if err != nil {
+		return gitError("git branch "+flag+" "+name, err, stderr)
+	}
+
+	return nil
+}
+
 // StashPush saves the current state of the working directory and the index, but leaves the working directory clean.
 func (c *GitClient) StashPush(ctx context.Context) error {
 	// Using -u to include untracked files, which is generally desired for this workflow.
@@ -492,3 +1224,14 @@ func (c *GitClient) captureGitOutput(ctx context.Context, args ...string) (strin
 	//nolint:wrapcheck // Wrapping is handled by caller or executor.
 	return c.executor.CaptureOutput(ctx, c.repoPath, c.config.GitExecutable, args...)
 }
+
+// gitError wraps a failed git invocation, appending stderr unless the executor
+// already included it in the error message.
+func gitError(action string, err error, stderr string) error {
+	trimmed := strings.TrimSpace(stderr)
+	if trimmed == "" || strings.Contains(err.Error(), trimmed) {
+		return fmt.Errorf("%s failed: %w", action, err)
+	}
+
+	return fmt.Errorf("%s failed: %w (stderr: %s)", action, err, trimmed)
+}
@@ -0,0 +1,749 @@
+package git_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errExit = errors.New("exit status 1")
+
+func newScriptedClient(t *testing.T, responses map[string]exectest.Response) (*git.GitClient, *exectest.Executor) {
+	t.Helper()
+
+	//nolint:exhaustruct // Recorded fields start empty.
+	executor := &exectest.Executor{RepoDir: "/repo", Responses: responses}
+
+	//nolint:exhaustruct // Defaults are fine for tests.
+	client, err := git.NewClient(context.Background(), "/repo", git.GitClientConfig{Executor: executor})
+	require.NoError(t, err)
+
+	return client, executor
+}
+
+func TestGitClient_GetMergeBase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the trimmed hash", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git merge-base origin/main HEAD": {Stdout: "abc123\n", Stderr: "", Err: nil},
+		})
+
+		base, err := client.GetMergeBase(context.Background(), "origin/main")
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", base)
+	})
+
+	t.Run("unrelated histories", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git merge-base orphan HEAD": {Stdout: "", Stderr: "", Err: errExit},
+		})
+
+		_, err := client.GetMergeBase(context.Background(), "orphan")
+		require.ErrorIs(t, err, git.ErrNoMergeBase)
+	})
+
+	t.Run("unknown ref includes stderr", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git merge-base nope HEAD": {Stdout: "", Stderr: "fatal: Not a valid object name nope\n", Err: errExit},
+		})
+
+		_, err := client.GetMergeBase(context.Background(), "nope")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, git.ErrNoMergeBase)
+		assert.Contains(t, err.Error(), "Not a valid object name nope")
+	})
+
+	t.Run("empty ref", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, nil)
+
+		_, err := client.GetMergeBase(context.Background(), " ")
+		require.ErrorIs(t, err, git.ErrEmptyRef)
+	})
+}
+
+func TestGitClient_GetCommitCount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts commits since base", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git rev-list --count abc123..HEAD": {Stdout: "3\n", Stderr: "", Err: nil},
+		})
+
+		count, err := client.GetCommitCount(context.Background(), "abc123")
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("fresh branch has zero commits", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git rev-list --count abc123..HEAD": {Stdout: "0\n", Stderr: "", Err: nil},
+		})
+
+		count, err := client.GetCommitCount(context.Background(), "abc123")
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("repository without commits", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git rev-list --count HEAD": {
+				Stdout: "",
+				Stderr: "fatal: ambiguous argument 'HEAD': unknown revision or path not in the working tree.",
+				Err:    errExit,
+			},
+		})
+
+		count, err := client.GetCommitCount(context.Background(), "")
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("failure includes stderr", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git rev-list --count bad..HEAD": {Stdout: "", Stderr: "fatal: bad revision 'bad..HEAD'", Err: errExit},
+		})
+
+		_, err := client.GetCommitCount(context.Background(), "bad")
+		require.ErrorIs(t, err, errExit)
+		assert.Contains(t, err.Error(), "bad revision")
+	})
+}
+
+func TestGitClient_ResetSoft(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resets to the ref", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, map[string]exectest.Response{})
+
+		require.NoError(t, client.ResetSoft(context.Background(), "abc123"))
+		assert.Contains(t, executor.Commands(), "git reset --soft abc123")
+	})
+
+	t.Run("failure includes stderr", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git reset --soft nope": {Stdout: "", Stderr: "fatal: ambiguous argument 'nope'", Err: errExit},
+		})
+
+		err := client.ResetSoft(context.Background(), "nope")
+		require.ErrorIs(t, err, errExit)
+		assert.Contains(t, err.Error(), "ambiguous argument 'nope'")
+	})
+
+	t.Run("empty ref", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, nil)
+
+		require.ErrorIs(t, client.ResetSoft(context.Background(), ""), git.ErrEmptyRef)
+		assert.NotContains(t, executor.Commands(), "git reset --soft ")
+	})
+}
+
+func TestGitClient_ForcePushLease(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pushes the branch with a lease", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, map[string]exectest.Response{})
+
+		require.NoError(t, client.ForcePushLease(context.Background(), "feature/x"))
+		assert.Contains(t, executor.Commands(), "git push --force-with-lease origin feature/x")
+	})
+
+	t.Run("stale lease is reported with stderr", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git push --force-with-lease origin feature/x": {
+				Stdout: "",
+				Stderr: "! [rejected] feature/x -> feature/x (stale info)",
+				Err:    errExit,
+			},
+		})
+
+		err := client.ForcePushLease(context.Background(), "feature/x")
+		require.ErrorIs(t, err, errExit)
+		assert.Contains(t, err.Error(), "stale info")
+	})
+}
+
+func TestGitClient_ListMergedBranches(t *testing.T) {
+	t.Parallel()
+
+	t.Run("excludes base, main and current branch", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git branch --merged main --format=%(HEAD) %(refname:short)": {
+				Stdout: "  feature/done\n  main\n* fix/current\n  docs/readme\n",
+				Stderr: "",
+				Err:    nil,
+			},
+		})
+
+		branches, err := client.ListMergedBranches(context.Background(), "main")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"feature/done", "docs/readme"}, branches)
+	})
+
+	t.Run("no merged branches", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git branch --merged main --format=%(HEAD) %(refname:short)": {Stdout: "* main\n", Stderr: "", Err: nil},
+		})
+
+		branches, err := client.ListMergedBranches(context.Background(), "main")
+		require.NoError(t, err)
+		assert.Empty(t, branches)
+	})
+
+	t.Run("empty base", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, nil)
+
+		_, err := client.ListMergedBranches(context.Background(), "")
+		require.ErrorIs(t, err, git.ErrEmptyRef)
+	})
+}
+
+func TestGitClient_DeleteLocalBranch(t *testing.T) {
+	t.Parallel()
+
+	currentBranch := map[string]exectest.Response{
+		"git rev-parse --abbrev-ref HEAD": {Stdout: "feature/wip\n", Stderr: "", Err: nil},
+	}
+
+	t.Run("deletes with -d", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, currentBranch)
+
+		require.NoError(t, client.DeleteLocalBranch(context.Background(), "feature/done", false))
+		assert.Contains(t, executor.Commands(), "git branch -d feature/done")
+	})
+
+	t.Run("force deletes with -D", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, currentBranch)
+
+		require.NoError(t, client.DeleteLocalBranch(context.Background(), "feature/done", true))
+		assert.Contains(t, executor.Commands(), "git branch -D feature/done")
+	})
+
+	t.Run("refuses the main branch", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, currentBranch)
+
+		err := client.DeleteLocalBranch(context.Background(), "main", true)
+		require.ErrorIs(t, err, git.ErrProtectedBranch)
+		assert.NotContains(t, executor.Commands(), "git branch -D main")
+	})
+
+	t.Run("refuses the current branch", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, currentBranch)
+
+		err := client.DeleteLocalBranch(context.Background(), "feature/wip", false)
+		require.ErrorIs(t, err, git.ErrProtectedBranch)
+		assert.NotContains(t, executor.Commands(), "git branch -d feature/wip")
+	})
+
+	t.Run("reports unmerged branch errors", func(t *testing.T) {
+		t.Parallel()
+
+		responses := map[string]exectest.Response{
+			"git rev-parse --abbrev-ref HEAD": currentBranch["git rev-parse --abbrev-ref HEAD"],
+			"git branch -d feature/unmerged": {
+				Stdout: "",
+				Stderr: "error: the branch 'feature/unmerged' is not fully merged",
+				Err:    errExit,
+			},
+		}
+		client, _ := newScriptedClient(t, responses)
+
+		err := client.DeleteLocalBranch(context.Background(), "feature/unmerged", false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not fully merged")
+	})
+}
+
+func TestGitClient_GetLog(t *testing.T) {
+	t.Parallel()
+
+	const format = "git log --format=%H%x1f%an%x1f%aI%x1f%s%x1f%b%x1e"
+
+	t.Run("parses multiple commits", func(t *testing.T) {
+		t.Parallel()
+
+		fixture := "aaa111\x1fAda Lovelace\x1f2025-03-01T10:00:00+01:00\x1ffeat(ui): add quiet mode\x1f" +
+			"Adds --quiet.\n\nCloses #12\n\x1e\n" +
+			"bbb222\x1fAlan Turing\x1f2025-02-28T09:30:00Z\x1ffix: handle empty config\x1f\x1e\n"
+
+		client, executor := newScriptedClient(t, map[string]exectest.Response{
+			format + " --max-count=2 v1.0.0..HEAD": {Stdout: fixture, Stderr: "", Err: nil},
+		})
+
+		commits, err := client.GetLog(context.Background(), "v1.0.0..HEAD", 2)
+		require.NoError(t, err)
+		require.Len(t, commits, 2)
+		assert.Contains(t, executor.Commands(), format+" --max-count=2 v1.0.0..HEAD")
+
+		assert.Equal(t, "aaa111", commits[0].Hash)
+		assert.Equal(t, "Ada Lovelace", commits[0].Author)
+		assert.Equal(t, "feat(ui): add quiet mode", commits[0].Subject)
+		assert.Equal(t, "Adds --quiet.\n\nCloses #12", commits[0].Body)
+		assert.Equal(t, time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC), commits[0].Date.UTC())
+
+		assert.Equal(t, "bbb222", commits[1].Hash)
+		assert.Equal(t, "fix: handle empty config", commits[1].Subject)
+		assert.Empty(t, commits[1].Body)
+	})
+
+	t.Run("repository without commits", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			format: {
+				Stdout: "",
+				Stderr: "fatal: your current branch 'main' does not have any commits yet",
+				Err:    errExit,
+			},
+		})
+
+		commits, err := client.GetLog(context.Background(), "", 0)
+		require.NoError(t, err)
+		assert.Empty(t, commits)
+	})
+
+	t.Run("unknown range", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			format + " nope..HEAD": {Stdout: "", Stderr: "fatal: bad revision 'nope..HEAD'", Err: errExit},
+		})
+
+		_, err := client.GetLog(context.Background(), "nope..HEAD", 0)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "bad revision")
+	})
+}
+
+func TestGitClient_GetLatestTag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the tag", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git describe --tags --abbrev=0": {Stdout: "v1.2.0\n", Stderr: "", Err: nil},
+		})
+
+		tag, err := client.GetLatestTag(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "v1.2.0", tag)
+	})
+
+	t.Run("no tags", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git describe --tags --abbrev=0": {
+				Stdout: "",
+				Stderr: "fatal: No names found, cannot describe anything.",
+				Err:    errExit,
+			},
+		})
+
+		tag, err := client.GetLatestTag(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, tag)
+	})
+}
+
+func TestValidateSemverTag(t *testing.T) {
+	t.Parallel()
+
+	for _, tag := range []string{"v1.2.3", "1.2.3", "v0.1.0", "v2.0.0-rc.1", "v1.0.0+build.5", "v1.0.0-beta.2+exp.sha.5114f85"} {
+		require.NoError(t, git.ValidateSemverTag(tag), tag)
+	}
+
+	for _, tag := range []string{"", "v1", "v1.2", "release-1.2.3", "v1.2.3.4", "v01.2.3", "v1.2.3-", "latest"} {
+		require.ErrorIs(t, git.ValidateSemverTag(tag), git.ErrInvalidSemverTag, tag)
+	}
+}
+
+func TestGitClient_ListTags(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newScriptedClient(t, map[string]exectest.Response{
+		"git tag --list --sort=-v:refname": {Stdout: "v1.10.0\nv1.9.0\n\nv1.0.0\n", Stderr: "", Err: nil},
+	})
+
+	tags, err := client.ListTags(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.10.0", "v1.9.0", "v1.0.0"}, tags)
+}
+
+func TestGitClient_CreateTag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("annotated", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, nil)
+
+		require.NoError(t, client.CreateTag(context.Background(), "v1.2.0", "Release v1.2.0", false))
+		assert.Contains(t, executor.Commands(), "git tag -a --cleanup=whitespace v1.2.0 -m Release v1.2.0")
+	})
+
+	t.Run("signed with default message", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, nil)
+
+		require.NoError(t, client.CreateTag(context.Background(), "v1.2.0", "", true))
+		assert.Contains(t, executor.Commands(), "git tag -s --cleanup=whitespace v1.2.0 -m v1.2.0")
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, nil)
+
+		require.ErrorIs(t, client.CreateTag(context.Background(), "", "x", false), git.ErrEmptyRef)
+	})
+}
+
+func TestGitClient_PushTag(t *testing.T) {
+	t.Parallel()
+
+	client, executor := newScriptedClient(t, nil)
+
+	require.NoError(t, client.PushTag(context.Background(), "v1.2.0"))
+	assert.Contains(t, executor.Commands(), "git push origin refs/tags/v1.2.0")
+}
+
+func TestGitClient_ListChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newScriptedClient(t, map[string]exectest.Response{
+		"git diff --name-only main...HEAD": {Stdout: "a.go\n\ndocs/b.md\n", Stderr: "", Err: nil},
+	})
+
+	files, err := client.ListChangedFiles(context.Background(), "main")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "docs/b.md"}, files)
+
+	_, err = client.ListChangedFiles(context.Background(), " ")
+	require.ErrorIs(t, err, git.ErrEmptyRef)
+}
+
+func TestGitClient_FilesMarkedBinary(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newScriptedClient(t, map[string]exectest.Response{
+		"git check-attr -z binary text -- a.dat b.txt c.go": {
+			Stdout: "a.dat\x00binary\x00set\x00a.dat\x00text\x00unset\x00" +
+				"b.txt\x00binary\x00unspecified\x00b.txt\x00text\x00unset\x00" +
+				"c.go\x00binary\x00unspecified\x00c.go\x00text\x00unspecified\x00",
+			Stderr: "",
+			Err:    nil,
+		},
+	})
+
+	marked, err := client.FilesMarkedBinary(context.Background(), []string{"a.dat", "b.txt", "c.go"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"a.dat": true, "b.txt": true}, marked)
+
+	marked, err = client.FilesMarkedBinary(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, marked)
+}
+
+func TestGitClient_GetAheadBehind(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts both sides", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git rev-list --left-right --count HEAD...@{upstream}": {Stdout: "2\t5\n", Stderr: "", Err: nil},
+		})
+
+		ahead, behind, err := client.GetAheadBehind(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, ahead)
+		assert.Equal(t, 5, behind)
+	})
+
+	t.Run("branch without upstream", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git rev-list --left-right --count HEAD...@{upstream}": {
+				Stdout: "",
+				Stderr: "fatal: no upstream configured for branch 'feature/x'",
+				Err:    errExit,
+			},
+		})
+
+		_, _, err := client.GetAheadBehind(context.Background())
+		require.ErrorIs(t, err, git.ErrNoUpstream)
+	})
+}
+
+func TestGitClient_PullRebase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pulls with rebase from the default remote", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, map[string]exectest.Response{})
+
+		require.NoError(t, client.PullRebase(context.Background(), "main"))
+		assert.Contains(t, executor.Commands(), "git pull --rebase origin main")
+	})
+
+	t.Run("conflicts are distinguishable", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git pull --rebase origin main": {
+				Stdout: "Auto-merging go.mod\nCONFLICT (content): Merge conflict in go.mod\n",
+				Stderr: "error: could not apply 1a2b3c4... Bump deps\n" +
+					"hint: Resolve all conflicts manually, mark them as resolved with\n" +
+					"hint: \"git add/rm <conflicted_files>\", then run \"git rebase --continue\".\n",
+				Err: errExit,
+			},
+		})
+
+		err := client.PullRebase(context.Background(), "main")
+		require.ErrorIs(t, err, git.ErrRebaseConflict)
+	})
+
+	t.Run("other failures keep stderr", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git pull --rebase origin main": {Stdout: "", Stderr: "fatal: couldn't find remote ref main", Err: errExit},
+		})
+
+		err := client.PullRebase(context.Background(), "main")
+		require.ErrorIs(t, err, errExit)
+		require.NotErrorIs(t, err, git.ErrRebaseConflict)
+		assert.Contains(t, err.Error(), "couldn't find remote ref")
+	})
+}
+
+func TestGitClient_IsRebaseInProgress(t *testing.T) {
+	t.Parallel()
+
+	gitDir := t.TempDir()
+	client, _ := newScriptedClient(t, map[string]exectest.Response{
+		"git rev-parse --git-dir": {Stdout: gitDir + "\n", Stderr: "", Err: nil},
+	})
+
+	inProgress, err := client.IsRebaseInProgress(context.Background())
+	require.NoError(t, err)
+	assert.False(t, inProgress)
+
+	require.NoError(t, os.Mkdir(filepath.Join(gitDir, "rebase-merge"), 0o750))
+
+	inProgress, err = client.IsRebaseInProgress(context.Background())
+	require.NoError(t, err)
+	assert.True(t, inProgress)
+}
+
+func TestGitClient_AbortAndContinueRebase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("abort", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, map[string]exectest.Response{})
+
+		require.NoError(t, client.AbortRebase(context.Background()))
+		assert.Contains(t, executor.Commands(), "git rebase --abort")
+	})
+
+	t.Run("continue keeps messages without an editor", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newScriptedClient(t, map[string]exectest.Response{})
+
+		require.NoError(t, client.ContinueRebase(context.Background()))
+		assert.Contains(t, executor.Commands(), "git -c core.editor=true rebase --continue")
+	})
+
+	t.Run("continue into another conflict", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newScriptedClient(t, map[string]exectest.Response{
+			"git -c core.editor=true rebase --continue": {
+				Stdout: "CONFLICT (content): Merge conflict in go.sum\n",
+				Stderr: "error: could not apply 5d6e7f8... Tidy\n",
+				Err:    errExit,
+			},
+		})
+
+		require.ErrorIs(t, client.ContinueRebase(context.Background()), git.ErrRebaseConflict)
+	})
+}
+
+func TestGitClient_GetConfig(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newScriptedClient(t, map[string]exectest.Response{
+		"git config --get user.email":      {Stdout: "dev@example.com\n", Stderr: "", Err: nil},
+		"git config --get user.signingkey": {Stdout: "", Stderr: "", Err: errExit},
+		"git config --get core.broken": {
+			Stdout: "", Stderr: "fatal: bad config line 3 in file .git/config", Err: errExit,
+		},
+	})
+
+	value, err := client.GetConfig(context.Background(), "user.email")
+	require.NoError(t, err)
+	assert.Equal(t, "dev@example.com", value)
+
+	_, err = client.GetConfig(context.Background(), "user.signingkey")
+	require.ErrorIs(t, err, git.ErrConfigNotSet)
+
+	_, err = client.GetConfig(context.Background(), "core.broken")
+	require.ErrorIs(t, err, errExit)
+	require.NotErrorIs(t, err, git.ErrConfigNotSet)
+	assert.Contains(t, err.Error(), "bad config line 3")
+
+	_, err = client.GetConfig(context.Background(), " ")
+	require.ErrorIs(t, err, git.ErrEmptyConfigKey)
+}
+
+func TestGitClient_SetConfig(t *testing.T) {
+	t.Parallel()
+
+	client, executor := newScriptedClient(t, map[string]exectest.Response{
+		"git config --global gpg.program nope": {Stdout: "", Stderr: "error: could not lock config file", Err: errExit},
+	})
+
+	require.NoError(t, client.SetConfig(context.Background(), "commit.gpgsign", "true", true))
+	require.NoError(t, client.SetConfig(context.Background(), "user.email", "dev@example.com", false))
+	assert.Contains(t, executor.Commands(), "git config --global commit.gpgsign true")
+	assert.Contains(t, executor.Commands(), "git config user.email dev@example.com")
+
+	err := client.SetConfig(context.Background(), "gpg.program", "nope", true)
+	require.ErrorIs(t, err, errExit)
+	assert.Contains(t, err.Error(), "could not lock config file")
+
+	require.ErrorIs(t, client.SetConfig(context.Background(), "", "x", false), git.ErrEmptyConfigKey)
+}
+
+func TestGlobalConfig(t *testing.T) {
+	t.Parallel()
+
+	// No RepoDir: the helpers must work outside a repository.
+	//nolint:exhaustruct // Recorded fields start empty.
+	executor := &exectest.Executor{
+		Responses: map[string]exectest.Response{
+			"git config --global --get user.signingkey": {Stdout: "ABCDEF\n", Stderr: "", Err: nil},
+			"git config --global --get gpg.program":     {Stdout: "", Stderr: "", Err: errExit},
+			"git config --global --get core.broken": {
+				Stdout: "", Stderr: "fatal: bad config line 3 in file ~/.gitconfig", Err: errExit,
+			},
+		},
+	}
+
+	value, err := git.GetGlobalConfig(context.Background(), executor, "user.signingkey")
+	require.NoError(t, err)
+	assert.Equal(t, "ABCDEF", value)
+
+	_, err = git.GetGlobalConfig(context.Background(), executor, "gpg.program")
+	require.ErrorIs(t, err, git.ErrConfigNotSet)
+
+	_, err = git.GetGlobalConfig(context.Background(), executor, "core.broken")
+	require.NotErrorIs(t, err, git.ErrConfigNotSet)
+	assert.Contains(t, err.Error(), "bad config line 3")
+
+	_, err = git.GetGlobalConfig(context.Background(), executor, "")
+	require.ErrorIs(t, err, git.ErrEmptyConfigKey)
+
+	require.NoError(t, git.SetGlobalConfig(context.Background(), executor, "commit.gpgsign", "true"))
+	assert.Equal(t, "git config --global commit.gpgsign true", executor.LastCall().String())
+	require.ErrorIs(t, git.SetGlobalConfig(context.Background(), executor, " ", "x"), git.ErrEmptyConfigKey)
+}
+
+func TestGitClient_AddRemote(t *testing.T) {
+	t.Parallel()
+
+	client, executor := newScriptedClient(t, map[string]exectest.Response{
+		"git remote add upstream https://github.com/octo/taken.git": {
+			Stdout: "",
+			Stderr: "error: remote upstream already exists.",
+			Err:    errExit,
+		},
+	})
+
+	require.NoError(t, client.AddRemote(context.Background(), "origin", "https://github.com/octo/widgets.git"))
+	assert.Contains(t, executor.Commands(), "git remote add origin https://github.com/octo/widgets.git")
+
+	err := client.AddRemote(context.Background(), "upstream", "https://github.com/octo/taken.git")
+	require.ErrorIs(t, err, errExit)
+	assert.Contains(t, err.Error(), "already exists")
+}
+
+func TestGitClient_IsDetachedHead(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newScriptedClient(t, map[string]exectest.Response{
+		"git symbolic-ref --quiet HEAD": {Stdout: "refs/heads/main\n", Stderr: "", Err: nil},
+	})
+
+	detached, err := client.IsDetachedHead(context.Background())
+	require.NoError(t, err)
+	assert.False(t, detached)
+
+	client, _ = newScriptedClient(t, map[string]exectest.Response{
+		"git symbolic-ref --quiet HEAD":   {Stdout: "", Stderr: "", Err: errExit},
+		"git rev-parse --abbrev-ref HEAD": {Stdout: "HEAD\n", Stderr: "", Err: nil},
+	})
+
+	detached, err = client.IsDetachedHead(context.Background())
+	require.NoError(t, err)
+	assert.True(t, detached)
+
+	_, err = client.GetCurrentBranchName(context.Background())
+	require.ErrorIs(t, err, git.ErrDetachedHead)
+}
@@ -5,12 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/network"
 	"github.com/google/go-github/v74/github"
 	"github.com/shurcooL/githubv4"
 	"golang.org/x/oauth2"
@@ -25,7 +29,8 @@ const GHTokenEnvVar = "GITHUB_TOKEN"
 const PassTokenKey = "github/token"
 
 var (
-	sshRemoteRegex = regexp.MustCompile(`^git@github\.com:([\w-]+)/([\w-]+)\.git$`)
+	// scpRemoteRegex matches SCP-style remotes such as "git@github.com:owner/repo.git".
+	scpRemoteRegex = regexp.MustCompile(`^(?:[\w.-]+@)?([\w.-]+):([\w.-]+)/([\w.-]+?)(?:\.git)?/?$`)
 
 	// ErrInvalidRemoteURL is returned when the remote URL is invalid.
 	ErrInvalidRemoteURL = errors.New("invalid remote URL")
@@ -45,6 +50,14 @@ var (
 	ErrPassCommandNotFound = errors.New("'pass' command not found")
 	// ErrPassOutputEmpty is returned when 'pass' returns no output.
 	ErrPassOutputEmpty = errors.New("pass output was empty")
+	// ErrGHCommandNotFound is returned when the 'gh' command is not available.
+	ErrGHCommandNotFound = errors.New("'gh' command not found")
+	// ErrGHOutputEmpty is returned when 'gh auth token' returns no token.
+	ErrGHOutputEmpty = errors.New("gh auth token output was empty")
+	// ErrDefaultBranchNotFound is returned when the repository reports no default branch.
+	ErrDefaultBranchNotFound = errors.New("repository has no default branch")
+	// ErrInvalidPullRequestState is returned for a state other than open, closed or all.
+	ErrInvalidPullRequestState = errors.New("invalid pull request state")
 )
 
 // Client wraps the go-github clients for both REST and GraphQL APIs.
@@ -55,6 +68,10 @@ type Client struct {
 	logger  *slog.Logger
 	owner   string
 	repo    string
+
+	// defaultBranch caches the remote default branch once resolved.
+	defaultBranch   string
+	defaultBranchMu sync.Mutex
 }
 
 // Project represents a GitHub Project (V2) for listing.
@@ -72,103 +89,172 @@ type ProjectWithID struct {
 	URL    string
 }
 
-// ParseGitHubRemote extracts the owner and repository name from a GitHub remote URL.
+// PullRequest represents a GitHub pull request summary.
+type PullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Author string `json:"author"`
+	URL    string `json:"url"`
+	State  string `json:"state"`
+	Draft  bool   `json:"draft"`
+}
+
+// DefaultHost is the host of github.com remotes.
+const DefaultHost = "github.com"
+
+// remoteSchemes are the URL schemes a GitHub remote may use.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var remoteSchemes = []string{"https", "http", "ssh", "git+ssh"}
+
+// Remote identifies a GitHub repository parsed from a Git remote URL.
+type Remote struct {
+	Host  string
+	Owner string
+	Repo  string
+}
+
+// ParseGitHubRemote extracts the owner and repository name from a github.com remote URL.
 //
 //nolint:nonamedreturns // Named returns are used for clarity in return signature.
 func ParseGitHubRemote(remoteURL string) (owner, repo string, err error) {
-	//nolint:mnd // Regex match count 3 is specific to this pattern.
-	if matches := sshRemoteRegex.FindStringSubmatch(remoteURL); len(matches) == 3 {
-		return matches[1], matches[2], nil
+	remote, err := ParseRemote(remoteURL, "")
+	if err != nil {
+		return "", "", err
 	}
 
-	parsed, err := url.Parse(remoteURL)
-	if err != nil {
-		return "", "", fmt.Errorf("could not parse remote URL: %w", err)
+	return remote.Owner, remote.Repo, nil
+}
+
+// ParseRemote extracts the host, owner and repository name from a GitHub remote
+// URL. It accepts SCP-style ("git@host:owner/repo.git"), ssh:// and https://
+// URLs. The host must be github.com or, when set, enterpriseHost.
+func ParseRemote(remoteURL, enterpriseHost string) (Remote, error) {
+	var host, path string
+
+	//nolint:mnd // Regex match count 4 is specific to this pattern.
+	if matches := scpRemoteRegex.FindStringSubmatch(remoteURL); len(matches) == 4 {
+		host, path = matches[1], matches[2]+"/"+matches[3]
+	} else {
+		parsed, err := url.Parse(remoteURL)
+		if err != nil {
+			return Remote{}, fmt.Errorf("could not parse remote URL: %w", err)
+		}
+
+		if !slices.Contains(remoteSchemes, parsed.Scheme) {
+			return Remote{}, fmt.Errorf("%w: unsupported scheme '%s' in %s", ErrInvalidRemoteURL, parsed.Scheme, remoteURL)
+		}
+
+		host, path = parsed.Hostname(), parsed.Path
 	}
 
-	if parsed.Hostname() != "github.com" {
-		return "", "", fmt.Errorf("%w: not a github.com URL: %s", ErrInvalidRemoteURL, parsed.Hostname())
+	if !strings.EqualFold(host, DefaultHost) && (enterpriseHost == "" || !strings.EqualFold(host, enterpriseHost)) {
+		return Remote{}, fmt.Errorf("%w: host '%s' is not %s", ErrInvalidRemoteURL, host, allowedHosts(enterpriseHost))
 	}
 
-	pathParts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
 	//nolint:mnd // Expecting at least owner and repo.
-	if len(pathParts) < 2 {
-		return "", "", fmt.Errorf("%w: path does not contain owner/repo: %s", ErrInvalidRemoteURL, parsed.Path)
+	if len(pathParts) < 2 || pathParts[0] == "" || pathParts[1] == "" {
+		return Remote{}, fmt.Errorf("%w: path does not contain owner/repo: %s", ErrInvalidRemoteURL, path)
 	}
 
-	repo = strings.TrimSuffix(pathParts[1], ".git")
+	return Remote{
+		Host:  strings.ToLower(host),
+		Owner: pathParts[0],
+		Repo:  strings.TrimSuffix(pathParts[1], ".git"),
+	}, nil
+}
+
+func allowedHosts(enterpriseHost string) string {
+	if enterpriseHost == "" {
+		return DefaultHost
+	}
 
-	return pathParts[0], repo, nil
+	return DefaultHost + " or " + enterpriseHost
 }
 
 // NewClient creates a new GitHub client.
 // It first checks the GITHUB_TOKEN environment variable.
 // If not found, it attempts to retrieve the token from 'pass' (github/token).
 func NewClient(ctx context.Context, logger *slog.Logger, owner, repo string) (*Client, error) {
-	token := os.Getenv(GHTokenEnvVar)
-
-	if token == "" {
-		logger.DebugContext(ctx, "GITHUB_TOKEN env var empty, attempting to retrieve from 'pass'...")
-
-		passToken, err := tryFetchTokenFromPass(ctx, logger)
-		if err == nil && passToken != "" {
-			token = passToken
+	return NewClientForHost(ctx, logger, DefaultHost, owner, repo)
+}
 
-			logger.InfoContext(ctx, "Successfully retrieved GitHub token from 'pass'")
-		} else if err != nil {
-			logger.DebugContext(ctx, "Failed to retrieve from pass", "error", err)
-		}
+// NewClientForHost creates a GitHub client like NewClient, for github.com or a
+// GitHub Enterprise Server host such as "github.example.com". It returns
+// network.ErrOffline when ctx is marked offline.
+func NewClientForHost(ctx context.Context, logger *slog.Logger, host, owner, repo string) (*Client, error) {
+	if network.Offline(ctx) {
+		return nil, network.ErrOffline
 	}
 
-	if token == "" {
-		errorMsg := `
-GitHub token not found. 
-
-1. Ensure you have run 'contextvibes factory setup-identity' to store your token in 'pass'.
-2. OR export it manually: export GITHUB_TOKEN="your_token_here"`
+	execClient := exec.NewClient(exec.NewOSCommandExecutor(logger))
 
-		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, strings.TrimSpace(errorMsg))
+	token, source, err := ResolveToken(ctx, logger, execClient, os.Getenv, host)
+	if err != nil {
+		return nil, err
 	}
 
+	logger.DebugContext(ctx, "Using GitHub token", "source", source, "host", host)
+
 	ts := oauth2.StaticTokenSource(
 		//nolint:exhaustruct // Only AccessToken is needed.
 		&oauth2.Token{AccessToken: token},
 	)
 	httpClient := oauth2.NewClient(ctx, ts)
+	httpClient.Timeout = network.Timeout(ctx)
 
 	ghClient := github.NewClient(httpClient)
 	ghGraphQLClient := githubv4.NewClient(httpClient)
 
+	if host != "" && !strings.EqualFold(host, DefaultHost) {
+		baseURL := "https://" + host
+
+		var err error
+
+		ghClient, err = ghClient.WithEnterpriseURLs(baseURL+"/api/v3/", baseURL+"/api/uploads/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub Enterprise host '%s': %w", host, err)
+		}
+
+		ghGraphQLClient = githubv4.NewEnterpriseClient(baseURL+"/api/graphql", httpClient)
+	}
+
 	return &Client{
 		Client:  ghClient,
 		GraphQL: ghGraphQLClient,
 		logger:  logger,
 		owner:   owner,
 		repo:    repo,
+
+		defaultBranch:   "",
+		defaultBranchMu: sync.Mutex{},
 	}, nil
 }
 
-func tryFetchTokenFromPass(ctx context.Context, logger *slog.Logger) (string, error) {
-	// Create a temporary executor just for this check
-	executor := exec.NewOSCommandExecutor(logger)
-	client := exec.NewClient(executor)
-
-	if !client.CommandExists("pass") {
-		return "", ErrPassCommandNotFound
-	}
+// NewClientWithAPI wraps an existing go-github REST client, e.g. one pointed at a test server.
+// The GraphQL client is left nil.
+func NewClientWithAPI(ghClient *github.Client, logger *slog.Logger, owner, repo string) *Client {
+	return NewClientWithAPIs(ghClient, nil, logger, owner, repo)
+}
 
-	stdout, _, err := client.CaptureOutput(ctx, ".", "pass", "show", PassTokenKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to capture pass output: %w", err)
-	}
+// NewClientWithAPIs wraps existing REST and GraphQL clients, e.g. ones pointed at a test server.
+func NewClientWithAPIs(
+	ghClient *github.Client,
+	graphQLClient *githubv4.Client,
+	logger *slog.Logger,
+	owner, repo string,
+) *Client {
+	return &Client{
+		Client:  ghClient,
+		GraphQL: graphQLClient,
+		logger:  logger,
+		owner:   owner,
+		repo:    repo,
 
-	// pass output might contain multiple lines, the first line is the secret
-	lines := strings.Split(strings.TrimSpace(stdout), "\n")
-	if len(lines) > 0 {
-		return strings.TrimSpace(lines[0]), nil
+		defaultBranch:   "",
+		defaultBranchMu: sync.Mutex{},
 	}
-
-	return "", ErrPassOutputEmpty
 }
 
 // ListProjects fetches the first 100 GitHub Projects (V2) for the repository's owner.
@@ -182,7 +268,7 @@ func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
 					URL    githubv4.URI
 				}
 			} `graphql:"projectsV2(first: 100)"`
-		} `graphql:"organization(login: )"`
+		} `graphql:"organization(login: $owner)"`
 	}
 
 	variables := map[string]any{"owner": githubv4.String(c.owner)}
@@ -218,8 +304,8 @@ func (c *Client) GetProjectByNumber(ctx context.Context, number int) (*ProjectWi
 				Title  githubv4.String
 				Number githubv4.Int
 				URL    githubv4.URI
-			} `graphql:"projectV2(number: )"`
-		} `graphql:"organization(login: )"`
+			} `graphql:"projectV2(number: $number)"`
+		} `graphql:"organization(login: $owner)"`
 	}
 
 	variables := map[string]any{
@@ -269,12 +355,13 @@ func (c *Client) AddIssueToProject(ctx context.Context, projectID string, issueI
 			Item struct {
 				ID githubv4.ID
 			}
-		} `graphql:"addProjectV2ItemById(input: {projectId: , contentId: })"`
+		} `graphql:"addProjectV2ItemById(input: $input)"`
 	}
 
-	variables := map[string]any{
-		"projectID": githubv4.ID(projectID),
-		"issueID":   githubv4.ID(issueID),
+	//nolint:exhaustruct // ClientMutationID is optional.
+	input := githubv4.AddProjectV2ItemByIdInput{
+		ProjectID: githubv4.ID(projectID),
+		ContentID: githubv4.ID(issueID),
 	}
 
 	c.logger.DebugContext(
@@ -286,7 +373,7 @@ func (c *Client) AddIssueToProject(ctx context.Context, projectID string, issueI
 		issueID,
 	)
 
-	err := c.GraphQL.Mutate(ctx, &mutation, variables, nil)
+	err := c.GraphQL.Mutate(ctx, &mutation, input, nil)
 	if err != nil {
 		return fmt.Errorf("failed to add issue %s to project %s: %w", issueID, projectID, err)
 	}
@@ -294,6 +381,88 @@ func (c *Client) AddIssueToProject(ctx context.Context, projectID string, issueI
 	return nil
 }
 
+// ListPullRequests fetches up to 100 pull requests for the repository in the given state
+// ("open", "closed" or "all"; empty means "open"), most recently updated first.
+func (c *Client) ListPullRequests(ctx context.Context, state string) ([]PullRequest, error) {
+	switch state {
+	case "":
+		state = "open"
+	case "open", "closed", "all":
+	default:
+		return nil, fmt.Errorf("%w: '%s' (expected open, closed or all)", ErrInvalidPullRequestState, state)
+	}
+
+	//nolint:exhaustruct // Partial options are valid.
+	opts := &github.PullRequestListOptions{
+		State:     state,
+		Sort:      "updated",
+		Direction: "desc",
+		//nolint:exhaustruct,mnd // 100 is the API maximum page size.
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	c.logger.DebugContext(ctx, "Listing GitHub pull requests", "owner", c.owner, "repo", c.repo, "state", state)
+
+	prs, _, err := c.PullRequests.List(ctx, c.owner, c.repo, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list github pull requests: %w", err)
+	}
+
+	pullRequests := make([]PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		pullRequests = append(pullRequests, PullRequest{
+			Number: pr.GetNumber(),
+			Title:  pr.GetTitle(),
+			Author: pr.GetUser().GetLogin(),
+			URL:    pr.GetHTMLURL(),
+			State:  pr.GetState(),
+			Draft:  pr.GetDraft(),
+		})
+	}
+
+	return pullRequests, nil
+}
+
+// GetDefaultBranch returns the repository's default branch as reported by GitHub.
+// The result is cached for the lifetime of the client.
+func (c *Client) GetDefaultBranch(ctx context.Context) (string, error) {
+	c.defaultBranchMu.Lock()
+	defer c.defaultBranchMu.Unlock()
+
+	if c.defaultBranch != "" {
+		return c.defaultBranch, nil
+	}
+
+	c.logger.DebugContext(ctx, "Resolving remote default branch", "owner", c.owner, "repo", c.repo)
+
+	repository, _, err := c.Repositories.Get(ctx, c.owner, c.repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository '%s/%s': %w", c.owner, c.repo, err)
+	}
+
+	branch := repository.GetDefaultBranch()
+	if branch == "" {
+		return "", fmt.Errorf("%w: '%s/%s'", ErrDefaultBranchNotFound, c.owner, c.repo)
+	}
+
+	c.defaultBranch = branch
+
+	return branch, nil
+}
+
+// DefaultBranchOr returns the remote default branch, or fallback (typically the
+// configured main branch) when it cannot be resolved.
+func (c *Client) DefaultBranchOr(ctx context.Context, fallback string) string {
+	branch, err := c.GetDefaultBranch(ctx)
+	if err != nil {
+		c.logger.DebugContext(ctx, "Falling back to configured main branch", "fallback", fallback, "error", err)
+
+		return fallback
+	}
+
+	return branch
+}
+
 // GetAuthenticatedUserLogin returns the login name of the user authenticated by the token.
 func (c *Client) GetAuthenticatedUserLogin(ctx context.Context) (string, error) {
 	user, _, err := c.Users.Get(ctx, "")
@@ -370,12 +539,22 @@ func (c *Client) CreateRepo(
 	return createdRepo, nil
 }
 
-// UpdateBranchProtection applies a set of protection rules to the client's configured branch.
+// UpdateBranchProtection applies a set of protection rules to a branch.
+// An empty branch targets the repository's remote default branch.
 func (c *Client) UpdateBranchProtection(
 	ctx context.Context,
 	branch string,
 	request github.ProtectionRequest,
 ) error {
+	if branch == "" {
+		defaultBranch, err := c.GetDefaultBranch(ctx)
+		if err != nil {
+			return err
+		}
+
+		branch = defaultBranch
+	}
+
 	c.logger.InfoContext(
 		ctx,
 		"Applying branch protection rules",
@@ -391,7 +570,7 @@ func (c *Client) UpdateBranchProtection(
 	if err != nil {
 		c.logger.ErrorContext(ctx, "Failed to update branch protection", "error", err)
 
-		if strings.Contains(err.Error(), "404 Not Found") {
+		if isNotFound(err) {
 			return fmt.Errorf(
 				"%w: '%s/%s'",
 				ErrRepoNotFoundOrAuth,
@@ -407,3 +586,11 @@ func (c *Client) UpdateBranchProtection(
 
 	return nil
 }
+
+// isNotFound reports whether a REST API call failed with 404 Not Found, which GitHub
+// also returns when the token may not see the repository.
+func isNotFound(err error) bool {
+	var ghErr *github.ErrorResponse
+
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,390 @@
+// Package github_test contains tests for the github package.
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/contextvibes/cli/internal/network"
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *github.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	apiClient := gogithub.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+
+	apiClient.BaseURL = baseURL
+
+	return github.NewClientWithAPI(apiClient, slog.New(slog.DiscardHandler), "octo", "widgets")
+}
+
+// graphQLRequest is the body githubv4 posts to the GraphQL endpoint.
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// newGraphQLClient returns a client whose GraphQL requests are answered by respond,
+// which receives the decoded request and returns the "data" payload.
+func newGraphQLClient(t *testing.T, respond func(req graphQLRequest) any) *github.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		w.Header().Set("Content-Type", "application/json")
+		//nolint:errchkjson // Test fixture encoding cannot fail.
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": respond(req)})
+	}))
+	t.Cleanup(server.Close)
+
+	graphQLClient := githubv4.NewEnterpriseClient(server.URL, server.Client())
+
+	return github.NewClientWithAPIs(nil, graphQLClient, slog.New(slog.DiscardHandler), "octo", "widgets")
+}
+
+func TestListPullRequests(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success: maps pull requests", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/octo/widgets/pulls", r.URL.Path)
+			assert.Equal(t, "open", r.URL.Query().Get("state"))
+
+			w.Header().Set("Content-Type", "application/json")
+			//nolint:errchkjson // Test fixture encoding cannot fail.
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				{
+					"number":   42,
+					"title":    "Add widget cache",
+					"state":    "open",
+					"draft":    true,
+					"html_url": "https://github.com/octo/widgets/pull/42",
+					"user":     map[string]any{"login": "alice"},
+				},
+			})
+		})
+
+		prs, err := client.ListPullRequests(context.Background(), "open")
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		assert.Equal(t, github.PullRequest{
+			Number: 42,
+			Title:  "Add widget cache",
+			Author: "alice",
+			URL:    "https://github.com/octo/widgets/pull/42",
+			State:  "open",
+			Draft:  true,
+		}, prs[0])
+	})
+
+	t.Run("success: state is passed through and defaults to open", func(t *testing.T) {
+		t.Parallel()
+
+		fixtures := map[string][]map[string]any{
+			"open":   {{"number": 1, "state": "open"}},
+			"closed": {{"number": 2, "state": "closed"}},
+			"all":    {{"number": 1, "state": "open"}, {"number": 2, "state": "closed"}},
+		}
+
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			//nolint:errchkjson // Test fixture encoding cannot fail.
+			_ = json.NewEncoder(w).Encode(fixtures[r.URL.Query().Get("state")])
+		})
+
+		testCases := []struct {
+			state       string
+			wantNumbers []int
+		}{
+			{state: "", wantNumbers: []int{1}},
+			{state: "open", wantNumbers: []int{1}},
+			{state: "closed", wantNumbers: []int{2}},
+			{state: "all", wantNumbers: []int{1, 2}},
+		}
+
+		for _, testCase := range testCases {
+			prs, err := client.ListPullRequests(context.Background(), testCase.state)
+			require.NoError(t, err, "state %q", testCase.state)
+
+			numbers := make([]int, 0, len(prs))
+			for _, pr := range prs {
+				numbers = append(numbers, pr.Number)
+			}
+
+			assert.Equal(t, testCase.wantNumbers, numbers, "state %q", testCase.state)
+		}
+	})
+
+	t.Run("failure: invalid state is rejected without a request", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, func(_ http.ResponseWriter, _ *http.Request) {
+			t.Error("unexpected request to the API")
+		})
+
+		_, err := client.ListPullRequests(context.Background(), "merged")
+		require.ErrorIs(t, err, github.ErrInvalidPullRequestState)
+	})
+
+	t.Run("failure: API error is returned", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+
+		_, err := client.ListPullRequests(context.Background(), "open")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list github pull requests")
+	})
+}
+
+func TestGetDefaultBranch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success: resolves and caches the default branch", func(t *testing.T) {
+		t.Parallel()
+
+		requests := 0
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			assert.Equal(t, "/repos/octo/widgets", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name": "widgets", "default_branch": "develop"}`))
+		})
+
+		for range 2 {
+			branch, err := client.GetDefaultBranch(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "develop", branch)
+		}
+
+		assert.Equal(t, 1, requests, "default branch should be cached")
+		assert.Equal(t, "develop", client.DefaultBranchOr(context.Background(), "main"))
+	})
+
+	t.Run("failure: falls back when the API errors", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		_, err := client.GetDefaultBranch(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, "main", client.DefaultBranchOr(context.Background(), "main"))
+	})
+}
+
+func TestUpdateBranchProtection_DefaultsToRemoteDefaultBranch(t *testing.T) {
+	t.Parallel()
+
+	var protectedPath string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"default_branch": "trunk"}`))
+
+			return
+		}
+
+		protectedPath = r.URL.Path
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	//nolint:exhaustruct // Empty protection rules are enough for routing.
+	err := client.UpdateBranchProtection(context.Background(), "", gogithub.ProtectionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "/repos/octo/widgets/branches/trunk/protection", protectedPath)
+}
+
+func TestGetProjectByNumber(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success: resolves the project node ID", func(t *testing.T) {
+		t.Parallel()
+
+		client := newGraphQLClient(t, func(req graphQLRequest) any {
+			assert.Contains(t, req.Query, "organization(login: $owner)")
+			assert.Contains(t, req.Query, "projectV2(number: $number)")
+			assert.Equal(t, map[string]any{"owner": "octo", "number": float64(3)}, req.Variables)
+
+			return map[string]any{"organization": map[string]any{"projectV2": map[string]any{
+				"id":     "PVT_kwDO123",
+				"title":  "Roadmap",
+				"number": 3,
+				"url":    "https://github.com/orgs/octo/projects/3",
+			}}}
+		})
+
+		project, err := client.GetProjectByNumber(context.Background(), 3)
+		require.NoError(t, err)
+		assert.Equal(t, &github.ProjectWithID{
+			ID:     "PVT_kwDO123",
+			Title:  "Roadmap",
+			Number: 3,
+			URL:    "https://github.com/orgs/octo/projects/3",
+		}, project)
+	})
+
+	t.Run("failure: missing project", func(t *testing.T) {
+		t.Parallel()
+
+		client := newGraphQLClient(t, func(graphQLRequest) any {
+			return map[string]any{"organization": map[string]any{"projectV2": nil}}
+		})
+
+		_, err := client.GetProjectByNumber(context.Background(), 9)
+		require.ErrorIs(t, err, github.ErrProjectNotFound)
+	})
+}
+
+func TestAddIssueToProject(t *testing.T) {
+	t.Parallel()
+
+	client := newGraphQLClient(t, func(req graphQLRequest) any {
+		assert.Contains(t, req.Query, "addProjectV2ItemById(input: $input)")
+		assert.Equal(t, map[string]any{
+			"input": map[string]any{"projectId": "PVT_kwDO123", "contentId": "I_kwDO456"},
+		}, req.Variables)
+
+		return map[string]any{"addProjectV2ItemById": map[string]any{"item": map[string]any{"id": "PVTI_789"}}}
+	})
+
+	require.NoError(t, client.AddIssueToProject(context.Background(), "PVT_kwDO123", "I_kwDO456"))
+}
+
+func TestParseRemote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		remoteURL      string
+		enterpriseHost string
+		want           github.Remote
+		wantErr        bool
+	}{
+		{
+			name:      "scp-style github.com",
+			remoteURL: "git@github.com:octo/widgets.git",
+			want:      github.Remote{Host: "github.com", Owner: "octo", Repo: "widgets"},
+		},
+		{
+			name:      "scp-style without .git and with dots",
+			remoteURL: "git@github.com:octo-org/widgets.js",
+			want:      github.Remote{Host: "github.com", Owner: "octo-org", Repo: "widgets.js"},
+		},
+		{
+			name:      "https",
+			remoteURL: "https://github.com/octo/widgets.git",
+			want:      github.Remote{Host: "github.com", Owner: "octo", Repo: "widgets"},
+		},
+		{
+			name:      "https without .git",
+			remoteURL: "https://github.com/octo/widgets",
+			want:      github.Remote{Host: "github.com", Owner: "octo", Repo: "widgets"},
+		},
+		{
+			name:      "ssh URL",
+			remoteURL: "ssh://git@github.com/octo/widgets.git",
+			want:      github.Remote{Host: "github.com", Owner: "octo", Repo: "widgets"},
+		},
+		{
+			name:           "ssh URL with port on enterprise host",
+			remoteURL:      "ssh://git@github.example.com:2222/platform/api.git",
+			enterpriseHost: "github.example.com",
+			want:           github.Remote{Host: "github.example.com", Owner: "platform", Repo: "api"},
+		},
+		{
+			name:           "scp-style enterprise host",
+			remoteURL:      "git@GitHub.Example.com:platform/api.git",
+			enterpriseHost: "github.example.com",
+			want:           github.Remote{Host: "github.example.com", Owner: "platform", Repo: "api"},
+		},
+		{
+			name:           "https enterprise host",
+			remoteURL:      "https://github.example.com/platform/api",
+			enterpriseHost: "github.example.com",
+			want:           github.Remote{Host: "github.example.com", Owner: "platform", Repo: "api"},
+		},
+		{name: "enterprise host not configured", remoteURL: "git@github.example.com:platform/api.git", wantErr: true},
+		{name: "other host", remoteURL: "https://gitlab.com/octo/widgets.git", wantErr: true},
+		{name: "missing repo", remoteURL: "https://github.com/octo", wantErr: true},
+		{name: "unsupported scheme", remoteURL: "ftp://github.com/octo/widgets.git", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			remote, err := github.ParseRemote(tc.remoteURL, tc.enterpriseHost)
+			if tc.wantErr {
+				require.ErrorIs(t, err, github.ErrInvalidRemoteURL)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, remote)
+		})
+	}
+}
+
+func TestParseGitHubRemote_RejectsEnterpriseHosts(t *testing.T) {
+	t.Parallel()
+
+	owner, repo, err := github.ParseGitHubRemote("ssh://git@github.com/octo/widgets.git")
+	require.NoError(t, err)
+	assert.Equal(t, "octo", owner)
+	assert.Equal(t, "widgets", repo)
+
+	_, _, err = github.ParseGitHubRemote("https://github.example.com/platform/api.git")
+	require.ErrorIs(t, err, github.ErrInvalidRemoteURL)
+}
+
+//nolint:paralleltest // t.Setenv is incompatible with parallel tests.
+func TestNewClientForHost_EnterpriseURLs(t *testing.T) {
+	t.Setenv(github.GHTokenEnvVar, "test-token")
+
+	logger := slog.New(slog.DiscardHandler)
+
+	client, err := github.NewClientForHost(context.Background(), logger, "github.example.com", "platform", "api")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/api/v3/", client.BaseURL.String())
+
+	client, err = github.NewClientForHost(context.Background(), logger, github.DefaultHost, "octo", "widgets")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.github.com/", client.BaseURL.String())
+}
+
+func TestNewClientForHost_Offline(t *testing.T) {
+	t.Parallel()
+
+	ctx := network.WithOffline(context.Background(), true)
+
+	client, err := github.NewClientForHost(ctx, slog.New(slog.DiscardHandler), github.DefaultHost, "octo", "widgets")
+	require.ErrorIs(t, err, network.ErrOffline)
+	assert.Nil(t, client)
+}
@@ -0,0 +1,9 @@
+package github
+
+// ResetTokenCache forgets tokens resolved from gh or pass.
+func ResetTokenCache() {
+	tokenCacheMu.Lock()
+	defer tokenCacheMu.Unlock()
+
+	clear(tokenCache)
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// maxRequiredReviews is the largest approving review count GitHub accepts.
+const maxRequiredReviews = 6
+
+// ErrInvalidReviewCount is returned when a protection spec asks for an
+// unsupported number of approving reviews.
+var ErrInvalidReviewCount = errors.New("required approving reviews must be between 0 and 6")
+
+// ProtectionSpec is a provider-neutral description of branch protection rules.
+type ProtectionSpec struct {
+	// RequiredApprovingReviews is the number of approvals a pull request needs;
+	// zero means pull request reviews are not required.
+	RequiredApprovingReviews int
+	DismissStaleReviews      bool
+	RequireCodeOwnerReviews  bool
+	// RequiredStatusChecks lists the check names that must pass before merging.
+	RequiredStatusChecks []string
+	// StrictStatusChecks requires branches to be up to date before merging.
+	StrictStatusChecks bool
+	// EnforceAdmins applies the rules to repository administrators too.
+	EnforceAdmins bool
+}
+
+// Request builds the GitHub API request for the spec. Review and status check
+// requirements are left unset (disabled) when the spec does not ask for them,
+// and push restrictions are never applied.
+func (s ProtectionSpec) Request() (github.ProtectionRequest, error) {
+	if s.RequiredApprovingReviews < 0 || s.RequiredApprovingReviews > maxRequiredReviews {
+		return github.ProtectionRequest{}, fmt.Errorf("%w: got %d", ErrInvalidReviewCount, s.RequiredApprovingReviews)
+	}
+
+	//nolint:exhaustruct // Optional protection toggles keep GitHub's defaults.
+	request := github.ProtectionRequest{
+		EnforceAdmins: s.EnforceAdmins,
+		Restrictions:  nil,
+	}
+
+	if s.RequiredApprovingReviews > 0 {
+		//nolint:exhaustruct // Bypass and dismissal allowances are not managed here.
+		request.RequiredPullRequestReviews = &github.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          s.DismissStaleReviews,
+			RequireCodeOwnerReviews:      s.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: s.RequiredApprovingReviews,
+		}
+	}
+
+	if len(s.RequiredStatusChecks) > 0 || s.StrictStatusChecks {
+		checks := make([]*github.RequiredStatusCheck, 0, len(s.RequiredStatusChecks))
+		for _, name := range s.RequiredStatusChecks {
+			//nolint:exhaustruct // Any app may report the check.
+			checks = append(checks, &github.RequiredStatusCheck{Context: name})
+		}
+
+		//nolint:exhaustruct // Only the request fields are set.
+		request.RequiredStatusChecks = &github.RequiredStatusChecks{
+			Strict: s.StrictStatusChecks,
+			Checks: &checks,
+		}
+	}
+
+	return request, nil
+}
@@ -0,0 +1,67 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/github"
+	gogithub "github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtectionSpec_Request(t *testing.T) {
+	t.Parallel()
+
+	t.Run("full spec", func(t *testing.T) {
+		t.Parallel()
+
+		request, err := github.ProtectionSpec{
+			RequiredApprovingReviews: 2,
+			DismissStaleReviews:      true,
+			RequireCodeOwnerReviews:  true,
+			RequiredStatusChecks:     []string{"build", "lint"},
+			StrictStatusChecks:       true,
+			EnforceAdmins:            true,
+		}.Request()
+		require.NoError(t, err)
+
+		assert.True(t, request.EnforceAdmins)
+		assert.Nil(t, request.Restrictions)
+		//nolint:exhaustruct // Only the managed fields are set.
+		assert.Equal(t, &gogithub.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          true,
+			RequireCodeOwnerReviews:      true,
+			RequiredApprovingReviewCount: 2,
+		}, request.RequiredPullRequestReviews)
+		require.NotNil(t, request.RequiredStatusChecks)
+		assert.True(t, request.RequiredStatusChecks.Strict)
+		require.NotNil(t, request.RequiredStatusChecks.Checks)
+
+		var names []string
+		for _, check := range *request.RequiredStatusChecks.Checks {
+			names = append(names, check.Context)
+		}
+
+		assert.Equal(t, []string{"build", "lint"}, names)
+	})
+
+	t.Run("empty spec disables reviews and checks", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // The zero spec is the case under test.
+		request, err := github.ProtectionSpec{}.Request()
+		require.NoError(t, err)
+
+		assert.Nil(t, request.RequiredPullRequestReviews)
+		assert.Nil(t, request.RequiredStatusChecks)
+		assert.False(t, request.EnforceAdmins)
+	})
+
+	t.Run("review count out of range", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Only the review count matters.
+		_, err := github.ProtectionSpec{RequiredApprovingReviews: 7}.Request()
+		require.ErrorIs(t, err, github.ErrInvalidReviewCount)
+	})
+}
@@ -0,0 +1,136 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/contextvibes/cli/internal/exec"
+)
+
+// Token sources reported by ResolveToken.
+const (
+	TokenSourceEnv  = "env"
+	TokenSourceGH   = "gh"
+	TokenSourcePass = "pass"
+)
+
+// tokenHelp lists the ways to provide a token, in the order they are tried.
+const tokenHelp = `
+1. Export it: export GITHUB_TOKEN="your_token_here"
+2. OR sign in with the GitHub CLI: gh auth login
+3. OR run 'contextvibes factory setup-identity' to store your token in 'pass'.`
+
+// resolvedToken is a token and the source it came from.
+type resolvedToken struct {
+	token  string
+	source string
+}
+
+// tokenCache holds tokens found with gh or pass, by host, so those commands run
+// at most once per process.
+//
+//nolint:gochecknoglobals // Process-wide cache of resolved tokens.
+var (
+	tokenCache   = make(map[string]resolvedToken)
+	tokenCacheMu sync.Mutex
+)
+
+// tokenFetcher retrieves a token for host from an external command.
+type tokenFetcher struct {
+	source string
+	fetch  func(ctx context.Context, execClient *exec.ExecutorClient, host string) (string, error)
+}
+
+// ResolveToken finds a GitHub token for host. It tries, in order, the
+// GITHUB_TOKEN environment variable (read with getenv), 'gh auth token' and
+// 'pass show github/token', and returns the token with the name of its source.
+// Tokens from gh or pass are cached for the rest of the process.
+//
+//nolint:nonamedreturns // Named returns are used for clarity in return signature.
+func ResolveToken(
+	ctx context.Context,
+	logger *slog.Logger,
+	execClient *exec.ExecutorClient,
+	getenv func(string) string,
+	host string,
+) (token, source string, err error) {
+	if envToken := strings.TrimSpace(getenv(GHTokenEnvVar)); envToken != "" {
+		return envToken, TokenSourceEnv, nil
+	}
+
+	tokenCacheMu.Lock()
+	defer tokenCacheMu.Unlock()
+
+	if cached, ok := tokenCache[host]; ok {
+		return cached.token, cached.source, nil
+	}
+
+	fetchers := []tokenFetcher{
+		{source: TokenSourceGH, fetch: fetchTokenFromGH},
+		{source: TokenSourcePass, fetch: fetchTokenFromPass},
+	}
+
+	for _, fetcher := range fetchers {
+		fetched, err := fetcher.fetch(ctx, execClient, host)
+		if err != nil {
+			logger.DebugContext(ctx, "GitHub token source unavailable", "source", fetcher.source, "error", err)
+
+			continue
+		}
+
+		tokenCache[host] = resolvedToken{token: fetched, source: fetcher.source}
+
+		return fetched, fetcher.source, nil
+	}
+
+	return "", "", fmt.Errorf("%w: %s", ErrTokenNotFound, tokenHelp)
+}
+
+// fetchTokenFromGH reads the token the GitHub CLI is signed in with.
+func fetchTokenFromGH(ctx context.Context, execClient *exec.ExecutorClient, host string) (string, error) {
+	if !execClient.CommandExists("gh") {
+		return "", ErrGHCommandNotFound
+	}
+
+	args := []string{"auth", "token"}
+	if host != "" {
+		args = append(args, "--hostname", host)
+	}
+
+	stdout, _, err := execClient.CaptureOutput(ctx, ".", "gh", args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture gh output: %w", err)
+	}
+
+	token := strings.TrimSpace(stdout)
+	if token == "" {
+		return "", ErrGHOutputEmpty
+	}
+
+	return token, nil
+}
+
+// fetchTokenFromPass reads the token stored by setup-identity in 'pass'.
+func fetchTokenFromPass(ctx context.Context, execClient *exec.ExecutorClient, _ string) (string, error) {
+	if !execClient.CommandExists("pass") {
+		return "", ErrPassCommandNotFound
+	}
+
+	stdout, _, err := execClient.CaptureOutput(ctx, ".", "pass", "show", PassTokenKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture pass output: %w", err)
+	}
+
+	// pass output might contain multiple lines, the first line is the secret
+	firstLine, _, _ := strings.Cut(strings.TrimSpace(stdout), "\n")
+
+	token := strings.TrimSpace(firstLine)
+	if token == "" {
+		return "", ErrPassOutputEmpty
+	}
+
+	return token, nil
+}
@@ -0,0 +1,143 @@
+package github_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotLoggedIn = errors.New("exit status 1")
+
+// newTokenExecutor stubs gh and pass: only the installed commands exist, outputs
+// maps a command line to its stdout and any other command fails as if signed out.
+func newTokenExecutor(installed []string, outputs map[string]string) *exectest.Executor {
+	responses := make(map[string]exectest.Response, len(outputs))
+	for line, stdout := range outputs {
+		responses[line] = exectest.Response{Stdout: stdout, Stderr: "", Err: nil}
+	}
+
+	missing := map[string]bool{"gh": true, "pass": true}
+	for _, name := range installed {
+		delete(missing, name)
+	}
+
+	return &exectest.Executor{
+		RepoDir:   "",
+		Responses: responses,
+		Handler: func(exectest.Call) exectest.Response {
+			return exectest.Response{Stdout: "", Stderr: "not logged in", Err: errNotLoggedIn}
+		},
+		Missing: missing,
+	}
+}
+
+func resolveToken(t *testing.T, executor *exectest.Executor, env map[string]string) (string, string, error) {
+	t.Helper()
+
+	return github.ResolveToken(
+		context.Background(),
+		slog.New(slog.DiscardHandler),
+		exec.NewClient(executor),
+		func(key string) string { return env[key] },
+		github.DefaultHost,
+	)
+}
+
+//nolint:paralleltest // The token cache is shared by the whole process.
+func TestResolveToken_Sources(t *testing.T) {
+	both := func() *exectest.Executor {
+		return newTokenExecutor([]string{"gh", "pass"}, map[string]string{
+			"gh auth token --hostname github.com": "gho_fromgh\n",
+			"pass show github/token":              "ghp_frompass\nlogin: octo\n",
+		})
+	}
+
+	tests := []struct {
+		name       string
+		executor   *exectest.Executor
+		env        map[string]string
+		wantToken  string
+		wantSource string
+	}{
+		{
+			name:       "environment variable wins",
+			executor:   both(),
+			env:        map[string]string{github.GHTokenEnvVar: "ghp_fromenv"},
+			wantToken:  "ghp_fromenv",
+			wantSource: github.TokenSourceEnv,
+		},
+		{
+			name:       "gh before pass",
+			executor:   both(),
+			env:        nil,
+			wantToken:  "gho_fromgh",
+			wantSource: github.TokenSourceGH,
+		},
+		{
+			name: "pass when gh is not signed in",
+			executor: newTokenExecutor(
+				[]string{"gh", "pass"},
+				map[string]string{"pass show github/token": "ghp_frompass\n"},
+			),
+			env:        nil,
+			wantToken:  "ghp_frompass",
+			wantSource: github.TokenSourcePass,
+		},
+		{
+			name: "pass when gh is not installed",
+			executor: newTokenExecutor(
+				[]string{"pass"},
+				map[string]string{"pass show github/token": "ghp_frompass\n"},
+			),
+			env:        nil,
+			wantToken:  "ghp_frompass",
+			wantSource: github.TokenSourcePass,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			github.ResetTokenCache()
+
+			token, source, err := resolveToken(t, tc.executor, tc.env)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantToken, token)
+			assert.Equal(t, tc.wantSource, source)
+		})
+	}
+}
+
+//nolint:paralleltest // The token cache is shared by the whole process.
+func TestResolveToken_NotFound(t *testing.T) {
+	github.ResetTokenCache()
+
+	executor := newTokenExecutor([]string{"gh"}, nil)
+
+	_, _, err := resolveToken(t, executor, nil)
+	require.ErrorIs(t, err, github.ErrTokenNotFound)
+	assert.Contains(t, err.Error(), "gh auth login")
+	assert.Equal(t, []string{"gh auth token --hostname github.com"}, executor.Commands(), "pass is skipped when missing")
+}
+
+//nolint:paralleltest // The token cache is shared by the whole process.
+func TestResolveToken_CachesCommandTokens(t *testing.T) {
+	github.ResetTokenCache()
+
+	executor := newTokenExecutor([]string{"gh"}, map[string]string{"gh auth token --hostname github.com": "gho_fromgh\n"})
+
+	for range 3 {
+		token, source, err := resolveToken(t, executor, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "gho_fromgh", token)
+		assert.Equal(t, github.TokenSourceGH, source)
+	}
+
+	assert.Len(t, executor.Commands(), 1, "gh runs once per process")
+}
@@ -4,6 +4,7 @@ package globals
 import (
 	"log/slog"
 
+	"github.com/contextvibes/cli/internal/clock"
 	"github.com/contextvibes/cli/internal/config"
 	"github.com/contextvibes/cli/internal/exec"
 )
@@ -16,6 +17,14 @@ var (
 	LoadedAppConfig *config.Config
 	ExecClient      *exec.ExecutorClient
 	AssumeYes       bool
+	// Verbose is set by the global --verbose flag; workflow commands then report
+	// each step's elapsed time as it finishes.
+	Verbose bool
+	// Clock stamps generated artifacts; tests replace it with a clock.Fixed.
+	Clock clock.Clock = clock.Real{}
+	// ConfigPath is the file given with the global --config flag; empty when the
+	// config file is discovered in the repository root.
+	ConfigPath string
 	// AppVersion is the current version of the CLI.
 	AppVersion = "0.6.0"
 )
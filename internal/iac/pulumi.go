@@ -0,0 +1,39 @@
+// Package iac holds helpers shared by the infrastructure-as-code commands
+// ('factory plan' and 'factory deploy') for Terraform and Pulumi.
+package iac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/exec"
+)
+
+// ErrPulumiStackNotFound is returned when the requested Pulumi stack does not exist.
+var ErrPulumiStackNotFound = errors.New("pulumi stack not found")
+
+// SelectPulumiStack makes stack the active Pulumi stack in dir. An empty stack
+// leaves the current selection untouched.
+func SelectPulumiStack(ctx context.Context, execClient *exec.ExecutorClient, dir, stack string) error {
+	if stack == "" {
+		return nil
+	}
+
+	_, stderr, err := execClient.CaptureOutput(ctx, dir, "pulumi", "stack", "select", stack)
+	if err != nil {
+		if strings.Contains(stderr, "no stack named") {
+			return fmt.Errorf("%w: '%s' (run 'pulumi stack ls' to list stacks)", ErrPulumiStackNotFound, stack)
+		}
+
+		detail := strings.TrimSpace(stderr)
+		if detail == "" {
+			return fmt.Errorf("pulumi stack select %s failed: %w", stack, err)
+		}
+
+		return fmt.Errorf("pulumi stack select %s failed: %w: %s", stack, err, detail)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package iac
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/contextvibes/cli/internal/exec"
+)
+
+// ErrStalePlan is returned when Terraform configuration changed after the plan file was written.
+var ErrStalePlan = errors.New("terraform plan file is older than the configuration")
+
+// terraformConfigPatterns are the files whose changes invalidate a saved plan.
+//
+//nolint:gochecknoglobals // Static list of patterns.
+var terraformConfigPatterns = []string{"*.tf", "*.tfvars", "*.tf.json", ".terraform.lock.hcl"}
+
+// PlanSummary counts the resource changes in a Terraform plan. A replacement
+// (delete then create, or create then delete) is counted in Replace only.
+type PlanSummary struct {
+	Create  int
+	Update  int
+	Delete  int
+	Replace int
+	// Destroyed lists the addresses of resources that are deleted or replaced.
+	Destroyed []string
+}
+
+// Total returns the number of resources the plan changes.
+func (s PlanSummary) Total() int {
+	return s.Create + s.Update + s.Delete + s.Replace
+}
+
+// terraformPlanJSON is the subset of `terraform show -json` output we read.
+type terraformPlanJSON struct {
+	ResourceChanges []struct {
+		Address string `json:"address"`
+		Change  struct {
+			Actions []string `json:"actions"`
+		} `json:"change"`
+	} `json:"resource_changes"` //nolint:tagliatelle // Terraform's JSON format.
+}
+
+// ParsePlanJSON summarizes the output of `terraform show -json <planfile>`.
+func ParsePlanJSON(data []byte) (PlanSummary, error) {
+	var plan terraformPlanJSON
+
+	err := json.Unmarshal(data, &plan)
+	if err != nil {
+		return PlanSummary{}, fmt.Errorf("failed to parse terraform plan JSON: %w", err)
+	}
+
+	var summary PlanSummary
+
+	for _, change := range plan.ResourceChanges {
+		actions := change.Change.Actions
+
+		switch {
+		case slices.Contains(actions, "delete") && slices.Contains(actions, "create"):
+			summary.Replace++
+			summary.Destroyed = append(summary.Destroyed, change.Address)
+		case slices.Contains(actions, "delete"):
+			summary.Delete++
+			summary.Destroyed = append(summary.Destroyed, change.Address)
+		case slices.Contains(actions, "create"):
+			summary.Create++
+		case slices.Contains(actions, "update"):
+			summary.Update++
+		}
+	}
+
+	return summary, nil
+}
+
+// ShowPlan runs `terraform show -json` on planFile in dir and summarizes it.
+func ShowPlan(ctx context.Context, execClient *exec.ExecutorClient, dir, planFile string) (PlanSummary, error) {
+	stdout, stderr, err := execClient.CaptureOutput(ctx, dir, "terraform", "show", "-json", planFile)
+	if err != nil {
+		return PlanSummary{}, fmt.Errorf("terraform show -json %s failed: %w: %s", planFile, err, strings.TrimSpace(stderr))
+	}
+
+	return ParsePlanJSON([]byte(stdout))
+}
+
+// CheckPlanFresh returns ErrStalePlan when any Terraform configuration file in
+// dir was modified after planFile was written.
+func CheckPlanFresh(dir, planFile string) error {
+	planInfo, err := os.Stat(filepath.Join(dir, planFile))
+	if err != nil {
+		return fmt.Errorf("failed to check plan file: %w", err)
+	}
+
+	newest, newestPath, err := newestConfigFile(dir)
+	if err != nil {
+		return err
+	}
+
+	if newest.After(planInfo.ModTime()) {
+		return fmt.Errorf("%w: '%s' changed after '%s' was written", ErrStalePlan, newestPath, planFile)
+	}
+
+	return nil
+}
+
+func newestConfigFile(dir string) (time.Time, string, error) {
+	var (
+		newest     time.Time
+		newestPath string
+	)
+
+	for _, pattern := range terraformConfigPatterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("error checking for %s files: %w", pattern, err)
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return time.Time{}, "", fmt.Errorf("failed to stat %s: %w", match, err)
+			}
+
+			if info.ModTime().After(newest) {
+				newest = info.ModTime()
+				newestPath = filepath.Base(match)
+			}
+		}
+	}
+
+	return newest, newestPath, nil
+}
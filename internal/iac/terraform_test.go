@@ -0,0 +1,80 @@
+package iac_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/contextvibes/cli/internal/iac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// planFixture is trimmed `terraform show -json` output covering every action kind.
+const planFixture = `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "aws_s3_bucket.logs", "change": {"actions": ["create"]}},
+    {"address": "aws_s3_bucket.assets", "change": {"actions": ["create"]}},
+    {"address": "aws_iam_role.app", "change": {"actions": ["update"]}},
+    {"address": "aws_instance.web", "change": {"actions": ["delete", "create"]}},
+    {"address": "aws_db_instance.old", "change": {"actions": ["delete"]}},
+    {"address": "aws_vpc.main", "change": {"actions": ["no-op"]}},
+    {"address": "data.aws_ami.ubuntu", "change": {"actions": ["read"]}}
+  ]
+}`
+
+func TestParsePlanJSON(t *testing.T) {
+	t.Parallel()
+
+	summary, err := iac.ParsePlanJSON([]byte(planFixture))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, summary.Create)
+	assert.Equal(t, 1, summary.Update)
+	assert.Equal(t, 1, summary.Replace)
+	assert.Equal(t, 1, summary.Delete)
+	assert.Equal(t, 5, summary.Total())
+	assert.Equal(t, []string{"aws_instance.web", "aws_db_instance.old"}, summary.Destroyed)
+}
+
+func TestParsePlanJSON_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := iac.ParsePlanJSON([]byte("Error: not a plan"))
+	require.Error(t, err)
+}
+
+func TestCheckPlanFresh(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, configAge, planAge time.Duration) string {
+		t.Helper()
+
+		dir := t.TempDir()
+		now := time.Now()
+
+		for name, age := range map[string]time.Duration{"main.tf": configAge, "tfplan.out": planAge} {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.WriteFile(path, []byte{}, 0o600))
+			require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+		}
+
+		return dir
+	}
+
+	t.Run("plan newer than config", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, iac.CheckPlanFresh(setup(t, time.Hour, time.Minute), "tfplan.out"))
+	})
+
+	t.Run("config edited after plan", func(t *testing.T) {
+		t.Parallel()
+
+		err := iac.CheckPlanFresh(setup(t, time.Minute, time.Hour), "tfplan.out")
+		require.ErrorIs(t, err, iac.ErrStalePlan)
+		assert.Contains(t, err.Error(), "main.tf")
+	})
+}
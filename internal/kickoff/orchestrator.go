@@ -0,0 +1,290 @@
+// Package kickoff implements the strategic kickoff session: it gathers project
+// details and AI collaboration preferences, persists them, and renders a master
+// prompt for an external AI.
+package kickoff
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/contextvibes/cli/internal/clock"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/project"
+)
+
+// filePermUserRW represents read/write permissions for the user (0600).
+const filePermUserRW = 0o600
+
+//go:embed assets/master_prompt.md.tpl
+var masterPromptTemplate string
+
+// PresenterInterface is the subset of the UI presenter the orchestrator needs.
+type PresenterInterface interface {
+	Header(format string, a ...any)
+	Info(format string, a ...any)
+	Success(format string, a ...any)
+	PromptForInputWithDefault(prompt, defaultValue string) (string, error)
+}
+
+// ProjectInfo holds the answers from the initial information-gathering phase.
+type ProjectInfo struct {
+	Name string
+	Type string
+}
+
+// PromptData is the data rendered into the master prompt template.
+type PromptData struct {
+	Project       ProjectInfo
+	Collaboration config.AICollaborationPreferences
+}
+
+// Orchestrator drives the strategic kickoff session for a project directory.
+type Orchestrator struct {
+	presenter PresenterInterface
+	cfg       *config.Config
+	dir       string
+	clock     clock.Clock
+}
+
+// NewOrchestrator creates an orchestrator for the project in dir. The config is
+// the effective (merged) configuration used for prompt defaults.
+func NewOrchestrator(presenter PresenterInterface, cfg *config.Config, dir string) *Orchestrator {
+	return &Orchestrator{presenter: presenter, cfg: cfg, dir: dir, clock: clock.Real{}}
+}
+
+// SetClock replaces the clock used to date the kickoff, so tests can fix it.
+func (o *Orchestrator) SetClock(c clock.Clock) {
+	o.clock = c
+}
+
+// ConfigPath returns the project config file the orchestrator persists answers to.
+func (o *Orchestrator) ConfigPath() string {
+	return filepath.Join(o.dir, config.DefaultConfigFileName)
+}
+
+// GatherPromptData runs the interactive phases and returns the collected answers.
+func (o *Orchestrator) GatherPromptData() (PromptData, error) {
+	info, err := o.runInitialInfoGathering()
+	if err != nil {
+		return PromptData{}, err
+	}
+
+	prefs, err := o.runCollaborationSetup()
+	if err != nil {
+		return PromptData{}, err
+	}
+
+	return PromptData{Project: info, Collaboration: prefs}, nil
+}
+
+// GenerateMasterPrompt gathers the answers, saves them to the project config so the
+// next run can offer them as defaults, and writes the rendered master prompt to outputPath.
+func (o *Orchestrator) GenerateMasterPrompt(outputPath string) error {
+	data, err := o.GatherPromptData()
+	if err != nil {
+		return err
+	}
+
+	err = o.saveConfig(func(cfg *config.Config) {
+		cfg.Kickoff.ProjectName = data.Project.Name
+		cfg.Kickoff.ProjectType = data.Project.Type
+		cfg.AI.CollaborationPreferences = data.Collaboration
+	})
+	if err != nil {
+		return err
+	}
+
+	o.cfg.Kickoff.ProjectName = data.Project.Name
+	o.cfg.Kickoff.ProjectType = data.Project.Type
+	o.cfg.AI.CollaborationPreferences = data.Collaboration
+	o.presenter.Info("Saved kickoff answers to %s", o.ConfigPath())
+
+	prompt, err := RenderMasterPrompt(data)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(outputPath, []byte(prompt), filePermUserRW)
+	if err != nil {
+		return fmt.Errorf("failed to write prompt to %s: %w", outputPath, err)
+	}
+
+	o.presenter.Success("Master prompt generated: %s", outputPath)
+
+	return nil
+}
+
+// MarkStrategicKickoffComplete records in the project config that the strategic
+// kickoff is done, and returns the saved RFC3339 date.
+func (o *Orchestrator) MarkStrategicKickoffComplete() (string, error) {
+	completed := true
+	date := o.clock.Now().UTC().Format(time.RFC3339)
+
+	err := o.saveConfig(func(cfg *config.Config) {
+		cfg.ProjectState.StrategicKickoffCompleted = &completed
+		cfg.ProjectState.LastStrategicKickoffDate = date
+	})
+	if err != nil {
+		return "", err
+	}
+
+	o.cfg.ProjectState.StrategicKickoffCompleted = &completed
+	o.cfg.ProjectState.LastStrategicKickoffDate = date
+
+	return date, nil
+}
+
+// RenderMasterPrompt executes the master prompt template with the given data.
+func RenderMasterPrompt(data PromptData) (string, error) {
+	tmpl, err := template.New("master_prompt").Parse(masterPromptTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse prompt template: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// runInitialInfoGathering asks for the project name and type. Previously saved
+// answers are the defaults; otherwise the go.mod module name (or directory name)
+// and the detected project type are offered.
+func (o *Orchestrator) runInitialInfoGathering() (ProjectInfo, error) {
+	o.presenter.Header("Project Information")
+
+	defaultName := o.cfg.Kickoff.ProjectName
+	if defaultName == "" {
+		defaultName = o.defaultProjectName()
+	}
+
+	name, err := o.ask("Project name", defaultName)
+	if err != nil {
+		return ProjectInfo{}, err
+	}
+
+	defaultType := o.cfg.Kickoff.ProjectType
+	if defaultType == "" {
+		types, err := project.DetectAll(o.dir)
+		if err != nil {
+			return ProjectInfo{}, fmt.Errorf("failed to detect project type: %w", err)
+		}
+
+		defaultType = project.JoinTypes(types)
+	}
+
+	projectType, err := o.ask("Project type", defaultType)
+	if err != nil {
+		return ProjectInfo{}, err
+	}
+
+	return ProjectInfo{Name: name, Type: projectType}, nil
+}
+
+// runCollaborationSetup asks for each AI collaboration preference, defaulting to
+// the currently configured values.
+func (o *Orchestrator) runCollaborationSetup() (config.AICollaborationPreferences, error) {
+	o.presenter.Header("AI Collaboration Preferences")
+
+	current := o.cfg.AI.CollaborationPreferences
+	questions := []struct {
+		prompt string
+		target *string
+	}{
+		{"Code provisioning style", &current.CodeProvisioningStyle},
+		{"Markdown docs style", &current.MarkdownDocsStyle},
+		{"Detailed task mode", &current.DetailedTaskMode},
+		{"Proactive detail level", &current.ProactiveDetailLevel},
+		{"AI proactivity", &current.AIProactivity},
+	}
+
+	for _, question := range questions {
+		answer, err := o.ask(question.prompt, *question.target)
+		if err != nil {
+			return config.AICollaborationPreferences{}, err
+		}
+
+		*question.target = answer
+	}
+
+	return current, nil
+}
+
+func (o *Orchestrator) ask(prompt, defaultValue string) (string, error) {
+	answer, err := o.presenter.PromptForInputWithDefault(prompt, defaultValue)
+	if err != nil {
+		return "", fmt.Errorf("prompt failed: %w", err)
+	}
+
+	return strings.TrimSpace(answer), nil
+}
+
+// defaultProjectName returns the last element of the go.mod module path, or the
+// directory name when there is no go.mod.
+func (o *Orchestrator) defaultProjectName() string {
+	if module := readModulePath(filepath.Join(o.dir, "go.mod")); module != "" {
+		return path.Base(module)
+	}
+
+	abs, err := filepath.Abs(o.dir)
+	if err != nil {
+		return filepath.Base(o.dir)
+	}
+
+	return filepath.Base(abs)
+}
+
+// saveConfig applies mutate to the project's config file as written on disk (not
+// the merged defaults) and saves it, creating the file if needed.
+func (o *Orchestrator) saveConfig(mutate func(*config.Config)) error {
+	configPath := o.ConfigPath()
+
+	onDisk, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", configPath, err)
+	}
+
+	if onDisk == nil {
+		onDisk = &config.Config{} //nolint:exhaustruct // An empty config only holds the saved answers.
+	}
+
+	mutate(onDisk)
+
+	err = config.UpdateAndSaveConfig(onDisk, configPath)
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", configPath, err)
+	}
+
+	return nil
+}
+
+func readModulePath(goModPath string) string {
+	//nolint:gosec // Reading the project's own go.mod is intended.
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if module, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,165 @@
+package kickoff_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/contextvibes/cli/internal/clock"
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/kickoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedPresenter answers prompts in order and records each prompt's default.
+// An empty scripted answer accepts the default, like pressing Enter.
+type scriptedPresenter struct {
+	answers  []string
+	defaults map[string]string
+}
+
+func newScriptedPresenter(answers ...string) *scriptedPresenter {
+	return &scriptedPresenter{answers: answers, defaults: map[string]string{}}
+}
+
+func (p *scriptedPresenter) Header(_ string, _ ...any)  {}
+func (p *scriptedPresenter) Info(_ string, _ ...any)    {}
+func (p *scriptedPresenter) Success(_ string, _ ...any) {}
+
+func (p *scriptedPresenter) PromptForInputWithDefault(prompt, defaultValue string) (string, error) {
+	p.defaults[prompt] = defaultValue
+
+	answer := ""
+	if len(p.answers) > 0 {
+		answer, p.answers = p.answers[0], p.answers[1:]
+	}
+
+	if answer == "" {
+		return defaultValue, nil
+	}
+
+	return answer, nil
+}
+
+func TestGatherPromptData_Defaults(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/acme/rocket\n\ngo 1.25\n"), 0o600))
+
+	presenter := newScriptedPresenter()
+	orchestrator := kickoff.NewOrchestrator(presenter, config.GetDefaultConfig(), dir)
+
+	data, err := orchestrator.GatherPromptData()
+	require.NoError(t, err)
+
+	assert.Equal(t, kickoff.ProjectInfo{Name: "rocket", Type: "Go"}, data.Project)
+	assert.Equal(t, config.GetDefaultConfig().AI.CollaborationPreferences, data.Collaboration)
+	assert.Equal(t, "rocket", presenter.defaults["Project name"])
+	assert.Equal(t, "bash_cat_eof", presenter.defaults["Code provisioning style"])
+}
+
+func TestGatherPromptData_Answers(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "my-service")
+	require.NoError(t, os.Mkdir(dir, 0o750))
+
+	presenter := newScriptedPresenter("Billing API", "Python", "inline_snippets", "", "mode_a")
+	orchestrator := kickoff.NewOrchestrator(presenter, config.GetDefaultConfig(), dir)
+
+	data, err := orchestrator.GatherPromptData()
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-service", presenter.defaults["Project name"])
+	assert.Equal(t, "Unknown", presenter.defaults["Project type"])
+	assert.Equal(t, kickoff.ProjectInfo{Name: "Billing API", Type: "Python"}, data.Project)
+	assert.Equal(t, "inline_snippets", data.Collaboration.CodeProvisioningStyle)
+	assert.Equal(t, "raw_markdown", data.Collaboration.MarkdownDocsStyle)
+	assert.Equal(t, "mode_a", data.Collaboration.DetailedTaskMode)
+}
+
+func TestGenerateMasterPrompt(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "_contextvibes.md")
+
+	presenter := newScriptedPresenter("Billing API", "Go", "inline_snippets")
+	orchestrator := kickoff.NewOrchestrator(presenter, config.GetDefaultConfig(), dir)
+
+	require.NoError(t, orchestrator.GenerateMasterPrompt(output))
+
+	prompt, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Contains(t, string(prompt), "**Name:** Billing API")
+	assert.Contains(t, string(prompt), "**Type:** Go")
+	assert.Contains(t, string(prompt), "**Code provisioning style:** inline_snippets")
+	assert.NotContains(t, string(prompt), "New Awesome Project")
+
+	saved, err := config.LoadConfig(orchestrator.ConfigPath())
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, "inline_snippets", saved.AI.CollaborationPreferences.CodeProvisioningStyle)
+	assert.Equal(t, "raw_markdown", saved.AI.CollaborationPreferences.MarkdownDocsStyle)
+}
+
+func TestGenerateMasterPrompt_SecondRunOffersSavedAnswers(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "_contextvibes.md")
+
+	first := kickoff.NewOrchestrator(
+		newScriptedPresenter("Billing API", "Python", "", "", "mode_a"),
+		config.GetDefaultConfig(),
+		dir,
+	)
+	require.NoError(t, first.GenerateMasterPrompt(output))
+
+	saved, err := config.LoadConfig(first.ConfigPath())
+	require.NoError(t, err)
+	assert.Equal(t, config.KickoffSettings{ProjectName: "Billing API", ProjectType: "Python"}, saved.Kickoff)
+
+	presenter := newScriptedPresenter()
+	second := kickoff.NewOrchestrator(
+		presenter,
+		config.MergeWithDefaults(saved, config.GetDefaultConfig()),
+		dir,
+	)
+	data, err := second.GatherPromptData()
+	require.NoError(t, err)
+
+	assert.Equal(t, "Billing API", presenter.defaults["Project name"])
+	assert.Equal(t, "Python", presenter.defaults["Project type"])
+	assert.Equal(t, "mode_a", presenter.defaults["Detailed task mode"])
+	assert.Equal(t, kickoff.ProjectInfo{Name: "Billing API", Type: "Python"}, data.Project)
+}
+
+func TestMarkStrategicKickoffComplete(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, config.DefaultConfigFileName)
+	require.NoError(t, os.WriteFile(configPath, []byte("git:\n  defaultRemote: upstream\n"), 0o600))
+
+	cfg := config.GetDefaultConfig()
+	orchestrator := kickoff.NewOrchestrator(newScriptedPresenter(), cfg, dir)
+	eastern := time.FixedZone("UTC-5", -5*60*60)
+	orchestrator.SetClock(clock.Fixed{Time: time.Date(2026, time.January, 2, 20, 30, 0, 0, eastern)})
+
+	date, err := orchestrator.MarkStrategicKickoffComplete()
+	require.NoError(t, err)
+	assert.Equal(t, "2026-01-03T01:30:00Z", date, "the date is recorded in UTC")
+
+	saved, err := config.LoadConfig(configPath)
+	require.NoError(t, err)
+	require.NotNil(t, saved.ProjectState.StrategicKickoffCompleted)
+	assert.True(t, *saved.ProjectState.StrategicKickoffCompleted)
+	assert.Equal(t, date, saved.ProjectState.LastStrategicKickoffDate)
+	assert.Equal(t, "upstream", saved.Git.DefaultRemote, "existing settings are preserved")
+
+	assert.True(t, *cfg.ProjectState.StrategicKickoffCompleted)
+}
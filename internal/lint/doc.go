@@ -0,0 +1,6 @@
+/*
+Package lint implements small, dependency-free linters for the non-Go files that
+live alongside code, such as Markdown documentation and YAML configuration.
+Each linter returns Findings that commands can report through the presenter.
+*/
+package lint
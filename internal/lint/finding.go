@@ -0,0 +1,16 @@
+package lint
+
+import "fmt"
+
+// Finding is a single rule violation at a specific line of a file.
+type Finding struct {
+	Path    string
+	Line    int
+	Rule    string
+	Message string
+}
+
+// String formats the finding as "path:line: rule message".
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s %s", f.Path, f.Line, f.Rule, f.Message)
+}
@@ -0,0 +1,224 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Markdown rule identifiers. They follow markdownlint's numbering so existing
+// .markdownlint configuration files keep their meaning.
+const (
+	RuleHeadingIncrement  = "MD001"
+	RuleTrailingSpaces    = "MD009"
+	RuleHardTabs          = "MD010"
+	RuleBareURLs          = "MD034"
+	RuleTrailingNewline   = "MD047"
+	markdownConfigDefault = "default"
+)
+
+// MarkdownRule describes one Markdown check.
+type MarkdownRule struct {
+	ID          string
+	Alias       string
+	Description string
+}
+
+// MarkdownConfigFiles are the configuration files looked up in the lint root, in order.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var MarkdownConfigFiles = []string{".markdownlint.yaml", ".markdownlint.yml", ".markdownlint.json"}
+
+// ErrUnknownMarkdownRule is returned when a configuration file names a rule that does not exist.
+var ErrUnknownMarkdownRule = errors.New("unknown markdown rule")
+
+//nolint:gochecknoglobals // Static regex compilation.
+var (
+	atxHeadingRegex = regexp.MustCompile(`^ {0,3}(#{1,6})(\s|$)`)
+	fenceRegex      = regexp.MustCompile("^ {0,3}(```|~~~)")
+	inlineCodeRegex = regexp.MustCompile("`[^`]*`")
+	autolinkRegex   = regexp.MustCompile(`<[^>\s]+>`)
+	inlineLinkRegex = regexp.MustCompile(`!?\[[^\]]*\]\([^)]*\)`)
+	linkRefRegex    = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:\s`)
+	bareURLRegex    = regexp.MustCompile(`https?://[^\s<>()\[\]]+`)
+)
+
+// MarkdownRules returns the supported rules in ID order.
+func MarkdownRules() []MarkdownRule {
+	return []MarkdownRule{
+		{RuleHeadingIncrement, "heading-increment", "Heading levels should only increment by one level at a time"},
+		{RuleTrailingSpaces, "no-trailing-spaces", "Trailing spaces"},
+		{RuleHardTabs, "no-hard-tabs", "Hard tabs"},
+		{RuleBareURLs, "no-bare-urls", "Bare URL used"},
+		{RuleTrailingNewline, "single-trailing-newline", "Files should end with a single newline character"},
+	}
+}
+
+// MarkdownConfig selects which Markdown rules run. The zero value enables every rule.
+type MarkdownConfig struct {
+	disabled map[string]bool
+}
+
+// Enabled reports whether the rule with the given ID runs.
+func (c MarkdownConfig) Enabled(ruleID string) bool {
+	return !c.disabled[ruleID]
+}
+
+// ParseMarkdownConfig reads a markdownlint-style configuration (YAML or JSON).
+// "default: false" disables every rule not explicitly enabled; each rule is then
+// switched on or off by ID or alias, where an options object counts as enabled.
+func ParseMarkdownConfig(data []byte) (MarkdownConfig, error) {
+	var raw map[string]any
+
+	err := yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return MarkdownConfig{disabled: nil}, fmt.Errorf("failed to parse markdown lint config: %w", err)
+	}
+
+	defaultEnabled := true
+	if value, ok := raw[markdownConfigDefault].(bool); ok {
+		defaultEnabled = value
+	}
+
+	rules := MarkdownRules()
+	disabled := make(map[string]bool, len(rules))
+
+	for _, rule := range rules {
+		disabled[rule.ID] = !defaultEnabled
+	}
+
+	for key, value := range raw {
+		if key == markdownConfigDefault {
+			continue
+		}
+
+		ruleID, found := resolveMarkdownRule(rules, key)
+		if !found {
+			return MarkdownConfig{disabled: nil}, fmt.Errorf("%w: '%s'", ErrUnknownMarkdownRule, key)
+		}
+
+		enabled, isBool := value.(bool)
+		disabled[ruleID] = isBool && !enabled
+	}
+
+	return MarkdownConfig{disabled: disabled}, nil
+}
+
+// LoadMarkdownConfig loads the first configuration file from MarkdownConfigFiles found
+// in dir. When none exists, every rule is enabled.
+func LoadMarkdownConfig(dir string) (MarkdownConfig, error) {
+	for _, name := range MarkdownConfigFiles {
+		//nolint:gosec // Reading the project's lint configuration is intended.
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return MarkdownConfig{disabled: nil}, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		config, err := ParseMarkdownConfig(data)
+		if err != nil {
+			return MarkdownConfig{disabled: nil}, fmt.Errorf("%s: %w", name, err)
+		}
+
+		return config, nil
+	}
+
+	return MarkdownConfig{disabled: nil}, nil
+}
+
+// LintMarkdown checks Markdown content and returns its findings in line order.
+// Headings and URLs inside fenced code blocks are ignored; two trailing spaces
+// (a Markdown hard line break) are allowed.
+//
+//nolint:cyclop // A single pass over the lines keeps the rules easy to follow.
+func LintMarkdown(path string, content []byte, config MarkdownConfig) []Finding {
+	var findings []Finding
+
+	report := func(line int, rule, message string) {
+		if config.Enabled(rule) {
+			findings = append(findings, Finding{Path: path, Line: line, Rule: rule, Message: message})
+		}
+	}
+
+	text := string(content)
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	inFence := false
+	previousLevel := 0
+
+	for i, line := range lines {
+		lineNumber := i + 1
+		line = strings.TrimSuffix(line, "\r")
+
+		if trailing := len(line) - len(strings.TrimRight(line, " \t")); trailing > 0 {
+			if trailing != 2 || strings.TrimSpace(line) == "" || strings.HasSuffix(line, "\t") {
+				report(lineNumber, RuleTrailingSpaces, fmt.Sprintf("trailing whitespace (%d characters)", trailing))
+			}
+		}
+
+		if column := strings.IndexByte(line, '\t'); column >= 0 {
+			report(lineNumber, RuleHardTabs, fmt.Sprintf("hard tab at column %d", column+1))
+		}
+
+		if fenceRegex.MatchString(line) {
+			inFence = !inFence
+
+			continue
+		}
+
+		if inFence {
+			continue
+		}
+
+		if match := atxHeadingRegex.FindStringSubmatch(line); match != nil {
+			level := len(match[1])
+			if previousLevel > 0 && level > previousLevel+1 {
+				report(lineNumber, RuleHeadingIncrement,
+					fmt.Sprintf("heading level jumps from h%d to h%d", previousLevel, level))
+			}
+
+			previousLevel = level
+		}
+
+		if url := findBareURL(line); url != "" {
+			report(lineNumber, RuleBareURLs, fmt.Sprintf("bare URL '%s'; wrap it in <> or a link", url))
+		}
+	}
+
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		report(len(lines), RuleTrailingNewline, "file does not end with a newline")
+	}
+
+	return findings
+}
+
+// findBareURL returns the first URL on the line that is not already part of an
+// inline code span, an autolink, a link target or a link reference definition.
+func findBareURL(line string) string {
+	if linkRefRegex.MatchString(line) {
+		return ""
+	}
+
+	stripped := inlineCodeRegex.ReplaceAllString(line, "")
+	stripped = autolinkRegex.ReplaceAllString(stripped, "")
+	stripped = inlineLinkRegex.ReplaceAllString(stripped, "")
+
+	return bareURLRegex.FindString(stripped)
+}
+
+func resolveMarkdownRule(rules []MarkdownRule, key string) (string, bool) {
+	for _, rule := range rules {
+		if strings.EqualFold(key, rule.ID) || strings.EqualFold(key, rule.Alias) {
+			return rule.ID, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,102 @@
+package lint_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintMarkdown(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		content string
+		rule    string
+		line    int
+	}{
+		{"trailing whitespace", "# Title\n\nSome text   \n", lint.RuleTrailingSpaces, 3},
+		{"missing final newline", "# Title\n\nNo newline", lint.RuleTrailingNewline, 3},
+		{"hard tab", "# Title\n\n-\tItem\n", lint.RuleHardTabs, 3},
+		{"heading increment", "# Title\n\n### Skipped\n", lint.RuleHeadingIncrement, 3},
+		{"bare url", "# Title\n\nSee https://example.com for details.\n", lint.RuleBareURLs, 3},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			findings := lint.LintMarkdown("doc.md", []byte(testCase.content), lint.MarkdownConfig{})
+			require.Len(t, findings, 1)
+			assert.Equal(t, testCase.rule, findings[0].Rule)
+			assert.Equal(t, testCase.line, findings[0].Line)
+			assert.Contains(t, findings[0].String(), "doc.md:3: "+testCase.rule)
+		})
+	}
+}
+
+func TestLintMarkdown_CleanContent(t *testing.T) {
+	t.Parallel()
+
+	content := "# Title\n\n## Section\n\nA line break  \n" +
+		"continues, with a <https://example.com> autolink and [a link](https://example.com).\n\n" +
+		"[ref]: https://example.com\n\n" +
+		"```bash\n# not a heading\ncurl https://example.com\n```\n"
+
+	assert.Empty(t, lint.LintMarkdown("doc.md", []byte(content), lint.MarkdownConfig{}))
+}
+
+func TestParseMarkdownConfig(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("# Title\n\n### Skipped\tSee https://example.com\n")
+
+	t.Run("disables rules by id and alias", func(t *testing.T) {
+		t.Parallel()
+
+		config, err := lint.ParseMarkdownConfig([]byte("MD034: false\nno-hard-tabs: false\n"))
+		require.NoError(t, err)
+
+		findings := lint.LintMarkdown("doc.md", content, config)
+		require.Len(t, findings, 1)
+		assert.Equal(t, lint.RuleHeadingIncrement, findings[0].Rule)
+	})
+
+	t.Run("default false enables only listed rules", func(t *testing.T) {
+		t.Parallel()
+
+		config, err := lint.ParseMarkdownConfig([]byte(`{"default": false, "MD010": true}`))
+		require.NoError(t, err)
+
+		findings := lint.LintMarkdown("doc.md", content, config)
+		require.Len(t, findings, 1)
+		assert.Equal(t, lint.RuleHardTabs, findings[0].Rule)
+	})
+
+	t.Run("unknown rule is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := lint.ParseMarkdownConfig([]byte("MD999: false\n"))
+		require.ErrorIs(t, err, lint.ErrUnknownMarkdownRule)
+	})
+}
+
+func TestLoadMarkdownConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".markdownlint.yaml"), []byte("MD047: false\n"), 0o600))
+
+	config, err := lint.LoadMarkdownConfig(dir)
+	require.NoError(t, err)
+	assert.False(t, config.Enabled(lint.RuleTrailingNewline))
+	assert.True(t, config.Enabled(lint.RuleBareURLs))
+
+	defaults, err := lint.LoadMarkdownConfig(t.TempDir())
+	require.NoError(t, err)
+	assert.True(t, defaults.Enabled(lint.RuleTrailingNewline))
+}
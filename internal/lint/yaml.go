@@ -0,0 +1,137 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML rule identifiers, named after their yamllint equivalents.
+const (
+	RuleYAMLSyntax        = "syntax"
+	RuleYAMLDuplicateKeys = "key-duplicates"
+	RuleYAMLIndentation   = "indentation"
+)
+
+// YAMLExtensions are the file extensions treated as YAML.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var YAMLExtensions = []string{".yaml", ".yml"}
+
+//nolint:gochecknoglobals // Static regex compilation.
+var yamlErrorLineRegex = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// yamlIndent tracks the indentation step of nested block mappings in one file.
+type yamlIndent struct {
+	step int
+	line int
+}
+
+// LintYAML parses every document in content and reports syntax errors, duplicate
+// mapping keys and nested block mappings whose indentation step differs from the
+// first one used in the file. Parsing stops at the first syntax error.
+func LintYAML(path string, content []byte) []Finding {
+	var findings []Finding
+
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	indent := &yamlIndent{step: 0, line: 0}
+
+	for {
+		var document yaml.Node
+
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return append(findings, yamlSyntaxFinding(path, err))
+		}
+
+		findings = append(findings, lintYAMLNode(path, &document, indent)...)
+	}
+
+	return findings
+}
+
+func lintYAMLNode(path string, node *yaml.Node, indent *yamlIndent) []Finding {
+	var findings []Finding
+
+	if node.Kind == yaml.MappingNode {
+		seen := make(map[string]int, len(node.Content)/2) //nolint:mnd // Keys and values alternate.
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			if firstLine, duplicate := seen[key.Value]; duplicate {
+				findings = append(findings, Finding{
+					Path:    path,
+					Line:    key.Line,
+					Rule:    RuleYAMLDuplicateKeys,
+					Message: fmt.Sprintf("duplicate key '%s' (first defined at line %d)", key.Value, firstLine),
+				})
+			} else {
+				seen[key.Value] = key.Line
+			}
+
+			if finding, ok := indent.check(path, key, value); !ok {
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		findings = append(findings, lintYAMLNode(path, child, indent)...)
+	}
+
+	return findings
+}
+
+// check compares the indentation of a block mapping nested under key with the
+// step recorded for the file, recording it if this is the first nested mapping.
+func (y *yamlIndent) check(path string, key, value *yaml.Node) (Finding, bool) {
+	if value.Kind != yaml.MappingNode || value.Style&yaml.FlowStyle != 0 || len(value.Content) == 0 {
+		return Finding{}, true
+	}
+
+	child := value.Content[0]
+	if child.Line == key.Line {
+		return Finding{}, true
+	}
+
+	step := child.Column - key.Column
+	if y.step == 0 {
+		y.step, y.line = step, child.Line
+
+		return Finding{}, true
+	}
+
+	if step == y.step {
+		return Finding{}, true
+	}
+
+	return Finding{
+		Path: path,
+		Line: child.Line,
+		Rule: RuleYAMLIndentation,
+		Message: fmt.Sprintf("indented by %d spaces; expected %d (as at line %d)",
+			step, y.step, y.line),
+	}, false
+}
+
+func yamlSyntaxFinding(path string, err error) Finding {
+	line, message := 1, err.Error()
+
+	if match := yamlErrorLineRegex.FindStringSubmatch(message); match != nil {
+		if parsed, convErr := strconv.Atoi(match[1]); convErr == nil {
+			line, message = parsed, match[2]
+		}
+	}
+
+	return Finding{Path: path, Line: line, Rule: RuleYAMLSyntax, Message: message}
+}
@@ -0,0 +1,56 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintYAML(t *testing.T) {
+	t.Parallel()
+
+	t.Run("duplicate key", func(t *testing.T) {
+		t.Parallel()
+
+		content := "project:\n  name: cli\n  name: thea\nversion: 1\n"
+
+		findings := lint.LintYAML("config.yaml", []byte(content))
+		require.Len(t, findings, 1)
+		assert.Equal(t, lint.RuleYAMLDuplicateKeys, findings[0].Rule)
+		assert.Equal(t, 3, findings[0].Line)
+		assert.Contains(t, findings[0].Message, "first defined at line 2")
+	})
+
+	t.Run("malformed file", func(t *testing.T) {
+		t.Parallel()
+
+		content := "steps:\n  - run: [go, test\n  - run: lint\n"
+
+		findings := lint.LintYAML("ci.yml", []byte(content))
+		require.Len(t, findings, 1)
+		assert.Equal(t, lint.RuleYAMLSyntax, findings[0].Rule)
+		assert.Positive(t, findings[0].Line)
+		assert.NotContains(t, findings[0].Message, "yaml: line")
+	})
+
+	t.Run("inconsistent indentation", func(t *testing.T) {
+		t.Parallel()
+
+		content := "git:\n  remote: origin\nlogging:\n    level: info\n"
+
+		findings := lint.LintYAML("config.yaml", []byte(content))
+		require.Len(t, findings, 1)
+		assert.Equal(t, lint.RuleYAMLIndentation, findings[0].Rule)
+		assert.Equal(t, 4, findings[0].Line)
+	})
+
+	t.Run("clean multi-document file", func(t *testing.T) {
+		t.Parallel()
+
+		content := "a:\n  b: 1\n  c: {d: 2}\n---\na:\n  b: 1\nlist:\n  - x: 1\n    y: 2\n"
+
+		assert.Empty(t, lint.LintYAML("multi.yaml", []byte(content)))
+	})
+}
@@ -0,0 +1,122 @@
+// Package logging builds the application's slog logger, which can write a
+// human-readable console log and a structured JSON AI trace log at the same time.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// ErrInvalidLevel is returned by ParseLevel for names other than debug, info, warn and error.
+var ErrInvalidLevel = errors.New("invalid log level")
+
+// ParseLevel converts a level name (case-insensitive) to a slog.Level.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("%w '%s' (expected debug|info|warn|error)", ErrInvalidLevel, name)
+	}
+}
+
+// Options selects the logger's sinks. A nil writer disables that sink.
+type Options struct {
+	// Console receives human-readable text records at ConsoleLevel or above.
+	Console      io.Writer
+	ConsoleLevel slog.Level
+	// File receives JSON records (the AI trace log) at FileLevel or above.
+	File      io.Writer
+	FileLevel slog.Level
+}
+
+// NewLogger returns a logger writing to every sink configured in opts. With no
+// sinks, records are discarded.
+func NewLogger(opts Options) *slog.Logger {
+	var handlers []slog.Handler
+
+	if opts.Console != nil {
+		handlers = append(handlers, slog.NewTextHandler(opts.Console, &slog.HandlerOptions{
+			Level:       opts.ConsoleLevel,
+			AddSource:   false,
+			ReplaceAttr: nil,
+		}))
+	}
+
+	if opts.File != nil {
+		handlers = append(handlers, slog.NewJSONHandler(opts.File, &slog.HandlerOptions{
+			Level:       opts.FileLevel,
+			AddSource:   false,
+			ReplaceAttr: nil,
+		}))
+	}
+
+	switch len(handlers) {
+	case 0:
+		return slog.New(slog.DiscardHandler)
+	case 1:
+		return slog.New(handlers[0])
+	default:
+		return slog.New(&teeHandler{handlers: handlers})
+	}
+}
+
+// teeHandler forwards each record to every handler that accepts its level.
+type teeHandler struct {
+	handlers []slog.Handler
+}
+
+func (h *teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *teeHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+
+		err := handler.Handle(ctx, record.Clone())
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h *teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithAttrs(attrs))
+	}
+
+	return &teeHandler{handlers: handlers}
+}
+
+func (h *teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithGroup(name))
+	}
+
+	return &teeHandler{handlers: handlers}
+}
@@ -0,0 +1,108 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogger_DualOutput(t *testing.T) {
+	t.Parallel()
+
+	var console, file bytes.Buffer
+
+	logger := logging.NewLogger(logging.Options{
+		Console:      &console,
+		ConsoleLevel: slog.LevelInfo,
+		File:         &file,
+		FileLevel:    slog.LevelDebug,
+	})
+
+	logger.With("component", "test").Info("pushed branch", "branch", "feature/x")
+	logger.Debug("executing command", "command", "git")
+
+	assert.Contains(t, console.String(), `msg="pushed branch" component=test branch=feature/x`)
+	assert.NotContains(t, console.String(), "executing command", "debug records stay out of the console")
+
+	lines := bytes.Split(bytes.TrimSpace(file.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &record))
+	assert.Equal(t, "pushed branch", record["msg"])
+	assert.Equal(t, "test", record["component"])
+	assert.Equal(t, "feature/x", record["branch"])
+}
+
+func TestNewLogger_SingleSink(t *testing.T) {
+	t.Parallel()
+
+	var file bytes.Buffer
+
+	//nolint:exhaustruct // Console is disabled.
+	logger := logging.NewLogger(logging.Options{File: &file, FileLevel: slog.LevelInfo})
+	logger.Info("only to file")
+
+	assert.Contains(t, file.String(), `"msg":"only to file"`)
+}
+
+func TestNewLogger_NoSinks(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // No sinks configured.
+	logger := logging.NewLogger(logging.Options{})
+
+	assert.False(t, logger.Enabled(t.Context(), slog.LevelError))
+}
+
+func TestNewLogger_Level(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		level     string
+		wantDebug bool
+	}{
+		{"info", false},
+		{"debug", true},
+	} {
+		t.Run(tc.level, func(t *testing.T) {
+			t.Parallel()
+
+			level, err := logging.ParseLevel(tc.level)
+			require.NoError(t, err)
+
+			var console, file bytes.Buffer
+
+			logger := logging.NewLogger(logging.Options{
+				Console:      &console,
+				ConsoleLevel: level,
+				File:         &file,
+				FileLevel:    level,
+			})
+			logger.Debug("debug record")
+			logger.Info("info record")
+
+			for _, sink := range []string{console.String(), file.String()} {
+				assert.Contains(t, sink, "info record")
+				assert.Equal(t, tc.wantDebug, strings.Contains(sink, "debug record"))
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	level, err := logging.ParseLevel("WARN")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelWarn, level)
+
+	_, err = logging.ParseLevel("verbose")
+	require.ErrorIs(t, err, logging.ErrInvalidLevel)
+}
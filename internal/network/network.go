@@ -0,0 +1,70 @@
+// Package network carries the --timeout and --offline settings to the clients
+// that call remote services (GitHub, THEA, release downloads). The timeout
+// travels on the command context rather than as a deadline on it, so local work
+// sharing that context, such as builds and test runs, is not cut short.
+package network
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout bounds each network request when --timeout is not given.
+const DefaultTimeout = 60 * time.Second
+
+// OfflineEnvVar enables offline mode when set to a true value (see strconv.ParseBool).
+const OfflineEnvVar = "CONTEXTVIBES_OFFLINE"
+
+// ErrOffline is returned by network clients that are asked to connect while
+// offline mode is on.
+var ErrOffline = errors.New("offline mode is on (--offline or " + OfflineEnvVar + ")")
+
+type (
+	timeoutKey struct{}
+	offlineKey struct{}
+)
+
+// WithTimeout returns a copy of ctx that carries timeout for network clients.
+// A zero timeout disables the bound.
+func WithTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, timeout)
+}
+
+// Timeout returns the timeout carried by ctx, or DefaultTimeout when none is set.
+func Timeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(timeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+
+	return DefaultTimeout
+}
+
+// HasTimeout reports whether ctx carries a timeout set with --timeout. Clients
+// with a longer bound of their own, such as release downloads, keep it otherwise.
+func HasTimeout(ctx context.Context) bool {
+	_, ok := ctx.Value(timeoutKey{}).(time.Duration)
+
+	return ok
+}
+
+// HTTPClient returns an HTTP client whose requests, including reading the
+// response body, are aborted once the timeout carried by ctx has passed.
+func HTTPClient(ctx context.Context) *http.Client {
+	//nolint:exhaustruct // Transport defaults are fine.
+	return &http.Client{Timeout: Timeout(ctx)}
+}
+
+// WithOffline returns a copy of ctx that marks whether network access is off.
+func WithOffline(ctx context.Context, offline bool) context.Context {
+	return context.WithValue(ctx, offlineKey{}, offline)
+}
+
+// Offline reports whether ctx was marked offline. Commands check it before
+// constructing any client that would reach a remote service.
+func Offline(ctx context.Context) bool {
+	offline, _ := ctx.Value(offlineKey{}).(bool)
+
+	return offline
+}
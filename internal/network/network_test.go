@@ -0,0 +1,74 @@
+package network_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/contextvibes/cli/internal/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, network.DefaultTimeout, network.Timeout(context.Background()))
+	assert.False(t, network.HasTimeout(context.Background()))
+
+	ctx := network.WithTimeout(context.Background(), 5*time.Second)
+	assert.Equal(t, 5*time.Second, network.Timeout(ctx))
+	assert.True(t, network.HasTimeout(ctx))
+	assert.Equal(t, 5*time.Second, network.HTTPClient(ctx).Timeout)
+
+	_, hasDeadline := ctx.Deadline()
+	assert.False(t, hasDeadline, "the command context itself gets no deadline")
+
+	assert.Zero(t, network.HTTPClient(network.WithTimeout(context.Background(), 0)).Timeout)
+}
+
+func TestOffline(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, network.Offline(context.Background()))
+	assert.True(t, network.Offline(network.WithOffline(context.Background(), true)))
+
+	ctx := network.WithTimeout(network.WithOffline(context.Background(), true), time.Second)
+	assert.True(t, network.Offline(ctx), "offline mode survives later context values")
+}
+
+func TestHTTPClient_AbortsSlowRequest(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(func() {
+		close(release)
+		server.Close()
+	})
+
+	ctx := network.WithTimeout(context.Background(), 50*time.Millisecond)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := network.HTTPClient(ctx).Do(req)
+
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Client.Timeout exceeded")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
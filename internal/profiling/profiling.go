@@ -0,0 +1,95 @@
+/*
+Package profiling writes pprof CPU and heap profiles for a single CLI invocation,
+so slow commands (such as describe or index over a large repository) can be
+analyzed with `go tool pprof`.
+*/
+package profiling
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// Session is an active profiling session. The zero value profiles nothing.
+type Session struct {
+	cpuFile *os.File
+	memPath string
+}
+
+// Start begins CPU profiling into cpuPath and arranges for a heap profile to be
+// written to memPath when the session stops. Empty paths disable the respective
+// profile, so a session with neither set costs nothing.
+func Start(cpuPath, memPath string) (*Session, error) {
+	session := &Session{cpuFile: nil, memPath: memPath}
+
+	if cpuPath == "" {
+		return session, nil
+	}
+
+	//nolint:gosec // Writing to a user-provided profile path is intended.
+	cpuFile, err := os.Create(cpuPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CPU profile '%s': %w", cpuPath, err)
+	}
+
+	err = pprof.StartCPUProfile(cpuFile)
+	if err != nil {
+		_ = cpuFile.Close()
+
+		return nil, fmt.Errorf("failed to start CPU profile: %w", err)
+	}
+
+	session.cpuFile = cpuFile
+
+	return session, nil
+}
+
+// Stop finishes the CPU profile and writes the heap profile, if requested.
+// It is safe to call on a nil session and more than once.
+func (s *Session) Stop() error {
+	if s == nil {
+		return nil
+	}
+
+	var errs []error
+
+	if s.cpuFile != nil {
+		pprof.StopCPUProfile()
+
+		if err := s.cpuFile.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close CPU profile: %w", err))
+		}
+
+		s.cpuFile = nil
+	}
+
+	if s.memPath != "" {
+		errs = append(errs, writeHeapProfile(s.memPath))
+		s.memPath = ""
+	}
+
+	return errors.Join(errs...)
+}
+
+func writeHeapProfile(path string) error {
+	//nolint:gosec // Writing to a user-provided profile path is intended.
+	memFile, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create memory profile '%s': %w", path, err)
+	}
+
+	defer func() { _ = memFile.Close() }()
+
+	// Collect garbage first so the profile reflects live allocations.
+	runtime.GC()
+
+	err = pprof.WriteHeapProfile(memFile)
+	if err != nil {
+		return fmt.Errorf("failed to write memory profile: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package profiling_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/profiling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // Only one CPU profile can be active per process.
+func TestStart_WritesProfiles(t *testing.T) {
+	dir := t.TempDir()
+	cpuPath := filepath.Join(dir, "cpu.pprof")
+	memPath := filepath.Join(dir, "mem.pprof")
+
+	session, err := profiling.Start(cpuPath, memPath)
+	require.NoError(t, err)
+
+	// A trivial workload standing in for a command.
+	_ = strings.Repeat("contextvibes", 10000)
+
+	require.NoError(t, session.Stop())
+	require.NoError(t, session.Stop(), "stopping twice is a no-op")
+
+	for _, path := range []string{cpuPath, memPath} {
+		info, statErr := os.Stat(path)
+		require.NoError(t, statErr)
+		assert.Positive(t, info.Size(), "%s should not be empty", filepath.Base(path))
+	}
+}
+
+//nolint:paralleltest // Only one CPU profile can be active per process.
+func TestStart_Disabled(t *testing.T) {
+	session, err := profiling.Start("", "")
+	require.NoError(t, err)
+	require.NoError(t, session.Stop())
+
+	var nilSession *profiling.Session
+	require.NoError(t, nilSession.Stop())
+}
+
+//nolint:paralleltest // Only one CPU profile can be active per process.
+func TestStart_InvalidPath(t *testing.T) {
+	_, err := profiling.Start(filepath.Join(t.TempDir(), "missing", "cpu.pprof"), "")
+	require.Error(t, err)
+}
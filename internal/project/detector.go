@@ -5,10 +5,28 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 )
 
-// Detect determines the project type based on files in the directory.
+// Detect determines the primary project type based on files in the directory:
+// the first of DetectAll's results, or Unknown when nothing matches.
 func Detect(dir string) (Type, error) {
+	types, err := DetectAll(dir)
+	if err != nil {
+		return Unknown, err
+	}
+
+	if len(types) == 0 {
+		return Unknown, nil
+	}
+
+	return types[0], nil
+}
+
+// DetectAll returns every project type found in the directory, in priority
+// order and without duplicates. A directory with both Terraform files and a
+// go.mod yields [Terraform, Go]. It returns an empty slice when nothing matches.
+func DetectAll(dir string) ([]Type, error) {
 	checks := []struct {
 		typ   Type
 		check func(string) (bool, error)
@@ -18,20 +36,28 @@ func Detect(dir string) (Type, error) {
 		{Go, func(d string) (bool, error) { return fileExists(d, "go.mod") }},
 		{Python, func(d string) (bool, error) { return fileExists(d, "requirements.txt") }},
 		{Python, func(d string) (bool, error) { return fileExists(d, "pyproject.toml") }},
+		{Node, func(d string) (bool, error) { return fileExists(d, "package.json") }},
+		{Rust, func(d string) (bool, error) { return fileExists(d, "Cargo.toml") }},
 	}
 
+	types := []Type{}
+
 	for _, checkItem := range checks {
+		if slices.Contains(types, checkItem.typ) {
+			continue
+		}
+
 		exists, err := checkItem.check(dir)
 		if err != nil {
-			return Unknown, err
+			return nil, err
 		}
 
 		if exists {
-			return checkItem.typ, nil
+			types = append(types, checkItem.typ)
 		}
 	}
 
-	return Unknown, nil
+	return types, nil
 }
 
 func hasFiles(dir, pattern string) (bool, error) {
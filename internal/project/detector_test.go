@@ -0,0 +1,90 @@
+package project_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		files []string
+		want  project.Type
+	}{
+		{"terraform", []string{"main.tf"}, project.Terraform},
+		{"pulumi", []string{"Pulumi.yaml"}, project.Pulumi},
+		{"go", []string{"go.mod"}, project.Go},
+		{"python requirements", []string{"requirements.txt"}, project.Python},
+		{"python pyproject", []string{"pyproject.toml"}, project.Python},
+		{"node", []string{"package.json"}, project.Node},
+		{"rust", []string{"Cargo.toml"}, project.Rust},
+		{"go wins over node", []string{"go.mod", "package.json"}, project.Go},
+		{"empty", nil, project.Unknown},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			for _, name := range tc.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, 0o600))
+			}
+
+			got, err := project.Detect(dir)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDetectAll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mixed terraform and go", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		for _, name := range []string{"go.mod", "main.tf", "variables.tf"} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, 0o600))
+		}
+
+		types, err := project.DetectAll(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []project.Type{project.Terraform, project.Go}, types)
+		assert.Equal(t, "Terraform, Go", project.JoinTypes(types))
+
+		primary, err := project.Detect(dir)
+		require.NoError(t, err)
+		assert.Equal(t, project.Terraform, primary)
+	})
+
+	t.Run("python is reported once", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		for _, name := range []string{"requirements.txt", "pyproject.toml"} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, 0o600))
+		}
+
+		types, err := project.DetectAll(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []project.Type{project.Python}, types)
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		t.Parallel()
+
+		types, err := project.DetectAll(t.TempDir())
+		require.NoError(t, err)
+		assert.Empty(t, types)
+		assert.Equal(t, "Unknown", project.JoinTypes(types))
+	})
+}
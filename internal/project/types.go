@@ -1,5 +1,7 @@
 package project
 
+import "strings"
+
 // Type represents the detected project type.
 type Type string
 
@@ -9,5 +11,46 @@ const (
 	Pulumi    Type = "Pulumi"
 	Go        Type = "Go"
 	Python    Type = "Python"
+	Node      Type = "Node.js"
+	Rust      Type = "Rust"
 	Unknown   Type = "Unknown"
 )
+
+// JoinTypes renders detected types for messages, e.g. "Terraform, Go".
+// An empty list renders as Unknown.
+func JoinTypes(types []Type) string {
+	if len(types) == 0 {
+		return string(Unknown)
+	}
+
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, string(t))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// IsInfrastructure reports whether the type is an infrastructure-as-code
+// project handled by 'factory plan' and 'factory deploy'.
+func (t Type) IsInfrastructure() bool {
+	return t == Terraform || t == Pulumi
+}
+
+// InfrastructureTools returns the command-line tools needed to plan and deploy
+// the given types, e.g. "terraform" for Terraform. Other types need none.
+func InfrastructureTools(types []Type) []string {
+	var tools []string
+
+	for _, t := range types {
+		switch t {
+		case Terraform:
+			tools = append(tools, "terraform")
+		case Pulumi:
+			tools = append(tools, "pulumi")
+		case Go, Python, Node, Rust, Unknown:
+		}
+	}
+
+	return tools
+}
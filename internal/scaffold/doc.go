@@ -0,0 +1,14 @@
+/*
+Package scaffold holds the embedded templates used to generate editor and
+environment configuration for a project.
+
+Templates are grouped by target (for example "vscode"); each file under
+templates/<target>/ is written to the same relative path in the repository,
+with the leading "dot" directory restored (templates/vscode/settings.json is
+written to .vscode/settings.json).
+
+A repository can replace any template by placing a file of the same name under
+.contextvibes/templates/<target>/; templates without an override fall back to
+the embedded default.
+*/
+package scaffold
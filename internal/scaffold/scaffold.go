@@ -0,0 +1,161 @@
+package scaffold
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+//go:embed all:templates
+var templatesFS embed.FS
+
+const (
+	templatesRoot = "templates"
+
+	// OverridesDir is the repository directory whose <target>/<file name>
+	// entries replace the embedded templates of the same name.
+	OverridesDir = ".contextvibes/templates"
+	// SourceEmbedded is the Source of a file taken from the embedded templates.
+	SourceEmbedded = "embedded template"
+)
+
+var (
+	// ErrUnknownTarget is returned when a scaffold target has no templates.
+	ErrUnknownTarget = errors.New("unknown scaffold target")
+	// ErrUnknownFile is returned when a file selection names a file the target does not have.
+	ErrUnknownFile = errors.New("unknown scaffold file")
+)
+
+// File is a single scaffolded file: its destination relative to the repository
+// root, the content to write, and where that content came from (SourceEmbedded
+// or the path of a repository override).
+type File struct {
+	Path    string
+	Content []byte
+	Source  string
+}
+
+// targetDirs maps each target to the directory its files are written to.
+//
+//nolint:gochecknoglobals // Static lookup table.
+var targetDirs = map[string]string{
+	"vscode": ".vscode",
+}
+
+// Targets returns the names of all available scaffold targets, sorted.
+func Targets() []string {
+	targets := make([]string, 0, len(targetDirs))
+	for name := range targetDirs {
+		targets = append(targets, name)
+	}
+
+	slices.Sort(targets)
+
+	return targets
+}
+
+// Files returns the files of a target in a stable order.
+func Files(target string) ([]File, error) {
+	destDir, ok := targetDirs[target]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s' (available: %s)", ErrUnknownTarget, target, strings.Join(Targets(), ", "))
+	}
+
+	srcDir := path.Join(templatesRoot, target)
+
+	entries, err := fs.ReadDir(templatesFS, srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates for '%s': %w", target, err)
+	}
+
+	files := make([]File, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		content, err := fs.ReadFile(templatesFS, path.Join(srcDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template '%s': %w", entry.Name(), err)
+		}
+
+		files = append(files, File{
+			Path:    path.Join(destDir, entry.Name()),
+			Content: content,
+			Source:  SourceEmbedded,
+		})
+	}
+
+	return files, nil
+}
+
+// ApplyOverrides replaces the content of each file that has a same-named
+// override under rootDir's OverridesDir/<target>/ and records the override as
+// its Source. Files without an override keep the embedded default; overrides
+// that name no file of the target are ignored.
+func ApplyOverrides(files []File, rootDir, target string) ([]File, error) {
+	result := slices.Clone(files)
+
+	for i, file := range result {
+		override := path.Join(OverridesDir, target, path.Base(file.Path))
+
+		content, err := os.ReadFile(filepath.Join(rootDir, filepath.FromSlash(override)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template override '%s': %w", override, err)
+		}
+
+		result[i].Content = content
+		result[i].Source = override
+	}
+
+	return result, nil
+}
+
+// SelectFiles narrows a target's files to those named in only (all when empty),
+// minus those named in skip. Files are named by base name ("settings.json") or
+// by path (".vscode/settings.json"); any other name is an ErrUnknownFile.
+func SelectFiles(files []File, only, skip []string) ([]File, error) {
+	known := make([]string, 0, len(files))
+	for _, file := range files {
+		known = append(known, path.Base(file.Path))
+	}
+
+	for _, name := range slices.Concat(only, skip) {
+		if !slices.ContainsFunc(files, func(file File) bool { return file.matches(name) }) {
+			return nil, fmt.Errorf("%w: '%s' (available: %s)", ErrUnknownFile, name, strings.Join(known, ", "))
+		}
+	}
+
+	selected := make([]File, 0, len(files))
+
+	for _, file := range files {
+		if len(only) > 0 && !slices.ContainsFunc(only, file.matches) {
+			continue
+		}
+
+		if slices.ContainsFunc(skip, file.matches) {
+			continue
+		}
+
+		selected = append(selected, file)
+	}
+
+	return selected, nil
+}
+
+func (f File) matches(name string) bool {
+	name = strings.TrimSpace(name)
+
+	return name == f.Path || name == path.Base(f.Path)
+}
@@ -0,0 +1,6 @@
+/*
+Package security turns the output of security scanners into structured reports
+that commands can present. It currently understands the JSON stream written by
+`govulncheck -json`.
+*/
+package security
@@ -0,0 +1,213 @@
+package security
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ErrVulnerabilitiesFound is returned when a scan reports known vulnerabilities.
+var ErrVulnerabilitiesFound = errors.New("known vulnerabilities found")
+
+// Severity ranks a vulnerability by how directly the project reaches the affected
+// code. The Go vulnerability database has no CVSS scores, so reachability is the
+// most useful signal govulncheck provides.
+type Severity string
+
+// Severity levels, from most to least urgent.
+const (
+	// SeverityHigh means the project calls a vulnerable function.
+	SeverityHigh Severity = "high"
+	// SeverityMedium means the project imports a vulnerable package but does not
+	// call the affected code.
+	SeverityMedium Severity = "medium"
+	// SeverityLow means a vulnerable module is only in the dependency graph.
+	SeverityLow Severity = "low"
+)
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityHigh:
+		return 0
+	case SeverityMedium:
+		return 1
+	case SeverityLow:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Vulnerability is one known vulnerability affecting one module of the project.
+type Vulnerability struct {
+	ID       string
+	Aliases  []string
+	Summary  string
+	URL      string
+	Severity Severity
+	Module   string
+	// Package is empty when the vulnerable package is not imported.
+	Package      string
+	FoundVersion string
+	// FixedVersion is empty when no fix has been released.
+	FixedVersion string
+	// Symbol is the vulnerable function the project calls, e.g. "html.Parse".
+	Symbol string
+}
+
+// Report is the result of a vulnerability scan, most severe findings first.
+type Report struct {
+	Vulnerabilities []Vulnerability
+}
+
+// Count returns the number of vulnerabilities with the given severity.
+func (r *Report) Count(severity Severity) int {
+	count := 0
+
+	for _, vuln := range r.Vulnerabilities {
+		if vuln.Severity == severity {
+			count++
+		}
+	}
+
+	return count
+}
+
+// govulncheckMessage is one object of the govulncheck JSON stream. Each message
+// sets exactly one field; config, progress and SBOM messages are ignored.
+type govulncheckMessage struct {
+	OSV     *osvEntry `json:"osv"`
+	Finding *finding  `json:"finding"`
+}
+
+type osvEntry struct {
+	ID               string   `json:"id"`
+	Aliases          []string `json:"aliases"`
+	Summary          string   `json:"summary"`
+	DatabaseSpecific struct {
+		URL string `json:"url"`
+	} `json:"database_specific"`
+}
+
+type finding struct {
+	OSV          string  `json:"osv"`
+	FixedVersion string  `json:"fixed_version"`
+	Trace        []frame `json:"trace"`
+}
+
+// frame is a step of a finding's trace. The first frame is the vulnerable code:
+// it names a function for called symbols, a package for imported packages and
+// only a module otherwise.
+type frame struct {
+	Module   string `json:"module"`
+	Version  string `json:"version"`
+	Package  string `json:"package"`
+	Function string `json:"function"`
+	Receiver string `json:"receiver"`
+}
+
+// ParseGovulncheckJSON reads the output of `govulncheck -json` and returns one
+// Vulnerability per OSV entry and affected module, at the highest severity any
+// of its findings reached.
+func ParseGovulncheckJSON(r io.Reader) (*Report, error) {
+	decoder := json.NewDecoder(r)
+	entries := make(map[string]*osvEntry)
+	vulns := make(map[string]*Vulnerability)
+
+	var order []string
+
+	for {
+		var message govulncheckMessage
+
+		err := decoder.Decode(&message)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse govulncheck output: %w", err)
+		}
+
+		if message.OSV != nil {
+			entries[message.OSV.ID] = message.OSV
+		}
+
+		if message.Finding == nil || len(message.Finding.Trace) == 0 {
+			continue
+		}
+
+		vuln := newVulnerability(message.Finding)
+		key := vuln.ID + " " + vuln.Module
+
+		existing, ok := vulns[key]
+		if !ok {
+			vulns[key] = &vuln
+			order = append(order, key)
+
+			continue
+		}
+
+		if vuln.Severity.rank() < existing.Severity.rank() {
+			*existing = vuln
+		}
+	}
+
+	report := &Report{Vulnerabilities: make([]Vulnerability, 0, len(order))}
+
+	for _, key := range order {
+		vuln := *vulns[key]
+		if entry, ok := entries[vuln.ID]; ok {
+			vuln.Aliases = entry.Aliases
+			vuln.Summary = entry.Summary
+			vuln.URL = entry.DatabaseSpecific.URL
+		}
+
+		report.Vulnerabilities = append(report.Vulnerabilities, vuln)
+	}
+
+	slices.SortStableFunc(report.Vulnerabilities, func(a, b Vulnerability) int {
+		return cmp.Or(cmp.Compare(a.Severity.rank(), b.Severity.rank()), cmp.Compare(a.ID, b.ID))
+	})
+
+	return report, nil
+}
+
+func newVulnerability(f *finding) Vulnerability {
+	top := f.Trace[0]
+
+	//nolint:exhaustruct // OSV details are filled in once the whole stream is read.
+	vuln := Vulnerability{
+		ID:           f.OSV,
+		Module:       top.Module,
+		Package:      top.Package,
+		FoundVersion: top.Version,
+		FixedVersion: f.FixedVersion,
+		Severity:     SeverityLow,
+	}
+
+	switch {
+	case top.Function != "":
+		vuln.Severity = SeverityHigh
+		vuln.Symbol = symbolName(top)
+	case top.Package != "":
+		vuln.Severity = SeverityMedium
+	}
+
+	return vuln
+}
+
+// symbolName formats a frame's function like govulncheck's text output, e.g.
+// "html.Parse" or "tls.Conn.Handshake".
+func symbolName(f frame) string {
+	name := path.Base(f.Package) + "."
+	if f.Receiver != "" {
+		name += strings.TrimPrefix(f.Receiver, "*") + "."
+	}
+
+	return name + f.Function
+}
@@ -0,0 +1,94 @@
+package security_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// govulncheckFixture is trimmed `govulncheck -json ./...` output: x/net's html
+// package is called, x/text's language package is imported, and the stdlib
+// vulnerability is in a module the project does not reach.
+const govulncheckFixture = `{"config":{"protocol_version":"v1.0.0","scanner_name":"govulncheck","scan_level":"symbol"}}
+{"progress":{"message":"Scanning your code and 48 packages across 6 dependent modules for known vulnerabilities..."}}
+{"osv":{"id":"GO-2023-1988","aliases":["CVE-2023-3978"],"summary":"Improper rendering of text nodes in golang.org/x/net/html","database_specific":{"url":"https://pkg.go.dev/vuln/GO-2023-1988"}}}
+{"osv":{"id":"GO-2022-1059","aliases":["CVE-2022-32149"],"summary":"Denial of service via crafted Accept-Language header in golang.org/x/text/language","database_specific":{"url":"https://pkg.go.dev/vuln/GO-2022-1059"}}}
+{"osv":{"id":"GO-2024-2687","aliases":["CVE-2023-45288"],"summary":"HTTP/2 CONTINUATION flood in net/http","database_specific":{"url":"https://pkg.go.dev/vuln/GO-2024-2687"}}}
+{"finding":{"osv":"GO-2023-1988","fixed_version":"v0.13.0","trace":[{"module":"golang.org/x/net","version":"v0.10.0"}]}}
+{"finding":{"osv":"GO-2023-1988","fixed_version":"v0.13.0","trace":[{"module":"golang.org/x/net","version":"v0.10.0","package":"golang.org/x/net/html"}]}}
+{"finding":{"osv":"GO-2023-1988","fixed_version":"v0.13.0","trace":[{"module":"golang.org/x/net","version":"v0.10.0","package":"golang.org/x/net/html","function":"Render","position":{"filename":"render.go","line":49}},{"module":"example.com/app","package":"example.com/app","function":"main","position":{"filename":"main.go","line":12}}]}}
+{"finding":{"osv":"GO-2022-1059","fixed_version":"v0.3.8","trace":[{"module":"golang.org/x/text","version":"v0.3.7","package":"golang.org/x/text/language"}]}}
+{"finding":{"osv":"GO-2024-2687","trace":[{"module":"stdlib","version":"v1.22.1"}]}}
+`
+
+func TestParseGovulncheckJSON(t *testing.T) {
+	t.Parallel()
+
+	report, err := security.ParseGovulncheckJSON(strings.NewReader(govulncheckFixture))
+	require.NoError(t, err)
+
+	assert.Equal(t, []security.Vulnerability{
+		{
+			ID:           "GO-2023-1988",
+			Aliases:      []string{"CVE-2023-3978"},
+			Summary:      "Improper rendering of text nodes in golang.org/x/net/html",
+			URL:          "https://pkg.go.dev/vuln/GO-2023-1988",
+			Severity:     security.SeverityHigh,
+			Module:       "golang.org/x/net",
+			Package:      "golang.org/x/net/html",
+			FoundVersion: "v0.10.0",
+			FixedVersion: "v0.13.0",
+			Symbol:       "html.Render",
+		},
+		{
+			ID:           "GO-2022-1059",
+			Aliases:      []string{"CVE-2022-32149"},
+			Summary:      "Denial of service via crafted Accept-Language header in golang.org/x/text/language",
+			URL:          "https://pkg.go.dev/vuln/GO-2022-1059",
+			Severity:     security.SeverityMedium,
+			Module:       "golang.org/x/text",
+			Package:      "golang.org/x/text/language",
+			FoundVersion: "v0.3.7",
+			FixedVersion: "v0.3.8",
+			Symbol:       "",
+		},
+		{
+			ID:           "GO-2024-2687",
+			Aliases:      []string{"CVE-2023-45288"},
+			Summary:      "HTTP/2 CONTINUATION flood in net/http",
+			URL:          "https://pkg.go.dev/vuln/GO-2024-2687",
+			Severity:     security.SeverityLow,
+			Module:       "stdlib",
+			Package:      "",
+			FoundVersion: "v1.22.1",
+			FixedVersion: "",
+			Symbol:       "",
+		},
+	}, report.Vulnerabilities)
+
+	assert.Equal(t, 1, report.Count(security.SeverityHigh))
+	assert.Equal(t, 1, report.Count(security.SeverityMedium))
+	assert.Equal(t, 1, report.Count(security.SeverityLow))
+}
+
+func TestParseGovulncheckJSON_NoFindings(t *testing.T) {
+	t.Parallel()
+
+	output := `{"config":{"protocol_version":"v1.0.0","scanner_name":"govulncheck"}}
+{"osv":{"id":"GO-2024-2687","summary":"HTTP/2 CONTINUATION flood in net/http"}}
+`
+
+	report, err := security.ParseGovulncheckJSON(strings.NewReader(output))
+	require.NoError(t, err)
+	assert.Empty(t, report.Vulnerabilities, "OSV entries without findings do not affect the project")
+}
+
+func TestParseGovulncheckJSON_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := security.ParseGovulncheckJSON(strings.NewReader("govulncheck: loading packages: no go.mod\n"))
+	require.Error(t, err)
+}
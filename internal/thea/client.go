@@ -7,13 +7,23 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
 	"time"
 )
 
+// ErrNotCached is returned in offline mode when the manifest or an artifact has
+// not been cached by an earlier online run.
+var ErrNotCached = errors.New("not available in the THEA cache")
+
+const manifestCacheFile = "thea-manifest.json"
+
 // Manifest represents the structure of the thea-manifest.json file.
 type Manifest struct {
 	ManifestSchemaVersion       string     `json:"manifestSchemaVersion"`
@@ -41,7 +51,7 @@ type Artifact struct {
 type Client struct {
 	logger     *slog.Logger
 	config     *ServiceConfig // A dedicated config substruct for this client
-	httpClient *http.Client   // For making HTTP requests
+	httpClient *http.Client   // For making HTTP requests; nil in offline mode
 }
 
 // ServiceConfig contains configuration specific to the THEA client.
@@ -53,6 +63,18 @@ type ServiceConfig struct {
 	CacheDir           string        // Directory for caching manifests/artifacts (e.g., ~/.contextvibes/cache/thea)
 	CacheTTL           time.Duration // Time-to-live for cached items
 	RequestTimeout     time.Duration // Timeout for HTTP requests
+	Offline            bool          // Serve the manifest and artifacts from CacheDir only, without HTTP requests
+}
+
+// DefaultCacheDir returns the directory get-artifact caches THEA downloads in,
+// or an empty string (no caching) when the user cache directory is unknown.
+func DefaultCacheDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(cacheDir, "contextvibes", "thea")
 }
 
 // NewClient creates a new THEA client.
@@ -88,6 +110,16 @@ func NewClient(_ context.Context, cfg *ServiceConfig, logger *slog.Logger) (*Cli
 		cfg.DefaultArtifactRef = "main" // Or handle as error
 	}
 
+	client := &Client{
+		logger:     logger.With(slog.String("service", "thea")), // Add service context to logger
+		config:     cfg,
+		httpClient: nil,
+	}
+
+	if cfg.Offline {
+		return client, nil
+	}
+
 	// Default timeout for HTTP client if not specified in config
 	timeout := cfg.RequestTimeout
 	if timeout == 0 {
@@ -95,30 +127,36 @@ func NewClient(_ context.Context, cfg *ServiceConfig, logger *slog.Logger) (*Cli
 		timeout = 30 * time.Second // Default to 30 seconds
 	}
 
-	return &Client{
-		logger: logger.With(slog.String("service", "thea")), // Add service context to logger
-		config: cfg,
-		//nolint:exhaustruct // Transport defaults are fine.
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
-	}, nil
+	//nolint:exhaustruct // Transport defaults are fine.
+	client.httpClient = &http.Client{
+		Timeout: timeout,
+	}
+
+	return client, nil
 }
 
 // --- Manifest Methods ---
 
-// LoadManifest retrieves the THEA manifest, using a cache if available and valid.
-// For MVP, it might just always fetch.
+// LoadManifest retrieves the THEA manifest. Online it is always fetched, and the
+// copy in CacheDir is refreshed; in offline mode that copy is read instead.
 func (c *Client) LoadManifest(ctx context.Context) (*Manifest, error) {
-	//nolint:godox // TODO is acceptable here.
-	// TODO: Implement caching logic:
-	// 1. Check if manifest exists in c.config.CacheDir.
-	// 2. If yes, check if it's within c.config.CacheTTL.
-	// 3. If yes and valid, load and return from cache.
-	// 4. Otherwise, fetch, save to cache, and return.
-
-	// For MVP - always fetch:
-	return c.fetchManifest(ctx)
+	if !c.config.Offline {
+		return c.fetchManifest(ctx)
+	}
+
+	data, err := c.readCache(manifestCacheFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("decoding cached manifest: %w", err)
+	}
+
+	return &manifest, nil
 }
 
 // GetArtifactByID finds an artifact in the loaded manifest by ID.
@@ -193,6 +231,16 @@ func (c *Client) FetchArtifactContentByID(
 		"/",
 	) // Ensure no leading slash for JoinPath
 
+	cacheFile := path.Join("artifacts", gitRef, effectiveSourcePathInRepo)
+	if c.config.Offline {
+		content, err := c.readCache(cacheFile)
+		if err != nil {
+			return "", err
+		}
+
+		return string(content), nil
+	}
+
 	// Construct the full raw download URL
 	// Example: https://raw.githubusercontent.com/contextvibes/THEA/main/docs/templates/contributing-guide.md
 	fullURL, err := url.JoinPath(c.config.RawContentBaseURL, gitRef, effectiveSourcePathInRepo)
@@ -256,6 +304,8 @@ func (c *Client) FetchArtifactContentByID(
 		return "", fmt.Errorf("reading artifact content from %s: %w", fullURL, err)
 	}
 
+	c.writeCache(ctx, cacheFile, contentBytes)
+
 	return string(contentBytes), nil
 }
 
@@ -319,11 +369,66 @@ func (c *Client) fetchManifest(ctx context.Context) (*Manifest, error) {
 
 		return nil, fmt.Errorf("decoding manifest JSON from %s: %w", c.config.ManifestURL, err)
 	}
-	//nolint:godox // TODO is acceptable here.
-	// TODO: Add manifest caching logic here (save to c.config.CacheDir)
+
+	cached, err := json.Marshal(&manifest)
+	if err == nil {
+		c.writeCache(ctx, manifestCacheFile, cached)
+	}
+
 	return &manifest, nil
 }
 
+// cachePath maps a slash-separated cache key to a file under CacheDir. Keys that
+// would escape CacheDir, for example through a "../" version hint, are rejected.
+func (c *Client) cachePath(key string) (string, bool) {
+	if c.config.CacheDir == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", false
+	}
+
+	return filepath.Join(c.config.CacheDir, filepath.FromSlash(key)), true
+}
+
+// readCache returns a file cached by an earlier online run.
+func (c *Client) readCache(key string) ([]byte, error) {
+	cacheFile, ok := c.cachePath(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotCached, key)
+	}
+
+	data, err := os.ReadFile(cacheFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotCached, key)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading cached %s: %w", key, err)
+	}
+
+	return data, nil
+}
+
+// writeCache stores data for offline use. Failures only cost the cached copy,
+// so they are logged rather than returned.
+func (c *Client) writeCache(ctx context.Context, key string, data []byte) {
+	cacheFile, ok := c.cachePath(key)
+	if !ok {
+		return
+	}
+
+	//nolint:mnd // 0750 is standard directory permission.
+	err := os.MkdirAll(filepath.Dir(cacheFile), 0o750)
+	if err == nil {
+		//nolint:mnd // 0600 is standard file permission.
+		err = os.WriteFile(cacheFile, data, 0o600)
+	}
+
+	if err != nil {
+		c.logger.WarnContext(ctx, "Failed to cache THEA download",
+			slog.String("path", cacheFile),
+			slog.String("error", err.Error()))
+	}
+}
+
 func (c *Client) createManifestRequest(ctx context.Context) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.ManifestURL, nil)
 	if err != nil {
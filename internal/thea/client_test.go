@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 
 	"github.com/contextvibes/cli/internal/thea"
@@ -102,3 +103,76 @@ func TestFetchManifest_ServerReturns404(t *testing.T) {
 		assert.Contains(t, err.Error(), "received status 404")
 	}
 }
+
+func TestOfflineMode_ServesCachedDownloads(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.URL.Path == "/thea-manifest.json" {
+			_, _ = w.Write([]byte(`{"manifestSchemaVersion":"1.3.0","artifacts":[{"id":"guide","fileExtension":"md"}]}`))
+
+			return
+		}
+
+		assert.Equal(t, "/raw/main/guide.md", r.URL.Path)
+		_, _ = w.Write([]byte("# Guide\n"))
+	}))
+	defer server.Close()
+
+	//nolint:exhaustruct // Partial config is sufficient for test.
+	cfg := thea.ServiceConfig{
+		ManifestURL:        server.URL + "/thea-manifest.json",
+		RawContentBaseURL:  server.URL + "/raw",
+		DefaultArtifactRef: "main",
+		CacheDir:           t.TempDir(),
+	}
+
+	online, err := thea.NewClient(context.Background(), &cfg, newTestLogger())
+	require.NoError(t, err)
+
+	_, err = online.FetchArtifactContentByID(context.Background(), "guide", "")
+	require.NoError(t, err)
+	require.Equal(t, int32(2), requests.Load())
+
+	offlineCfg := cfg
+	offlineCfg.Offline = true
+
+	offline, err := thea.NewClient(context.Background(), &offlineCfg, newTestLogger())
+	require.NoError(t, err)
+
+	manifest, err := offline.LoadManifest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", manifest.ManifestSchemaVersion)
+
+	content, err := offline.FetchArtifactContentByID(context.Background(), "guide", "")
+	require.NoError(t, err)
+	assert.Equal(t, "# Guide\n", content)
+
+	_, err = offline.FetchArtifactContentByID(context.Background(), "guide", "v0.7.0")
+	require.ErrorIs(t, err, thea.ErrNotCached, "other refs were never downloaded")
+
+	assert.Equal(t, int32(2), requests.Load(), "offline mode makes no HTTP requests")
+}
+
+func TestOfflineMode_EmptyCache(t *testing.T) {
+	t.Parallel()
+
+	//nolint:exhaustruct // Partial config is sufficient for test.
+	cfg := thea.ServiceConfig{
+		ManifestURL:        "http://127.0.0.1:1/thea-manifest.json",
+		RawContentBaseURL:  "http://127.0.0.1:1",
+		DefaultArtifactRef: "main",
+		CacheDir:           t.TempDir(),
+		Offline:            true,
+	}
+
+	client, err := thea.NewClient(context.Background(), &cfg, newTestLogger())
+	require.NoError(t, err)
+
+	_, err = client.LoadManifest(context.Background())
+	require.ErrorIs(t, err, thea.ErrNotCached)
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNoClipboardTool is returned when none of the supported clipboard tools is installed.
+var ErrNoClipboardTool = errors.New("no clipboard tool found (install pbcopy, wl-copy or xclip)")
+
+// ClipboardRunner is the part of the command executor needed to copy to the clipboard.
+type ClipboardRunner interface {
+	CommandExists(commandName string) bool
+	ExecuteWithStdin(ctx context.Context, dir string, stdin io.Reader, commandName string, args ...string) error
+}
+
+// ClipboardCommand is a platform-native command that copies its stdin to the clipboard.
+type ClipboardCommand struct {
+	Name string
+	Args []string
+}
+
+// clipboardCommands are tried in order: macOS, Wayland, then X11.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var clipboardCommands = []ClipboardCommand{
+	{Name: "pbcopy", Args: nil},
+	{Name: "wl-copy", Args: nil},
+	{Name: "xclip", Args: []string{"-selection", "clipboard"}},
+}
+
+// FindClipboardCommand returns the first supported clipboard tool that exists.
+func FindClipboardCommand(commandExists func(string) bool) (ClipboardCommand, error) {
+	for _, candidate := range clipboardCommands {
+		if commandExists(candidate.Name) {
+			return candidate, nil
+		}
+	}
+
+	return ClipboardCommand{}, ErrNoClipboardTool
+}
+
+// CopyToClipboard copies content to the system clipboard and returns the tool used.
+func CopyToClipboard(ctx context.Context, runner ClipboardRunner, content string) (string, error) {
+	command, err := FindClipboardCommand(runner.CommandExists)
+	if err != nil {
+		return "", err
+	}
+
+	err = runner.ExecuteWithStdin(ctx, ".", strings.NewReader(content), command.Name, command.Args...)
+	if err != nil {
+		return command.Name, fmt.Errorf("failed to copy to clipboard with %s: %w", command.Name, err)
+	}
+
+	return command.Name, nil
+}
@@ -0,0 +1,98 @@
+package tools_test
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClipboardRunner struct {
+	installed []string
+	command   string
+	args      []string
+	stdin     string
+}
+
+func (f *fakeClipboardRunner) CommandExists(name string) bool {
+	return slices.Contains(f.installed, name)
+}
+
+func (f *fakeClipboardRunner) ExecuteWithStdin(
+	_ context.Context,
+	_ string,
+	stdin io.Reader,
+	name string,
+	args ...string,
+) error {
+	content, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+
+	f.command, f.args, f.stdin = name, args, string(content)
+
+	return nil
+}
+
+func TestFindClipboardCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		installed []string
+		want      string
+		wantArgs  []string
+	}{
+		{name: "macOS", installed: []string{"pbcopy"}, want: "pbcopy", wantArgs: nil},
+		{name: "wayland", installed: []string{"wl-copy"}, want: "wl-copy", wantArgs: nil},
+		{name: "x11", installed: []string{"xclip"}, want: "xclip", wantArgs: []string{"-selection", "clipboard"}},
+		{name: "wayland preferred over x11", installed: []string{"xclip", "wl-copy"}, want: "wl-copy", wantArgs: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			runner := &fakeClipboardRunner{installed: tt.installed}
+
+			command, err := tools.FindClipboardCommand(runner.CommandExists)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, command.Name)
+			assert.Equal(t, tt.wantArgs, command.Args)
+		})
+	}
+}
+
+func TestCopyToClipboard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pipes the content to the tool", func(t *testing.T) {
+		t.Parallel()
+
+		runner := &fakeClipboardRunner{installed: []string{"xclip"}}
+
+		used, err := tools.CopyToClipboard(context.Background(), runner, "# Context\n")
+		require.NoError(t, err)
+		assert.Equal(t, "xclip", used)
+		assert.Equal(t, "xclip", runner.command)
+		assert.Equal(t, []string{"-selection", "clipboard"}, runner.args)
+		assert.Equal(t, "# Context\n", runner.stdin)
+	})
+
+	t.Run("no tool installed", func(t *testing.T) {
+		t.Parallel()
+
+		runner := &fakeClipboardRunner{installed: nil}
+
+		_, err := tools.CopyToClipboard(context.Background(), runner, "x")
+		require.ErrorIs(t, err, tools.ErrNoClipboardTool)
+		assert.True(t, strings.Contains(err.Error(), "xclip"))
+		assert.Empty(t, runner.command)
+	})
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change.
+const diffContextLines = 2
+
+// LineDiff returns a compact, unified-style diff between two texts.
+// Changed lines are prefixed with "-" or "+", nearby unchanged lines with a space,
+// and each hunk starts with an "@@ -a +b @@" header using 1-based line numbers.
+// It returns an empty string if the texts are identical.
+func LineDiff(oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+
+	// Longest-common-subsequence table, computed from the end.
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type diffLine struct {
+		op      byte
+		text    string
+		oldLine int
+		newLine int
+	}
+
+	var lines []diffLine
+
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			lines = append(lines, diffLine{op: ' ', text: oldLines[i], oldLine: i + 1, newLine: j + 1})
+			i++
+			j++
+		case i < len(oldLines) && (j == len(newLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{op: '-', text: oldLines[i], oldLine: i + 1, newLine: j + 1})
+			i++
+		default:
+			lines = append(lines, diffLine{op: '+', text: newLines[j], oldLine: i + 1, newLine: j + 1})
+			j++
+		}
+	}
+
+	// Mark which lines are within context distance of a change.
+	visible := make([]bool, len(lines))
+
+	for idx, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+
+		for k := max(0, idx-diffContextLines); k <= min(len(lines)-1, idx+diffContextLines); k++ {
+			visible[k] = true
+		}
+	}
+
+	var builder strings.Builder
+
+	for idx, line := range lines {
+		if !visible[idx] {
+			continue
+		}
+
+		if idx == 0 || !visible[idx-1] {
+			fmt.Fprintf(&builder, "@@ -%d +%d @@\n", line.oldLine, line.newLine)
+		}
+
+		builder.WriteByte(line.op)
+		builder.WriteString(line.text)
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
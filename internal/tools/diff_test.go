@@ -0,0 +1,43 @@
+// Package tools_test contains tests for the tools package.
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineDiff(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		oldText string
+		newText string
+		want    string
+	}{
+		{name: "identical", oldText: "a\nb\n", newText: "a\nb\n", want: ""},
+		{name: "new file", oldText: "", newText: "x\ny\n", want: "@@ -1 +1 @@\n+x\n+y\n"},
+		{
+			name:    "replacement with context",
+			oldText: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			newText: "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want:    "@@ -3 +3 @@\n 3\n 4\n-5\n+five\n 6\n 7\n",
+		},
+		{
+			name:    "separate hunks",
+			oldText: "a\nb\nc\nd\ne\nf\ng\nh\ni\n",
+			newText: "A\nb\nc\nd\ne\nf\ng\nh\nI\n",
+			want:    "@@ -1 +1 @@\n-a\n+A\n b\n c\n@@ -7 +7 @@\n g\n h\n-i\n+I\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.want, tools.LineDiff(testCase.oldText, testCase.newText))
+		})
+	}
+}
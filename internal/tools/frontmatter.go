@@ -0,0 +1,41 @@
+package tools
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNoFrontMatter is returned when a document does not open with a front matter
+// block delimited by "---" lines.
+var ErrNoFrontMatter = errors.New("document has no front matter")
+
+const frontMatterDelimiter = "---"
+
+// SplitFrontMatter separates a Markdown document into its YAML front matter and
+// body. The front matter must open the document (blank lines may precede it)
+// and end with a second "---" line. The body is returned as written, minus the
+// line break after the closing delimiter.
+func SplitFrontMatter(content string) (frontMatter, body string, err error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	rest := strings.TrimLeft(content, "\r\n")
+
+	firstLine, rest, _ := strings.Cut(rest, "\n")
+	if strings.TrimSpace(firstLine) != frontMatterDelimiter {
+		return "", "", ErrNoFrontMatter
+	}
+
+	var lines []string
+
+	for rest != "" {
+		var line string
+
+		line, rest, _ = strings.Cut(rest, "\n")
+		if strings.TrimSpace(line) == frontMatterDelimiter {
+			return strings.Join(lines, "\n"), rest, nil
+		}
+
+		lines = append(lines, strings.TrimSuffix(line, "\r"))
+	}
+
+	return "", "", ErrNoFrontMatter
+}
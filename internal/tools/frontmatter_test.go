@@ -0,0 +1,29 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	t.Parallel()
+
+	frontMatter, body, err := tools.SplitFrontMatter("\n---\ntitle: Hello\ntags: [a]\n---\n# Heading\n\nText\n")
+	require.NoError(t, err)
+	assert.Equal(t, "title: Hello\ntags: [a]", frontMatter)
+	assert.Equal(t, "# Heading\n\nText\n", body)
+
+	frontMatter, body, err = tools.SplitFrontMatter("---\r\ntitle: Windows\r\n---\r\nBody\r\n")
+	require.NoError(t, err)
+	assert.Equal(t, "title: Windows", frontMatter)
+	assert.Equal(t, "Body\r\n", body)
+
+	_, _, err = tools.SplitFrontMatter("# No front matter\n\n---\nnot: front matter\n---\n")
+	require.ErrorIs(t, err, tools.ErrNoFrontMatter, "a later horizontal rule is not front matter")
+
+	_, _, err = tools.SplitFrontMatter("---\ntitle: Unterminated\n")
+	require.ErrorIs(t, err, tools.ErrNoFrontMatter)
+}
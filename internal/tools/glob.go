@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"regexp"
+	"strings"
+)
+
+// GlobToRegexp converts a slash-separated glob to an anchored regexp: "**"
+// matches across directories, "*" and "?" stay within one.
+func GlobToRegexp(glob string) *regexp.Regexp {
+	var pattern strings.Builder
+
+	pattern.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			pattern.WriteString("(?:.*/)?")
+
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			pattern.WriteString(".*")
+
+			i++
+		case glob[i] == '*':
+			pattern.WriteString("[^/]*")
+		case glob[i] == '?':
+			pattern.WriteString("[^/]")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(glob[i])))
+		}
+	}
+
+	pattern.WriteString("$")
+
+	return regexp.MustCompile(pattern.String())
+}
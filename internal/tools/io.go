@@ -3,7 +3,10 @@ package tools
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // ReadFileContent reads the entire content of the file at the specified path.
@@ -19,6 +22,15 @@ func ReadFileContent(filePath string) ([]byte, error) {
 	return content, nil
 }
 
+// binarySniffLength is how much of a file IsBinaryContent inspects, matching git's heuristic.
+const binarySniffLength = 8000
+
+// IsBinaryContent reports whether content looks binary, using the same heuristic as
+// git: a NUL byte within the first block.
+func IsBinaryContent(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), binarySniffLength)], 0) != -1
+}
+
 // WriteBufferToFile writes the content of a bytes.Buffer to the specified file path.
 // It uses default file permissions (0600).
 // It prints informational messages about writing to os.Stdout.
@@ -48,3 +60,107 @@ func WriteBufferToFile(filePath string, buf *bytes.Buffer) error {
 
 	return nil
 }
+
+// WriteFileAtomic writes data to filePath by writing a temporary file in the same
+// directory and renaming it into place, so readers never observe a partial file.
+// The final file has the given permissions.
+func WriteFileAtomic(filePath string, data []byte, perm os.FileMode) error {
+	return writeAtomic(filePath, bytes.NewReader(data), perm)
+}
+
+// ReplaceFile atomically replaces filePath with everything read from content,
+// keeping the permissions of the existing file; newFilePerm is used when the file
+// does not exist yet. If writing fails part way, for example because content
+// returns an error, the original file is left untouched.
+func ReplaceFile(filePath string, content io.Reader, newFilePerm os.FileMode) error {
+	perm := newFilePerm
+
+	info, err := os.Stat(filePath)
+	if err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	return writeAtomic(filePath, content, perm)
+}
+
+func writeAtomic(filePath string, content io.Reader, perm os.FileMode) error {
+	dir := filepath.Dir(filePath)
+
+	tempFile, err := os.CreateTemp(dir, filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file in '%s': %w", dir, err)
+	}
+
+	defer func() { _ = os.Remove(tempFile.Name()) }()
+
+	_, err = io.Copy(tempFile, content)
+	if err != nil {
+		_ = tempFile.Close()
+
+		return fmt.Errorf("failed to write temporary file '%s': %w", tempFile.Name(), err)
+	}
+
+	err = tempFile.Chmod(perm)
+	if err != nil {
+		_ = tempFile.Close()
+
+		return fmt.Errorf("failed to set permissions on '%s': %w", tempFile.Name(), err)
+	}
+
+	err = tempFile.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close temporary file '%s': %w", tempFile.Name(), err)
+	}
+
+	err = os.Rename(tempFile.Name(), filePath)
+	if err != nil {
+		return fmt.Errorf("failed to move temporary file into place at '%s': %w", filePath, err)
+	}
+
+	return nil
+}
+
+// TailFile returns at most the last maxLines lines of a file, further trimmed from
+// the front so the result does not exceed maxBytes. truncated reports whether any
+// content was dropped. A non-positive limit disables that limit.
+//
+//nolint:nonamedreturns // Named returns clarify the boolean result.
+func TailFile(filePath string, maxLines, maxBytes int) (tail string, truncated bool, err error) {
+	content, err := ReadFileContent(filePath)
+	if err != nil {
+		return "", false, err
+	}
+
+	text := strings.TrimRight(string(content), "\n")
+	if text == "" {
+		return "", false, nil
+	}
+
+	lines := strings.Split(text, "\n")
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+		truncated = true
+	}
+
+	if maxBytes > 0 {
+		size := 0
+		start := len(lines)
+
+		for start > 0 {
+			next := size + len(lines[start-1]) + 1
+			if next > maxBytes {
+				break
+			}
+
+			size = next
+			start--
+		}
+
+		if start > 0 {
+			lines = lines[start:]
+			truncated = true
+		}
+	}
+
+	return strings.Join(lines, "\n"), truncated, nil
+}
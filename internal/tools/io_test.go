@@ -0,0 +1,82 @@
+package tools_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errInterrupted = errors.New("interrupted")
+
+// interruptedReader yields some content and then fails, like a write cut short.
+type interruptedReader struct {
+	partial io.Reader
+}
+
+func (r *interruptedReader) Read(p []byte) (int, error) {
+	n, err := r.partial.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, errInterrupted
+	}
+
+	return n, err
+}
+
+func TestReplaceFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keeps the permissions of the existing file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "run.sh")
+		require.NoError(t, os.WriteFile(path, []byte("echo old\n"), 0o600))
+		require.NoError(t, os.Chmod(path, 0o750))
+
+		require.NoError(t, tools.ReplaceFile(path, strings.NewReader("echo new\n"), 0o600))
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "echo new\n", string(content))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o750), info.Mode().Perm())
+	})
+
+	t.Run("creates missing files with the given permissions", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "new.txt")
+		require.NoError(t, tools.ReplaceFile(path, strings.NewReader("hello"), 0o600))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("leaves the original intact when interrupted", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "main.go")
+		require.NoError(t, os.WriteFile(path, []byte("package main\n"), 0o600))
+
+		err := tools.ReplaceFile(path, &interruptedReader{partial: strings.NewReader("package ma")}, 0o600)
+		require.ErrorIs(t, err, errInterrupted)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "package main\n", string(content))
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "the temporary file is cleaned up")
+	})
+}
@@ -50,3 +50,11 @@ func AppendFileMarkerFooter(buf *bytes.Buffer, filePath string) {
 	// Add exactly two newlines before the footer marker
 	fmt.Fprintf(buf, "\n\n======== END FILE: %s ========\n\n", filePath)
 }
+
+// AppendCollapsibleCodeBlock adds a fenced code block wrapped in an HTML
+// <details> element, which GitHub renders collapsed under the given summary.
+func AppendCollapsibleCodeBlock(buf *bytes.Buffer, summary, content, languageHint string) {
+	fmt.Fprintf(buf, "<details>\n<summary>%s</summary>\n\n", summary)
+	AppendFencedCodeBlock(buf, content, languageHint)
+	buf.WriteString("</details>\n")
+}
@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrUnterminatedBlock is returned when a BEGIN marker has no matching END marker.
+var ErrUnterminatedBlock = errors.New("marked block has no END marker")
+
+// BlockMarkers returns the begin and end marker lines that delimit the named region,
+// e.g. "# --- BEGIN SECURE ENV CONFIG ---" and "# --- END SECURE ENV CONFIG ---".
+//
+//nolint:nonamedreturns // Named returns document which marker is which.
+func BlockMarkers(name string) (begin, end string) {
+	return "# --- BEGIN " + name + " ---", "# --- END " + name + " ---"
+}
+
+// UpsertMarkedBlock returns content with the named region set to body. An existing
+// region is replaced in place; otherwise the region is appended at the end,
+// separated from any previous content by a blank line. changed reports whether the
+// result differs from the input.
+//
+//nolint:nonamedreturns // Named returns clarify the boolean result.
+func UpsertMarkedBlock(content, name, body string) (result string, changed bool, err error) {
+	begin, end := BlockMarkers(name)
+	block := begin + "\n" + strings.TrimRight(body, "\n") + "\n" + end + "\n"
+
+	lines := strings.SplitAfter(content, "\n")
+
+	startIdx, endIdx, err := findMarkedBlock(lines, begin, end)
+	if err != nil {
+		return content, false, err
+	}
+
+	if startIdx < 0 {
+		prefix := content
+		if prefix != "" && !strings.HasSuffix(prefix, "\n") {
+			prefix += "\n"
+		}
+
+		if prefix != "" && !strings.HasSuffix(prefix, "\n\n") {
+			prefix += "\n"
+		}
+
+		result = prefix + block
+
+		return result, true, nil
+	}
+
+	result = strings.Join(lines[:startIdx], "") + block + strings.Join(lines[endIdx+1:], "")
+
+	return result, result != content, nil
+}
+
+// RemoveMarkedBlock returns content with the named region (markers included) removed.
+// A blank separator line directly before the region is removed with it. changed is
+// false when the region was not present.
+//
+//nolint:nonamedreturns // Named returns clarify the boolean result.
+func RemoveMarkedBlock(content, name string) (result string, changed bool, err error) {
+	begin, end := BlockMarkers(name)
+	lines := strings.SplitAfter(content, "\n")
+
+	startIdx, endIdx, err := findMarkedBlock(lines, begin, end)
+	if err != nil || startIdx < 0 {
+		return content, false, err
+	}
+
+	if startIdx > 0 && strings.TrimSpace(lines[startIdx-1]) == "" {
+		startIdx--
+	}
+
+	return strings.Join(lines[:startIdx], "") + strings.Join(lines[endIdx+1:], ""), true, nil
+}
+
+// UpsertMarkedBlockInFile applies UpsertMarkedBlock to a file, creating it when it
+// does not exist. The file is only rewritten (atomically) when the content changes.
+func UpsertMarkedBlockInFile(filePath, name, body string, perm os.FileMode) (bool, error) {
+	return editFile(filePath, perm, func(content string) (string, bool, error) {
+		return UpsertMarkedBlock(content, name, body)
+	})
+}
+
+// RemoveMarkedBlockFromFile applies RemoveMarkedBlock to a file. A missing file is
+// treated as having no region.
+func RemoveMarkedBlockFromFile(filePath, name string, perm os.FileMode) (bool, error) {
+	return editFile(filePath, perm, func(content string) (string, bool, error) {
+		return RemoveMarkedBlock(content, name)
+	})
+}
+
+func editFile(filePath string, perm os.FileMode, edit func(string) (string, bool, error)) (bool, error) {
+	//nolint:gosec // Generic file edit helper.
+	content, err := os.ReadFile(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("error reading file '%s': %w", filePath, err)
+	}
+
+	updated, changed, err := edit(string(content))
+	if err != nil {
+		return false, fmt.Errorf("failed to edit '%s': %w", filePath, err)
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	err = WriteFileAtomic(filePath, []byte(updated), perm)
+	if err != nil {
+		return false, fmt.Errorf("failed to write '%s': %w", filePath, err)
+	}
+
+	return true, nil
+}
+
+// findMarkedBlock returns the line indexes of the begin and end markers, or -1, -1
+// when the region is absent.
+func findMarkedBlock(lines []string, begin, end string) (int, int, error) {
+	for i, line := range lines {
+		if strings.TrimSpace(line) != begin {
+			continue
+		}
+
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == end {
+				return i, j, nil
+			}
+		}
+
+		return -1, -1, fmt.Errorf("%w: '%s'", ErrUnterminatedBlock, begin)
+	}
+
+	return -1, -1, nil
+}
@@ -0,0 +1,114 @@
+package tools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertMarkedBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("inserts into empty content", func(t *testing.T) {
+		t.Parallel()
+
+		out, changed, err := tools.UpsertMarkedBlock("", "DEMO", "export A=1\n")
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "# --- BEGIN DEMO ---\nexport A=1\n# --- END DEMO ---\n", out)
+	})
+
+	t.Run("appends after existing content with a blank line", func(t *testing.T) {
+		t.Parallel()
+
+		out, changed, err := tools.UpsertMarkedBlock("alias ll='ls -l'", "DEMO", "export A=1")
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "alias ll='ls -l'\n\n# --- BEGIN DEMO ---\nexport A=1\n# --- END DEMO ---\n", out)
+	})
+
+	t.Run("updates in place and keeps surrounding content", func(t *testing.T) {
+		t.Parallel()
+
+		input := "before\n# --- BEGIN DEMO ---\nexport A=old\nstale line\n# --- END DEMO ---\nafter\n"
+
+		out, changed, err := tools.UpsertMarkedBlock(input, "DEMO", "export A=new\n")
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "before\n# --- BEGIN DEMO ---\nexport A=new\n# --- END DEMO ---\nafter\n", out)
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		first, _, err := tools.UpsertMarkedBlock("x\n", "DEMO", "export A=1\n")
+		require.NoError(t, err)
+
+		second, changed, err := tools.UpsertMarkedBlock(first, "DEMO", "export A=1\n")
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("rejects an unterminated block", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := tools.UpsertMarkedBlock("# --- BEGIN DEMO ---\nexport A=1\n", "DEMO", "x")
+		require.ErrorIs(t, err, tools.ErrUnterminatedBlock)
+	})
+}
+
+func TestRemoveMarkedBlock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("removes the block and its separator", func(t *testing.T) {
+		t.Parallel()
+
+		input := "keep\n\n# --- BEGIN DEMO ---\nexport A=1\n# --- END DEMO ---\ntail\n"
+
+		out, changed, err := tools.RemoveMarkedBlock(input, "DEMO")
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, "keep\ntail\n", out)
+	})
+
+	t.Run("no-op when absent", func(t *testing.T) {
+		t.Parallel()
+
+		out, changed, err := tools.RemoveMarkedBlock("keep\n", "DEMO")
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, "keep\n", out)
+	})
+}
+
+func TestMarkedBlockInFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".bashrc")
+	require.NoError(t, os.WriteFile(path, []byte("alias ll='ls -l'\n"), 0o600))
+
+	changed, err := tools.UpsertMarkedBlockInFile(path, "DEMO", "export A=1\n", 0o600)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = tools.UpsertMarkedBlockInFile(path, "DEMO", "export A=2\n", 0o600)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "alias ll='ls -l'\n\n# --- BEGIN DEMO ---\nexport A=2\n# --- END DEMO ---\n", string(content))
+
+	changed, err = tools.RemoveMarkedBlockFromFile(path, "DEMO", 0o600)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "alias ll='ls -l'\n", string(content))
+}
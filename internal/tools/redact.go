@@ -0,0 +1,40 @@
+package tools
+
+import "regexp"
+
+// RedactedPlaceholder replaces secrets removed by RedactSecrets.
+const RedactedPlaceholder = "[REDACTED]"
+
+// secretPatterns match common credential shapes. Patterns with a capture group keep
+// the group (e.g. the key name) and redact the rest of the match.
+//
+//nolint:gochecknoglobals // Compiled once and read-only.
+var secretPatterns = []*regexp.Regexp{
+	// GitHub tokens: classic, OAuth, user-to-server, server-to-server, refresh, fine-grained.
+	regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`),
+	// AWS access key IDs.
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`),
+	// OpenAI/Anthropic-style API keys.
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}\b`),
+	// Authorization headers.
+	regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9._~+/=-]+`),
+	// key=value / "key": "value" pairs whose key looks sensitive.
+	regexp.MustCompile(
+		`(?i)((?:token|secret|password|passwd|api[_-]?key|access[_-]?key)["']?\s*[:=]\s*["']?)[^\s"',}]+`,
+	),
+}
+
+// RedactSecrets replaces obvious credentials (tokens, API keys, passwords in
+// key/value pairs) in text with RedactedPlaceholder. It is a best-effort filter for
+// content that is about to leave the machine, not a guarantee.
+func RedactSecrets(text string) string {
+	for _, pattern := range secretPatterns {
+		if pattern.NumSubexp() > 0 {
+			text = pattern.ReplaceAllString(text, "${1}"+RedactedPlaceholder)
+		} else {
+			text = pattern.ReplaceAllString(text, RedactedPlaceholder)
+		}
+	}
+
+	return text
+}
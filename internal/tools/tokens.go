@@ -0,0 +1,31 @@
+package tools
+
+import "strings"
+
+const (
+	// charsPerToken is the usual characters-per-token ratio for English text and code.
+	charsPerToken = 4.0
+	// tokensPerWord is the usual tokens-per-word ratio (about 0.75 words per token).
+	tokensPerWord = 4.0 / 3.0
+)
+
+// EstimateTokens returns a rough token count for text as an AI model would see it.
+// It averages a characters/4 estimate with a word-based one, which corrects the
+// character count for whitespace-heavy or very dense content. The result is an
+// estimate only; real tokenizers differ per model.
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	byChars := float64(len([]rune(text))) / charsPerToken
+	byWords := float64(len(strings.Fields(text))) * tokensPerWord
+
+	return int((byChars+byWords)/2 + 0.5) //nolint:mnd // Averages and rounds the two estimates.
+}
+
+// ExceedsTokenThreshold reports whether an estimated token count is over the
+// threshold. A threshold of zero or less disables the check.
+func ExceedsTokenThreshold(tokens, threshold int) bool {
+	return threshold > 0 && tokens > threshold
+}
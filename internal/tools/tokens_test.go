@@ -0,0 +1,71 @@
+package tools_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	t.Parallel()
+
+	// Reference counts are approximately what the cl100k_base tokenizer produces.
+	testCases := []struct {
+		name      string
+		text      string
+		reference int
+	}{
+		{
+			name:      "short sentence",
+			text:      "The quick brown fox jumps over the lazy dog.",
+			reference: 10,
+		},
+		{
+			name: "prose paragraph",
+			text: "Since these artifacts feed AI models with token limits, users need to know " +
+				"the size of the context before they upload it. A rough estimate is enough " +
+				"to decide whether to trim the output.",
+			reference: 38,
+		},
+		{
+			name:      "go code",
+			text:      "func main() {\n\tfmt.Println(\"hello, world\")\n}\n",
+			reference: 12,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			estimate := tools.EstimateTokens(testCase.text)
+			assert.InEpsilon(t, testCase.reference, estimate, 0.25)
+		})
+	}
+}
+
+func TestEstimateTokens_Empty(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, tools.EstimateTokens(""))
+}
+
+func TestEstimateTokens_ScalesWithLength(t *testing.T) {
+	t.Parallel()
+
+	sentence := "The quick brown fox jumps over the lazy dog. "
+	single := tools.EstimateTokens(sentence)
+	hundred := tools.EstimateTokens(strings.Repeat(sentence, 100))
+
+	assert.InEpsilon(t, single*100, hundred, 0.05)
+}
+
+func TestExceedsTokenThreshold(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, tools.ExceedsTokenThreshold(1001, 1000))
+	assert.False(t, tools.ExceedsTokenThreshold(1000, 1000))
+	assert.False(t, tools.ExceedsTokenThreshold(1_000_000, 0), "zero disables the check")
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gitignore "github.com/denormal/go-gitignore"
+)
+
+// TreeOptions controls GenerateTree.
+type TreeOptions struct {
+	// MaxDepth is the number of directory levels to show, as with `tree -L`.
+	MaxDepth int
+	// IgnoreNames are glob patterns (filepath.Match syntax) matched against each
+	// entry's base name, like the `|`-separated list given to `tree -I`.
+	IgnoreNames []string
+	// RespectGitignore also skips paths matched by the repository's .gitignore files.
+	RespectGitignore bool
+}
+
+// GenerateTree walks root and renders a portable, tree-like listing without
+// requiring the external `tree` binary. Top-level entries are printed bare and
+// nested entries as "|- name", indented two spaces per level.
+//
+//nolint:cyclop // Walk callback handles ignore rules and depth in one place.
+func GenerateTree(root string, opts TreeOptions) (string, error) {
+	var buf bytes.Buffer
+
+	var ignorer gitignore.GitIgnore
+
+	if opts.RespectGitignore {
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve tree root: %w", err)
+		}
+
+		// A missing or unreadable .gitignore simply means nothing extra is skipped.
+		repoIgnore, ignoreErr := gitignore.NewRepository(absRoot)
+		if ignoreErr == nil {
+			ignorer = repoIgnore
+		}
+	}
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err // Propagate error
+		}
+
+		relPath, _ := filepath.Rel(root, path)
+		if relPath == "." {
+			return nil
+		}
+
+		if matchesAnyName(entry.Name(), opts.IgnoreNames) || isGitignored(ignorer, relPath, entry.IsDir()) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		depth := strings.Count(relPath, string(os.PathSeparator))
+		if depth >= opts.MaxDepth {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		indent := strings.Repeat("  ", depth)
+
+		marker := "|-"
+		if depth == 0 {
+			marker = ""
+		}
+
+		fmt.Fprintf(&buf, "%s%s %s\n", indent, marker, entry.Name())
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error walking directory: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func matchesAnyName(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isGitignored(ignorer gitignore.GitIgnore, relPath string, isDir bool) bool {
+	if ignorer == nil {
+		return false
+	}
+
+	match := ignorer.Relative(relPath, isDir)
+
+	return match != nil && match.Ignore()
+}
@@ -0,0 +1,86 @@
+package tools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTreeFixture(t *testing.T, files []string) string {
+	t.Helper()
+
+	root := t.TempDir()
+
+	for _, name := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(name+"\n"), 0o600))
+	}
+
+	return root
+}
+
+func TestGenerateTree(t *testing.T) {
+	t.Parallel()
+
+	root := writeTreeFixture(t, []string{
+		".gitignore",
+		"README.md",
+		"cmd/app/main.go",
+		"internal/core/core.go",
+		"internal/core/deep/deeper.go",
+		"node_modules/pkg/index.js",
+		"debug.log",
+		"dist/bundle.js",
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".gitignore"), []byte("dist/\n"), 0o600))
+
+	t.Run("ignore names and depth match tree -L -I", func(t *testing.T) {
+		t.Parallel()
+
+		tree, err := tools.GenerateTree(root, tools.TreeOptions{
+			MaxDepth:         2,
+			IgnoreNames:      []string{"node_modules", "*.log"},
+			RespectGitignore: false,
+		})
+		require.NoError(t, err)
+
+		expected := ` .gitignore
+ README.md
+ cmd
+  |- app
+ dist
+  |- bundle.js
+ internal
+  |- core
+`
+		assert.Equal(t, expected, tree)
+	})
+
+	t.Run("gitignore and deeper levels", func(t *testing.T) {
+		t.Parallel()
+
+		tree, err := tools.GenerateTree(root, tools.TreeOptions{
+			MaxDepth:         3,
+			IgnoreNames:      []string{"node_modules", "*.log"},
+			RespectGitignore: true,
+		})
+		require.NoError(t, err)
+
+		expected := ` .gitignore
+ README.md
+ cmd
+  |- app
+    |- main.go
+ internal
+  |- core
+    |- core.go
+    |- deep
+`
+		assert.Equal(t, expected, tree)
+	})
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	gitignore "github.com/denormal/go-gitignore"
+)
+
+// FindFiles walks root and returns the files whose extension is one of extensions
+// (for example ".md"), in lexical order. The .git directory and anything matched
+// by the repository's .gitignore files are skipped. If root is itself a file, it is
+// returned as-is when its extension matches.
+func FindFiles(root string, extensions ...string) ([]string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve '%s': %w", root, err)
+	}
+
+	var ignorer gitignore.GitIgnore
+
+	repoIgnore, ignoreErr := gitignore.NewRepository(absRoot)
+	if ignoreErr == nil {
+		ignorer = repoIgnore
+	}
+
+	var files []string
+
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if entry.IsDir() && entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		if ignorer != nil && path != root {
+			absPath, absErr := filepath.Abs(path)
+			if absErr == nil {
+				if match := ignorer.Absolute(absPath, entry.IsDir()); match != nil && match.Ignore() {
+					if entry.IsDir() {
+						return filepath.SkipDir
+					}
+
+					return nil
+				}
+			}
+		}
+
+		if !entry.IsDir() && slices.Contains(extensions, strings.ToLower(filepath.Ext(path))) {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk '%s': %w", root, err)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,40 @@
+package tools_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	files := map[string]string{
+		".gitignore":            "build/\n*.generated.md\n",
+		"README.md":             "# Readme\n",
+		"docs/guide.MD":         "# Guide\n",
+		"docs/notes.txt":        "notes\n",
+		"docs/api.generated.md": "# Generated\n",
+		"build/output.md":       "# Output\n",
+		".git/description.md":   "# Git\n",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	found, err := tools.FindFiles(root, ".md")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		filepath.Join(root, "README.md"),
+		filepath.Join(root, "docs", "guide.MD"),
+	}, found)
+}
@@ -3,6 +3,8 @@ package ui
 
 import (
 	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -13,11 +15,62 @@ import (
 	"github.com/mattn/go-isatty"
 )
 
+// Options control how a Presenter renders output.
+type Options struct {
+	// Quiet suppresses progress output (Header, Step, Info, Detail, Separator and
+	// blank lines). Errors, warnings, advice and final results are still shown.
+	Quiet bool
+	// NoColor disables ANSI colors. Colors are also disabled automatically when
+	// the output writer is a file that is not a terminal.
+	NoColor bool
+	// Events replaces formatted text with newline-delimited JSON events on the
+	// output writer, one per presenter call (and one per line written to Out),
+	// for tools that wrap the CLI.
+	Events bool
+	// AssumeYes and AssumeNo answer every confirmation prompt without asking.
+	AssumeYes bool
+	AssumeNo  bool
+	// NonInteractive never reads from a terminal, as when none is available:
+	// inputs fall back to their defaults and prompts without one fail with
+	// ErrNonInteractive.
+	NonInteractive bool
+}
+
+// ErrNonInteractive is returned by prompts that need an answer when no terminal is available.
+var ErrNonInteractive = errors.New("cannot prompt: no interactive terminal available")
+
+// EventsEnvVar enables the event stream when set to a true value (see strconv.ParseBool).
+const EventsEnvVar = "CONTEXTVIBES_OUTPUT_EVENTS"
+
+// Event is one presenter call in event stream mode.
+type Event struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// defaultOptions are used by NewPresenter; the root command sets them from the
+// global --quiet and --no-color flags.
+//
+//nolint:gochecknoglobals // Process-wide output settings chosen once at startup.
+var defaultOptions Options
+
+// SetDefaultOptions sets the options used by every Presenter created with NewPresenter.
+func SetDefaultOptions(opts Options) {
+	defaultOptions = opts
+}
+
 // Presenter handles structured writing to standard output and standard error,
 // and reading standardized user input, mimicking the Pulumi CLI style.
 type Presenter struct {
 	outW io.Writer
 	errW io.Writer
+	inR  *bufio.Reader // Optional prompt input override; nil means terminal detection.
+
+	quiet          bool
+	events         *json.Encoder // Non-nil in event stream mode.
+	assumeYes      bool
+	assumeNo       bool
+	nonInteractive bool
 
 	// Color instances (initialized in New)
 	successColor *color.Color
@@ -32,8 +85,14 @@ type Presenter struct {
 	summaryColor *color.Color
 }
 
-// NewPresenter creates a new Console instance with Pulumi-like color support.
+// NewPresenter creates a new Console instance with Pulumi-like color support,
+// using the options set with SetDefaultOptions.
 func NewPresenter(outW, errW io.Writer) *Presenter {
+	return NewPresenterWithOptions(outW, errW, defaultOptions)
+}
+
+// NewPresenterWithOptions creates a Presenter with explicit options.
+func NewPresenterWithOptions(outW, errW io.Writer, opts Options) *Presenter {
 	var out io.Writer = os.Stdout
 	if outW != nil {
 		out = outW
@@ -44,9 +103,15 @@ func NewPresenter(outW, errW io.Writer) *Presenter {
 		err = errW
 	}
 
-	return &Presenter{
-		outW: out,
-		errW: err,
+	presenter := &Presenter{
+		outW:  out,
+		errW:  err,
+		inR:   nil,
+		quiet: opts.Quiet,
+
+		assumeYes:      opts.AssumeYes,
+		assumeNo:       opts.AssumeNo,
+		nonInteractive: opts.NonInteractive,
 
 		// Initialize all color fields
 		successColor: color.New(color.FgGreen, color.Bold),
@@ -60,15 +125,89 @@ func NewPresenter(outW, errW io.Writer) *Presenter {
 		boldColor:    color.New(color.Bold),
 		summaryColor: color.New(color.FgCyan, color.Bold),
 	}
+
+	if opts.NoColor || opts.Events || !isTerminalWriter(out) {
+		presenter.disableColor()
+	}
+
+	if opts.Events {
+		presenter.events = json.NewEncoder(out)
+	}
+
+	return presenter
+}
+
+// isTerminalWriter reports false for files that are not terminals (pipes and
+// redirects). Other writers defer to the color package's global detection.
+func isTerminalWriter(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return true
+	}
+
+	return isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd())
+}
+
+// emit writes an event when in event stream mode and reports whether it did.
+func (p *Presenter) emit(level, format string, a ...any) bool {
+	if p.events == nil {
+		return false
+	}
+
+	_ = p.events.Encode(Event{Level: level, Message: fmt.Sprintf(format, a...)})
+
+	return true
+}
+
+func (p *Presenter) disableColor() {
+	for _, c := range []*color.Color{
+		p.successColor, p.errorColor, p.warningColor, p.infoColor, p.stepColor,
+		p.detailColor, p.promptColor, p.headerColor, p.boldColor, p.summaryColor,
+	} {
+		c.DisableColor()
+	}
 }
 
-// Out returns the configured output writer (typically os.Stdout).
+// Out returns the configured output writer (typically os.Stdout). In event
+// stream mode, text written to it is emitted as "output" events, one per line.
 //
 //nolint:ireturn // Returning interface is intended.
 func (p *Presenter) Out() io.Writer {
+	if p.events != nil {
+		return eventWriter{presenter: p}
+	}
+
 	return p.outW
 }
 
+// eventWriter keeps the event stream valid for commands that print raw text,
+// such as issue bodies, diffs and release notes, by wrapping each line in an event.
+type eventWriter struct {
+	presenter *Presenter
+}
+
+func (w eventWriter) Write(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	for line := range strings.SplitSeq(strings.TrimSuffix(string(data), "\n"), "\n") {
+		w.presenter.emit("output", "%s", line)
+	}
+
+	return len(data), nil
+}
+
+// SetInput overrides the reader used to answer prompts, e.g. with a scripted
+// reader in tests. Passing os.Stdin (cobra's default) keeps the terminal detection.
+func (p *Presenter) SetInput(inR io.Reader) {
+	if file, ok := inR.(*os.File); ok && file == os.Stdin {
+		return
+	}
+
+	p.inR = bufio.NewReader(inR)
+}
+
 // Err returns the configured error writer (typically os.Stderr).
 //
 //nolint:ireturn // Returning interface is intended.
@@ -82,6 +221,14 @@ func (p *Presenter) Err() io.Writer {
 //
 //nolint:goprintffuncname // Noun-based naming is intended.
 func (p *Presenter) Header(format string, a ...any) {
+	if p.quiet {
+		return
+	}
+
+	if p.emit("header", format, a...) {
+		return
+	}
+
 	_, _ = p.headerColor.Fprintf(p.outW, format+"\n", a...)
 }
 
@@ -89,6 +236,10 @@ func (p *Presenter) Header(format string, a ...any) {
 //
 //nolint:goprintffuncname // Noun-based naming is intended.
 func (p *Presenter) Summary(format string, a ...any) {
+	if p.emit("summary", format, a...) {
+		return
+	}
+
 	_, _ = p.summaryColor.Fprint(p.outW, "SUMMARY:\n")
 
 	_, _ = fmt.Fprintf(p.outW, "  "+format+"\n", a...)
@@ -99,6 +250,14 @@ func (p *Presenter) Summary(format string, a ...any) {
 //
 //nolint:goprintffuncname // Noun-based naming is intended.
 func (p *Presenter) Step(format string, a ...any) {
+	if p.quiet {
+		return
+	}
+
+	if p.emit("step", format, a...) {
+		return
+	}
+
 	_, _ = p.stepColor.Fprintf(p.outW, "- "+format+"\n", a...)
 }
 
@@ -106,16 +265,34 @@ func (p *Presenter) Step(format string, a ...any) {
 //
 //nolint:goprintffuncname // Noun-based naming is intended.
 func (p *Presenter) Info(format string, a ...any) {
+	if p.quiet {
+		return
+	}
+
+	if p.emit("info", format, a...) {
+		return
+	}
+
 	_, _ = p.infoColor.Fprintf(p.outW, "~ "+format+"\n", a...)
 }
 
 // InfoPrefixOnly prints the info prefix without a newline.
-func (p *Presenter) InfoPrefixOnly() { _, _ = p.infoColor.Fprint(p.outW, "~ ") }
+func (p *Presenter) InfoPrefixOnly() {
+	if p.quiet || p.events != nil {
+		return
+	}
+
+	_, _ = p.infoColor.Fprint(p.outW, "~ ")
+}
 
 // Success prints success.
 //
 //nolint:goprintffuncname // Noun-based naming is intended.
 func (p *Presenter) Success(format string, a ...any) {
+	if p.emit("success", format, a...) {
+		return
+	}
+
 	_, _ = p.successColor.Fprintf(p.outW, "+ "+format+"\n", a...)
 }
 
@@ -123,6 +300,10 @@ func (p *Presenter) Success(format string, a ...any) {
 //
 //nolint:goprintffuncname // Noun-based naming is intended.
 func (p *Presenter) Error(format string, a ...any) {
+	if p.emit("error", format, a...) {
+		return
+	}
+
 	_, _ = p.errorColor.Fprintf(p.errW, "! "+format+"\n", a...)
 }
 
@@ -130,6 +311,10 @@ func (p *Presenter) Error(format string, a ...any) {
 //
 //nolint:goprintffuncname // Noun-based naming is intended.
 func (p *Presenter) Warning(format string, a ...any) {
+	if p.emit("warning", format, a...) {
+		return
+	}
+
 	_, _ = p.warningColor.Fprintf(p.errW, "~ "+format+"\n", a...)
 }
 
@@ -137,6 +322,10 @@ func (p *Presenter) Warning(format string, a ...any) {
 //
 //nolint:goprintffuncname // Noun-based naming is intended.
 func (p *Presenter) Advice(format string, a ...any) {
+	if p.emit("advice", format, a...) {
+		return
+	}
+
 	_, _ = p.warningColor.Fprintf(p.outW, "~ "+format+"\n", a...)
 }
 
@@ -144,6 +333,14 @@ func (p *Presenter) Advice(format string, a ...any) {
 //
 //nolint:goprintffuncname // Noun-based naming is intended.
 func (p *Presenter) Detail(format string, a ...any) {
+	if p.quiet {
+		return
+	}
+
+	if p.emit("detail", format, a...) {
+		return
+	}
+
 	_, _ = p.detailColor.Fprintf(p.outW, "  "+format+"\n", a...)
 }
 
@@ -151,51 +348,112 @@ func (p *Presenter) Detail(format string, a ...any) {
 func (p *Presenter) Highlight(text string) string { return p.boldColor.Sprint(text) }
 
 // Newline prints a newline.
-func (p *Presenter) Newline() { _, _ = fmt.Fprintln(p.outW) }
+func (p *Presenter) Newline() {
+	if p.quiet || p.events != nil {
+		return
+	}
+
+	_, _ = fmt.Fprintln(p.outW)
+}
 
 // Separator prints a separator.
 func (p *Presenter) Separator() {
-	_, _ = color.New(color.Faint).Fprintln(p.outW, "----------------------------------------")
+	if p.quiet || p.events != nil {
+		return
+	}
+
+	_, _ = p.detailColor.Fprintln(p.outW, "----------------------------------------")
 }
 
 // --- Input Methods ---
 
-// PromptForInput prompts the user for input.
+// PromptForInput prompts the user for input. Without a terminal it fails with ErrNonInteractive.
 func (p *Presenter) PromptForInput(prompt string) (string, error) {
-	interactiveReader, cleanup, err := p.getInteractiveReader()
+	answer, _, err := p.promptLine(prompt, "")
 	if err != nil {
-		p.Error("Could not get an interactive terminal for prompting: %v", err)
+		return "", err
+	}
+
+	return answer, nil
+}
+
+// PromptForInputWithDefault prompts the user for input, showing defaultValue and
+// returning it when the answer is empty or no terminal is available.
+func (p *Presenter) PromptForInputWithDefault(prompt, defaultValue string) (string, error) {
+	answer, asked, err := p.promptLine(prompt, defaultValue)
+	if errors.Is(err, ErrNonInteractive) {
+		p.Info("Using default for '%s': %s", strings.TrimSpace(prompt), defaultValue)
+
+		return defaultValue, nil
+	}
 
+	if err != nil {
 		return "", err
 	}
+
+	if asked && answer == "" {
+		return defaultValue, nil
+	}
+
+	return answer, nil
+}
+
+// promptLine asks for one line of input. A non-empty defaultValue is shown in
+// brackets. It reports whether the user was actually asked.
+func (p *Presenter) promptLine(prompt, defaultValue string) (string, bool, error) {
+	interactiveReader, cleanup, err := p.getInteractiveReader()
+	if err != nil {
+		if defaultValue == "" {
+			p.Error("Could not get an interactive terminal for prompting: %v", err)
+		}
+
+		return "", false, err
+	}
 	defer cleanup()
 
 	reader := bufio.NewReader(interactiveReader)
 
 	prompt = strings.TrimSpace(prompt)
+	if defaultValue != "" {
+		prompt = strings.TrimSuffix(prompt, ":") + " [" + defaultValue + "]"
+	}
+
 	if !strings.HasSuffix(prompt, ":") {
 		prompt += ":"
 	}
 
-	prompt += " "
-
-	_, _ = p.promptColor.Fprint(p.errW, prompt)
+	if !p.emit("prompt", "%s", prompt) {
+		_, _ = p.promptColor.Fprint(p.errW, prompt+" ")
+	}
 
 	input, err := reader.ReadString('\n')
-	if err != nil {
+	if err != nil && !(errors.Is(err, io.EOF) && defaultValue != "") {
 		_, _ = p.errorColor.Fprintf(p.errW, "\n! Error reading input: %v\n", err)
 
-		return "", fmt.Errorf("reading input failed: %w", err)
+		return "", true, fmt.Errorf("reading input failed: %w", err)
 	}
 
-	return strings.TrimSpace(input), nil
+	return strings.TrimSpace(input), true, nil
 }
 
-// PromptForConfirmation prompts the user for confirmation.
+// PromptForConfirmation prompts the user for confirmation. The AssumeYes and
+// AssumeNo options answer without asking; otherwise a terminal is required.
 func (p *Presenter) PromptForConfirmation(prompt string) (bool, error) {
+	if p.assumeYes || p.assumeNo {
+		answer := "yes"
+		if !p.assumeYes {
+			answer = "no"
+		}
+
+		p.Info("%s? %s (assumed)", strings.TrimSuffix(strings.TrimSpace(prompt), "?"), answer)
+
+		return p.assumeYes, nil
+	}
+
 	interactiveReader, cleanup, err := p.getInteractiveReader()
 	if err != nil {
 		p.Error("Could not get an interactive terminal for prompting: %v", err)
+		p.Advice("Re-run with --yes or --no to answer confirmations non-interactively.")
 
 		return false, err
 	}
@@ -208,9 +466,11 @@ func (p *Presenter) PromptForConfirmation(prompt string) (bool, error) {
 		prompt += "?"
 	}
 
-	fullPrompt := prompt + " [y/N]: "
+	fullPrompt := prompt + " [y/N]:"
 	for {
-		_, _ = p.promptColor.Fprint(p.errW, fullPrompt)
+		if !p.emit("prompt", "%s", fullPrompt) {
+			_, _ = p.promptColor.Fprint(p.errW, fullPrompt+" ")
+		}
 
 		input, err := reader.ReadString('\n')
 		if err != nil {
@@ -228,10 +488,7 @@ func (p *Presenter) PromptForConfirmation(prompt string) (bool, error) {
 			return false, nil
 		}
 
-		_, _ = p.warningColor.Fprintf(
-			p.errW,
-			"~ Invalid input. Please enter 'y' or 'n'.\n",
-		)
+		p.Warning("Invalid input. Please enter 'y' or 'n'.")
 	}
 }
 
@@ -280,16 +537,33 @@ func (p *Presenter) PromptForMultiSelect(title string, options []string) ([]stri
 }
 
 // getInteractiveReader intelligently selects the correct input for user prompts.
-// If stdin is a pipe, it opens /dev/tty for interactive input. Otherwise, it uses stdin.
+// If stdin is a pipe, it opens /dev/tty for interactive input (except in event
+// stream mode). Otherwise, it uses stdin.
 // The returned cleanup function MUST be called by the caller to close /dev/tty if it was opened.
 //
 //nolint:ireturn // Returning interface is intended.
 func (p *Presenter) getInteractiveReader() (io.Reader, func(), error) {
+	// A buffered override is returned as-is so that bufio.NewReader reuses it
+	// and no input is lost between consecutive prompts.
+	if p.inR != nil {
+		return p.inR, func() {}, nil
+	}
+
+	if p.nonInteractive {
+		return nil, func() {}, ErrNonInteractive
+	}
+
+	// A wrapping tool answers prompts on stdin, which is usually a pipe.
+	if p.events != nil {
+		return os.Stdin, func() {}, nil
+	}
+
 	if !isatty.IsTerminal(os.Stdin.Fd()) {
 		tty, ttyErr := os.Open("/dev/tty")
 		if ttyErr != nil {
 			return nil, func() {}, fmt.Errorf(
-				"stdin is a pipe and could not open /dev/tty for interactive prompt: %w",
+				"%w: stdin is a pipe and /dev/tty could not be opened: %w",
+				ErrNonInteractive,
 				ttyErr,
 			)
 		}
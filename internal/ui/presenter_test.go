@@ -0,0 +1,200 @@
+// Package ui_test contains tests for the presenter.
+package ui_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func render(opts ui.Options) (string, string) {
+	var out, errOut bytes.Buffer
+
+	presenter := ui.NewPresenterWithOptions(&out, &errOut, opts)
+	presenter.Header("Header")
+	presenter.Step("Step")
+	presenter.Info("Info")
+	presenter.Detail("Detail")
+	presenter.Separator()
+	presenter.Newline()
+	presenter.Success("Done")
+	presenter.Warning("Careful")
+	presenter.Error("Broken")
+
+	return out.String(), errOut.String()
+}
+
+func TestPresenter_Quiet(t *testing.T) {
+	t.Parallel()
+
+	out, errOut := render(ui.Options{Quiet: true, NoColor: true, Events: false})
+
+	assert.Equal(t, "+ Done\n", out)
+	assert.Equal(t, "~ Careful\n! Broken\n", errOut)
+}
+
+func TestPresenter_Default(t *testing.T) {
+	t.Parallel()
+
+	out, _ := render(ui.Options{Quiet: false, NoColor: true, Events: false})
+
+	assert.Equal(t, "Header\n- Step\n~ Info\n  Detail\n----------------------------------------\n\n+ Done\n", out)
+}
+
+//nolint:paralleltest // Forces color on through the color package's global switch.
+func TestPresenter_NoColor(t *testing.T) {
+	original := color.NoColor
+	color.NoColor = false
+
+	t.Cleanup(func() { color.NoColor = original })
+
+	colored, _ := render(ui.Options{Quiet: false, NoColor: false, Events: false})
+	assert.Contains(t, colored, "\x1b[", "colors are on when enabled")
+
+	plain, plainErr := render(ui.Options{Quiet: false, NoColor: true, Events: false})
+	assert.NotContains(t, plain, "\x1b[")
+	assert.NotContains(t, plainErr, "\x1b[")
+}
+
+func TestPresenter_Events(t *testing.T) {
+	t.Parallel()
+
+	var out, errOut bytes.Buffer
+
+	presenter := ui.NewPresenterWithOptions(&out, &errOut, ui.Options{Quiet: false, NoColor: false, Events: true})
+	presenter.SetInput(strings.NewReader("y\n"))
+
+	presenter.Step("Checking %s", presenter.Highlight("go.mod"))
+	presenter.Newline()
+	presenter.Error("Broken")
+	_, _ = fmt.Fprint(presenter.Out(), "## Notes\n\n- fixed \"quotes\"\n")
+
+	confirmed, err := presenter.PromptForConfirmation("Continue")
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+
+	assert.Empty(t, errOut.String(), "events replace stderr output")
+
+	var events []ui.Event
+
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var event ui.Event
+		require.NoError(t, decoder.Decode(&event))
+		events = append(events, event)
+	}
+
+	assert.Equal(t, []ui.Event{
+		{Level: "step", Message: "Checking go.mod"},
+		{Level: "error", Message: "Broken"},
+		{Level: "output", Message: "## Notes"},
+		{Level: "output", Message: ""},
+		{Level: "output", Message: "- fixed \"quotes\""},
+		{Level: "prompt", Message: "Continue? [y/N]:"},
+	}, events)
+}
+
+func TestPresenter_PromptForInputWithDefault(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty answer uses the default", "\n", "main"},
+		{"answer overrides the default", "develop\n", "develop"},
+		{"closed input uses the default", "", "main"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out, errOut bytes.Buffer
+
+			//nolint:exhaustruct // Defaults are sufficient.
+			presenter := ui.NewPresenterWithOptions(&out, &errOut, ui.Options{NoColor: true})
+			presenter.SetInput(strings.NewReader(tc.input))
+
+			answer, err := presenter.PromptForInputWithDefault("Base branch", "main")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, answer)
+			assert.Equal(t, "Base branch [main]: ", errOut.String())
+		})
+	}
+}
+
+func TestPresenter_NonInteractive(t *testing.T) {
+	t.Parallel()
+
+	newPresenter := func(opts ui.Options) (*ui.Presenter, *bytes.Buffer) {
+		var out bytes.Buffer
+
+		opts.NoColor = true
+		opts.NonInteractive = true
+
+		return ui.NewPresenterWithOptions(&out, &out, opts), &out
+	}
+
+	t.Run("input with a default", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Defaults are sufficient.
+		presenter, out := newPresenter(ui.Options{})
+
+		answer, err := presenter.PromptForInputWithDefault("Base branch", "main")
+		require.NoError(t, err)
+		assert.Equal(t, "main", answer)
+		assert.Contains(t, out.String(), "Using default for 'Base branch': main")
+	})
+
+	t.Run("input without a default", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Defaults are sufficient.
+		presenter, _ := newPresenter(ui.Options{})
+
+		_, err := presenter.PromptForInput("Commit message")
+		require.ErrorIs(t, err, ui.ErrNonInteractive)
+	})
+
+	t.Run("confirmation without a policy", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Defaults are sufficient.
+		presenter, out := newPresenter(ui.Options{})
+
+		_, err := presenter.PromptForConfirmation("Push now")
+		require.ErrorIs(t, err, ui.ErrNonInteractive)
+		assert.Contains(t, out.String(), "--yes or --no")
+	})
+
+	t.Run("confirmation with --yes", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Defaults are sufficient.
+		presenter, _ := newPresenter(ui.Options{AssumeYes: true})
+
+		confirmed, err := presenter.PromptForConfirmation("Push now")
+		require.NoError(t, err)
+		assert.True(t, confirmed)
+	})
+
+	t.Run("confirmation with --no", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Defaults are sufficient.
+		presenter, out := newPresenter(ui.Options{AssumeNo: true})
+
+		confirmed, err := presenter.PromptForConfirmation("Push now")
+		require.NoError(t, err)
+		assert.False(t, confirmed)
+		assert.Contains(t, out.String(), "Push now? no (assumed)")
+	})
+}
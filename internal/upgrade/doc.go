@@ -0,0 +1,6 @@
+/*
+Package upgrade resolves contextvibes releases and rewrites the pinned release
+in a Nix derivation (such as .idx/contextvibes.nix), so a development
+environment can be moved to a new CLI version with a single command.
+*/
+package upgrade
@@ -0,0 +1,332 @@
+package upgrade
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/contextvibes/cli/internal/tools"
+)
+
+const (
+	// DefaultNixFile is the derivation that pins the CLI in IDX workspaces.
+	DefaultNixFile = ".idx/contextvibes.nix"
+	// DefaultRepository is the GitHub repository that publishes releases.
+	DefaultRepository = "contextvibes/cli"
+	// DefaultBinaryName is the release asset name of the CLI binary.
+	DefaultBinaryName = "contextvibes"
+
+	defaultAPIBaseURL      = "https://api.github.com"
+	defaultDownloadBaseURL = "https://github.com"
+	defaultRequestTimeout  = 2 * time.Minute
+	nixFilePerm            = 0o644
+
+	// FakeHash is the placeholder Nix accepts in place of a hash it should compute
+	// (lib.fakeHash); the next build fails and reports the real value.
+	FakeHash = "sha256-AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+)
+
+var (
+	// ErrVersionFieldNotFound is returned when the Nix file has no version field.
+	ErrVersionFieldNotFound = errors.New("no version field found in nix file")
+	// ErrHashFieldNotFound is returned when the Nix file has no binary hash field.
+	ErrHashFieldNotFound = errors.New("no binHash/sha256 field found in nix file")
+	// ErrUnexpectedStatus is returned when a release endpoint responds with a non-200 status.
+	ErrUnexpectedStatus = errors.New("unexpected HTTP status")
+
+	versionFieldRegex    = regexp.MustCompile(`(\bversion\s*[=?]\s*")([^"]*)(")`)
+	revFieldRegex        = regexp.MustCompile(`(\brev\s*[=?]\s*")([^"]*)(")`)
+	sourceHashFieldRegex = regexp.MustCompile(`(\b(srcHash|vendorHash)\s*[=?]\s*")([^"]*)(")`)
+	hashFieldRegex       = regexp.MustCompile(`(\b(?:binHash|sha256)\s*[=?]\s*")([^"]*)(")`)
+	downloadPathRegex    = regexp.MustCompile(`(/releases/download/)[^/"]+(/)`)
+)
+
+// Resolver looks up releases and their binaries.
+type Resolver struct {
+	APIBaseURL      string
+	DownloadBaseURL string
+	Repository      string
+	BinaryName      string
+	HTTPClient      *http.Client
+}
+
+// Result describes a rewrite of the pinned release.
+type Result struct {
+	PreviousVersion string
+	Version         string
+	Hash            string
+	Changed         bool
+	// PendingHashes names the hash fields of a source build (srcHash, vendorHash)
+	// that were reset to FakeHash and must be filled in from the next build.
+	PendingHashes []string
+}
+
+// NewResolver returns a Resolver for the public GitHub releases of the CLI.
+func NewResolver() *Resolver {
+	return &Resolver{
+		APIBaseURL:      defaultAPIBaseURL,
+		DownloadBaseURL: defaultDownloadBaseURL,
+		Repository:      DefaultRepository,
+		BinaryName:      DefaultBinaryName,
+		//nolint:exhaustruct // Transport defaults are fine.
+		HTTPClient: &http.Client{Timeout: defaultRequestTimeout},
+	}
+}
+
+// LatestVersion returns the tag name (e.g. "v0.6.0") of the latest published release.
+func (r *Resolver) LatestVersion(ctx context.Context) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", r.APIBaseURL, r.Repository)
+
+	body, err := r.get(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	var release struct {
+		TagName string `json:"tag_name"` //nolint:tagliatelle // GitHub API field name.
+	}
+
+	err = json.NewDecoder(body).Decode(&release)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode latest release: %w", err)
+	}
+
+	if release.TagName == "" {
+		//nolint:err113 // Dynamic error is appropriate here.
+		return "", errors.New("latest release has no tag name")
+	}
+
+	return release.TagName, nil
+}
+
+// BinaryURL returns the download URL of the CLI binary for a release tag.
+func (r *Resolver) BinaryURL(tag string) string {
+	return fmt.Sprintf("%s/%s/releases/download/%s/%s", r.DownloadBaseURL, r.Repository, tag, r.BinaryName)
+}
+
+// BinarySHA256 downloads the release binary and returns its SHA-256 digest.
+func (r *Resolver) BinarySHA256(ctx context.Context, tag string) ([]byte, error) {
+	body, err := r.get(ctx, r.BinaryURL(tag))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	hasher := sha256.New()
+
+	_, err = io.Copy(hasher, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download release binary: %w", err)
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// UpgradeNixFile pins the Nix file at path to the given version, or to the latest
+// release when version is empty. The file is rewritten atomically.
+func (r *Resolver) UpgradeNixFile(ctx context.Context, path, version string) (*Result, error) {
+	//nolint:gosec // Reading the user's nix file is intended.
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", path, err)
+	}
+
+	tag := NormalizeTag(version)
+	if tag == "" {
+		tag, err = r.LatestVersion(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if IsSourcePin(string(content)) {
+		return upgradeSourcePin(path, string(content), tag)
+	}
+
+	digest, err := r.BinarySHA256(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := ""
+	if match := versionFieldRegex.FindStringSubmatch(string(content)); match != nil {
+		previous = match[2]
+	}
+
+	updated, hash, err := RewriteNixPin(string(content), tag, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		PreviousVersion: previous,
+		Version:         strings.TrimPrefix(tag, "v"),
+		Hash:            hash,
+		Changed:         updated != string(content),
+	}
+
+	if !result.Changed {
+		return result, nil
+	}
+
+	err = tools.WriteFileAtomic(path, []byte(updated), nixFilePerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write '%s': %w", path, err)
+	}
+
+	return result, nil
+}
+
+// upgradeSourcePin rewrites a derivation that builds the CLI from source. Its
+// hashes cover the unpacked source tree and the Go module vendor directory,
+// which only Nix can compute, so they are reset for the next build to report.
+func upgradeSourcePin(path, content, tag string) (*Result, error) {
+	previous := ""
+	if match := versionFieldRegex.FindStringSubmatch(content); match != nil {
+		previous = match[2]
+	}
+
+	result := &Result{
+		PreviousVersion: previous,
+		Version:         strings.TrimPrefix(tag, "v"),
+		Hash:            "",
+		Changed:         false,
+		PendingHashes:   nil,
+	}
+
+	if strings.TrimPrefix(previous, "v") == result.Version {
+		return result, nil
+	}
+
+	updated, pending, err := RewriteNixSourcePin(content, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Changed = updated != content
+	if !result.Changed {
+		return result, nil
+	}
+
+	result.PendingHashes = pending
+
+	err = tools.WriteFileAtomic(path, []byte(updated), nixFilePerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write '%s': %w", path, err)
+	}
+
+	return result, nil
+}
+
+// IsSourcePin reports whether a derivation builds the CLI from source (it has a
+// srcHash or vendorHash field) rather than downloading the release binary.
+func IsSourcePin(content string) bool {
+	return sourceHashFieldRegex.MatchString(content) && !hashFieldRegex.MatchString(content)
+}
+
+// RewriteNixSourcePin updates the version and the source rev of a derivation that
+// builds from source, and resets its srcHash and vendorHash to FakeHash. A rev
+// that interpolates the version (`rev = "v${version}"`) is left alone. It returns
+// the new content and the names of the reset hash fields.
+func RewriteNixSourcePin(content, tag string) (string, []string, error) {
+	if !versionFieldRegex.MatchString(content) {
+		return "", nil, ErrVersionFieldNotFound
+	}
+
+	content = versionFieldRegex.ReplaceAllString(content, "${1}"+strings.TrimPrefix(tag, "v")+"${3}")
+	content = revFieldRegex.ReplaceAllStringFunc(content, func(field string) string {
+		match := revFieldRegex.FindStringSubmatch(field)
+		if strings.Contains(match[2], "${") {
+			return field
+		}
+
+		return match[1] + tag + match[3]
+	})
+
+	var pending []string
+
+	for _, match := range sourceHashFieldRegex.FindAllStringSubmatch(content, -1) {
+		pending = append(pending, match[2])
+	}
+
+	content = sourceHashFieldRegex.ReplaceAllString(content, "${1}"+FakeHash+"${4}")
+
+	return content, pending, nil
+}
+
+// NormalizeTag turns "0.6.0" or "v0.6.0" into "v0.6.0"; empty input stays empty.
+func NormalizeTag(version string) string {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return ""
+	}
+
+	return "v" + strings.TrimPrefix(version, "v")
+}
+
+// RewriteNixPin updates the version, release download URL and binary hash in a Nix
+// derivation. Both attribute (`version = "..."`) and argument-default
+// (`version ? "..."`) styles are supported. The hash keeps the format already
+// used in the file: SRI ("sha256-<base64>"), "sha256:<hex>" or bare hex.
+// It returns the new content and the formatted hash.
+func RewriteNixPin(content, tag string, digest []byte) (string, string, error) {
+	if !versionFieldRegex.MatchString(content) {
+		return "", "", ErrVersionFieldNotFound
+	}
+
+	hashMatch := hashFieldRegex.FindStringSubmatch(content)
+	if hashMatch == nil {
+		return "", "", ErrHashFieldNotFound
+	}
+
+	hash := formatHash(hashMatch[2], digest)
+	version := strings.TrimPrefix(tag, "v")
+
+	content = versionFieldRegex.ReplaceAllString(content, "${1}"+version+"${3}")
+	content = downloadPathRegex.ReplaceAllString(content, "${1}"+tag+"${2}")
+	content = hashFieldRegex.ReplaceAllString(content, "${1}"+hash+"${3}")
+
+	return content, hash, nil
+}
+
+func formatHash(existing string, digest []byte) string {
+	switch {
+	case strings.HasPrefix(existing, "sha256-"):
+		return "sha256-" + base64.StdEncoding.EncodeToString(digest)
+	case strings.HasPrefix(existing, "sha256:"):
+		return "sha256:" + hex.EncodeToString(digest)
+	default:
+		return hex.EncodeToString(digest)
+	}
+}
+
+func (r *Resolver) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for '%s': %w", url, err)
+	}
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to '%s' failed: %w", url, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+
+		return nil, fmt.Errorf("%w %d from '%s'", ErrUnexpectedStatus, resp.StatusCode, url)
+	}
+
+	return resp.Body, nil
+}
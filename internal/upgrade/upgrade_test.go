@@ -0,0 +1,265 @@
+package upgrade_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/upgrade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fixtureNix = `{ pkgs }:
+
+pkgs.stdenv.mkDerivation {
+  pname = "contextvibes";
+  version = "0.5.0";
+
+  src = pkgs.fetchurl {
+    url = "https://github.com/contextvibes/cli/releases/download/v0.5.0/contextvibes";
+    sha256 = "sha256:c519ee03b6b77721dfc78bb03b638c3327096affafd8968d49b2bbd9a89ffc10";
+  };
+}
+`
+
+var fakeBinary = []byte("fake contextvibes binary")
+
+func newTestResolver(t *testing.T) *upgrade.Resolver {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/contextvibes/cli/releases/latest", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"tag_name": "v0.6.0"}`))
+	})
+	mux.HandleFunc("/contextvibes/cli/releases/download/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/contextvibes/cli/releases/download/v0.6.0/contextvibes" &&
+			r.URL.Path != "/contextvibes/cli/releases/download/v0.7.1/contextvibes" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write(fakeBinary)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	resolver := upgrade.NewResolver()
+	resolver.APIBaseURL = server.URL
+	resolver.DownloadBaseURL = server.URL
+	resolver.HTTPClient = server.Client()
+
+	return resolver
+}
+
+func writeFixture(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "contextvibes.nix")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestUpgradeNixFile(t *testing.T) {
+	t.Parallel()
+
+	digest := sha256.Sum256(fakeBinary)
+	wantHash := "sha256:" + hex.EncodeToString(digest[:])
+
+	t.Run("latest release", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFixture(t, fixtureNix)
+
+		result, err := newTestResolver(t).UpgradeNixFile(t.Context(), path, "")
+		require.NoError(t, err)
+
+		assert.True(t, result.Changed)
+		assert.Equal(t, "0.5.0", result.PreviousVersion)
+		assert.Equal(t, "0.6.0", result.Version)
+		assert.Equal(t, wantHash, result.Hash)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `version = "0.6.0";`)
+		assert.Contains(t, string(content), `/releases/download/v0.6.0/contextvibes"`)
+		assert.Contains(t, string(content), `sha256 = "`+wantHash+`";`)
+		assert.NotContains(t, string(content), "0.5.0")
+	})
+
+	t.Run("explicit version without v prefix", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFixture(t, fixtureNix)
+
+		result, err := newTestResolver(t).UpgradeNixFile(t.Context(), path, "0.7.1")
+		require.NoError(t, err)
+		assert.Equal(t, "0.7.1", result.Version)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `version = "0.7.1";`)
+		assert.Contains(t, string(content), `/releases/download/v0.7.1/contextvibes"`)
+	})
+
+	t.Run("already pinned", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFixture(t, fixtureNix)
+		resolver := newTestResolver(t)
+
+		_, err := resolver.UpgradeNixFile(t.Context(), path, "v0.6.0")
+		require.NoError(t, err)
+
+		result, err := resolver.UpgradeNixFile(t.Context(), path, "v0.6.0")
+		require.NoError(t, err)
+		assert.False(t, result.Changed)
+	})
+
+	t.Run("unknown release leaves the file untouched", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFixture(t, fixtureNix)
+
+		_, err := newTestResolver(t).UpgradeNixFile(t.Context(), path, "v9.9.9")
+		require.ErrorIs(t, err, upgrade.ErrUnexpectedStatus)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, fixtureNix, string(content))
+	})
+}
+
+func TestRewriteNixPin(t *testing.T) {
+	t.Parallel()
+
+	digest := sha256.Sum256(fakeBinary)
+
+	t.Run("argument defaults with SRI hash", func(t *testing.T) {
+		t.Parallel()
+
+		input := "{ pkgs, version ? \"0.5.0\", binHash ? \"sha256-AAAA\" }:\n"
+
+		out, hash, err := upgrade.RewriteNixPin(input, "v0.6.0", digest[:])
+		require.NoError(t, err)
+
+		wantHash := "sha256-" + base64.StdEncoding.EncodeToString(digest[:])
+		assert.Equal(t, wantHash, hash)
+		assert.Contains(t, out, `version ? "0.6.0"`)
+		assert.Contains(t, out, `binHash ? "`+wantHash+`"`)
+	})
+
+	t.Run("bare hex hash", func(t *testing.T) {
+		t.Parallel()
+
+		input := "  version = \"0.5.0\";\n  sha256 = \"abc123\";\n"
+
+		_, hash, err := upgrade.RewriteNixPin(input, "v0.6.0", digest[:])
+		require.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(digest[:]), hash)
+	})
+
+	t.Run("missing fields", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := upgrade.RewriteNixPin("{ pkgs }: {}\n", "v0.6.0", digest[:])
+		require.ErrorIs(t, err, upgrade.ErrVersionFieldNotFound)
+
+		_, _, err = upgrade.RewriteNixPin("  version = \"0.5.0\";\n", "v0.6.0", digest[:])
+		require.ErrorIs(t, err, upgrade.ErrHashFieldNotFound)
+	})
+}
+
+const sourceFixtureNix = `{ pkgs }:
+
+pkgs.buildGoModule rec {
+  pname = "contextvibes";
+  version = "0.5.0";
+
+  src = pkgs.fetchFromGitHub {
+    owner = "contextvibes";
+    repo = "cli";
+    rev = "v0.5.0";
+    hash = srcHash;
+  };
+
+  srcHash = "sha256-oldsource";
+  vendorHash = "sha256-oldvendor";
+}
+`
+
+func TestUpgradeNixFile_SourcePin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rewrites version and rev and resets hashes", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFixture(t, sourceFixtureNix)
+
+		result, err := newTestResolver(t).UpgradeNixFile(t.Context(), path, "0.7.1")
+		require.NoError(t, err)
+		assert.True(t, result.Changed)
+		assert.Equal(t, "0.5.0", result.PreviousVersion)
+		assert.Equal(t, "0.7.1", result.Version)
+		assert.Equal(t, []string{"srcHash", "vendorHash"}, result.PendingHashes)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `version = "0.7.1";`)
+		assert.Contains(t, string(content), `rev = "v0.7.1";`)
+		assert.Contains(t, string(content), `srcHash = "`+upgrade.FakeHash+`";`)
+		assert.Contains(t, string(content), `vendorHash = "`+upgrade.FakeHash+`";`)
+		assert.NotContains(t, string(content), "oldsource")
+	})
+
+	t.Run("same version leaves the hashes alone", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFixture(t, sourceFixtureNix)
+
+		result, err := newTestResolver(t).UpgradeNixFile(t.Context(), path, "v0.5.0")
+		require.NoError(t, err)
+		assert.False(t, result.Changed)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, sourceFixtureNix, string(content))
+	})
+
+	t.Run("v-prefixed version counts as up to date", func(t *testing.T) {
+		t.Parallel()
+
+		fixture := strings.Replace(sourceFixtureNix, `version = "0.5.0";`, `version = "v0.5.0";`, 1)
+		path := writeFixture(t, fixture)
+
+		result, err := newTestResolver(t).UpgradeNixFile(t.Context(), path, "0.5.0")
+		require.NoError(t, err)
+		assert.False(t, result.Changed)
+		assert.Empty(t, result.PendingHashes)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, fixture, string(content))
+	})
+}
+
+func TestRewriteNixSourcePin_InterpolatedRev(t *testing.T) {
+	t.Parallel()
+
+	input := "  version = \"0.5.0\";\n  rev = \"v${version}\";\n  vendorHash = \"sha256-old\";\n"
+
+	out, pending, err := upgrade.RewriteNixSourcePin(input, "v0.6.0")
+	require.NoError(t, err)
+	assert.Contains(t, out, `rev = "v${version}";`)
+	assert.Contains(t, out, `version = "0.6.0";`)
+	assert.Equal(t, []string{"vendorHash"}, pending)
+}
@@ -0,0 +1,102 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/exec"
+)
+
+// ErrCommandNotFound is returned by CommandStep.PreCheck when the command is not installed.
+var ErrCommandNotFound = errors.New("command not found")
+
+// CommandStep runs an external command and fails when it exits non-zero.
+// It lets workflows compose tool invocations without a bespoke step type.
+type CommandStep struct {
+	ExecClient *exec.ExecutorClient
+	// Desc is shown in the workflow plan; it defaults to the command line.
+	Desc    string
+	Dir     string
+	Command string
+	Args    []string
+	// CaptureOutput collects the command's output instead of streaming it. The
+	// output is kept in Output, shown through Presenter when one is set, and
+	// attached to the *CommandError returned on failure.
+	CaptureOutput bool
+	Presenter     PresenterInterface
+	// Output holds the combined stdout and stderr of the last captured run.
+	Output string
+}
+
+// CommandError reports a failed CommandStep together with the output it captured.
+type CommandError struct {
+	CommandLine string
+	Output      string
+	Err         error
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("'%s' failed: %v", e.CommandLine, e.Err)
+}
+
+func (e *CommandError) Unwrap() error { return e.Err }
+
+// Description returns the step description.
+func (s *CommandStep) Description() string {
+	if s.Desc != "" {
+		return s.Desc
+	}
+
+	return "Run: " + s.commandLine()
+}
+
+// PreCheck verifies the command is available.
+func (s *CommandStep) PreCheck(_ context.Context) error {
+	if !s.ExecClient.CommandExists(s.Command) {
+		return fmt.Errorf("%w: '%s'", ErrCommandNotFound, s.Command)
+	}
+
+	return nil
+}
+
+// Execute runs the command in Dir (the current directory when empty).
+func (s *CommandStep) Execute(ctx context.Context) error {
+	dir := s.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	if s.CaptureOutput {
+		return s.executeCaptured(ctx, dir)
+	}
+
+	err := s.ExecClient.Execute(ctx, dir, s.Command, s.Args...)
+	if err != nil {
+		return fmt.Errorf("'%s' failed: %w", s.commandLine(), err)
+	}
+
+	return nil
+}
+
+func (s *CommandStep) executeCaptured(ctx context.Context, dir string) error {
+	stdout, stderr, err := s.ExecClient.CaptureOutput(ctx, dir, s.Command, s.Args...)
+	s.Output = strings.TrimSpace(stdout + stderr)
+
+	if s.Presenter != nil && s.Output != "" {
+		for line := range strings.SplitSeq(s.Output, "\n") {
+			s.Presenter.Detail("%s", line)
+		}
+	}
+
+	if err != nil {
+		return &CommandError{CommandLine: s.commandLine(), Output: s.Output, Err: err}
+	}
+
+	return nil
+}
+
+func (s *CommandStep) commandLine() string {
+	return strings.TrimSpace(s.Command + " " + strings.Join(s.Args, " "))
+}
@@ -0,0 +1,110 @@
+// Package workflow_test contains tests for the workflow package.
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errExitStatus = errors.New("exit status 1")
+
+func TestCommandStep(t *testing.T) {
+	t.Parallel()
+
+	t.Run("passing command", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Recorded fields start empty.
+		mockExec := &exectest.Executor{}
+		//nolint:exhaustruct // Desc and Dir use their defaults.
+		step := &workflow.CommandStep{
+			ExecClient: exec.NewClient(mockExec),
+			Command:    "go",
+			Args:       []string{"vet", "./..."},
+		}
+
+		assert.Equal(t, "Run: go vet ./...", step.Description())
+		require.NoError(t, step.PreCheck(context.Background()))
+		require.NoError(t, step.Execute(context.Background()))
+		assert.Equal(t, []string{"go vet ./..."}, mockExec.Commands())
+
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		assert.Equal(t, wd, mockExec.LastCall().Dir, "the current directory is passed as an absolute path")
+	})
+
+	t.Run("failing command", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Only the failing command is scripted.
+		mockExec := &exectest.Executor{Responses: map[string]exectest.Response{
+			"false": {Stdout: "", Stderr: "", Err: errExitStatus},
+		}}
+		dir := t.TempDir()
+		//nolint:exhaustruct // Args are not needed.
+		step := &workflow.CommandStep{
+			ExecClient: exec.NewClient(mockExec),
+			Desc:       "Always fails",
+			Dir:        dir,
+			Command:    "false",
+		}
+
+		assert.Equal(t, "Always fails", step.Description())
+
+		err := step.Execute(context.Background())
+		require.ErrorIs(t, err, errExitStatus)
+		assert.Contains(t, err.Error(), "'false' failed")
+		assert.Equal(t, dir, mockExec.LastCall().Dir)
+	})
+
+	t.Run("captured output is shown and attached to the failure", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Only the failing command is scripted.
+		mockExec := &exectest.Executor{Responses: map[string]exectest.Response{
+			"false": {Stdout: "main.go:3: unused variable\nmain.go:7: missing return\n", Stderr: "", Err: errExitStatus},
+		}}
+		//nolint:exhaustruct // Recorded fields start empty.
+		presenter := &mockPresenter{}
+		//nolint:exhaustruct // Desc and Dir use their defaults.
+		step := &workflow.CommandStep{
+			ExecClient:    exec.NewClient(mockExec),
+			Command:       "false",
+			CaptureOutput: true,
+			Presenter:     presenter,
+		}
+
+		err := step.Execute(context.Background())
+
+		var cmdErr *workflow.CommandError
+		require.ErrorAs(t, err, &cmdErr)
+		require.ErrorIs(t, err, errExitStatus)
+		assert.Equal(t, "main.go:3: unused variable\nmain.go:7: missing return", cmdErr.Output)
+		assert.Equal(t, cmdErr.Output, step.Output)
+		assert.Equal(t, []string{
+			"detail: main.go:3: unused variable",
+			"detail: main.go:7: missing return",
+		}, presenter.messages)
+	})
+
+	t.Run("missing command fails the pre-check", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Only the command matters.
+		step := &workflow.CommandStep{
+			//nolint:exhaustruct // Only the missing command matters.
+			ExecClient: exec.NewClient(&exectest.Executor{Missing: map[string]bool{"missing": true}}),
+			Command:    "missing",
+		}
+
+		require.ErrorIs(t, step.PreCheck(context.Background()), workflow.ErrCommandNotFound)
+	})
+}
@@ -8,6 +8,14 @@ import (
 	"github.com/contextvibes/cli/internal/git"
 )
 
+// AdviseDetachedHead explains that no branch is checked out and how to get back
+// onto one. mainBranch is suggested as the branch to switch to.
+func AdviseDetachedHead(presenter PresenterInterface, mainBranch string) {
+	presenter.Error("HEAD is detached: you are not on any branch.")
+	presenter.Advice("Switch to a branch first, e.g. 'git switch %s'.", mainBranch)
+	presenter.Advice("To keep commits made while detached, create a branch for them: 'git switch -c <new-branch>'.")
+}
+
 // EnsureNotMainBranchStep ensures the user is NOT on the main branch.
 type EnsureNotMainBranchStep struct {
 	GitClient *git.GitClient
@@ -24,6 +32,10 @@ func (s *EnsureNotMainBranchStep) PreCheck(ctx context.Context) error {
 	mainBranch := s.GitClient.MainBranchName()
 
 	currentBranch, err := s.GitClient.GetCurrentBranchName(ctx)
+	if errors.Is(err, git.ErrDetachedHead) {
+		AdviseDetachedHead(s.Presenter, mainBranch)
+	}
+
 	if err != nil {
 		return fmt.Errorf("failed to get current branch: %w", err)
 	}
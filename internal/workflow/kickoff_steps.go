@@ -11,6 +11,16 @@ import (
 	"github.com/contextvibes/cli/internal/git"
 )
 
+// maxBranchSlugLength caps the title portion of a proposed branch name.
+const maxBranchSlugLength = 50
+
+//nolint:gochecknoglobals // Static regex compilation.
+var (
+	nonSlugChars      = regexp.MustCompile(`[^a-z0-9]+`)
+	repeatedHyphens   = regexp.MustCompile(`-{2,}`)
+	hyphensAroundPath = regexp.MustCompile(`-*/-*`)
+)
+
 // CheckOnMainBranchStep verifies that the current branch is the main branch.
 type CheckOnMainBranchStep struct {
 	GitClient *git.GitClient
@@ -34,6 +44,12 @@ func (s *CheckOnMainBranchStep) PreCheck(ctx context.Context) error {
 	mainBranch := s.GitClient.MainBranchName()
 
 	currentBranch, err := s.GitClient.GetCurrentBranchName(ctx)
+	if errors.Is(err, git.ErrDetachedHead) {
+		AdviseDetachedHead(s.Presenter, mainBranch)
+
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+
 	if err != nil {
 		s.Presenter.Error("Could not determine current branch: %v", err)
 
@@ -183,6 +199,43 @@ func (s *CreateAndPushBranchStep) Execute(ctx context.Context) error {
 	return nil
 }
 
+// SlugifyTitle converts a work item title into a lowercase, hyphen-separated slug
+// suitable for a branch name. Long titles are cut at a word boundary.
+func SlugifyTitle(title string) string {
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(slug) <= maxBranchSlugLength {
+		return slug
+	}
+
+	slug = slug[:maxBranchSlugLength]
+	if cut := strings.LastIndex(slug, "-"); cut > 0 {
+		slug = slug[:cut]
+	}
+
+	return strings.Trim(slug, "-")
+}
+
+// ExpandBranchNameTemplate builds a branch name from a template such as
+// "feature/{ticket}-{slug}". {ticket} is replaced with the ticket ID and {slug}
+// with the slugified title; separators left dangling by an empty placeholder are
+// dropped. An empty template falls back to config.DefaultBranchNameTemplate.
+// The result is not validated.
+func ExpandBranchNameTemplate(template, ticket, title string) string {
+	if template == "" {
+		template = config.DefaultBranchNameTemplate
+	}
+
+	name := strings.NewReplacer(
+		"{ticket}", ticket,
+		"{slug}", SlugifyTitle(title),
+	).Replace(template)
+
+	name = repeatedHyphens.ReplaceAllString(name, "-")
+	name = hyphensAroundPath.ReplaceAllString(name, "/")
+
+	return strings.Trim(name, "-")
+}
+
 // GetValidatedBranchName is a helper function that can be used by the command
 // before initializing the workflow. It's not a step itself.
 func GetValidatedBranchName(
@@ -0,0 +1,88 @@
+package workflow_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugifyTitle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{name: "simple", title: "Fix login redirect", want: "fix-login-redirect"},
+		{name: "punctuation", title: "Add `--yes` flag (again!)", want: "add-yes-flag-again"},
+		{name: "surrounding noise", title: "  [bug]: Crash on start...  ", want: "bug-crash-on-start"},
+		{name: "non ascii", title: "Café — menü", want: "caf-men"},
+		{name: "empty", title: "!!!", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, workflow.SlugifyTitle(tt.title))
+		})
+	}
+}
+
+func TestSlugifyTitle_Truncates(t *testing.T) {
+	t.Parallel()
+
+	slug := workflow.SlugifyTitle(strings.Repeat("refactor the presenter ", 10))
+
+	assert.LessOrEqual(t, len(slug), 50)
+	assert.False(t, strings.HasSuffix(slug, "-"))
+	assert.True(t, strings.HasPrefix(slug, "refactor-the-presenter-"))
+}
+
+func TestExpandBranchNameTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		ticket   string
+		title    string
+		want     string
+	}{
+		{name: "default template", template: "", ticket: "7", title: "Fix login redirect", want: "feature/ISSUE-7-fix-login-redirect"},
+		{name: "custom template", template: "fix/{ticket}-{slug}", ticket: "PROJ-12", title: "Crash on start", want: "fix/PROJ-12-crash-on-start"},
+		{name: "slug first", template: "docs/{slug}-{ticket}", ticket: "3", title: "Update README", want: "docs/update-readme-3"},
+		{name: "empty slug", template: "", ticket: "7", title: "???", want: "feature/ISSUE-7"},
+		{name: "empty ticket", template: "feature/{ticket}-{slug}", ticket: "", title: "Add tests", want: "feature/add-tests"},
+		{name: "unknown placeholder kept", template: "feature/{user}-{slug}", ticket: "1", title: "x", want: "feature/{user}-x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, workflow.ExpandBranchNameTemplate(tt.template, tt.ticket, tt.title))
+		})
+	}
+}
+
+func TestExpandBranchNameTemplate_Validation(t *testing.T) {
+	t.Parallel()
+
+	pattern := regexp.MustCompile(config.DefaultBranchNamePattern)
+
+	for _, title := range []string{"Fix login redirect", "", "???", strings.Repeat("x", 200)} {
+		name := workflow.ExpandBranchNameTemplate(config.DefaultBranchNameTemplate, "42", title)
+
+		assert.True(t, strings.HasPrefix(name, "feature/ISSUE-42"), name)
+		assert.Regexp(t, pattern, name)
+	}
+
+	// A template without a type prefix expands fine but must still fail validation.
+	name := workflow.ExpandBranchNameTemplate("{ticket}-{slug}", "42", "Fix login")
+	assert.Equal(t, "42-fix-login", name)
+	assert.NotRegexp(t, pattern, name)
+}
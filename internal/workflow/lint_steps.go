@@ -0,0 +1,58 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/contextvibes/cli/internal/lint"
+	"github.com/contextvibes/cli/internal/tools"
+)
+
+// ErrLintFindings is returned by lint steps when any file has findings.
+var ErrLintFindings = errors.New("lint findings")
+
+// YAMLLintStep lints every .yaml/.yml file under RootDir that is not git-ignored.
+type YAMLLintStep struct {
+	RootDir   string
+	Presenter PresenterInterface
+}
+
+// Description returns the step description.
+func (s *YAMLLintStep) Description() string {
+	return "Lint YAML files"
+}
+
+// PreCheck is a no-op; the linter is built in.
+func (s *YAMLLintStep) PreCheck(_ context.Context) error {
+	return nil
+}
+
+// Execute lints the files and reports each finding.
+func (s *YAMLLintStep) Execute(_ context.Context) error {
+	files, err := tools.FindFiles(s.RootDir, lint.YAMLExtensions...)
+	if err != nil {
+		return fmt.Errorf("failed to find YAML files: %w", err)
+	}
+
+	var findings []lint.Finding
+
+	for _, file := range files {
+		content, err := tools.ReadFileContent(file)
+		if err != nil {
+			return fmt.Errorf("failed to lint YAML: %w", err)
+		}
+
+		findings = append(findings, lint.LintYAML(file, content)...)
+	}
+
+	for _, finding := range findings {
+		s.Presenter.Detail("%s", finding)
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("%w: %d YAML issue(s) in %d file(s)", ErrLintFindings, len(findings), len(files))
+	}
+
+	return nil
+}
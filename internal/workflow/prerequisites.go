@@ -0,0 +1,28 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/exec"
+)
+
+// RequireCommands checks that every named command is installed. When some are
+// missing, it lists all of them at once, rather than stopping at the first,
+// and returns an error wrapping ErrCommandNotFound.
+func RequireCommands(presenter PresenterInterface, execClient *exec.ExecutorClient, names ...string) error {
+	missing := execClient.EnsureCommands(names...)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	presenter.Error("Required tools are not installed:")
+
+	for _, name := range missing {
+		presenter.Detail("- %s", name)
+	}
+
+	presenter.Advice("Install the missing tools and make sure they are in your PATH, then try again.")
+
+	return fmt.Errorf("%w: %s", ErrCommandNotFound, strings.Join(missing, ", "))
+}
@@ -0,0 +1,50 @@
+package workflow_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/ui"
+	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureCommands(t *testing.T) {
+	t.Parallel()
+
+	execClient := exec.NewClient(newMissingToolsExecutor())
+
+	missing := execClient.EnsureCommands("go", "missing-terraform", "git", "missing-pulumi", "missing-terraform")
+	assert.Equal(t, []string{"missing-terraform", "missing-pulumi"}, missing)
+	assert.False(t, execClient.CommandExistsAll("go", "missing-pulumi"))
+	assert.True(t, execClient.CommandExistsAll("go", "git"))
+	assert.Empty(t, execClient.EnsureCommands())
+}
+
+func TestRequireCommands(t *testing.T) {
+	t.Parallel()
+
+	execClient := exec.NewClient(newMissingToolsExecutor())
+	out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+	presenter := ui.NewPresenter(out, errOut)
+
+	require.NoError(t, workflow.RequireCommands(presenter, execClient, "go", "git"))
+	assert.Empty(t, errOut.String())
+
+	err := workflow.RequireCommands(presenter, execClient, "missing-terraform", "go", "missing-pulumi")
+	require.ErrorIs(t, err, workflow.ErrCommandNotFound)
+	require.ErrorContains(t, err, "missing-terraform, missing-pulumi")
+	assert.Contains(t, errOut.String(), "Required tools are not installed")
+	assert.Contains(t, out.String(), "- missing-terraform")
+	assert.Contains(t, out.String(), "- missing-pulumi")
+	assert.NotContains(t, out.String(), "- go")
+}
+
+// newMissingToolsExecutor reports missing-terraform and missing-pulumi as not installed.
+func newMissingToolsExecutor() *exectest.Executor {
+	//nolint:exhaustruct // Only installed tools matter.
+	return &exectest.Executor{Missing: map[string]bool{"missing-terraform": true, "missing-pulumi": true}}
+}
@@ -2,18 +2,71 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 )
 
 // Runner manages the execution of a series of workflow steps.
 type Runner struct {
-	presenter PresenterInterface
-	assumeYes bool
+	presenter       PresenterInterface
+	assumeYes       bool
+	continueOnError bool
+	verboseTiming   bool
+	timings         []stepTiming
 }
 
+// stepTiming records the outcome and duration of one executed step.
+type stepTiming struct {
+	description string
+	status      string
+	elapsed     time.Duration
+}
+
+// RunnerOption configures optional Runner behavior.
+type RunnerOption func(*Runner)
+
+// WithContinueOnError makes the Runner execute every step even after one fails,
+// returning all failures together at the end. It suits independent steps such as
+// running several linters. PreCheck failures still abort, and no rollbacks run.
+func WithContinueOnError() RunnerOption {
+	return func(r *Runner) { r.continueOnError = true }
+}
+
+// WithVerboseTiming, when enabled, prints each step's elapsed time as soon as it
+// finishes, in addition to the summary table that is always shown at the end.
+// Commands pass globals.Verbose, which is set by the global --verbose flag.
+func WithVerboseTiming(enabled bool) RunnerOption {
+	return func(r *Runner) { r.verboseTiming = enabled }
+}
+
+// StepError attributes an Execute failure to the step that produced it.
+type StepError struct {
+	Index       int
+	Description string
+	Err         error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %d (%s): %v", e.Index, e.Description, e.Err)
+}
+
+func (e *StepError) Unwrap() error { return e.Err }
+
 // NewRunner creates a new workflow runner.
-func NewRunner(presenter PresenterInterface, assumeYes bool) *Runner {
-	return &Runner{presenter: presenter, assumeYes: assumeYes}
+func NewRunner(presenter PresenterInterface, assumeYes bool, opts ...RunnerOption) *Runner {
+	runner := &Runner{
+		presenter:       presenter,
+		assumeYes:       assumeYes,
+		continueOnError: false,
+		verboseTiming:   false,
+		timings:         nil,
+	}
+	for _, opt := range opts {
+		opt(runner)
+	}
+
+	return runner
 }
 
 // Run executes the entire workflow.
@@ -30,12 +83,20 @@ func (r *Runner) Run(ctx context.Context, title string, steps ...Step) error {
 
 	r.presentPlan(steps)
 
-	err = r.confirmExecution()
+	confirmed, err := r.confirmExecution()
 	if err != nil {
 		return err
 	}
 
+	if !confirmed {
+		return nil
+	}
+
+	started := time.Now()
 	err = r.executeSteps(ctx, steps)
+
+	r.presentTimingSummary(time.Since(started))
+
 	if err != nil {
 		return err
 	}
@@ -71,44 +132,136 @@ func (r *Runner) presentPlan(steps []Step) {
 	r.presenter.Newline()
 }
 
-func (r *Runner) confirmExecution() error {
+// confirmExecution reports whether the user agreed to run the plan. Declining is
+// not an error; Run stops before executing any step.
+func (r *Runner) confirmExecution() (bool, error) {
 	if r.assumeYes {
 		r.presenter.Info("Confirmation bypassed via --yes flag.")
 		r.presenter.Newline()
 
-		return nil
+		return true, nil
 	}
 
 	confirmed, err := r.presenter.PromptForConfirmation("Proceed with this workflow?")
 	if err != nil {
-		return fmt.Errorf("confirmation prompt failed: %w", err)
+		return false, fmt.Errorf("confirmation prompt failed: %w", err)
 	}
 
 	if !confirmed {
 		r.presenter.Info("Workflow aborted by user.")
 
-		return nil // Return nil to stop without error
+		return false, nil
 	}
 
 	r.presenter.Newline()
 
-	return nil
+	return true, nil
 }
 
 func (r *Runner) executeSteps(ctx context.Context, steps []Step) error {
-	for _, step := range steps {
+	var failures []error
+
+	r.timings = r.timings[:0]
+
+	for i, step := range steps {
 		r.presenter.Step(step.Description())
 
+		started := time.Now()
 		err := step.Execute(ctx)
-		if err != nil {
-			// The step's Execute method is responsible for its own user-facing error message.
-			return fmt.Errorf("step execution failed: %w", err) // Abort on first failure.
+		r.recordTiming(step, err, time.Since(started))
+
+		if err == nil {
+			continue
+		}
+
+		// The step's Execute method is responsible for its own user-facing error message.
+		if r.continueOnError {
+			r.presenter.Warning("Step %d failed; continuing with the remaining steps.", i+1)
+
+			failures = append(failures, &StepError{Index: i + 1, Description: step.Description(), Err: err})
+
+			continue
 		}
+
+		r.rollback(ctx, steps[:i])
+
+		return fmt.Errorf("step execution failed: %w", err) // Abort on first failure.
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d steps failed: %w", len(failures), len(steps), errors.Join(failures...))
 	}
 
 	return nil
 }
 
+func (r *Runner) recordTiming(step Step, err error, elapsed time.Duration) {
+	status := "ok"
+	if err != nil {
+		status = "failed"
+	}
+
+	r.timings = append(r.timings, stepTiming{
+		description: step.Description(),
+		status:      status,
+		elapsed:     elapsed,
+	})
+
+	if r.verboseTiming {
+		r.presenter.Detail("%s %s in %s", step.Description(), status, formatElapsed(elapsed))
+	}
+}
+
+// presentTimingSummary prints one row per executed step and the total elapsed time.
+func (r *Runner) presentTimingSummary(total time.Duration) {
+	if len(r.timings) == 0 {
+		return
+	}
+
+	width := 0
+	for _, timing := range r.timings {
+		width = max(width, len(timing.description))
+	}
+
+	r.presenter.Newline()
+	r.presenter.Header("Step Timing")
+
+	for i, timing := range r.timings {
+		r.presenter.Detail(
+			"%2d. %-*s  %-6s  %8s",
+			i+1, width, timing.description, timing.status, formatElapsed(timing.elapsed),
+		)
+	}
+
+	r.presenter.Detail("    %-*s  %-6s  %8s", width, "Total", "", formatElapsed(total))
+}
+
+func formatElapsed(elapsed time.Duration) string {
+	if elapsed < time.Second {
+		return elapsed.Round(time.Millisecond).String()
+	}
+
+	return elapsed.Round(10 * time.Millisecond).String() //nolint:mnd // Centisecond precision.
+}
+
+// rollback undoes already-executed steps in reverse order. Rollback failures are
+// reported but do not stop the remaining rollbacks.
+func (r *Runner) rollback(ctx context.Context, executed []Step) {
+	for i := len(executed) - 1; i >= 0; i-- {
+		rollbacker, ok := executed[i].(Rollbacker)
+		if !ok {
+			continue
+		}
+
+		r.presenter.Info("Rolling back: %s", executed[i].Description())
+
+		err := rollbacker.Rollback(ctx)
+		if err != nil {
+			r.presenter.Warning("Rollback of '%s' failed: %v", executed[i].Description(), err)
+		}
+	}
+}
+
 func (r *Runner) checkStashAdvice(steps []Step) {
 	// ADDED: After success, check if a stash was made and provide advice.
 	for _, step := range steps {
@@ -0,0 +1,293 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPresenter records every message so tests can assert on the runner's output.
+type mockPresenter struct {
+	messages []string
+}
+
+func (p *mockPresenter) record(kind, format string, a ...any) {
+	p.messages = append(p.messages, kind+": "+fmt.Sprintf(format, a...))
+}
+
+func (p *mockPresenter) Error(format string, a ...any)   { p.record("error", format, a...) }
+func (p *mockPresenter) Warning(format string, a ...any) { p.record("warning", format, a...) }
+func (p *mockPresenter) Info(format string, a ...any)    { p.record("info", format, a...) }
+func (p *mockPresenter) Success(format string, a ...any) { p.record("success", format, a...) }
+func (p *mockPresenter) Detail(format string, a ...any)  { p.record("detail", format, a...) }
+func (p *mockPresenter) Step(format string, a ...any)    { p.record("step", format, a...) }
+func (p *mockPresenter) Header(format string, a ...any)  { p.record("header", format, a...) }
+func (p *mockPresenter) Summary(format string, a ...any) { p.record("summary", format, a...) }
+func (p *mockPresenter) Advice(format string, a ...any)  { p.record("advice", format, a...) }
+func (p *mockPresenter) Newline()                        {}
+
+func (p *mockPresenter) PromptForConfirmation(_ string) (bool, error) { return true, nil }
+
+func (p *mockPresenter) PromptForInput(_ string) (string, error) { return "", nil }
+
+// recordingStep appends its lifecycle events to a shared journal.
+type recordingStep struct {
+	name    string
+	journal *[]string
+	failErr error
+}
+
+func (s *recordingStep) Description() string { return s.name }
+
+func (s *recordingStep) PreCheck(_ context.Context) error { return nil }
+
+func (s *recordingStep) Execute(_ context.Context) error {
+	*s.journal = append(*s.journal, "execute "+s.name)
+
+	return s.failErr
+}
+
+// rollbackStep is a recordingStep that can be undone.
+type rollbackStep struct {
+	recordingStep
+
+	rollbackErr error
+}
+
+func (s *rollbackStep) Rollback(_ context.Context) error {
+	*s.journal = append(*s.journal, "rollback "+s.name)
+
+	return s.rollbackErr
+}
+
+var errStepFailed = errors.New("step failed")
+
+func TestRunner_Rollback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rolls back executed steps in reverse order", func(t *testing.T) {
+		t.Parallel()
+
+		var journal []string
+
+		steps := []workflow.Step{
+			&rollbackStep{recordingStep: recordingStep{name: "one", journal: &journal}},
+			&rollbackStep{recordingStep: recordingStep{name: "two", journal: &journal}},
+			&rollbackStep{recordingStep: recordingStep{name: "three", journal: &journal, failErr: errStepFailed}},
+			&rollbackStep{recordingStep: recordingStep{name: "four", journal: &journal}},
+		}
+
+		//nolint:exhaustruct // Recorded fields start empty.
+		runner := workflow.NewRunner(&mockPresenter{}, true)
+
+		err := runner.Run(context.Background(), "test", steps...)
+		require.ErrorIs(t, err, errStepFailed)
+
+		assert.Equal(t, []string{
+			"execute one",
+			"execute two",
+			"execute three",
+			"rollback two",
+			"rollback one",
+		}, journal)
+	})
+
+	t.Run("skips steps without rollback and continues past rollback failures", func(t *testing.T) {
+		t.Parallel()
+
+		var journal []string
+
+		rollbackErr := errors.New("cannot undo")
+		steps := []workflow.Step{
+			&rollbackStep{recordingStep: recordingStep{name: "one", journal: &journal}},
+			&recordingStep{name: "plain", journal: &journal},
+			&rollbackStep{recordingStep: recordingStep{name: "two", journal: &journal}, rollbackErr: rollbackErr},
+			&recordingStep{name: "boom", journal: &journal, failErr: errStepFailed},
+		}
+
+		//nolint:exhaustruct // Recorded fields start empty.
+		presenter := &mockPresenter{}
+
+		err := workflow.NewRunner(presenter, true).Run(context.Background(), "test", steps...)
+		require.ErrorIs(t, err, errStepFailed)
+
+		assert.Equal(t, []string{
+			"execute one",
+			"execute plain",
+			"execute two",
+			"execute boom",
+			"rollback two",
+			"rollback one",
+		}, journal)
+		assert.Contains(t, presenter.messages, "warning: Rollback of 'two' failed: cannot undo")
+	})
+
+	t.Run("no rollback on success", func(t *testing.T) {
+		t.Parallel()
+
+		var journal []string
+
+		steps := []workflow.Step{
+			&rollbackStep{recordingStep: recordingStep{name: "one", journal: &journal}},
+		}
+
+		//nolint:exhaustruct // Recorded fields start empty.
+		err := workflow.NewRunner(&mockPresenter{}, true).Run(context.Background(), "test", steps...)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"execute one"}, journal)
+	})
+}
+
+func TestRunner_ContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	newSteps := func(journal *[]string, lintErr, vetErr error) []workflow.Step {
+		return []workflow.Step{
+			&recordingStep{name: "lint", journal: journal, failErr: lintErr},
+			&rollbackStep{recordingStep: recordingStep{name: "fmt", journal: journal}},
+			&recordingStep{name: "vet", journal: journal, failErr: vetErr},
+			&recordingStep{name: "test", journal: journal},
+		}
+	}
+
+	t.Run("runs all steps and reports every failure", func(t *testing.T) {
+		t.Parallel()
+
+		var journal []string
+
+		lintErr := errors.New("lint failed")
+		vetErr := errors.New("vet failed")
+
+		//nolint:exhaustruct // Recorded fields start empty.
+		runner := workflow.NewRunner(&mockPresenter{}, true, workflow.WithContinueOnError())
+
+		err := runner.Run(context.Background(), "test", newSteps(&journal, lintErr, vetErr)...)
+		require.Error(t, err)
+
+		assert.Equal(t, []string{"execute lint", "execute fmt", "execute vet", "execute test"}, journal)
+		require.ErrorIs(t, err, lintErr)
+		require.ErrorIs(t, err, vetErr)
+		assert.Contains(t, err.Error(), "2 of 4 steps failed")
+		assert.Contains(t, err.Error(), "step 1 (lint): lint failed")
+		assert.Contains(t, err.Error(), "step 3 (vet): vet failed")
+
+		var stepErr *workflow.StepError
+		require.ErrorAs(t, err, &stepErr)
+		assert.Equal(t, 1, stepErr.Index)
+	})
+
+	t.Run("stops at the first failure by default", func(t *testing.T) {
+		t.Parallel()
+
+		var journal []string
+
+		//nolint:exhaustruct // Recorded fields start empty.
+		runner := workflow.NewRunner(&mockPresenter{}, true)
+
+		err := runner.Run(context.Background(), "test", newSteps(&journal, nil, errStepFailed)...)
+		require.ErrorIs(t, err, errStepFailed)
+		assert.Equal(t, []string{"execute lint", "execute fmt", "execute vet", "rollback fmt"}, journal)
+	})
+
+	t.Run("pre-check failures still abort", func(t *testing.T) {
+		t.Parallel()
+
+		var journal []string
+
+		steps := []workflow.Step{
+			&recordingStep{name: "lint", journal: &journal},
+			&failingPreCheckStep{},
+		}
+
+		//nolint:exhaustruct // Recorded fields start empty.
+		runner := workflow.NewRunner(&mockPresenter{}, true, workflow.WithContinueOnError())
+
+		err := runner.Run(context.Background(), "test", steps...)
+		require.ErrorIs(t, err, errStepFailed)
+		assert.Empty(t, journal)
+	})
+}
+
+type failingPreCheckStep struct{}
+
+func (s *failingPreCheckStep) Description() string { return "broken" }
+
+func (s *failingPreCheckStep) PreCheck(_ context.Context) error { return errStepFailed }
+
+func (s *failingPreCheckStep) Execute(_ context.Context) error { return nil }
+
+func TestRunner_TimingSummary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("one row per executed step plus total", func(t *testing.T) {
+		t.Parallel()
+
+		var journal []string
+
+		steps := []workflow.Step{
+			&recordingStep{name: "lint", journal: &journal},
+			&recordingStep{name: "vet", journal: &journal, failErr: errStepFailed},
+			&recordingStep{name: "never runs", journal: &journal},
+		}
+
+		//nolint:exhaustruct // Recorded fields start empty.
+		presenter := &mockPresenter{}
+
+		err := workflow.NewRunner(presenter, true).Run(context.Background(), "test", steps...)
+		require.Error(t, err)
+
+		rows := timingRows(presenter.messages)
+		require.Len(t, rows, 3, "two executed steps plus the total")
+		assert.Regexp(t, `^detail:  1\. lint\s+ok\s+\S+$`, rows[0])
+		assert.Regexp(t, `^detail:  2\. vet\s+failed\s+\S+$`, rows[1])
+		assert.Regexp(t, `^detail:     Total\s+\S+$`, rows[2])
+	})
+
+	t.Run("verbose timing reports each step as it finishes", func(t *testing.T) {
+		t.Parallel()
+
+		var journal []string
+
+		//nolint:exhaustruct // Recorded fields start empty.
+		presenter := &mockPresenter{}
+		runner := workflow.NewRunner(presenter, true, workflow.WithVerboseTiming(true))
+
+		require.NoError(t, runner.Run(context.Background(), "test", &recordingStep{name: "lint", journal: &journal}))
+
+		found := false
+		for _, message := range presenter.messages {
+			if strings.HasPrefix(message, "detail: lint ok in ") {
+				found = true
+			}
+		}
+
+		assert.True(t, found, "expected a per-step timing line, got %v", presenter.messages)
+	})
+}
+
+// timingRows returns the messages that follow the timing summary header.
+func timingRows(messages []string) []string {
+	for i, message := range messages {
+		if message == "header: Step Timing" {
+			rows := []string{}
+
+			for _, row := range messages[i+1:] {
+				if !strings.HasPrefix(row, "detail: ") {
+					break
+				}
+
+				rows = append(rows, row)
+			}
+
+			return rows
+		}
+	}
+
+	return nil
+}
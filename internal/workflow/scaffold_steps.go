@@ -0,0 +1,111 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/contextvibes/cli/internal/scaffold"
+)
+
+// ScaffoldStep writes the embedded files of a scaffold target into the repository.
+// Existing files are only overwritten after confirmation (or with AssumeYes).
+// Only and Skip restrict which of the target's files are written. Templates are
+// overridden by same-named files in the repository's scaffold.OverridesDir.
+type ScaffoldStep struct {
+	Target    string
+	RootDir   string
+	Only      []string
+	Skip      []string
+	Presenter PresenterInterface
+	AssumeYes bool
+}
+
+// Description returns the step description.
+func (s *ScaffoldStep) Description() string {
+	return fmt.Sprintf("Scaffold '%s' configuration files", s.Target)
+}
+
+// PreCheck verifies the target exists, its overrides are readable and the file
+// selection names its files.
+func (s *ScaffoldStep) PreCheck(_ context.Context) error {
+	_, err := s.files()
+	if err != nil {
+		s.Presenter.Error("%v", err)
+
+		return fmt.Errorf("invalid scaffold target: %w", err)
+	}
+
+	return nil
+}
+
+// Execute writes the selected files of the target.
+func (s *ScaffoldStep) Execute(_ context.Context) error {
+	files, err := s.files()
+	if err != nil {
+		return fmt.Errorf("failed to load scaffold templates: %w", err)
+	}
+
+	for _, file := range files {
+		err := s.writeFile(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ScaffoldStep) files() ([]scaffold.File, error) {
+	files, err := scaffold.Files(s.Target)
+	if err != nil {
+		//nolint:wrapcheck // Scaffold errors already name the target.
+		return nil, err
+	}
+
+	files, err = scaffold.ApplyOverrides(files, s.RootDir, s.Target)
+	if err != nil {
+		//nolint:wrapcheck // Override errors already name the file.
+		return nil, err
+	}
+
+	//nolint:wrapcheck // Selection errors already list the available files.
+	return scaffold.SelectFiles(files, s.Only, s.Skip)
+}
+
+func (s *ScaffoldStep) writeFile(file scaffold.File) error {
+	target := filepath.Join(s.RootDir, filepath.FromSlash(file.Path))
+
+	_, err := os.Stat(target)
+	if err == nil && !s.AssumeYes {
+		confirmed, err := s.Presenter.PromptForConfirmation(
+			fmt.Sprintf("%s already exists. Overwrite?", file.Path),
+		)
+		if err != nil {
+			return fmt.Errorf("confirmation prompt failed: %w", err)
+		}
+
+		if !confirmed {
+			s.Presenter.Info("Preserved existing %s.", file.Path)
+
+			return nil
+		}
+	}
+
+	//nolint:mnd // 0750 is standard directory permission.
+	err = os.MkdirAll(filepath.Dir(target), 0o750)
+	if err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+	}
+
+	//nolint:mnd // 0644 is standard for project config files.
+	err = os.WriteFile(target, file.Content, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", file.Path, err)
+	}
+
+	s.Presenter.Success("✓ Wrote %s (from %s)", file.Path, file.Source)
+
+	return nil
+}
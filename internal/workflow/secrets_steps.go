@@ -0,0 +1,58 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/contextvibes/cli/internal/exec"
+)
+
+// ErrSecretsDetected is returned when the secrets scanner reports findings in the staged changes.
+var ErrSecretsDetected = errors.New("potential secrets detected in staged changes")
+
+const gitleaksCommand = "gitleaks"
+
+// SecretsScanStep runs `gitleaks protect --staged` and fails when it reports
+// findings. The scan is skipped with a warning when gitleaks is not installed.
+type SecretsScanStep struct {
+	ExecClient *exec.ExecutorClient
+	Presenter  PresenterInterface
+	// Dir is the repository to scan; it defaults to the current directory.
+	Dir string
+}
+
+// Description returns a description of the step.
+func (s *SecretsScanStep) Description() string {
+	return "Scan staged changes for secrets (gitleaks)"
+}
+
+// PreCheck performs pre-execution checks.
+func (s *SecretsScanStep) PreCheck(_ context.Context) error { return nil }
+
+// Execute runs the scan. Findings are redacted in gitleaks' own output.
+func (s *SecretsScanStep) Execute(ctx context.Context) error {
+	if !s.ExecClient.CommandExists(gitleaksCommand) {
+		s.Presenter.Warning("Skipping secrets scan: '%s' is not installed.", gitleaksCommand)
+
+		return nil
+	}
+
+	dir := s.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	err := s.ExecClient.Execute(ctx, dir, gitleaksCommand, "protect", "--staged", "--redact", "--verbose")
+	if err != nil {
+		s.Presenter.Error("gitleaks found potential secrets in the staged changes.")
+		s.Presenter.Advice("Unstage the affected files with 'git restore --staged <file>' and remove the secrets.")
+		s.Presenter.Advice("Rotate any credential that was ever pushed; add known false positives to .gitleaksignore.")
+
+		return fmt.Errorf("%w: %w", ErrSecretsDetected, err)
+	}
+
+	s.Presenter.Success("No secrets found in the staged changes.")
+
+	return nil
+}
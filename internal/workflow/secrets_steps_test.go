@@ -0,0 +1,64 @@
+package workflow_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsScanStep(t *testing.T) {
+	t.Parallel()
+
+	t.Run("clean scan passes", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Recorded fields start empty.
+		mockExec := &exectest.Executor{}
+		presenter := &mockPresenter{}
+		//nolint:exhaustruct // Dir defaults to the current directory.
+		step := &workflow.SecretsScanStep{ExecClient: exec.NewClient(mockExec), Presenter: presenter}
+
+		require.NoError(t, step.Execute(context.Background()))
+		assert.Equal(t, []string{"gitleaks protect --staged --redact --verbose"}, mockExec.Commands())
+
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		assert.Equal(t, wd, mockExec.LastCall().Dir)
+	})
+
+	t.Run("findings abort with advice", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Only the scan is scripted.
+		mockExec := &exectest.Executor{Responses: map[string]exectest.Response{
+			"gitleaks protect --staged --redact --verbose": {Stdout: "", Stderr: "", Err: errExitStatus},
+		}}
+		presenter := &mockPresenter{}
+		step := &workflow.SecretsScanStep{ExecClient: exec.NewClient(mockExec), Presenter: presenter, Dir: t.TempDir()}
+
+		err := step.Execute(context.Background())
+		require.ErrorIs(t, err, workflow.ErrSecretsDetected)
+		require.ErrorIs(t, err, errExitStatus)
+		assert.Contains(t, presenter.messages, "error: gitleaks found potential secrets in the staged changes.")
+	})
+
+	t.Run("missing gitleaks is skipped", func(t *testing.T) {
+		t.Parallel()
+
+		//nolint:exhaustruct // Only the missing command matters.
+		mockExec := &exectest.Executor{Missing: map[string]bool{"gitleaks": true}}
+		presenter := &mockPresenter{}
+		//nolint:exhaustruct // Dir defaults to the current directory.
+		step := &workflow.SecretsScanStep{ExecClient: exec.NewClient(mockExec), Presenter: presenter}
+
+		require.NoError(t, step.Execute(context.Background()))
+		assert.Empty(t, mockExec.Commands())
+		assert.Contains(t, presenter.messages, "warning: Skipping secrets scan: 'gitleaks' is not installed.")
+	})
+}
@@ -0,0 +1,360 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/git"
+)
+
+//go:embed assets/squash_prompt.md.tpl
+var squashPromptTemplate string
+
+var (
+	// ErrNothingToSquash is returned when the branch has fewer than two commits on top of its base.
+	ErrNothingToSquash = errors.New("nothing to squash")
+	// ErrSquashBaseNotFound is returned when the requested base ref does not exist.
+	ErrSquashBaseNotFound = errors.New("squash base not found")
+	// ErrEmptySquashMessage is returned when no commit message is provided for the squash.
+	ErrEmptySquashMessage = errors.New("squash commit message cannot be empty")
+)
+
+// squashPromptFile is where GenerateSquashPromptStep writes the AI prompt.
+const squashPromptFile = "_contextvibes.md"
+
+// SquashState carries data between the squash steps.
+type SquashState struct {
+	// TargetBase is the ref the branch is squashed onto. When empty it defaults to
+	// <remote>/<main>; AnalyzeBranchStep replaces it with the resolved ref.
+	TargetBase  string
+	Branch      string
+	MergeBase   string
+	CommitCount int
+}
+
+// AnalyzeBranchStep resolves the squash base and counts the commits to squash.
+type AnalyzeBranchStep struct {
+	GitClient *git.GitClient
+	Presenter PresenterInterface
+	State     *SquashState
+}
+
+// Description returns a description of the step.
+func (s *AnalyzeBranchStep) Description() string {
+	if s.State.TargetBase == "" {
+		return "Find the commits to squash since the main branch"
+	}
+
+	return fmt.Sprintf("Find the commits to squash since '%s'", s.State.TargetBase)
+}
+
+// PreCheck validates the base ref and that the working directory is clean.
+func (s *AnalyzeBranchStep) PreCheck(ctx context.Context) error {
+	base, err := s.resolveBase(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.State.TargetBase = base
+
+	clean, err := s.GitClient.IsWorkingDirClean(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check working directory: %w", err)
+	}
+
+	if !clean {
+		s.Presenter.Error("The working directory has uncommitted changes.")
+		s.Presenter.Advice("Commit or stash them first so they are not folded into the squash commit.")
+		//nolint:err113 // Dynamic error is appropriate here.
+		return errors.New("working directory is not clean")
+	}
+
+	return nil
+}
+
+// Execute computes the merge base and the number of commits on the branch.
+func (s *AnalyzeBranchStep) Execute(ctx context.Context) error {
+	branch, err := s.GitClient.GetCurrentBranchName(ctx)
+	if errors.Is(err, git.ErrDetachedHead) {
+		AdviseDetachedHead(s.Presenter, s.GitClient.MainBranchName())
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	mergeBase, err := s.GitClient.GetMergeBase(ctx, s.State.TargetBase)
+	if err != nil {
+		//nolint:wrapcheck // Git client errors are already descriptive.
+		return err
+	}
+
+	count, err := s.GitClient.GetCommitCount(ctx, mergeBase)
+	if err != nil {
+		//nolint:wrapcheck // Git client errors are already descriptive.
+		return err
+	}
+
+	s.State.Branch = branch
+	s.State.MergeBase = mergeBase
+	s.State.CommitCount = count
+
+	//nolint:mnd // A squash needs at least two commits.
+	if count < 2 {
+		s.Presenter.Info("'%s' has %d commit(s) since '%s'; there is nothing to squash.", branch, count, s.State.TargetBase)
+
+		return fmt.Errorf("%w: %d commit(s) since '%s'", ErrNothingToSquash, count, s.State.TargetBase)
+	}
+
+	s.Presenter.Info("Squashing %d commits on '%s' onto %s (merge base %s).",
+		count, branch, s.State.TargetBase, shortHash(mergeBase))
+
+	return nil
+}
+
+// resolveBase returns the ref to squash onto: the requested one (also tried as
+// <remote>/<ref>) or <remote>/<main> by default.
+func (s *AnalyzeBranchStep) resolveBase(ctx context.Context) (string, error) {
+	remote := s.GitClient.RemoteName()
+
+	candidates := []string{remote + "/" + s.GitClient.MainBranchName()}
+	if s.State.TargetBase != "" {
+		candidates = []string{s.State.TargetBase}
+		if !strings.HasPrefix(s.State.TargetBase, remote+"/") {
+			candidates = append(candidates, remote+"/"+s.State.TargetBase)
+		}
+	}
+
+	for _, candidate := range candidates {
+		exists, err := s.GitClient.RefExists(ctx, candidate)
+		if err != nil {
+			//nolint:wrapcheck // Git client errors are already descriptive.
+			return "", err
+		}
+
+		if exists {
+			return candidate, nil
+		}
+	}
+
+	s.Presenter.Error("Cannot squash onto '%s': the ref does not exist.", candidates[0])
+	s.Presenter.Advice("Run 'git fetch %s' or check the branch name.", remote)
+
+	return "", fmt.Errorf("%w: '%s'", ErrSquashBaseNotFound, candidates[0])
+}
+
+// GenerateSquashPromptStep writes an AI prompt summarizing the commits being squashed.
+type GenerateSquashPromptStep struct {
+	GitClient *git.GitClient
+	Presenter PresenterInterface
+	State     *SquashState
+	// Skip disables prompt generation for users who already have a message in mind.
+	Skip bool
+}
+
+type squashPromptData struct {
+	Branch      string
+	TargetBase  string
+	CommitCount int
+	Log         string
+	Diff        string
+}
+
+// Description returns a description of the step.
+func (s *GenerateSquashPromptStep) Description() string {
+	return "Generate AI prompt for the squash commit message"
+}
+
+// PreCheck performs pre-execution checks.
+func (s *GenerateSquashPromptStep) PreCheck(_ context.Context) error { return nil }
+
+// Execute writes the prompt file.
+func (s *GenerateSquashPromptStep) Execute(ctx context.Context) error {
+	if s.Skip {
+		s.Presenter.Info("Skipping prompt generation.")
+
+		return nil
+	}
+
+	log, diff, err := s.GitClient.GetLogAndDiffFromMergeBase(ctx, s.State.TargetBase)
+	if err != nil {
+		//nolint:wrapcheck // Git client errors are already descriptive.
+		return err
+	}
+
+	tmpl, err := template.New("squash_prompt").Parse(squashPromptTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse prompt template: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = tmpl.Execute(&buf, squashPromptData{
+		Branch:      s.State.Branch,
+		TargetBase:  s.State.TargetBase,
+		CommitCount: s.State.CommitCount,
+		Log:         log,
+		Diff:        diff,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	err = os.WriteFile(squashPromptFile, buf.Bytes(), filePermUserRW)
+	if err != nil {
+		return fmt.Errorf("failed to write prompt to %s: %w", squashPromptFile, err)
+	}
+
+	s.Presenter.Success("Prompt generated: %s", squashPromptFile)
+	s.Presenter.Info("Pass this file to your AI to draft the squash commit message.")
+
+	return nil
+}
+
+// CommitSquashStep asks for the commit message, then replaces the branch's commits
+// with a single commit on top of the merge base.
+type CommitSquashStep struct {
+	GitClient *git.GitClient
+	Presenter PresenterInterface
+	State     *SquashState
+	// Message is used as-is when set; otherwise the user is prompted for one.
+	Message string
+	// Validation is the commit message rule applied to the squash message.
+	Validation config.ValidationRule
+	// AssumeYes turns a rejected message into an error instead of a new prompt.
+	AssumeYes bool
+}
+
+// Description returns a description of the step.
+func (s *CommitSquashStep) Description() string {
+	return "Squash the branch into a single commit"
+}
+
+// PreCheck performs pre-execution checks.
+func (s *CommitSquashStep) PreCheck(_ context.Context) error { return nil }
+
+// Execute soft-resets to the merge base and commits the staged result.
+func (s *CommitSquashStep) Execute(ctx context.Context) error {
+	message, err := s.resolveMessage()
+	if err != nil {
+		return err
+	}
+
+	err = s.GitClient.ResetSoft(ctx, s.State.MergeBase)
+	if err != nil {
+		//nolint:wrapcheck // Git client errors are already descriptive.
+		return err
+	}
+
+	err = s.GitClient.Commit(ctx, message)
+	if err != nil {
+		s.Presenter.Error("Commit failed after the reset; your changes are still staged.")
+		s.Presenter.Advice("Use 'git reset --soft ORIG_HEAD' to restore the original commits.")
+
+		return fmt.Errorf("failed to commit squash: %w", err)
+	}
+
+	s.Presenter.Success("Squashed %d commits into one.", s.State.CommitCount)
+
+	return nil
+}
+
+// resolveMessage returns a message that passes the commit message rule. Messages
+// are taken from Message first, then prompted for; a rejected message is
+// re-prompted interactively and is an error with AssumeYes.
+func (s *CommitSquashStep) resolveMessage() (string, error) {
+	message := strings.TrimSpace(s.Message)
+
+	for {
+		if message == "" {
+			answer, err := s.Presenter.PromptForInput("Squash commit message:")
+			if err != nil {
+				return "", fmt.Errorf("failed to read commit message: %w", err)
+			}
+
+			message = strings.TrimSpace(answer)
+			if message == "" {
+				return "", ErrEmptySquashMessage
+			}
+		}
+
+		err := config.ValidateCommitMessage(s.Validation, message)
+		if err == nil {
+			return message, nil
+		}
+
+		if !errors.Is(err, config.ErrInvalidCommitMessage) || s.AssumeYes {
+			s.Presenter.Error("Invalid squash commit message: %v", err)
+
+			//nolint:wrapcheck // Validation errors are already descriptive.
+			return "", err
+		}
+
+		s.Presenter.Warning(
+			"Subject must match pattern: %s",
+			s.Validation.PatternOrDefault(config.DefaultCommitMessagePattern),
+		)
+
+		message = ""
+	}
+}
+
+// ForcePushSquashStep publishes the rewritten branch with --force-with-lease.
+type ForcePushSquashStep struct {
+	GitClient *git.GitClient
+	Presenter PresenterInterface
+	State     *SquashState
+	AssumeYes bool
+}
+
+// Description returns a description of the step.
+func (s *ForcePushSquashStep) Description() string {
+	return "Force-push the squashed branch (--force-with-lease)"
+}
+
+// PreCheck performs pre-execution checks.
+func (s *ForcePushSquashStep) PreCheck(_ context.Context) error { return nil }
+
+// Execute pushes after confirmation (or with AssumeYes).
+func (s *ForcePushSquashStep) Execute(ctx context.Context) error {
+	if !s.AssumeYes {
+		confirmed, err := s.Presenter.PromptForConfirmation(
+			fmt.Sprintf("Force-push '%s' to %s now?", s.State.Branch, s.GitClient.RemoteName()),
+		)
+		if err != nil {
+			return fmt.Errorf("confirmation failed: %w", err)
+		}
+
+		if !confirmed {
+			s.Presenter.Info("Skipped push. Run 'git push --force-with-lease' when ready.")
+
+			return nil
+		}
+	}
+
+	err := s.GitClient.ForcePushLease(ctx, s.State.Branch)
+	if err != nil {
+		//nolint:wrapcheck // Git client errors are already descriptive.
+		return err
+	}
+
+	s.Presenter.Success("Pushed '%s'.", s.State.Branch)
+
+	return nil
+}
+
+func shortHash(hash string) string {
+	//nolint:mnd // Seven characters is git's default abbreviation.
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+
+	return hash
+}
@@ -0,0 +1,264 @@
+package workflow_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/config"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/git"
+	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSquashGitClient(t *testing.T, responses map[string]exectest.Response) (*git.GitClient, *exectest.Executor) {
+	t.Helper()
+
+	//nolint:exhaustruct // Recorded fields start empty.
+	executor := &exectest.Executor{RepoDir: "/repo", Responses: responses}
+
+	//nolint:exhaustruct // Defaults (origin/main) are what the tests expect.
+	client, err := git.NewClient(context.Background(), "/repo", git.GitClientConfig{Executor: executor})
+	require.NoError(t, err)
+
+	return client, executor
+}
+
+// missingRef is how `git rev-parse --verify --quiet` reports an unknown ref.
+var missingRef = exectest.Response{Stdout: "", Stderr: "", Err: errExitStatus}
+
+func TestAnalyzeBranchStep(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults to the remote main branch", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newSquashGitClient(t, map[string]exectest.Response{
+			"git rev-parse --abbrev-ref HEAD":        {Stdout: "feature/x\n", Stderr: "", Err: nil},
+			"git merge-base origin/main HEAD":        {Stdout: "1111111aaaa\n", Stderr: "", Err: nil},
+			"git rev-list --count 1111111aaaa..HEAD": {Stdout: "3\n", Stderr: "", Err: nil},
+		})
+		//nolint:exhaustruct // Filled in by the step.
+		state := &workflow.SquashState{}
+		step := &workflow.AnalyzeBranchStep{GitClient: client, Presenter: &mockPresenter{}, State: state}
+
+		require.NoError(t, step.PreCheck(context.Background()))
+		require.NoError(t, step.Execute(context.Background()))
+
+		assert.Equal(t, "origin/main", state.TargetBase)
+		assert.Equal(t, "1111111aaaa", state.MergeBase)
+		assert.Equal(t, 3, state.CommitCount)
+		assert.Contains(t, executor.Commands(), "git merge-base origin/main HEAD")
+	})
+
+	t.Run("uses a non-main base for the merge base", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newSquashGitClient(t, map[string]exectest.Response{
+			"git rev-parse --verify --quiet release/1.4^{commit}": missingRef,
+			"git rev-parse --abbrev-ref HEAD":                     {Stdout: "fix/y\n", Stderr: "", Err: nil},
+			"git merge-base origin/release/1.4 HEAD":              {Stdout: "2222222bbbb\n", Stderr: "", Err: nil},
+			"git rev-list --count 2222222bbbb..HEAD":              {Stdout: "2\n", Stderr: "", Err: nil},
+		})
+		//nolint:exhaustruct // Filled in by the step.
+		state := &workflow.SquashState{TargetBase: "release/1.4"}
+		step := &workflow.AnalyzeBranchStep{GitClient: client, Presenter: &mockPresenter{}, State: state}
+
+		require.NoError(t, step.PreCheck(context.Background()))
+		require.NoError(t, step.Execute(context.Background()))
+
+		assert.Equal(t, "origin/release/1.4", state.TargetBase)
+		assert.Equal(t, "2222222bbbb", state.MergeBase)
+		assert.Contains(t, executor.Commands(), "git merge-base origin/release/1.4 HEAD")
+		assert.NotContains(t, executor.Commands(), "git merge-base origin/main HEAD")
+	})
+
+	t.Run("unknown base is rejected before anything runs", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newSquashGitClient(t, map[string]exectest.Response{
+			"git rev-parse --verify --quiet nope^{commit}":        missingRef,
+			"git rev-parse --verify --quiet origin/nope^{commit}": missingRef,
+		})
+		//nolint:exhaustruct // Filled in by the step.
+		state := &workflow.SquashState{TargetBase: "nope"}
+		step := &workflow.AnalyzeBranchStep{GitClient: client, Presenter: &mockPresenter{}, State: state}
+
+		require.ErrorIs(t, step.PreCheck(context.Background()), workflow.ErrSquashBaseNotFound)
+	})
+
+	t.Run("a single commit is nothing to squash", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newSquashGitClient(t, map[string]exectest.Response{
+			"git rev-parse --abbrev-ref HEAD":    {Stdout: "feature/x\n", Stderr: "", Err: nil},
+			"git merge-base origin/main HEAD":    {Stdout: "3333333\n", Stderr: "", Err: nil},
+			"git rev-list --count 3333333..HEAD": {Stdout: "1\n", Stderr: "", Err: nil},
+		})
+		//nolint:exhaustruct // Filled in by the step.
+		state := &workflow.SquashState{}
+		step := &workflow.AnalyzeBranchStep{GitClient: client, Presenter: &mockPresenter{}, State: state}
+
+		require.NoError(t, step.PreCheck(context.Background()))
+		require.ErrorIs(t, step.Execute(context.Background()), workflow.ErrNothingToSquash)
+	})
+}
+
+// noPromptPresenter fails any attempt to ask the user for input.
+type noPromptPresenter struct {
+	mockPresenter
+}
+
+func (p *noPromptPresenter) PromptForConfirmation(question string) (bool, error) {
+	return false, fmt.Errorf("unexpected confirmation prompt: %s", question) //nolint:err113 // Test-only error.
+}
+
+func (p *noPromptPresenter) PromptForInput(prompt string) (string, error) {
+	return "", fmt.Errorf("unexpected input prompt: %s", prompt) //nolint:err113 // Test-only error.
+}
+
+func TestGenerateSquashPromptStep_Skip(t *testing.T) {
+	t.Parallel()
+
+	client, executor := newSquashGitClient(t, nil)
+	presenter := &mockPresenter{}
+
+	step := &workflow.GenerateSquashPromptStep{
+		GitClient: client,
+		Presenter: presenter,
+		State:     &workflow.SquashState{TargetBase: "origin/main", Branch: "feature/x", MergeBase: "abc", CommitCount: 2},
+		Skip:      true,
+	}
+
+	require.NoError(t, step.Execute(context.Background()))
+	assert.Empty(t, executor.Commands(), "skipping the prompt must not read the log or diff")
+	assert.Contains(t, presenter.messages, "info: Skipping prompt generation.")
+}
+
+func TestSquash_MessageWithAssumeYes(t *testing.T) {
+	t.Parallel()
+
+	client, executor := newSquashGitClient(t, nil)
+	presenter := &noPromptPresenter{}
+	state := &workflow.SquashState{
+		TargetBase:  "origin/main",
+		Branch:      "feature/x",
+		MergeBase:   "1111111aaaa",
+		CommitCount: 3,
+	}
+
+	err := workflow.NewRunner(presenter, true).Run(
+		context.Background(),
+		"squash",
+		&workflow.GenerateSquashPromptStep{GitClient: client, Presenter: presenter, State: state, Skip: true},
+		&workflow.CommitSquashStep{GitClient: client, Presenter: presenter, State: state, Message: "feat: Squash it"},
+		&workflow.ForcePushSquashStep{GitClient: client, Presenter: presenter, State: state, AssumeYes: true},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"git reset --soft 1111111aaaa",
+		"git commit -m feat: Squash it",
+		"git push --force-with-lease origin feature/x",
+	}, executor.Commands())
+}
+
+// scriptedPresenter answers input prompts from a queue.
+type scriptedPresenter struct {
+	mockPresenter
+
+	answers []string
+	prompts int
+}
+
+func (p *scriptedPresenter) PromptForInput(_ string) (string, error) {
+	p.prompts++
+
+	if len(p.answers) == 0 {
+		return "", nil
+	}
+
+	answer := p.answers[0]
+	p.answers = p.answers[1:]
+
+	return answer, nil
+}
+
+func TestCommitSquashStep_Validation(t *testing.T) {
+	t.Parallel()
+
+	newState := func() *workflow.SquashState {
+		return &workflow.SquashState{TargetBase: "origin/main", Branch: "feature/x", MergeBase: "abc", CommitCount: 2}
+	}
+
+	t.Run("accepts a conventional message", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newSquashGitClient(t, nil)
+		//nolint:exhaustruct // Validation uses the default rule.
+		step := &workflow.CommitSquashStep{
+			GitClient: client,
+			Presenter: &noPromptPresenter{},
+			State:     newState(),
+			Message:   "fix(git): Handle detached HEAD",
+			AssumeYes: true,
+		}
+
+		require.NoError(t, step.Execute(context.Background()))
+		assert.Contains(t, executor.Commands(), "git commit -m fix(git): Handle detached HEAD")
+	})
+
+	t.Run("rejects an invalid message with --yes", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newSquashGitClient(t, nil)
+		//nolint:exhaustruct // Validation uses the default rule.
+		step := &workflow.CommitSquashStep{
+			GitClient: client,
+			Presenter: &noPromptPresenter{},
+			State:     newState(),
+			Message:   "squashed stuff",
+			AssumeYes: true,
+		}
+
+		require.ErrorIs(t, step.Execute(context.Background()), config.ErrInvalidCommitMessage)
+		assert.NotContains(t, executor.Commands(), "git reset --soft abc", "nothing may be rewritten after a rejection")
+	})
+
+	t.Run("re-prompts interactively until the message is valid", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newSquashGitClient(t, nil)
+		presenter := &scriptedPresenter{answers: []string{"wip", "feat: Add squash"}}
+		//nolint:exhaustruct // Validation uses the default rule.
+		step := &workflow.CommitSquashStep{
+			GitClient: client,
+			Presenter: presenter,
+			State:     newState(),
+			Message:   "squashed stuff",
+		}
+
+		require.NoError(t, step.Execute(context.Background()))
+		assert.Equal(t, 2, presenter.prompts)
+		assert.Contains(t, executor.Commands(), "git commit -m feat: Add squash")
+	})
+
+	t.Run("custom rule from configuration", func(t *testing.T) {
+		t.Parallel()
+
+		client, executor := newSquashGitClient(t, nil)
+		step := &workflow.CommitSquashStep{
+			GitClient:  client,
+			Presenter:  &noPromptPresenter{},
+			State:      newState(),
+			Message:    "PROJ-42 Squash",
+			Validation: config.ValidationRule{Enable: nil, Pattern: `^PROJ-\d+ `},
+			AssumeYes:  true,
+		}
+
+		require.NoError(t, step.Execute(context.Background()))
+		assert.Contains(t, executor.Commands(), "git commit -m PROJ-42 Squash")
+	})
+}
@@ -34,3 +34,10 @@ type Step interface {
 	// Execute performs the primary action of the step.
 	Execute(ctx context.Context) error
 }
+
+// Rollbacker is an optional interface for steps that can undo their Execute.
+// When a later step fails, the Runner rolls back the already-executed steps
+// that implement it, in reverse order.
+type Rollbacker interface {
+	Rollback(ctx context.Context) error
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/contextvibes/cli/internal/exec"
@@ -34,12 +35,12 @@ func (s *CheckGoEnvStep) PreCheck(_ context.Context) error {
 
 // Execute runs the step logic.
 func (s *CheckGoEnvStep) Execute(ctx context.Context) error {
-	out, _, err := s.ExecClient.CaptureOutput(ctx, ".", "go", "version")
+	version, err := s.ExecClient.CommandVersion(ctx, "go")
 	if err != nil {
 		return fmt.Errorf("failed to get go version: %w", err)
 	}
 
-	s.Presenter.Info("Detected: %s", strings.TrimSpace(out))
+	s.Presenter.Info("Detected: go %s", version)
 
 	return nil
 }
@@ -116,15 +117,53 @@ func (s *ConfigurePathStep) Execute(_ context.Context) error {
 	return nil
 }
 
-// InstallGoToolsStep force-installs the required tools.
+// GoTool is a development tool installed with 'go install'.
+type GoTool struct {
+	// Name is the binary the package installs.
+	Name string
+	// Package is the 'go install' argument, including the version.
+	Package string
+	// MinVersion, when set, makes older installed versions count as out of date.
+	MinVersion string
+}
+
+// DefaultGoTools are the tools InstallGoToolsStep installs when none are given.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var DefaultGoTools = []GoTool{
+	{Name: "govulncheck", Package: "golang.org/x/vuln/cmd/govulncheck@latest", MinVersion: ""},
+	{
+		Name:       "golangci-lint",
+		Package:    "github.com/golangci/golangci-lint/v2/cmd/golangci-lint@latest",
+		MinVersion: "2.0.0",
+	},
+	{Name: "deadcode", Package: "golang.org/x/tools/cmd/deadcode@latest", MinVersion: ""},
+	{Name: "goimports", Package: "golang.org/x/tools/cmd/goimports@latest", MinVersion: ""},
+	{Name: "stringer", Package: "golang.org/x/tools/cmd/stringer@latest", MinVersion: ""},
+	{Name: "godoc", Package: "golang.org/x/tools/cmd/godoc@latest", MinVersion: ""},
+}
+
+//nolint:gochecknoglobals // Static regex compilation.
+var builtWithGoPattern = regexp.MustCompile(`:\s+go(\S+)`)
+
+// InstallGoToolsStep installs the development tools. Tools that are already on
+// the PATH, recent enough and built with the active Go version are skipped
+// unless Force is set.
 type InstallGoToolsStep struct {
 	ExecClient *exec.ExecutorClient
 	Presenter  PresenterInterface
+	// Tools defaults to DefaultGoTools.
+	Tools []GoTool
+	Force bool
 }
 
 // Description returns the step description.
 func (s *InstallGoToolsStep) Description() string {
-	return "Force rebuild and install Go tools"
+	if s.Force {
+		return "Force rebuild and install Go tools"
+	}
+
+	return "Install missing or outdated Go tools"
 }
 
 // PreCheck performs pre-flight checks.
@@ -132,28 +171,60 @@ func (s *InstallGoToolsStep) PreCheck(_ context.Context) error { return nil }
 
 // Execute runs the step logic.
 func (s *InstallGoToolsStep) Execute(ctx context.Context) error {
-	tools := []string{
-		"golang.org/x/vuln/cmd/govulncheck@latest",
-		"github.com/golangci/golangci-lint/v2/cmd/golangci-lint@latest",
-		"golang.org/x/tools/cmd/deadcode@latest",
-		"golang.org/x/tools/cmd/goimports@latest",
-		"golang.org/x/tools/cmd/stringer@latest",
-		"golang.org/x/tools/cmd/godoc@latest",
+	tools := s.Tools
+	if tools == nil {
+		tools = DefaultGoTools
 	}
 
+	// Tools built with another Go version are what this step exists to fix.
+	goVersion, _ := s.ExecClient.CommandVersion(ctx, "go")
+
 	// Ensure GOBIN is set for this session so installs go to the right place
 	home, _ := os.UserHomeDir()
 	goBin := filepath.Join(home, "go", "bin")
 
+	installed, skipped, failed := 0, 0, 0
+
 	for _, tool := range tools {
-		s.Presenter.Step("Installing %s...", tool)
+		reason := "forced"
+
+		if !s.Force {
+			var upToDate bool
+
+			reason, upToDate = s.checkTool(ctx, tool, goVersion)
+			if upToDate {
+				s.Presenter.Detail("%s is up to date (%s); skipping.", tool.Name, reason)
+
+				skipped++
+
+				continue
+			}
+		}
+
+		s.Presenter.Step("Installing %s (%s)...", tool.Package, reason)
 		// -a forces rebuild
-		err := s.ExecClient.Execute(ctx, ".", "go", "install", "-a", tool)
+		err := s.ExecClient.Execute(ctx, ".", "go", "install", "-a", tool.Package)
 		if err != nil {
-			s.Presenter.Error("Failed to install %s: %v", tool, err)
+			s.Presenter.Error("Failed to install %s: %v", tool.Package, err)
 
-			return fmt.Errorf("failed to install %s: %w", tool, err)
+			failed++
+
+			continue
 		}
+
+		installed++
+	}
+
+	s.Presenter.Newline()
+	s.Presenter.Info("Tools: %d installed, %d skipped, %d failed.", installed, skipped, failed)
+
+	if failed > 0 {
+		//nolint:err113 // Dynamic error is appropriate here.
+		return fmt.Errorf("%d tool(s) failed to install", failed)
+	}
+
+	if installed == 0 {
+		return nil
 	}
 
 	// Verification
@@ -175,3 +246,43 @@ func (s *InstallGoToolsStep) Execute(ctx context.Context) error {
 
 	return nil
 }
+
+// checkTool reports whether an installed tool can be kept, with the reason.
+func (s *InstallGoToolsStep) checkTool(ctx context.Context, tool GoTool, goVersion string) (string, bool) {
+	if !s.ExecClient.CommandExists(tool.Name) {
+		return "not installed", false
+	}
+
+	if tool.MinVersion != "" {
+		version, err := s.ExecClient.CommandVersion(ctx, tool.Name)
+		if err != nil {
+			return "version unknown", false
+		}
+
+		if exec.CompareVersions(version, tool.MinVersion) < 0 {
+			return fmt.Sprintf("%s is older than %s", version, tool.MinVersion), false
+		}
+	}
+
+	if goVersion == "" {
+		return "installed", true
+	}
+
+	path, _, err := s.ExecClient.CaptureOutput(ctx, ".", "which", tool.Name)
+	if err != nil {
+		return "location unknown", false
+	}
+
+	out, _, err := s.ExecClient.CaptureOutput(ctx, ".", "go", "version", strings.TrimSpace(path))
+	match := builtWithGoPattern.FindStringSubmatch(out)
+
+	if err != nil || match == nil {
+		return "build Go version unknown", false
+	}
+
+	if match[1] != goVersion {
+		return fmt.Sprintf("built with go%s, active go%s", match[1], goVersion), false
+	}
+
+	return "built with go" + goVersion, true
+}
@@ -0,0 +1,125 @@
+package workflow_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/contextvibes/cli/internal/exec"
+	"github.com/contextvibes/cli/internal/exec/exectest"
+	"github.com/contextvibes/cli/internal/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newToolsExecutor stubs installed tools: the scripted command lines answer the
+// version probes, installs of packages containing "broken" fail and any other
+// command fails as if the tool were missing.
+func newToolsExecutor() *exectest.Executor {
+	ok := func(stdout string) exectest.Response { return exectest.Response{Stdout: stdout, Stderr: "", Err: nil} }
+
+	return &exectest.Executor{
+		RepoDir: "",
+		Responses: map[string]exectest.Response{
+			"go version":                          ok("go version go1.25.5 linux/amd64\n"),
+			"which current":                       ok("/home/dev/go/bin/current\n"),
+			"go version /home/dev/go/bin/current": ok("/home/dev/go/bin/current: go1.25.5\n"),
+			"which stale":                         ok("/nix/store/abc/bin/stale\n"),
+			"go version /nix/store/abc/bin/stale": ok("/nix/store/abc/bin/stale: go1.24.2\n"),
+			"old --version":                       ok("old version 1.4.0\n"),
+		},
+		Handler: func(call exectest.Call) exectest.Response {
+			if strings.HasPrefix(call.String(), "go install ") && !strings.Contains(call.String(), "broken") {
+				return ok("")
+			}
+
+			return exectest.Response{Stdout: "", Stderr: "", Err: errExitStatus}
+		},
+		Missing: map[string]bool{"absent": true, "broken": true},
+	}
+}
+
+// installs returns the go install commands the executor ran.
+func installs(mockExec *exectest.Executor) []string {
+	var commands []string
+
+	for _, command := range mockExec.Commands() {
+		if strings.HasPrefix(command, "go install ") {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
+
+func TestInstallGoToolsStep(t *testing.T) {
+	t.Parallel()
+
+	tools := []workflow.GoTool{
+		{Name: "current", Package: "example.com/current@latest", MinVersion: ""},
+		{Name: "stale", Package: "example.com/stale@latest", MinVersion: ""},
+		{Name: "old", Package: "example.com/old@latest", MinVersion: "2.0.0"},
+		{Name: "absent", Package: "example.com/absent@latest", MinVersion: ""},
+	}
+
+	t.Run("skips up-to-date tools", func(t *testing.T) {
+		t.Parallel()
+
+		mockExec := newToolsExecutor()
+		presenter := &mockPresenter{}
+		//nolint:exhaustruct // Force defaults to false.
+		step := &workflow.InstallGoToolsStep{ExecClient: exec.NewClient(mockExec), Presenter: presenter, Tools: tools}
+
+		require.NoError(t, step.Execute(context.Background()))
+		assert.Equal(t, []string{
+			"go install -a example.com/stale@latest",
+			"go install -a example.com/old@latest",
+			"go install -a example.com/absent@latest",
+		}, installs(mockExec))
+		assert.Contains(t, presenter.messages, "detail: current is up to date (built with go1.25.5); skipping.")
+		assert.Contains(t, presenter.messages,
+			"step: Installing example.com/stale@latest (built with go1.24.2, active go1.25.5)...")
+		assert.Contains(t, presenter.messages,
+			"step: Installing example.com/old@latest (1.4.0 is older than 2.0.0)...")
+		assert.Contains(t, presenter.messages, "step: Installing example.com/absent@latest (not installed)...")
+		assert.Contains(t, presenter.messages, "info: Tools: 3 installed, 1 skipped, 0 failed.")
+	})
+
+	t.Run("force reinstalls everything", func(t *testing.T) {
+		t.Parallel()
+
+		mockExec := newToolsExecutor()
+		presenter := &mockPresenter{}
+		step := &workflow.InstallGoToolsStep{
+			ExecClient: exec.NewClient(mockExec),
+			Presenter:  presenter,
+			Tools:      tools,
+			Force:      true,
+		}
+
+		require.NoError(t, step.Execute(context.Background()))
+		assert.Len(t, installs(mockExec), len(tools))
+		assert.Contains(t, presenter.messages, "info: Tools: 4 installed, 0 skipped, 0 failed.")
+	})
+
+	t.Run("failures are counted and reported", func(t *testing.T) {
+		t.Parallel()
+
+		mockExec := newToolsExecutor()
+		presenter := &mockPresenter{}
+		//nolint:exhaustruct // Force defaults to false.
+		step := &workflow.InstallGoToolsStep{
+			ExecClient: exec.NewClient(mockExec),
+			Presenter:  presenter,
+			Tools: []workflow.GoTool{
+				{Name: "broken", Package: "example.com/broken@latest", MinVersion: ""},
+				{Name: "absent", Package: "example.com/absent@latest", MinVersion: ""},
+			},
+		}
+
+		err := step.Execute(context.Background())
+		require.Error(t, err)
+		assert.Len(t, installs(mockExec), 2, "a failure does not stop the remaining installs")
+		assert.Contains(t, presenter.messages, "info: Tools: 1 installed, 0 skipped, 1 failed.")
+	})
+}
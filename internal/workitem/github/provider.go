@@ -3,6 +3,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -52,7 +53,7 @@ func New(ctx context.Context, logger *slog.Logger, cfg *config.Config) (workitem
 		return nil, fmt.Errorf("could not get remote URL for '%s': %w", cfg.Git.DefaultRemote, err)
 	}
 
-	owner, repo, err := gh.ParseGitHubRemote(remoteURL)
+	remote, err := gh.ParseRemote(remoteURL, cfg.Project.GitHubHost)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"could not parse owner/repo from remote URL '%s': %w",
@@ -64,18 +65,20 @@ func New(ctx context.Context, logger *slog.Logger, cfg *config.Config) (workitem
 	logger.DebugContext(
 		ctx,
 		"Discovered GitHub repository from remote",
+		"host",
+		remote.Host,
 		"owner",
-		owner,
+		remote.Owner,
 		"repo",
-		repo,
+		remote.Repo,
 	)
 
-	ghClient, err := gh.NewClient(ctx, logger, owner, repo)
+	ghClient, err := gh.NewClientForHost(ctx, logger, remote.Host, remote.Owner, remote.Repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create github api client: %w", err)
 	}
 
-	return NewWithClient(ghClient, logger, owner, repo), nil
+	return NewWithClient(ghClient, logger, remote.Owner, remote.Repo), nil
 }
 
 // ListItems retrieves a collection of work items based on the provided options.
@@ -93,8 +96,11 @@ func (p *Provider) ListItems(
 			Page:    options.Page,
 		},
 	}
-	if options.State == workitem.StateClosed {
+	switch options.State {
+	case workitem.StateClosed:
 		ghOpts.State = "closed"
+	case workitem.StateAll:
+		ghOpts.State = "all"
 	}
 
 	p.logger.DebugContext(
@@ -271,6 +277,10 @@ func (p *Provider) CreateLabel(ctx context.Context, label workitem.Label) (*work
 
 	createdLabel, _, err := p.ghClient.Issues.CreateLabel(ctx, p.owner, p.repo, ghLabel)
 	if err != nil {
+		if isAlreadyExists(err) {
+			return nil, fmt.Errorf("%w: '%s'", workitem.ErrLabelExists, label.Name)
+		}
+
 		return nil, fmt.Errorf("failed to create github label: %w", err)
 	}
 
@@ -281,6 +291,23 @@ func (p *Provider) CreateLabel(ctx context.Context, label workitem.Label) (*work
 	}, nil
 }
 
+// isAlreadyExists reports whether a GitHub API error is a validation failure
+// because the resource already exists.
+func isAlreadyExists(err error) bool {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) {
+		return false
+	}
+
+	for _, detail := range ghErr.Errors {
+		if detail.Code == "already_exists" {
+			return true
+		}
+	}
+
+	return false
+}
+
 func toComment(comment *github.IssueComment) workitem.Comment {
 	return workitem.Comment{
 		Author:    comment.GetUser().GetLogin(),
@@ -379,3 +406,9 @@ func fromWorkItem(item workitem.WorkItem) *github.IssueRequest {
 
 	return req
 }
+
+// DefaultBranch returns the repository's default branch as reported by GitHub.
+func (p *Provider) DefaultBranch(ctx context.Context) (string, error) {
+	//nolint:wrapcheck // The client already wraps its errors.
+	return p.ghClient.GetDefaultBranch(ctx)
+}
@@ -0,0 +1,92 @@
+package workitem
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/contextvibes/cli/internal/tools"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrMissingTitle is returned when a work item document has no title.
+	ErrMissingTitle = errors.New("work item has no title")
+	// ErrUnknownType is returned when a work item document names an unsupported type.
+	ErrUnknownType = errors.New("unknown work item type")
+)
+
+// knownTypes are the types a work item document may name, matched case-insensitively.
+//
+//nolint:gochecknoglobals // Static lookup list.
+var knownTypes = []Type{TypeStory, TypeTask, TypeEpic, TypeBug, TypeChore}
+
+// markdownFrontMatter is the YAML front matter of a work item document.
+type markdownFrontMatter struct {
+	Title     string   `yaml:"title"`
+	Type      string   `yaml:"type"`
+	Labels    []string `yaml:"labels"`
+	Assignees []string `yaml:"assignees"`
+}
+
+// ParseMarkdown builds a WorkItem from a Markdown document whose YAML front
+// matter sets title, type, labels and assignees; the rest of the document is
+// the body. The type defaults to Task.
+func ParseMarkdown(content string) (WorkItem, error) {
+	frontMatter, body, err := tools.SplitFrontMatter(content)
+	if err != nil {
+		//nolint:wrapcheck // Splitter errors are already descriptive.
+		return WorkItem{}, err
+	}
+
+	var meta markdownFrontMatter
+
+	err = yaml.Unmarshal([]byte(frontMatter), &meta)
+	if err != nil {
+		return WorkItem{}, fmt.Errorf("failed to parse front matter: %w", err)
+	}
+
+	title := strings.TrimSpace(meta.Title)
+	if title == "" {
+		return WorkItem{}, ErrMissingTitle
+	}
+
+	itemType, err := ParseType(meta.Type)
+	if err != nil {
+		return WorkItem{}, err
+	}
+
+	//nolint:exhaustruct // Only the fields a new item carries are set.
+	return WorkItem{
+		Title:     title,
+		Body:      strings.TrimSpace(body),
+		Type:      itemType,
+		Labels:    meta.Labels,
+		Assignees: meta.Assignees,
+	}, nil
+}
+
+// ParseType maps a type name such as "bug" to its Type. An empty name is a Task.
+func ParseType(name string) (Type, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return TypeTask, nil
+	}
+
+	for _, known := range knownTypes {
+		if strings.EqualFold(name, string(known)) {
+			return known, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w '%s': expected one of %s", ErrUnknownType, name, joinTypes(knownTypes))
+}
+
+func joinTypes(types []Type) string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, string(t))
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,57 @@
+package workitem_test
+
+import (
+	"testing"
+
+	"github.com/contextvibes/cli/internal/tools"
+	"github.com/contextvibes/cli/internal/workitem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const issueFixture = `---
+title: Login fails with expired session
+type: bug
+labels: [auth, p1]
+assignees:
+  - octocat
+---
+
+## Steps to reproduce
+
+1. Sign in and wait for the session to expire.
+2. Click "Save".
+`
+
+func TestParseMarkdown(t *testing.T) {
+	t.Parallel()
+
+	item, err := workitem.ParseMarkdown(issueFixture)
+	require.NoError(t, err)
+
+	//nolint:exhaustruct // Only the fields a new item carries are set.
+	assert.Equal(t, workitem.WorkItem{
+		Title:     "Login fails with expired session",
+		Body:      "## Steps to reproduce\n\n1. Sign in and wait for the session to expire.\n2. Click \"Save\".",
+		Type:      workitem.TypeBug,
+		Labels:    []string{"auth", "p1"},
+		Assignees: []string{"octocat"},
+	}, item)
+}
+
+func TestParseMarkdown_Errors(t *testing.T) {
+	t.Parallel()
+
+	item, err := workitem.ParseMarkdown("---\ntitle: Tidy docs\n---\nBody\n")
+	require.NoError(t, err)
+	assert.Equal(t, workitem.TypeTask, item.Type, "type defaults to Task")
+
+	_, err = workitem.ParseMarkdown("# Just a heading\n")
+	require.ErrorIs(t, err, tools.ErrNoFrontMatter)
+
+	_, err = workitem.ParseMarkdown("---\nlabels: [docs]\n---\nBody\n")
+	require.ErrorIs(t, err, workitem.ErrMissingTitle)
+
+	_, err = workitem.ParseMarkdown("---\ntitle: Odd\ntype: incident\n---\n")
+	require.ErrorIs(t, err, workitem.ErrUnknownType)
+}
@@ -1,6 +1,13 @@
 package workitem
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrLabelExists is returned by CreateLabel when the backend already has a label
+// with the same name.
+var ErrLabelExists = errors.New("label already exists")
 
 // Provider defines the interface for a work item management system.
 // This allows for abstracting the backend (GitHub, GitLab, etc.).
@@ -20,6 +27,10 @@ type Provider interface {
 	// SearchItems uses a provider-specific query string to find work items.
 	SearchItems(ctx context.Context, query string) ([]WorkItem, error)
 
-	// CreateLabel creates a new label in the backend system.
+	// CreateLabel creates a new label in the backend system. It returns an error
+	// wrapping ErrLabelExists when the label is already defined.
 	CreateLabel(ctx context.Context, label Label) (*Label, error)
+
+	// DefaultBranch returns the default branch of the remote repository.
+	DefaultBranch(ctx context.Context) (string, error)
 }
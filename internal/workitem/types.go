@@ -21,6 +21,8 @@ type State string
 const (
 	StateOpen   State = "Open"
 	StateClosed State = "Closed"
+	// StateAll matches items in any state. It is only meaningful as a ListOptions filter.
+	StateAll State = "All"
 )
 
 // Comment represents a single comment on a work item.